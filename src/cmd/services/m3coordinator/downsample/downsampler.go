@@ -20,6 +20,11 @@
 
 package downsample
 
+import (
+	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/policy"
+)
+
 // Downsampler is a downsampler.
 type Downsampler interface {
 	NewMetricsAppender() MetricsAppender
@@ -30,6 +35,13 @@ type Downsampler interface {
 type MetricsAppender interface {
 	AddTag(name, value string)
 	SamplesAppender() (SamplesAppender, error)
+	// SamplesAppenderWithPolicies returns a samples appender that aggregates
+	// samples with the given aggregation and storage policies rather than
+	// those of the rules matching the metric.
+	SamplesAppenderWithPolicies(
+		aggregationID aggregation.ID,
+		policies policy.StoragePolicies,
+	) (SamplesAppender, error)
 	Reset()
 	Finalize()
 }
@@ -170,6 +170,42 @@ func TestDownsamplerAggregation(t *testing.T) {
 	}
 }
 
+func TestDownsamplerAggregationWithPolicies(t *testing.T) {
+	testDownsampler := newTestDownsampler(t, testDownsamplerOptions{})
+	downsampler := testDownsampler.downsampler
+
+	// No rules match the metric, the given policies are used instead
+	tags := map[string]string{"__name__": "gauge0", "app": "testapp"}
+	policies := policy.StoragePolicies{policy.MustParseStoragePolicy("2s:1d")}
+
+	appender := downsampler.NewMetricsAppender()
+	defer appender.Finalize()
+
+	for name, value := range tags {
+		appender.AddTag(name, value)
+	}
+
+	samplesAppender, err := appender.SamplesAppenderWithPolicies(
+		aggregation.MustCompressTypes(aggregation.Mean), policies)
+	require.NoError(t, err)
+
+	for _, sample := range []float64{4, 5, 6} {
+		require.NoError(t, samplesAppender.AppendGaugeSample(sample))
+	}
+
+	// Wait for writes
+	for len(testDownsampler.storage.Writes()) == 0 {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	write := mustFindWrite(t, testDownsampler.storage.Writes(), "gauge0")
+	assert.Equal(t, tags, write.Tags.StringMap())
+	require.Equal(t, 1, len(write.Datapoints))
+	assert.Equal(t, 5.0, write.Datapoints[0].Value)
+	assert.Equal(t, 2*time.Second, write.Attributes.Resolution)
+	assert.Equal(t, 24*time.Hour, write.Attributes.Retention)
+}
+
 type testDownsampler struct {
 	opts           DownsamplerOptions
 	downsampler    Downsampler
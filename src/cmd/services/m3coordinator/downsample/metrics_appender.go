@@ -27,7 +27,10 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/serialize"
 	"github.com/m3db/m3aggregator/aggregator"
+	"github.com/m3db/m3metrics/aggregation"
 	"github.com/m3db/m3metrics/matcher"
+	"github.com/m3db/m3metrics/metadata"
+	"github.com/m3db/m3metrics/policy"
 	"github.com/m3db/m3x/clock"
 )
 
@@ -51,22 +54,12 @@ func (a *metricsAppender) AddTag(name, value string) {
 }
 
 func (a *metricsAppender) SamplesAppender() (SamplesAppender, error) {
-	// Sort tags
-	sort.Sort(a.tags)
-
-	// Encode tags and compute a temporary (unowned) ID
-	a.tagEncoder.Reset()
-	if err := a.tagEncoder.Encode(a.tags); err != nil {
+	unownedID, err := a.encodeTags()
+	if err != nil {
 		return nil, err
 	}
-	data, ok := a.tagEncoder.Data()
-	if !ok {
-		return nil, fmt.Errorf("unable to encode tags: names=%v, values=%v",
-			a.tags.names, a.tags.values)
-	}
 
 	a.multiSamplesAppender.reset()
-	unownedID := data.Bytes()
 
 	// Match policies and rollups and build samples appender
 	id := a.encodedTagsIteratorPool.Get()
@@ -101,6 +94,54 @@ func (a *metricsAppender) SamplesAppender() (SamplesAppender, error) {
 	return a.multiSamplesAppender, nil
 }
 
+func (a *metricsAppender) SamplesAppenderWithPolicies(
+	aggregationID aggregation.ID,
+	policies policy.StoragePolicies,
+) (SamplesAppender, error) {
+	unownedID, err := a.encodeTags()
+	if err != nil {
+		return nil, err
+	}
+
+	a.multiSamplesAppender.reset()
+	a.multiSamplesAppender.addSamplesAppender(samplesAppender{
+		agg:       a.agg,
+		unownedID: unownedID,
+		stagedMetadatas: metadata.StagedMetadatas{
+			{
+				Metadata: metadata.Metadata{
+					Pipelines: metadata.PipelineMetadatas{
+						{
+							AggregationID:   aggregationID,
+							StoragePolicies: policies,
+						},
+					},
+				},
+			},
+		},
+	})
+
+	return a.multiSamplesAppender, nil
+}
+
+// encodeTags sorts and encodes the tags, returning a temporary (unowned) ID
+// which is only valid until the tags are next encoded
+func (a *metricsAppender) encodeTags() ([]byte, error) {
+	sort.Sort(a.tags)
+
+	a.tagEncoder.Reset()
+	if err := a.tagEncoder.Encode(a.tags); err != nil {
+		return nil, err
+	}
+	data, ok := a.tagEncoder.Data()
+	if !ok {
+		return nil, fmt.Errorf("unable to encode tags: names=%v, values=%v",
+			a.tags.names, a.tags.values)
+	}
+
+	return data.Bytes(), nil
+}
+
 func (a *metricsAppender) Reset() {
 	a.tags.names = a.tags.names[:0]
 	a.tags.values = a.tags.values[:0]
@@ -0,0 +1,330 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package carbon ingests metrics sent with the graphite carbon plaintext
+// protocol, the nodes of each dotted metric name are stored as positional
+// graphite tags.
+package carbon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/policy"
+	xerrors "github.com/m3db/m3x/errors"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxPacketSize is the largest UDP packet read, larger packets are
+	// truncated by the kernel
+	maxPacketSize = 65536
+)
+
+var (
+	errNoAppenderOrDownsampler    = errors.New("no appender or downsampler set, requires at least one or both")
+	errPoliciesWithoutDownsampler = errors.New("rules with storage policies require a downsampler to aggregate metrics")
+
+	// aggregationID aggregates the metrics matching rules with storage
+	// policies to the mean of each resolution window, as graphite does by
+	// default
+	aggregationID = aggregation.MustCompressTypes(aggregation.Mean)
+)
+
+// IngesterOptions are the options for a carbon ingester.
+type IngesterOptions struct {
+	// Appender writes metrics to storage.
+	Appender storage.Appender
+
+	// Downsampler, if set, is also sent the unaggregated metrics so that
+	// they are aggregated by the dynamic rules.
+	Downsampler downsample.Downsampler
+
+	// Rewrites are applied in order to each metric name.
+	Rewrites []Rewrite
+
+	// Rules map metrics to storage policies, the first matching rule is
+	// used and metrics matching no rule are dropped. Without any rules all
+	// metrics are written unaggregated. Metrics matching a rule with storage
+	// policies are aggregated by the downsampler, which is then required.
+	Rules []Rule
+
+	// Scope is the metrics scope.
+	Scope tally.Scope
+
+	// Logger is the logger.
+	Logger *zap.Logger
+}
+
+// Ingester writes the metrics received over carbon connections.
+type Ingester struct {
+	opts    IngesterOptions
+	metrics ingesterMetrics
+	nowFn   func() time.Time
+}
+
+type ingesterMetrics struct {
+	success   tally.Counter
+	malformed tally.Counter
+	unmatched tally.Counter
+	errors    tally.Counter
+}
+
+func newIngesterMetrics(scope tally.Scope) ingesterMetrics {
+	return ingesterMetrics{
+		success:   scope.Counter("ingest.success"),
+		malformed: scope.Counter("ingest.malformed"),
+		unmatched: scope.Counter("ingest.unmatched"),
+		errors:    scope.Counter("ingest.errors"),
+	}
+}
+
+// NewIngester returns a new carbon ingester.
+func NewIngester(opts IngesterOptions) (*Ingester, error) {
+	if opts.Appender == nil && opts.Downsampler == nil {
+		return nil, errNoAppenderOrDownsampler
+	}
+
+	for _, rule := range opts.Rules {
+		if len(rule.policies) > 0 && opts.Downsampler == nil {
+			return nil, errPoliciesWithoutDownsampler
+		}
+	}
+
+	if opts.Scope == nil {
+		opts.Scope = tally.NoopScope
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+
+	return &Ingester{
+		opts:    opts,
+		metrics: newIngesterMetrics(opts.Scope),
+		nowFn:   time.Now,
+	}, nil
+}
+
+// Serve accepts TCP connections until the listener is closed, each
+// connection is handled on its own goroutine.
+func (i *Ingester) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go i.Handle(conn)
+	}
+}
+
+// Handle ingests the lines of a connection until it is closed.
+func (i *Ingester) Handle(conn net.Conn) {
+	defer conn.Close()
+
+	w := i.newWriter()
+	defer w.finalize()
+
+	i.ingest(w, conn)
+}
+
+// ServePacket ingests UDP packets until the connection is closed, each
+// packet holds one or more lines.
+func (i *Ingester) ServePacket(conn net.PacketConn) error {
+	w := i.newWriter()
+	defer w.finalize()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		i.ingest(w, bytes.NewReader(buf[:n]))
+	}
+}
+
+func (i *Ingester) ingest(w *writer, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		metric, err := ParseLine(line, i.nowFn())
+		if err != nil {
+			i.metrics.malformed.Inc(1)
+			i.opts.Logger.Debug("malformed carbon line",
+				zap.ByteString("line", line), zap.Error(err))
+			continue
+		}
+
+		i.write(w, metric)
+	}
+
+	if err := scanner.Err(); err != nil {
+		i.opts.Logger.Warn("unable to read carbon lines", zap.Error(err))
+	}
+}
+
+func (i *Ingester) write(w *writer, metric Metric) {
+	name := metric.Name
+	for _, rewrite := range i.opts.Rewrites {
+		name = rewrite.apply(name)
+	}
+
+	rule, ok := i.match(name)
+	if !ok {
+		i.metrics.unmatched.Inc(1)
+		return
+	}
+
+	tags, err := graphite.TagsFromPath(name)
+	if err != nil {
+		i.metrics.malformed.Inc(1)
+		i.opts.Logger.Debug("malformed carbon metric name",
+			zap.String("name", name), zap.Error(err))
+		return
+	}
+
+	metric.Name = name
+	if err := w.write(models.Normalize(tags), metric, rule); err != nil {
+		i.metrics.errors.Inc(1)
+		i.opts.Logger.Error("unable to write carbon metric",
+			zap.String("name", name), zap.Error(err))
+		return
+	}
+
+	i.metrics.success.Inc(1)
+}
+
+func (i *Ingester) match(name string) (Rule, bool) {
+	if len(i.opts.Rules) == 0 {
+		return Rule{}, true
+	}
+
+	for _, rule := range i.opts.Rules {
+		if rule.matches(name) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// writer writes the metrics of a single connection, it is not safe for
+// concurrent use since it holds a downsampler metrics appender
+type writer struct {
+	appender        storage.Appender
+	metricsAppender downsample.MetricsAppender
+}
+
+func (i *Ingester) newWriter() *writer {
+	w := &writer{appender: i.opts.Appender}
+	if i.opts.Downsampler != nil {
+		w.metricsAppender = i.opts.Downsampler.NewMetricsAppender()
+	}
+
+	return w
+}
+
+func (w *writer) write(tags models.Tags, metric Metric, rule Rule) error {
+	if len(rule.policies) > 0 {
+		// The aggregated namespaces hold one value per resolution window, so
+		// metrics are aggregated by the downsampler rather than written as is
+		if w.metricsAppender == nil {
+			return errPoliciesWithoutDownsampler
+		}
+
+		return w.downsampleWithPolicies(tags, metric.Value, rule.policies)
+	}
+
+	var multiErr xerrors.MultiError
+	if w.appender != nil {
+		multiErr = multiErr.Add(w.appender.Write(context.Background(), &storage.WriteQuery{
+			Raw:        metric.Name,
+			Tags:       tags,
+			Datapoints: ts.Datapoints{{Timestamp: metric.Timestamp, Value: metric.Value}},
+			Unit:       xtime.Millisecond,
+			Attributes: storage.Attributes{
+				MetricsType: storage.UnaggregatedMetricsType,
+			},
+		}))
+	}
+
+	if w.metricsAppender != nil {
+		multiErr = multiErr.Add(w.downsample(tags, metric.Value))
+	}
+
+	return multiErr.FinalError()
+}
+
+func (w *writer) downsample(tags models.Tags, value float64) error {
+	w.resetTags(tags)
+	samplesAppender, err := w.metricsAppender.SamplesAppender()
+	if err != nil {
+		return err
+	}
+
+	return samplesAppender.AppendGaugeSample(value)
+}
+
+func (w *writer) downsampleWithPolicies(
+	tags models.Tags,
+	value float64,
+	policies policy.StoragePolicies,
+) error {
+	w.resetTags(tags)
+	samplesAppender, err := w.metricsAppender.SamplesAppenderWithPolicies(aggregationID, policies)
+	if err != nil {
+		return err
+	}
+
+	return samplesAppender.AppendGaugeSample(value)
+}
+
+func (w *writer) resetTags(tags models.Tags) {
+	w.metricsAppender.Reset()
+	for _, tag := range tags {
+		w.metricsAppender.AddTag(tag.Name, tag.Value)
+	}
+}
+
+func (w *writer) finalize() {
+	if w.metricsAppender != nil {
+		w.metricsAppender.Finalize()
+	}
+}
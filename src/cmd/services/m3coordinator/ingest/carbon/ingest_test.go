@@ -0,0 +1,240 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package carbon
+
+import (
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3metrics/aggregation"
+	"github.com/m3db/m3metrics/policy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDownsampler struct {
+	sync.Mutex
+	samples   []models.Tags
+	policies  []policy.StoragePolicies
+	finalized int
+}
+
+func (d *testDownsampler) NewMetricsAppender() downsample.MetricsAppender {
+	return &testMetricsAppender{downsampler: d}
+}
+
+type testMetricsAppender struct {
+	downsampler *testDownsampler
+	tags        models.Tags
+	policies    policy.StoragePolicies
+}
+
+func (a *testMetricsAppender) AddTag(name, value string) {
+	a.tags = append(a.tags, models.Tag{Name: name, Value: value})
+}
+
+func (a *testMetricsAppender) SamplesAppender() (downsample.SamplesAppender, error) {
+	a.policies = nil
+	return a, nil
+}
+
+func (a *testMetricsAppender) SamplesAppenderWithPolicies(
+	_ aggregation.ID,
+	policies policy.StoragePolicies,
+) (downsample.SamplesAppender, error) {
+	a.policies = policies
+	return a, nil
+}
+
+func (a *testMetricsAppender) AppendCounterSample(value int64) error {
+	return nil
+}
+
+func (a *testMetricsAppender) AppendGaugeSample(value float64) error {
+	a.downsampler.Lock()
+	a.downsampler.samples = append(a.downsampler.samples, a.tags.Clone())
+	a.downsampler.policies = append(a.downsampler.policies, a.policies)
+	a.downsampler.Unlock()
+	return nil
+}
+
+func (a *testMetricsAppender) Reset() {
+	a.tags = a.tags[:0]
+}
+
+func (a *testMetricsAppender) Finalize() {
+	a.downsampler.Lock()
+	a.downsampler.finalized++
+	a.downsampler.Unlock()
+}
+
+// handleLines writes the lines to a connection handled by the ingester and
+// waits for the connection to be drained
+func handleLines(t *testing.T, ingester *Ingester, lines ...string) {
+	server, client := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		ingester.Handle(server)
+		close(done)
+	}()
+
+	_, err := client.Write([]byte(strings.Join(lines, "\n") + "\n"))
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	<-done
+}
+
+func TestIngestUnaggregated(t *testing.T) {
+	store := mock.NewMockStorage()
+	downsampler := &testDownsampler{}
+	ingester, err := NewIngester(IngesterOptions{
+		Appender:    store,
+		Downsampler: downsampler,
+	})
+	require.NoError(t, err)
+
+	handleLines(t, ingester,
+		"foo.bar.baz 1 1500000000",
+		"",
+		"malformed",
+		"foo..baz 2 1500000000",
+		"qux 3 1500000010",
+	)
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+	assert.Equal(t, models.Tags{
+		{Name: "__g0__", Value: "foo"},
+		{Name: "__g1__", Value: "bar"},
+		{Name: "__g2__", Value: "baz"},
+	}, writes[0].Tags)
+	assert.Equal(t, 1.0, writes[0].Datapoints[0].Value)
+	assert.Equal(t, time.Unix(1500000000, 0), writes[0].Datapoints[0].Timestamp)
+	assert.Equal(t, storage.UnaggregatedMetricsType, writes[0].Attributes.MetricsType)
+	assert.Equal(t, models.Tags{{Name: "__g0__", Value: "qux"}}, writes[1].Tags)
+
+	// Unaggregated metrics are also sent to the downsampler
+	assert.Equal(t, []models.Tags{writes[0].Tags, writes[1].Tags}, downsampler.samples)
+	assert.Equal(t, 1, downsampler.finalized)
+}
+
+func TestIngestRewritesAndRules(t *testing.T) {
+	rewrite, err := NewRewrite(`[^a-zA-Z0-9_.\-]`, "_")
+	require.NoError(t, err)
+
+	aggregated, err := NewRule(`^stats\.`, []policy.StoragePolicy{
+		policy.MustParseStoragePolicy("1m:40d"),
+		policy.MustParseStoragePolicy("1h:1y"),
+	})
+	require.NoError(t, err)
+
+	unaggregated, err := NewRule(`^servers\.`, nil)
+	require.NoError(t, err)
+
+	store := mock.NewMockStorage()
+	downsampler := &testDownsampler{}
+	ingester, err := NewIngester(IngesterOptions{
+		Appender:    store,
+		Downsampler: downsampler,
+		Rewrites:    []Rewrite{rewrite},
+		Rules:       []Rule{aggregated, unaggregated},
+	})
+	require.NoError(t, err)
+
+	handleLines(t, ingester,
+		"stats.api:requests 5 1500000000",
+		"servers.a.cpu 0.5 1500000000",
+		"other.metric 1 1500000000",
+	)
+
+	// Metrics matching a rule with policies are only aggregated
+	writes := store.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, storage.UnaggregatedMetricsType, writes[0].Attributes.MetricsType)
+	assert.Equal(t, "servers.a.cpu", writes[0].Raw)
+
+	require.Len(t, downsampler.samples, 2)
+	assert.Equal(t, models.Tags{
+		{Name: "__g0__", Value: "stats"},
+		{Name: "__g1__", Value: "api_requests"},
+	}, downsampler.samples[0])
+	assert.Equal(t, policy.StoragePolicies{
+		policy.MustParseStoragePolicy("1m:40d"),
+		policy.MustParseStoragePolicy("1h:1y"),
+	}, downsampler.policies[0])
+	assert.Equal(t, writes[0].Tags, downsampler.samples[1])
+	assert.Nil(t, downsampler.policies[1])
+}
+
+func TestNewIngesterRequiresDownsamplerForPolicies(t *testing.T) {
+	rule, err := NewRule(`^stats\.`, []policy.StoragePolicy{
+		policy.MustParseStoragePolicy("1m:40d"),
+	})
+	require.NoError(t, err)
+
+	_, err = NewIngester(IngesterOptions{
+		Appender: mock.NewMockStorage(),
+		Rules:    []Rule{rule},
+	})
+	assert.Error(t, err)
+}
+
+func TestIngestPackets(t *testing.T) {
+	store := mock.NewMockStorage()
+	ingester, err := NewIngester(IngesterOptions{Appender: store})
+	require.NoError(t, err)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		ingester.ServePacket(conn)
+		close(done)
+	}()
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Write([]byte("foo.a 1 1500000000\nfoo.b 2 1500000000\n"))
+	require.NoError(t, err)
+
+	for start := time.Now(); len(store.Writes()) < 2; {
+		require.True(t, time.Since(start) < 5*time.Second, "timed out waiting for writes")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	require.NoError(t, conn.Close())
+	<-done
+}
+
+func TestNewIngesterRequiresAppenderOrDownsampler(t *testing.T) {
+	_, err := NewIngester(IngesterOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package carbon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+var (
+	errInvalidLine = errors.New("invalid carbon line, expected: <path> <value> <timestamp>")
+)
+
+// Metric is a single datapoint of the carbon plaintext protocol
+type Metric struct {
+	Name      string
+	Value     float64
+	Timestamp time.Time
+}
+
+// ParseLine parses a carbon plaintext line of the form
+// "<path> <value> <timestamp>", a negative timestamp is the current time
+func ParseLine(line []byte, now time.Time) (Metric, error) {
+	fields := bytes.Fields(line)
+	if len(fields) != 3 {
+		return Metric{}, errInvalidLine
+	}
+
+	value, err := strconv.ParseFloat(string(fields[1]), 64)
+	if err != nil {
+		return Metric{}, fmt.Errorf("invalid carbon value %s: %v", fields[1], err)
+	}
+
+	seconds, err := strconv.ParseFloat(string(fields[2]), 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return Metric{}, fmt.Errorf("invalid carbon timestamp: %s", fields[2])
+	}
+
+	timestamp := now
+	if seconds >= 0 {
+		whole, frac := math.Modf(seconds)
+		timestamp = time.Unix(int64(whole), int64(frac*float64(time.Second)))
+	}
+
+	return Metric{
+		Name:      string(fields[0]),
+		Value:     value,
+		Timestamp: timestamp,
+	}, nil
+}
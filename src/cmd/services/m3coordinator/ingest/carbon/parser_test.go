@@ -0,0 +1,61 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package carbon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	metric, err := ParseLine([]byte("foo.bar.baz 42.5 1500000000"), now)
+	require.NoError(t, err)
+	assert.Equal(t, Metric{Name: "foo.bar.baz", Value: 42.5, Timestamp: time.Unix(1500000000, 0)}, metric)
+
+	metric, err = ParseLine([]byte("  foo.bar\t-1e3  1500000000.25\r"), now)
+	require.NoError(t, err)
+	assert.Equal(t, -1000.0, metric.Value)
+	assert.Equal(t, time.Unix(1500000000, int64(250*time.Millisecond)), metric.Timestamp)
+
+	// A negative timestamp is the time the line is received
+	metric, err = ParseLine([]byte("foo.bar 1 -1"), now)
+	require.NoError(t, err)
+	assert.Equal(t, now, metric.Timestamp)
+}
+
+func TestParseLineErrors(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"foo.bar",
+		"foo.bar 1",
+		"foo.bar 1 1500000000 extra",
+		"foo.bar one 1500000000",
+		"foo.bar 1 yesterday",
+		"foo.bar 1 NaN",
+	} {
+		_, err := ParseLine([]byte(line), time.Now())
+		assert.Error(t, err, line)
+	}
+}
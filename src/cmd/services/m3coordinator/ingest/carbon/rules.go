@@ -0,0 +1,69 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package carbon
+
+import (
+	"regexp"
+
+	"github.com/m3db/m3metrics/policy"
+)
+
+// Rewrite replaces the matches of a pattern within metric names, rewrites
+// are applied before metrics are matched against rules
+type Rewrite struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// NewRewrite creates a new rewrite, the replacement may refer to submatches
+// of the pattern as with regexp.ReplaceAllString
+func NewRewrite(pattern, replacement string) (Rewrite, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rewrite{}, err
+	}
+
+	return Rewrite{pattern: re, replacement: replacement}, nil
+}
+
+func (r Rewrite) apply(name string) string {
+	return r.pattern.ReplaceAllString(name, r.replacement)
+}
+
+// Rule maps the metrics whose name matches a pattern to the storage policies
+// they are written with, a rule without policies writes metrics unaggregated
+type Rule struct {
+	pattern  *regexp.Regexp
+	policies []policy.StoragePolicy
+}
+
+// NewRule creates a new rule, the pattern is unanchored
+func NewRule(pattern string, policies []policy.StoragePolicy) (Rule, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return Rule{pattern: re, policies: policies}, nil
+}
+
+func (r Rule) matches(name string) bool {
+	return r.pattern.MatchString(name)
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3x/time"
+)
+
+const (
+	// alertsMetricName is the name of the series the active alerts of
+	// alerting rules are written to
+	alertsMetricName = "ALERTS"
+	alertNameLabel   = "alertname"
+	alertStateLabel  = "alertstate"
+
+	// resolvedRetention is how long resolved alerts are kept so their
+	// resolution keeps being sent to Alertmanager
+	resolvedRetention = 15 * time.Minute
+
+	// annotationTemplatePrefix defines the variables annotation templates
+	// may refer to, matching those of Prometheus
+	annotationTemplatePrefix = "{{$labels := .Labels}}{{$value := .Value}}"
+)
+
+type alertState int
+
+const (
+	alertStateInactive alertState = iota
+	alertStatePending
+	alertStateFiring
+)
+
+func (s alertState) String() string {
+	switch s {
+	case alertStatePending:
+		return "pending"
+	case alertStateFiring:
+		return "firing"
+	default:
+		return "inactive"
+	}
+}
+
+type activeAlert struct {
+	labels      models.Tags
+	annotations map[string]string
+	value       float64
+	state       alertState
+	activeAt    time.Time
+	resolvedAt  time.Time
+	lastSentAt  time.Time
+}
+
+// needsSending returns whether an alert should be sent to Alertmanager,
+// firing alerts are resent every resend delay and resolved alerts are sent
+// as soon as they resolve
+func (a *activeAlert) needsSending(t time.Time, resendDelay time.Duration) bool {
+	if a.state == alertStatePending {
+		return false
+	}
+
+	if a.resolvedAt.After(a.lastSentAt) {
+		return true
+	}
+
+	return !a.lastSentAt.Add(resendDelay).After(t)
+}
+
+type annotationData struct {
+	Labels map[string]string
+	Value  float64
+}
+
+func newAnnotationTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Option("missingkey=zero").Parse(annotationTemplatePrefix + text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %s: %v", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// alertingRule tracks the alerts of an alerting rule across evaluations,
+// keyed by the ID of their labels
+type alertingRule struct {
+	config      RuleConfiguration
+	annotations map[string]*template.Template
+	active      map[string]*activeAlert
+}
+
+func newAlertingRule(cfg RuleConfiguration) (*alertingRule, error) {
+	annotations := make(map[string]*template.Template, len(cfg.Annotations))
+	for name, text := range cfg.Annotations {
+		tmpl, err := newAnnotationTemplate(name, text)
+		if err != nil {
+			return nil, err
+		}
+
+		annotations[name] = tmpl
+	}
+
+	return &alertingRule{
+		config:      cfg,
+		annotations: annotations,
+		active:      make(map[string]*activeAlert),
+	}, nil
+}
+
+// update updates the alerts of the rule with the results of evaluating it
+// at an instant: new results become pending alerts, pending alerts fire once
+// they have been active for the duration of the rule and firing alerts
+// missing from the results are resolved
+func (r *alertingRule) update(series []*ts.Series, t time.Time) error {
+	results := make(map[string]*activeAlert, len(series))
+	for _, s := range series {
+		dp, ok := executor.InstantValue(s, t)
+		if !ok {
+			continue
+		}
+
+		labels := alertLabels(s.Tags, r.config.Alert, r.config.Labels)
+		id := labels.ID()
+		if _, ok := results[id]; ok {
+			return fmt.Errorf("multiple results with labels %s", id)
+		}
+
+		results[id] = &activeAlert{
+			labels:      labels,
+			annotations: r.expandAnnotations(s.Tags.WithoutName(), dp.Value),
+			value:       dp.Value,
+			state:       alertStatePending,
+			activeAt:    t,
+		}
+	}
+
+	for id, result := range results {
+		alert, ok := r.active[id]
+		if !ok || alert.state == alertStateInactive {
+			r.active[id] = result
+			continue
+		}
+
+		alert.annotations = result.annotations
+		alert.value = result.value
+	}
+
+	for id, alert := range r.active {
+		if _, ok := results[id]; ok {
+			if alert.state == alertStatePending && t.Sub(alert.activeAt) >= r.config.For {
+				alert.state = alertStateFiring
+			}
+			continue
+		}
+
+		switch alert.state {
+		case alertStatePending:
+			delete(r.active, id)
+		case alertStateFiring:
+			alert.state = alertStateInactive
+			alert.resolvedAt = t
+		case alertStateInactive:
+			if t.Sub(alert.resolvedAt) > resolvedRetention {
+				delete(r.active, id)
+			}
+		}
+	}
+
+	return nil
+}
+
+// expandAnnotations expands the annotation templates of the rule, a
+// template which fails to execute expands to its error so the alert is
+// still sent
+func (r *alertingRule) expandAnnotations(tags models.Tags, value float64) map[string]string {
+	if len(r.annotations) == 0 {
+		return nil
+	}
+
+	data := annotationData{Labels: tags.StringMap(), Value: value}
+	expanded := make(map[string]string, len(r.annotations))
+	for name, tmpl := range r.annotations {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			expanded[name] = fmt.Sprintf("<error expanding template: %v>", err)
+			continue
+		}
+
+		expanded[name] = buf.String()
+	}
+
+	return expanded
+}
+
+// writeQueries returns the ALERTS series of the pending and firing alerts
+// of the rule
+func (r *alertingRule) writeQueries(t time.Time, attrs storage.Attributes) []*storage.WriteQuery {
+	queries := make([]*storage.WriteQuery, 0, len(r.active))
+	for _, alert := range r.active {
+		if alert.state == alertStateInactive {
+			continue
+		}
+
+		tags := alert.labels.Clone().
+			AddTag(models.Tag{Name: models.MetricName, Value: alertsMetricName}).
+			AddTag(models.Tag{Name: alertStateLabel, Value: alert.state.String()})
+		queries = append(queries, &storage.WriteQuery{
+			Tags:       tags,
+			Datapoints: ts.Datapoints{{Timestamp: t, Value: 1}},
+			Unit:       xtime.Millisecond,
+			Attributes: attrs,
+		})
+	}
+
+	return queries
+}
+
+// alertsToSend returns the alerts of the rule which need to be sent to
+// Alertmanager. Firing alerts are sent with an end time far enough in the
+// future that they do not resolve in Alertmanager before they are resent.
+func (r *alertingRule) alertsToSend(t time.Time, resendDelay, interval time.Duration) []Alert {
+	validity := resendDelay
+	if interval > validity {
+		validity = interval
+	}
+
+	var alerts []Alert
+	for _, alert := range r.active {
+		if !alert.needsSending(t, resendDelay) {
+			continue
+		}
+
+		endsAt := t.Add(3 * validity)
+		if alert.state == alertStateInactive {
+			endsAt = alert.resolvedAt
+		}
+
+		alerts = append(alerts, Alert{
+			Labels:      alert.labels.StringMap(),
+			Annotations: alert.annotations,
+			StartsAt:    alert.activeAt,
+			EndsAt:      endsAt,
+		})
+	}
+
+	return alerts
+}
+
+// markSent marks the alerts of the rule which needed to be sent at an
+// instant as sent, once they have been sent successfully, so alerts which
+// failed to send are sent again on the next evaluation
+func (r *alertingRule) markSent(t time.Time, resendDelay time.Duration) {
+	for _, alert := range r.active {
+		if alert.needsSending(t, resendDelay) {
+			alert.lastSentAt = t
+		}
+	}
+}
+
+// alertLabels returns the labels of an alert, which are the tags of the
+// result without its name, with the labels of the rule and the name of the
+// alert
+func alertLabels(tags models.Tags, alert string, labels map[string]string) models.Tags {
+	merged := make(map[string]string, len(tags)+len(labels)+1)
+	for _, tag := range tags.WithoutName() {
+		merged[tag.Name] = tag.Value
+	}
+
+	for name, value := range labels {
+		merged[name] = value
+	}
+
+	merged[alertNameLabel] = alert
+	return models.FromMap(merged)
+}
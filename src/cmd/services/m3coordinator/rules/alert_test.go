@@ -0,0 +1,188 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAlertSeries(instance string, t time.Time, value float64) *ts.Series {
+	tags := models.Tags{{Name: models.MetricName, Value: "cpu_usage"}, {Name: "instance", Value: instance}}
+	return ts.NewSeries("cpu_usage", ts.Datapoints{{Timestamp: t, Value: value}}, tags)
+}
+
+func newTestAlertingRule(t *testing.T, forDuration time.Duration) *alertingRule {
+	r, err := newAlertingRule(RuleConfiguration{
+		Alert:  "HighCPU",
+		Expr:   "cpu_usage > 90",
+		For:    forDuration,
+		Labels: map[string]string{"severity": "page"},
+		Annotations: map[string]string{
+			"summary": "{{ $labels.instance }} is at {{ $value }}",
+		},
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func activeAlertStates(r *alertingRule) map[string]alertState {
+	states := make(map[string]alertState, len(r.active))
+	for _, alert := range r.active {
+		instance, _ := alert.labels.Get("instance")
+		states[instance] = alert.state
+	}
+
+	return states
+}
+
+func TestAlertingRuleUpdate(t *testing.T) {
+	r := newTestAlertingRule(t, 2*time.Minute)
+	start := time.Unix(1500000000, 0)
+
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", start, 95)}, start))
+	assert.Equal(t, map[string]alertState{"a": alertStatePending}, activeAlertStates(r))
+
+	for _, alert := range r.active {
+		assert.Equal(t, models.Tags{
+			{Name: alertNameLabel, Value: "HighCPU"},
+			{Name: "instance", Value: "a"},
+			{Name: "severity", Value: "page"},
+		}, alert.labels)
+		assert.Equal(t, map[string]string{"summary": "a is at 95"}, alert.annotations)
+		assert.Equal(t, start, alert.activeAt)
+	}
+
+	// Pending alerts do not fire until they have been active for the
+	// duration of the rule
+	next := start.Add(time.Minute)
+	require.NoError(t, r.update([]*ts.Series{
+		newTestAlertSeries("a", next, 96),
+		newTestAlertSeries("b", next, 97),
+	}, next))
+	assert.Equal(t, map[string]alertState{"a": alertStatePending, "b": alertStatePending}, activeAlertStates(r))
+
+	next = start.Add(2 * time.Minute)
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", next, 98)}, next))
+	assert.Equal(t, map[string]alertState{"a": alertStateFiring}, activeAlertStates(r))
+
+	// Firing alerts missing from the results resolve and are then kept
+	// until the resolved retention expires
+	resolved := start.Add(3 * time.Minute)
+	require.NoError(t, r.update(nil, resolved))
+	assert.Equal(t, map[string]alertState{"a": alertStateInactive}, activeAlertStates(r))
+
+	next = resolved.Add(resolvedRetention)
+	require.NoError(t, r.update(nil, next))
+	assert.Len(t, r.active, 1)
+
+	require.NoError(t, r.update(nil, next.Add(time.Second)))
+	assert.Len(t, r.active, 0)
+}
+
+func TestAlertingRuleUpdateWithoutFor(t *testing.T) {
+	r := newTestAlertingRule(t, 0)
+	start := time.Unix(1500000000, 0)
+
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", start, 95)}, start))
+	assert.Equal(t, map[string]alertState{"a": alertStateFiring}, activeAlertStates(r))
+
+	queries := r.writeQueries(start, storage.Attributes{MetricsType: storage.UnaggregatedMetricsType})
+	require.Len(t, queries, 1)
+	assert.Equal(t, models.Tags{
+		{Name: models.MetricName, Value: alertsMetricName},
+		{Name: alertNameLabel, Value: "HighCPU"},
+		{Name: alertStateLabel, Value: "firing"},
+		{Name: "instance", Value: "a"},
+		{Name: "severity", Value: "page"},
+	}, queries[0].Tags)
+	require.Len(t, queries[0].Datapoints, 1)
+	assert.Equal(t, float64(1), queries[0].Datapoints[0].Value)
+}
+
+func TestAlertingRuleUpdateDuplicateLabels(t *testing.T) {
+	r := newTestAlertingRule(t, 0)
+	start := time.Unix(1500000000, 0)
+
+	// The results only differ by name, which alerts do not keep
+	other := newTestAlertSeries("a", start, 95)
+	other.Tags = models.Tags{{Name: models.MetricName, Value: "cpu_other"}, {Name: "instance", Value: "a"}}
+
+	err := r.update([]*ts.Series{newTestAlertSeries("a", start, 95), other}, start)
+	assert.Error(t, err)
+	assert.Len(t, r.active, 0)
+}
+
+func TestAlertingRuleAlertsToSend(t *testing.T) {
+	r := newTestAlertingRule(t, 0)
+	start := time.Unix(1500000000, 0)
+	resendDelay := time.Minute
+	interval := 30 * time.Second
+
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", start, 95)}, start))
+	alerts := r.alertsToSend(start, resendDelay, interval)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, Alert{
+		Labels:      map[string]string{alertNameLabel: "HighCPU", "instance": "a", "severity": "page"},
+		Annotations: map[string]string{"summary": "a is at 95"},
+		StartsAt:    start,
+		EndsAt:      start.Add(3 * resendDelay),
+	}, alerts[0])
+
+	// Alerts which have not been sent successfully are sent again
+	next := start.Add(interval)
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", next, 95)}, next))
+	assert.Len(t, r.alertsToSend(next, resendDelay, interval), 1)
+	r.markSent(next, resendDelay)
+
+	// Firing alerts are only resent after the resend delay
+	next = next.Add(interval)
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", next, 95)}, next))
+	assert.Len(t, r.alertsToSend(next, resendDelay, interval), 0)
+
+	next = start.Add(interval + resendDelay)
+	require.NoError(t, r.update([]*ts.Series{newTestAlertSeries("a", next, 95)}, next))
+	assert.Len(t, r.alertsToSend(next, resendDelay, interval), 1)
+	r.markSent(next, resendDelay)
+
+	// Resolved alerts are sent straight away, ending when they resolved,
+	// until they have been sent successfully
+	resolved := next.Add(interval)
+	require.NoError(t, r.update(nil, resolved))
+	alerts = r.alertsToSend(resolved, resendDelay, interval)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, resolved, alerts[0].EndsAt)
+
+	next = resolved.Add(interval)
+	require.NoError(t, r.update(nil, next))
+	alerts = r.alertsToSend(next, resendDelay, interval)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, resolved, alerts[0].EndsAt)
+	r.markSent(next, resendDelay)
+	assert.Len(t, r.alertsToSend(next.Add(interval), resendDelay, interval), 0)
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package rules evaluates Prometheus recording and alerting rules against
+// the query engine, writing their results back to storage and sending the
+// alerts to Alertmanager.
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql"
+
+	pql "github.com/prometheus/prometheus/promql"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var (
+	errMissingGroupName = errors.New("missing rule group name")
+	errMissingRecord    = errors.New("missing record or alert")
+	errRecordAndAlert   = errors.New("only one of record or alert may be set")
+	errMissingExpr      = errors.New("missing expr")
+
+	metricNameRegex = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNameRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// File is a Prometheus rule file.
+type File struct {
+	Groups []GroupConfiguration `yaml:"groups"`
+}
+
+// GroupConfiguration is a group of rules evaluated together on an interval.
+type GroupConfiguration struct {
+	Name string `yaml:"name"`
+	// Interval is how often the rules of the group are evaluated, the
+	// default evaluation interval is used if it is not set.
+	Interval time.Duration       `yaml:"interval"`
+	Rules    []RuleConfiguration `yaml:"rules"`
+}
+
+// RuleConfiguration is a recording or alerting rule of a rule group.
+type RuleConfiguration struct {
+	// Record is the name of the series the results of a recording rule are
+	// written to.
+	Record string `yaml:"record"`
+	// Alert is the name of the alert of an alerting rule.
+	Alert string `yaml:"alert"`
+	// Expr is the promql expression of the rule.
+	Expr string `yaml:"expr"`
+	// For is how long an alert must be active before it fires, it is
+	// pending until then.
+	For time.Duration `yaml:"for"`
+	// Labels are added to the results of the rule, replacing existing labels.
+	Labels map[string]string `yaml:"labels"`
+	// Annotations are added to the alerts of an alerting rule, they are
+	// templates which may refer to the labels of an alert as $labels and
+	// to its value as $value.
+	Annotations map[string]string `yaml:"annotations"`
+}
+
+// Name returns the record or the alert of a rule.
+func (c RuleConfiguration) Name() string {
+	if c.Alert != "" {
+		return c.Alert
+	}
+
+	return c.Record
+}
+
+// LoadFiles loads and validates the rule groups of each rule file.
+func LoadFiles(paths []string) ([]GroupConfiguration, error) {
+	var (
+		groups []GroupConfiguration
+		names  = make(map[string]string)
+	)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := ParseFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule file %s: %v", path, err)
+		}
+
+		for _, group := range file.Groups {
+			if other, ok := names[group.Name]; ok {
+				return nil, fmt.Errorf("rule group %s in %s is already defined in %s",
+					group.Name, path, other)
+			}
+			names[group.Name] = path
+		}
+
+		groups = append(groups, file.Groups...)
+	}
+
+	return groups, nil
+}
+
+// ParseFile parses and validates the contents of a rule file.
+func ParseFile(data []byte) (File, error) {
+	var file File
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return File{}, err
+	}
+
+	names := make(map[string]struct{}, len(file.Groups))
+	for _, group := range file.Groups {
+		if err := group.Validate(); err != nil {
+			return File{}, err
+		}
+
+		if _, ok := names[group.Name]; ok {
+			return File{}, fmt.Errorf("duplicate rule group: %s", group.Name)
+		}
+		names[group.Name] = struct{}{}
+	}
+
+	return file, nil
+}
+
+// Validate validates a rule group.
+func (c GroupConfiguration) Validate() error {
+	if c.Name == "" {
+		return errMissingGroupName
+	}
+
+	if c.Interval < 0 {
+		return fmt.Errorf("invalid interval for rule group %s: %v", c.Name, c.Interval)
+	}
+
+	for i, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid rule %d of rule group %s: %v", i, c.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate validates a rule.
+func (c RuleConfiguration) Validate() error {
+	switch {
+	case c.Record == "" && c.Alert == "":
+		return errMissingRecord
+	case c.Record != "" && c.Alert != "":
+		return errRecordAndAlert
+	}
+
+	if !metricNameRegex.MatchString(c.Name()) {
+		return fmt.Errorf("invalid rule name: %s", c.Name())
+	}
+
+	if c.Record != "" && (c.For != 0 || len(c.Annotations) > 0) {
+		return fmt.Errorf("for and annotations are only valid for alerting rules")
+	}
+
+	if c.For < 0 {
+		return fmt.Errorf("invalid for: %v", c.For)
+	}
+
+	for name, text := range c.Annotations {
+		if !labelNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid annotation name: %s", name)
+		}
+
+		if _, err := newAnnotationTemplate(name, text); err != nil {
+			return err
+		}
+	}
+
+	for name := range c.Labels {
+		if !labelNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid label name: %s", name)
+		}
+	}
+
+	_, err := c.parse()
+	return err
+}
+
+// parse parses the expression of a rule, which must evaluate to an instant
+// vector or a scalar
+func (c RuleConfiguration) parse() (parser.Parser, error) {
+	if c.Expr == "" {
+		return nil, errMissingExpr
+	}
+
+	expr, err := pql.ParseExpr(c.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.Type() {
+	case pql.ValueTypeVector, pql.ValueTypeScalar:
+	default:
+		return nil, fmt.Errorf("expr must evaluate to an instant vector or scalar, got %s", expr.Type())
+	}
+
+	return promql.Parse(c.Expr)
+}
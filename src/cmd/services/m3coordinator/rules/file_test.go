@@ -0,0 +1,152 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRuleFile = `
+groups:
+  - name: http
+    interval: 30s
+    rules:
+      - record: job:http_requests:rate5m
+        expr: sum(rate(http_requests_total[5m])) by (job)
+        labels:
+          team: web
+  - name: cpu
+    rules:
+      - record: instance:cpu:avg
+        expr: avg(cpu_usage) by (instance)
+`
+
+func TestParseFile(t *testing.T) {
+	file, err := ParseFile([]byte(testRuleFile))
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		Groups: []GroupConfiguration{
+			{
+				Name:     "http",
+				Interval: 30 * time.Second,
+				Rules: []RuleConfiguration{
+					{
+						Record: "job:http_requests:rate5m",
+						Expr:   "sum(rate(http_requests_total[5m])) by (job)",
+						Labels: map[string]string{"team": "web"},
+					},
+				},
+			},
+			{
+				Name: "cpu",
+				Rules: []RuleConfiguration{
+					{Record: "instance:cpu:avg", Expr: "avg(cpu_usage) by (instance)"},
+				},
+			},
+		},
+	}, file)
+}
+
+func TestParseFileAlertingRule(t *testing.T) {
+	file, err := ParseFile([]byte(`
+groups:
+  - name: cpu
+    rules:
+      - alert: HighCPU
+        expr: cpu_usage > 90
+        for: 5m
+        labels:
+          severity: page
+        annotations:
+          summary: "{{ $labels.instance }} is at {{ $value }}"
+`))
+	require.NoError(t, err)
+	require.Len(t, file.Groups, 1)
+	assert.Equal(t, []RuleConfiguration{
+		{
+			Alert:       "HighCPU",
+			Expr:        "cpu_usage > 90",
+			For:         5 * time.Minute,
+			Labels:      map[string]string{"severity": "page"},
+			Annotations: map[string]string{"summary": "{{ $labels.instance }} is at {{ $value }}"},
+		},
+	}, file.Groups[0].Rules)
+	assert.Equal(t, "HighCPU", file.Groups[0].Rules[0].Name())
+}
+
+func TestParseFileErrors(t *testing.T) {
+	for _, data := range []string{
+		"groups: [{name: a, unknown: b}]",
+		"groups: [{rules: [{record: a, expr: b}]}]",
+		"groups: [{name: a}, {name: a}]",
+		"groups: [{name: a, interval: -1m}]",
+		"groups: [{name: a, rules: [{expr: b}]}]",
+		"groups: [{name: a, rules: [{record: a-b, expr: b}]}]",
+		"groups: [{name: a, rules: [{record: a}]}]",
+		"groups: [{name: a, rules: [{record: a, expr: 'sum('}]}]",
+		"groups: [{name: a, rules: [{record: a, expr: 'b[5m]'}]}]",
+		"groups: [{name: a, rules: [{record: a, expr: b, labels: {a-b: c}}]}]",
+		"groups: [{name: a, rules: [{record: a, alert: a, expr: b}]}]",
+		"groups: [{name: a, rules: [{record: a, expr: b, for: 1m}]}]",
+		"groups: [{name: a, rules: [{alert: a-b, expr: b}]}]",
+		"groups: [{name: a, rules: [{alert: a, expr: b, for: -1m}]}]",
+		"groups: [{name: a, rules: [{alert: a, expr: b, annotations: {a-b: c}}]}]",
+		"groups: [{name: a, rules: [{alert: a, expr: b, annotations: {a: '{{ $value '}}]}]",
+	} {
+		_, err := ParseFile([]byte(data))
+		assert.Error(t, err, data)
+	}
+}
+
+func TestLoadFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rules")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	first := filepath.Join(dir, "first.yml")
+	second := filepath.Join(dir, "second.yml")
+	require.NoError(t, ioutil.WriteFile(first, []byte(testRuleFile), 0644))
+	require.NoError(t, ioutil.WriteFile(second, []byte(`
+groups:
+  - name: mem
+    rules:
+      - record: instance:mem:avg
+        expr: avg(mem_usage) by (instance)
+`), 0644))
+
+	groups, err := LoadFiles([]string{first, second})
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	assert.Equal(t, "mem", groups[2].Name)
+
+	// Group names are unique across files
+	_, err = LoadFiles([]string{first, first})
+	assert.Error(t, err)
+
+	_, err = LoadFiles([]string{filepath.Join(dir, "missing.yml")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package rules
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3x/errors"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// instantQueryStep is the step rules are evaluated with
+	instantQueryStep = time.Second
+)
+
+type rule struct {
+	config RuleConfiguration
+	query  parser.Parser
+	// alerting is set for alerting rules
+	alerting *alertingRule
+}
+
+type groupMetrics struct {
+	evaluations        tally.Counter
+	evaluationFailures tally.Counter
+	evaluationLatency  tally.Timer
+	samplesWritten     tally.Counter
+	alertsSent         tally.Counter
+	notifyFailures     tally.Counter
+}
+
+func newGroupMetrics(scope tally.Scope) groupMetrics {
+	return groupMetrics{
+		evaluations:        scope.Counter("evaluations"),
+		evaluationFailures: scope.Counter("evaluation-failures"),
+		evaluationLatency:  scope.Timer("evaluation-latency"),
+		samplesWritten:     scope.Counter("samples-written"),
+		alertsSent:         scope.Counter("alerts-sent"),
+		notifyFailures:     scope.Counter("notification-failures"),
+	}
+}
+
+// group evaluates the rules of a rule group in order on an interval, so a
+// rule may use the results of the rules before it
+type group struct {
+	name     string
+	interval time.Duration
+	rules    []rule
+	opts     ManagerOptions
+	metrics  groupMetrics
+	logger   *zap.Logger
+}
+
+func newGroup(cfg GroupConfiguration, opts ManagerOptions) (*group, error) {
+	rules := make([]rule, 0, len(cfg.Rules))
+	for _, ruleCfg := range cfg.Rules {
+		query, err := ruleCfg.parse()
+		if err != nil {
+			return nil, fmt.Errorf("invalid rule %s of rule group %s: %v", ruleCfg.Name(), cfg.Name, err)
+		}
+
+		r := rule{config: ruleCfg, query: query}
+		if ruleCfg.Alert != "" {
+			if r.alerting, err = newAlertingRule(ruleCfg); err != nil {
+				return nil, fmt.Errorf("invalid rule %s of rule group %s: %v", ruleCfg.Name(), cfg.Name, err)
+			}
+		}
+
+		rules = append(rules, r)
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = opts.DefaultInterval
+	}
+
+	return &group{
+		name:     cfg.Name,
+		interval: interval,
+		rules:    rules,
+		opts:     opts,
+		metrics:  newGroupMetrics(opts.Scope.Tagged(map[string]string{"rule_group": cfg.Name})),
+		logger:   opts.Logger.With(zap.String("ruleGroup", cfg.Name)),
+	}, nil
+}
+
+// run evaluates the group on each tick of its interval until closed
+func (g *group) run(closeCh <-chan struct{}) {
+	ticker := time.NewTicker(g.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+			g.evaluate(g.opts.NowFn())
+		}
+	}
+}
+
+// evaluate evaluates each rule of the group at an instant, a failing rule
+// does not prevent the rules after it from being evaluated. The alerts of
+// the alerting rules of the group are then sent together.
+func (g *group) evaluate(t time.Time) error {
+	start := time.Now()
+	g.metrics.evaluations.Inc(1)
+
+	var (
+		multiErr xerrors.MultiError
+		alerts   []Alert
+		sending  []*alertingRule
+	)
+	for _, r := range g.rules {
+		var err error
+		if r.alerting != nil {
+			err = g.evaluateAlertingRule(r, t)
+		} else {
+			err = g.evaluateRule(r, t)
+		}
+
+		if err != nil {
+			g.logger.Error("unable to evaluate rule",
+				zap.String("rule", r.config.Name()), zap.Error(err))
+			multiErr = multiErr.Add(fmt.Errorf("rule %s: %v", r.config.Name(), err))
+		}
+
+		if r.alerting != nil && g.opts.Notifier != nil {
+			toSend := r.alerting.alertsToSend(t, g.opts.ResendDelay, g.interval)
+			if len(toSend) > 0 {
+				alerts = append(alerts, toSend...)
+				sending = append(sending, r.alerting)
+			}
+		}
+	}
+
+	if len(alerts) > 0 {
+		// The alerts are only marked as sent once sent successfully so that
+		// they are retried on the next evaluation
+		if err := g.notify(alerts); err != nil {
+			g.logger.Error("unable to send alerts",
+				zap.Int("alerts", len(alerts)), zap.Error(err))
+			multiErr = multiErr.Add(fmt.Errorf("unable to send alerts: %v", err))
+		} else {
+			for _, r := range sending {
+				r.markSent(t, g.opts.ResendDelay)
+			}
+		}
+	}
+
+	g.metrics.evaluationLatency.Record(time.Since(start))
+	err := multiErr.FinalError()
+	if err != nil {
+		g.metrics.evaluationFailures.Inc(1)
+	}
+
+	return err
+}
+
+func (g *group) evaluateRule(r rule, t time.Time) error {
+	series, err := evaluateInstant(g.opts, r.query, r.config.Expr, t)
+	if err != nil {
+		return err
+	}
+
+	queries := make([]*storage.WriteQuery, 0, len(series))
+	for _, s := range series {
+		dp, ok := executor.InstantValue(s, t)
+		if !ok {
+			continue
+		}
+
+		queries = append(queries, &storage.WriteQuery{
+			Tags:       recordTags(s.Tags, r.config.Record, r.config.Labels),
+			Datapoints: ts.Datapoints{{Timestamp: t, Value: dp.Value}},
+			Unit:       xtime.Millisecond,
+			Attributes: g.opts.Attributes,
+		})
+	}
+
+	return g.write(queries)
+}
+
+// evaluateAlertingRule updates the alerts of an alerting rule and writes
+// the ALERTS series of its pending and firing alerts
+func (g *group) evaluateAlertingRule(r rule, t time.Time) error {
+	series, err := evaluateInstant(g.opts, r.query, r.config.Expr, t)
+	if err != nil {
+		return err
+	}
+
+	if err := r.alerting.update(series, t); err != nil {
+		return err
+	}
+
+	return g.write(r.alerting.writeQueries(t, g.opts.Attributes))
+}
+
+func (g *group) write(queries []*storage.WriteQuery) error {
+	var multiErr xerrors.MultiError
+	for _, query := range queries {
+		ctx, cancel := context.WithTimeout(context.Background(), g.opts.QueryTimeout)
+		err := g.opts.Appender.Write(ctx, query)
+		cancel()
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+
+		g.metrics.samplesWritten.Inc(1)
+	}
+
+	return multiErr.FinalError()
+}
+
+func (g *group) notify(alerts []Alert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), g.opts.NotificationTimeout)
+	defer cancel()
+
+	if err := g.opts.Notifier.Notify(ctx, alerts); err != nil {
+		g.metrics.notifyFailures.Inc(1)
+		return err
+	}
+
+	g.metrics.alertsSent.Inc(int64(len(alerts)))
+	return nil
+}
+
+// evaluateInstant evaluates a parsed query at an instant
+func evaluateInstant(
+	opts ManagerOptions,
+	query parser.Parser,
+	expr string,
+	t time.Time,
+) ([]*ts.Series, error) {
+	params := models.RequestParams{
+		Start:      t,
+		End:        t,
+		Now:        t,
+		Step:       instantQueryStep,
+		IncludeEnd: true,
+		Timeout:    opts.QueryTimeout,
+		Query:      expr,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.QueryTimeout)
+	defer cancel()
+
+	result, err := opts.Engine.ReadParsed(ctx, query, &executor.EngineOptions{}, params)
+	return result.Series, err
+}
+
+// recordTags returns the tags of a recorded series, which are the tags of
+// the result with the name of the record and the labels of the rule
+func recordTags(tags models.Tags, record string, labels map[string]string) models.Tags {
+	merged := make(map[string]string, len(tags)+len(labels)+1)
+	for _, tag := range tags {
+		merged[tag.Name] = tag.Value
+	}
+
+	for name, value := range labels {
+		merged[name] = value
+	}
+
+	merged[models.MetricName] = record
+	return models.FromMap(merged)
+}
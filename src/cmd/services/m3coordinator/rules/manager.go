@@ -0,0 +1,167 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package rules
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/storage"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultInterval     = time.Minute
+	defaultQueryTimeout = 30 * time.Second
+	defaultResendDelay  = time.Minute
+	// defaultNotificationTimeout matches the default of Prometheus
+	defaultNotificationTimeout = 10 * time.Second
+)
+
+var (
+	errNoEngine   = errors.New("no engine set")
+	errNoAppender = errors.New("no appender set")
+)
+
+// ManagerOptions are the options of a rule manager.
+type ManagerOptions struct {
+	// Engine evaluates the expressions of rules.
+	Engine *executor.Engine
+	// Appender writes the results of rules.
+	Appender storage.Appender
+	// Attributes select the namespace the results of rules are written to.
+	Attributes storage.Attributes
+	// Groups are the rule groups to evaluate.
+	Groups []GroupConfiguration
+	// DefaultInterval is the interval of groups which do not set one,
+	// defaulting to one minute.
+	DefaultInterval time.Duration
+	// QueryTimeout is the timeout of evaluating a rule and of writing its
+	// results, defaulting to thirty seconds.
+	QueryTimeout time.Duration
+	// Notifier sends the alerts of alerting rules, alerts are only written
+	// to storage if it is not set.
+	Notifier Notifier
+	// ResendDelay is how often firing alerts are resent, defaulting to one
+	// minute.
+	ResendDelay time.Duration
+	// NotificationTimeout is the timeout of sending alerts, defaulting to
+	// ten seconds.
+	NotificationTimeout time.Duration
+	Scope               tally.Scope
+	Logger              *zap.Logger
+	NowFn               func() time.Time
+}
+
+// Manager evaluates rule groups, each on its own interval.
+type Manager struct {
+	groups  []*group
+	logger  *zap.Logger
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager returns a new rule manager, which evaluates its groups once
+// started.
+func NewManager(opts ManagerOptions) (*Manager, error) {
+	if opts.Engine == nil {
+		return nil, errNoEngine
+	}
+
+	if opts.Appender == nil {
+		return nil, errNoAppender
+	}
+
+	if opts.DefaultInterval <= 0 {
+		opts.DefaultInterval = defaultInterval
+	}
+
+	if opts.QueryTimeout <= 0 {
+		opts.QueryTimeout = defaultQueryTimeout
+	}
+
+	if opts.ResendDelay <= 0 {
+		opts.ResendDelay = defaultResendDelay
+	}
+
+	if opts.NotificationTimeout <= 0 {
+		opts.NotificationTimeout = defaultNotificationTimeout
+	}
+
+	if opts.Scope == nil {
+		opts.Scope = tally.NoopScope
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = zap.NewNop()
+	}
+
+	if opts.NowFn == nil {
+		opts.NowFn = time.Now
+	}
+
+	groups := make([]*group, 0, len(opts.Groups))
+	for _, cfg := range opts.Groups {
+		if err := cfg.Validate(); err != nil {
+			return nil, err
+		}
+
+		g, err := newGroup(cfg, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		groups = append(groups, g)
+	}
+
+	return &Manager{
+		groups:  groups,
+		logger:  opts.Logger,
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+// Start starts evaluating each rule group.
+func (m *Manager) Start() {
+	for _, g := range m.groups {
+		g := g
+		m.logger.Info("starting rule group evaluation",
+			zap.String("ruleGroup", g.name),
+			zap.Duration("interval", g.interval),
+			zap.Int("rules", len(g.rules)))
+
+		m.wg.Add(1)
+		go func() {
+			g.run(m.closeCh)
+			m.wg.Done()
+		}()
+	}
+}
+
+// Close stops evaluating the rule groups, waiting for any evaluation in
+// progress to finish.
+func (m *Manager) Close() {
+	close(m.closeCh)
+	m.wg.Wait()
+}
@@ -0,0 +1,292 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+var testEvaluationTime = time.Unix(1500000120, 0)
+
+func newTestStorage() mock.Storage {
+	bounds := block.Bounds{
+		Start:    time.Unix(1500000000, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	metas := []block.SeriesMeta{
+		{Name: "a", Tags: models.Tags{{Name: models.MetricName, Value: "cpu_usage"}, {Name: "instance", Value: "a"}}},
+		{Name: "b", Tags: models.Tags{{Name: models.MetricName, Value: "cpu_usage"}, {Name: "instance", Value: "b"}}},
+	}
+
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+	})
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	return store
+}
+
+func newTestManager(t *testing.T, store mock.Storage, groups []GroupConfiguration) *Manager {
+	manager, err := NewManager(ManagerOptions{
+		Engine:   executor.NewEngine(store),
+		Appender: store,
+		Attributes: storage.Attributes{
+			MetricsType: storage.AggregatedMetricsType,
+			Resolution:  time.Minute,
+			Retention:   40 * 24 * time.Hour,
+		},
+		Groups: groups,
+		NowFn: func() time.Time {
+			return testEvaluationTime
+		},
+	})
+	require.NoError(t, err)
+	return manager
+}
+
+func TestGroupEvaluate(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newTestStorage()
+	manager := newTestManager(t, store, []GroupConfiguration{
+		{
+			Name: "cpu",
+			Rules: []RuleConfiguration{
+				{
+					Record: "instance:cpu_usage:max",
+					Expr:   "max(cpu_usage) by (instance)",
+					Labels: map[string]string{"team": "infra"},
+				},
+			},
+		},
+	})
+	require.Len(t, manager.groups, 1)
+	assert.Equal(t, defaultInterval, manager.groups[0].interval)
+
+	require.NoError(t, manager.groups[0].evaluate(testEvaluationTime))
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+	sort.Slice(writes, func(i, j int) bool {
+		return writes[i].Tags.ID() < writes[j].Tags.ID()
+	})
+
+	for i, expected := range []struct {
+		instance string
+		value    float64
+	}{
+		{instance: "a", value: 3},
+		{instance: "b", value: 6},
+	} {
+		assert.Equal(t, models.Tags{
+			{Name: models.MetricName, Value: "instance:cpu_usage:max"},
+			{Name: "instance", Value: expected.instance},
+			{Name: "team", Value: "infra"},
+		}, writes[i].Tags)
+		require.Len(t, writes[i].Datapoints, 1)
+		assert.Equal(t, testEvaluationTime, writes[i].Datapoints[0].Timestamp)
+		assert.Equal(t, expected.value, writes[i].Datapoints[0].Value)
+		assert.Equal(t, storage.AggregatedMetricsType, writes[i].Attributes.MetricsType)
+	}
+}
+
+func TestGroupEvaluateFailures(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newTestStorage()
+	store.SetWriteResult(errors.New("write failed"))
+
+	scope := tally.NewTestScope("", nil)
+	manager, err := NewManager(ManagerOptions{
+		Engine:   executor.NewEngine(store),
+		Appender: store,
+		Groups: []GroupConfiguration{
+			{
+				Name:  "cpu",
+				Rules: []RuleConfiguration{{Record: "cpu:sum", Expr: "sum(cpu_usage)"}},
+			},
+		},
+		Scope: scope,
+	})
+	require.NoError(t, err)
+
+	err = manager.groups[0].evaluate(testEvaluationTime)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "write failed")
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["evaluations+rule_group=cpu"].Value())
+	assert.Equal(t, int64(1), counters["evaluation-failures+rule_group=cpu"].Value())
+}
+
+type alertmanagerStub struct {
+	sync.Mutex
+	alerts []Alert
+	status int
+}
+
+func (s *alertmanagerStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var alerts []Alert
+	if err := json.NewDecoder(r.Body).Decode(&alerts); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.alerts = append(s.alerts, alerts...)
+	if s.status != 0 {
+		w.WriteHeader(s.status)
+	}
+}
+
+func TestGroupEvaluateAlerts(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	stub := &alertmanagerStub{}
+	server := httptest.NewServer(stub)
+	defer server.Close()
+
+	store := newTestStorage()
+	scope := tally.NewTestScope("", nil)
+	manager, err := NewManager(ManagerOptions{
+		Engine:   executor.NewEngine(store),
+		Appender: store,
+		Groups: []GroupConfiguration{
+			{
+				Name: "cpu",
+				Rules: []RuleConfiguration{
+					{
+						Alert:       "HighCPU",
+						Expr:        "cpu_usage > 4",
+						Annotations: map[string]string{"summary": "{{ $labels.instance }} is high"},
+					},
+				},
+			},
+		},
+		Notifier: NewWebhookNotifier(server.URL + "/api/v1/alerts"),
+		Scope:    scope,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, manager.groups[0].evaluate(testEvaluationTime))
+
+	writes := store.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, models.Tags{
+		{Name: models.MetricName, Value: alertsMetricName},
+		{Name: alertNameLabel, Value: "HighCPU"},
+		{Name: alertStateLabel, Value: "firing"},
+		{Name: "instance", Value: "b"},
+	}, writes[0].Tags)
+
+	stub.Lock()
+	require.Len(t, stub.alerts, 1)
+	assert.Equal(t, map[string]string{alertNameLabel: "HighCPU", "instance": "b"}, stub.alerts[0].Labels)
+	assert.Equal(t, map[string]string{"summary": "b is high"}, stub.alerts[0].Annotations)
+	assert.True(t, stub.alerts[0].StartsAt.Equal(testEvaluationTime))
+	assert.True(t, stub.alerts[0].EndsAt.After(testEvaluationTime))
+	stub.status = http.StatusInternalServerError
+	stub.Unlock()
+
+	// The alert resolves once its series is gone, a failure to send the
+	// resolution fails the evaluation
+	store.SetFetchBlocksResult(block.Result{}, nil)
+	err = manager.groups[0].evaluate(testEvaluationTime.Add(time.Minute))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to send alerts")
+
+	stub.Lock()
+	require.Len(t, stub.alerts, 2)
+	assert.True(t, stub.alerts[1].EndsAt.Equal(testEvaluationTime.Add(time.Minute)))
+	stub.status = http.StatusOK
+	stub.Unlock()
+
+	// The resolution which failed to send is sent on the next evaluation
+	require.NoError(t, manager.groups[0].evaluate(testEvaluationTime.Add(2*time.Minute)))
+
+	stub.Lock()
+	require.Len(t, stub.alerts, 3)
+	assert.True(t, stub.alerts[2].EndsAt.Equal(testEvaluationTime.Add(time.Minute)))
+	stub.Unlock()
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(2), counters["alerts-sent+rule_group=cpu"].Value())
+	assert.Equal(t, int64(1), counters["notification-failures+rule_group=cpu"].Value())
+}
+
+func TestManagerStartClose(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newTestStorage()
+	manager := newTestManager(t, store, []GroupConfiguration{
+		{
+			Name:     "cpu",
+			Interval: 10 * time.Millisecond,
+			Rules:    []RuleConfiguration{{Record: "cpu:sum", Expr: "sum(cpu_usage)"}},
+		},
+	})
+
+	manager.Start()
+	for start := time.Now(); len(store.Writes()) == 0; {
+		require.True(t, time.Since(start) < 5*time.Second, "timed out waiting for evaluation")
+		time.Sleep(10 * time.Millisecond)
+	}
+	manager.Close()
+}
+
+func TestNewManagerErrors(t *testing.T) {
+	store := newTestStorage()
+	_, err := NewManager(ManagerOptions{Appender: store})
+	assert.Error(t, err)
+
+	_, err = NewManager(ManagerOptions{Engine: executor.NewEngine(store)})
+	assert.Error(t, err)
+
+	_, err = NewManager(ManagerOptions{
+		Engine:   executor.NewEngine(store),
+		Appender: store,
+		Groups:   []GroupConfiguration{{Name: "a", Rules: []RuleConfiguration{{Record: "a"}}}},
+	})
+	assert.Error(t, err)
+}
@@ -220,6 +220,14 @@ type RepairPolicy struct {
 
 	// The repair check interval.
 	CheckInterval time.Duration `yaml:"checkInterval" validate:"nonzero"`
+
+	// The number of blocks to fetch from peers per request when repairing,
+	// the default is used if unset.
+	FetchBatchSize int `yaml:"fetchBatchSize" validate:"min=0"`
+
+	// The maximum number of blocks per second to fetch from peers when
+	// repairing, the default is used if unset.
+	MaxBlocksPerSecond int `yaml:"maxBlocksPerSecond" validate:"min=0"`
 }
 
 // HashingConfiguration is the configuration for hashing.
@@ -399,6 +399,8 @@ db:
     jitter: 1h0m0s
     throttle: 2m0s
     checkInterval: 1m0s
+    fetchBatchSize: 0
+    maxBlocksPerSecond: 0
   pooling:
     blockAllocSize: 16
     type: simple
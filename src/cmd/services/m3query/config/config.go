@@ -23,8 +23,10 @@ package config
 import (
 	"time"
 
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/storage/local"
 	etcdclient "github.com/m3db/m3cluster/client/etcd"
+	"github.com/m3db/m3metrics/policy"
 	"github.com/m3db/m3x/config/listenaddress"
 	"github.com/m3db/m3x/instrument"
 )
@@ -52,12 +54,29 @@ type Configuration struct {
 	// RPC is the RPC configuration.
 	RPC *RPCConfiguration `yaml:"rpc"`
 
+	// Carbon is the carbon plaintext protocol ingestion configuration (optional).
+	Carbon *CarbonConfiguration `yaml:"carbon"`
+
+	// Rules is the recording and alerting rules configuration (optional).
+	Rules *RulesConfiguration `yaml:"rules"`
+
+	// Limits are the resource limits of each query (optional).
+	Limits LimitsConfiguration `yaml:"limits"`
+
 	// DecompressWorkerPoolCount is the number of decompression worker pools.
 	DecompressWorkerPoolCount int `yaml:"workerPoolCount"`
 
 	// DecompressWorkerPoolSize is the size of the worker pool given to each
 	// fetch request.
 	DecompressWorkerPoolSize int `yaml:"workerPoolSize"`
+
+	// WriteWorkerPoolSize is the number of goroutines shared by the write
+	// endpoints to write the series of each request concurrently.
+	WriteWorkerPoolSize int `yaml:"writeWorkerPoolSize"`
+
+	// EnableAdminAPI enables the admin endpoints which modify stored data,
+	// such as deleting series, they are disabled by default.
+	EnableAdminAPI bool `yaml:"enableAdminAPI"`
 }
 
 // LocalConfiguration is the local embedded configuration if running
@@ -89,4 +108,111 @@ type RPCConfiguration struct {
 	// RemoteListenAddresses is the remote listen addresses to call for remote
 	// coordinator calls.
 	RemoteListenAddresses []string `yaml:"remoteListenAddresses"`
+
+	// PartialResultsEnabled determines if reads return the results of the
+	// local storage with warnings when remote coordinator calls fail.
+	PartialResultsEnabled bool `yaml:"partialResultsEnabled"`
+}
+
+// CarbonConfiguration is the configuration for ingesting metrics sent with
+// the graphite carbon plaintext protocol.
+type CarbonConfiguration struct {
+	// ListenAddress is the TCP listen address.
+	ListenAddress string `yaml:"listenAddress" validate:"nonzero"`
+
+	// UDPListenAddress is the UDP listen address, UDP is disabled if unset.
+	UDPListenAddress string `yaml:"udpListenAddress"`
+
+	// Rewrites are applied in order to each metric name before it is
+	// matched against the rules.
+	Rewrites []CarbonRewriteConfiguration `yaml:"rewrites"`
+
+	// Rules map metric names to the storage policies they are written with,
+	// the first matching rule is used and metrics matching no rule are
+	// dropped. All metrics are written unaggregated if there are no rules.
+	Rules []CarbonRuleConfiguration `yaml:"rules"`
+}
+
+// CarbonRewriteConfiguration replaces the matches of a regular expression
+// within metric names.
+type CarbonRewriteConfiguration struct {
+	// Pattern is the regular expression to replace.
+	Pattern string `yaml:"pattern" validate:"nonzero"`
+
+	// Replacement is the replacement, which may refer to submatches.
+	Replacement string `yaml:"replacement"`
+}
+
+// CarbonRuleConfiguration maps the metrics whose name matches a regular
+// expression to storage policies.
+type CarbonRuleConfiguration struct {
+	// Pattern is the regular expression metric names are matched against.
+	Pattern string `yaml:"pattern" validate:"nonzero"`
+
+	// Policies are the storage policies metrics are written with, such as
+	// "1m:40d", metrics are written unaggregated if there are none.
+	Policies []policy.StoragePolicy `yaml:"policies"`
+}
+
+// LimitsConfiguration is the configuration for the resource limits of each
+// query, a limit of zero is unlimited.
+type LimitsConfiguration struct {
+	// MaxFetchedSeries is the maximum number of series a query may fetch.
+	MaxFetchedSeries int `yaml:"maxFetchedSeries"`
+
+	// MaxFetchedDatapoints is the maximum number of datapoints a query may
+	// decode.
+	MaxFetchedDatapoints int `yaml:"maxFetchedDatapoints"`
+
+	// MaxFetchedBlocks is the maximum number of blocks a query may
+	// materialize.
+	MaxFetchedBlocks int `yaml:"maxFetchedBlocks"`
+}
+
+// Limits returns the query limits of the configuration.
+func (c LimitsConfiguration) Limits() limits.Limits {
+	return limits.Limits{
+		MaxSeries:     c.MaxFetchedSeries,
+		MaxDatapoints: c.MaxFetchedDatapoints,
+		MaxBlocks:     c.MaxFetchedBlocks,
+	}
+}
+
+// RulesConfiguration is the configuration for evaluating Prometheus
+// recording and alerting rules.
+type RulesConfiguration struct {
+	// RuleFiles are the paths of the Prometheus rule files to load.
+	RuleFiles []string `yaml:"ruleFiles" validate:"nonzero"`
+
+	// EvaluationInterval is the interval of rule groups which do not set
+	// one, defaults to one minute.
+	EvaluationInterval time.Duration `yaml:"evaluationInterval"`
+
+	// QueryTimeout is the timeout of evaluating each rule, defaults to
+	// thirty seconds.
+	QueryTimeout time.Duration `yaml:"queryTimeout"`
+
+	// StoragePolicy is the resolution and retention of the aggregated
+	// namespace the results of rules are written to, they are written to
+	// the unaggregated namespace if it is not set.
+	StoragePolicy *policy.StoragePolicy `yaml:"storagePolicy"`
+
+	// Alertmanager is where the alerts of alerting rules are sent, they
+	// are only written to storage as ALERTS series if it is not set.
+	Alertmanager *AlertmanagerConfiguration `yaml:"alertmanager"`
+}
+
+// AlertmanagerConfiguration is the configuration for sending alerts to an
+// Alertmanager compatible webhook.
+type AlertmanagerConfiguration struct {
+	// URL is the URL alerts are posted to, such as
+	// http://alertmanager:9093/api/v1/alerts.
+	URL string `yaml:"url" validate:"nonzero"`
+
+	// Timeout is the timeout of sending alerts, defaults to ten seconds.
+	Timeout time.Duration `yaml:"timeout"`
+
+	// ResendDelay is how often firing alerts are resent, defaults to one
+	// minute.
+	ResendDelay time.Duration `yaml:"resendDelay"`
 }
@@ -0,0 +1,188 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3cluster/shard"
+	xerrors "github.com/m3db/m3x/errors"
+	"github.com/m3db/m3x/ident"
+)
+
+type aggregateOp struct {
+	request      rpc.AggregateQueryRawRequest
+	completionFn completionFn
+}
+
+func (a *aggregateOp) Size() int {
+	// Aggregate is always a single op
+	return 1
+}
+
+func (a *aggregateOp) CompletionFn() completionFn {
+	return a.completionFn
+}
+
+type aggregateResultAccumulatorOpts struct {
+	host     topology.Host
+	response *rpc.AggregateQueryRawResult_
+}
+
+// aggregateResultAccumulator merges the responses of an aggregate request
+// fanned out to every host, tracking the response consistency per shard in
+// the same manner as the fetchTaggedResultAccumulator.
+type aggregateResultAccumulator struct {
+	shardConsistencyResults []fetchTaggedShardConsistencyResult
+	numShardsPending        int32
+
+	errors     xerrors.Errors
+	results    index.AggregateResults
+	limit      int
+	exhaustive bool
+
+	majority         int
+	consistencyLevel topology.ReadConsistencyLevel
+	topoMap          topology.Map
+}
+
+func newAggregateResultAccumulator(
+	nsID ident.ID,
+	opts index.AggregateQueryOptions,
+	topoMap topology.Map,
+	majority int,
+	consistencyLevel topology.ReadConsistencyLevel,
+) *aggregateResultAccumulator {
+	accum := &aggregateResultAccumulator{
+		results:          index.NewAggregateResults(nsID, opts.Type),
+		limit:            opts.Limit,
+		exhaustive:       true,
+		majority:         majority,
+		consistencyLevel: consistencyLevel,
+		topoMap:          topoMap,
+		numShardsPending: int32(len(topoMap.ShardSet().All())),
+	}
+
+	// initialize shardResults based on current topology
+	targetLen := 1 + int(topoMap.ShardSet().Max())
+	accum.shardConsistencyResults = make([]fetchTaggedShardConsistencyResult, targetLen)
+	for _, hss := range topoMap.HostShardSets() {
+		for _, hShard := range hss.ShardSet().All() {
+			id := int(hShard.ID())
+			accum.shardConsistencyResults[id].enqueued++
+		}
+	}
+	return accum
+}
+
+func (accum *aggregateResultAccumulator) Add(
+	opts aggregateResultAccumulatorOpts,
+	resultErr error,
+) error {
+	host := opts.host
+	if host == nil {
+		// should never happen, guarding against incompatible changes to the `client` package.
+		err := fmt.Errorf("[invariant violated] nil host in aggregate completionFn")
+		return xerrors.NewNonRetryableError(err)
+	}
+
+	hostShardSet, ok := accum.topoMap.LookupHostShardSet(host.ID())
+	if !ok {
+		// should never happen, as we've taken a reference to the
+		// topology when beginning the request, and the var is immutable.
+		err := fmt.Errorf(
+			"[invariant violated] missing host shard in aggregate completionFn: %s", host.ID())
+		return xerrors.NewNonRetryableError(err)
+	}
+
+	if resultErr != nil {
+		accum.errors = append(accum.errors, xerrors.NewRenamedError(resultErr,
+			fmt.Errorf("error aggregating from host %s: %v", host.ID(), resultErr)))
+	} else {
+		accum.addResponse(opts.response)
+	}
+
+	for _, hs := range hostShardSet.ShardSet().All() {
+		shardID := int(hs.ID())
+		shardResult := accum.shardConsistencyResults[shardID]
+		if shardResult.done {
+			continue
+		}
+
+		if hs.State() != shard.Available || resultErr != nil {
+			shardResult.errors++
+		} else {
+			shardResult.success++
+		}
+
+		pending := shardResult.pending()
+		if topology.ReadConsistencyTermination(accum.consistencyLevel, int32(accum.majority), pending, int32(shardResult.success)) {
+			shardResult.done = true
+			if topology.ReadConsistencyAchieved(accum.consistencyLevel, accum.majority, int(shardResult.enqueued), int(shardResult.success)) {
+				accum.numShardsPending--
+			}
+		}
+
+		accum.shardConsistencyResults[shardID] = shardResult
+	}
+	return nil
+}
+
+func (accum *aggregateResultAccumulator) addResponse(response *rpc.AggregateQueryRawResult_) {
+	accum.exhaustive = accum.exhaustive && response.Exhaustive
+	for _, elem := range response.Results {
+		if accum.limitExceeded() {
+			return
+		}
+		if accum.results.Type() == index.AggregateTagNames || len(elem.TagValues) == 0 {
+			accum.results.Add(elem.TagName, nil)
+			continue
+		}
+		for _, value := range elem.TagValues {
+			if accum.limitExceeded() {
+				return
+			}
+			accum.results.Add(elem.TagName, value.TagValue)
+		}
+	}
+}
+
+func (accum *aggregateResultAccumulator) limitExceeded() bool {
+	if accum.limit > 0 && accum.results.Size() >= accum.limit {
+		accum.exhaustive = false
+		return true
+	}
+	return false
+}
+
+// Results returns the merged results once all hosts have responded, or an
+// error if the consistency requirements could not be satisfied for each shard.
+func (accum *aggregateResultAccumulator) Results() (index.AggregateResults, bool, error) {
+	if accum.numShardsPending != 0 {
+		return nil, false, fmt.Errorf(
+			"unable to satisfy consistency requirements for %d shards [ err = %s ]",
+			accum.numShardsPending, accum.errors.Error())
+	}
+	return accum.results, accum.exhaustive, nil
+}
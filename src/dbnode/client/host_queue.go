@@ -237,6 +237,10 @@ func (q *queue) drain() {
 				q.asyncFetchTagged(v)
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteOp:
+				q.asyncDelete(v)
+			case *aggregateOp:
+				q.asyncAggregate(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -486,6 +490,30 @@ func (q *queue) asyncFetchTagged(op *fetchTaggedOp) {
 	}()
 }
 
+func (q *queue) asyncAggregate(op *aggregateOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(aggregateResultAccumulatorOpts{host: q.host}, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.FetchRequestTimeout())
+		result, err := client.AggregateRaw(ctx, &op.request)
+		op.completionFn(aggregateResultAccumulatorOpts{
+			host:     q.host,
+			response: result,
+		}, err)
+		cleanup()
+	}()
+}
+
 func (q *queue) asyncTruncate(op *truncateOp) {
 	q.Add(1)
 
@@ -511,6 +539,33 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	}()
 }
 
+func (q *queue) asyncDelete(op *deleteOp) {
+	q.Add(1)
+
+	go func() {
+		cleanup := q.Done
+
+		client, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		// Deletes are administrative bulk mutations like truncates so
+		// share the same request timeout
+		ctx, _ := thrift.NewContext(q.opts.TruncateRequestTimeout())
+		if res, err := client.Delete(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	}()
+}
+
 func (q *queue) Len() int {
 	q.RLock()
 	v := q.opsSumSize
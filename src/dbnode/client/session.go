@@ -1172,6 +1172,82 @@ func (s *session) fetchTaggedIDsAttempt(
 	return iter, exhaustive, err
 }
 
+func (s *session) Aggregate(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) (index.AggregateResults, bool, error) {
+	var (
+		results    index.AggregateResults
+		exhaustive bool
+	)
+	err := s.fetchRetrier.Attempt(func() error {
+		var err error
+		results, exhaustive, err = s.aggregateAttempt(ns, q, opts)
+		return err
+	})
+	return results, exhaustive, err
+}
+
+func (s *session) aggregateAttempt(
+	ns ident.ID, q index.Query, opts index.AggregateQueryOptions,
+) (index.AggregateResults, bool, error) {
+	req, err := convert.ToRPCAggregateQueryRawRequest(ns, q, opts)
+	if err != nil {
+		return nil, false, xerrors.NewNonRetryableError(err)
+	}
+
+	var (
+		wg         sync.WaitGroup
+		enqueueErr xerrors.MultiError
+		accumLock  sync.Mutex
+		accumErr   xerrors.MultiError
+		accum      *aggregateResultAccumulator
+	)
+
+	a := &aggregateOp{request: req}
+	a.completionFn = func(result interface{}, err error) {
+		accumLock.Lock()
+		if addErr := accum.Add(result.(aggregateResultAccumulatorOpts), err); addErr != nil {
+			accumErr = accumErr.Add(addErr)
+		}
+		accumLock.Unlock()
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return nil, false, errSessionStatusNotOpen
+	}
+
+	accum = newAggregateResultAccumulator(ns, opts,
+		s.state.topoMap, s.state.majority, s.state.readLevel)
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(a); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		// NB: if this happens we have a bug, once we are in the read
+		// lock the current queues should never be closed
+		wrappedErr := fmt.Errorf("[invariant violated] failed to enqueue aggregate: %v", err)
+		s.log.Errorf(wrappedErr.Error())
+		wg.Wait()
+		return nil, false, wrappedErr
+	}
+
+	// Wait for all hosts to respond
+	wg.Wait()
+
+	if err := accumErr.FinalError(); err != nil {
+		return nil, false, err
+	}
+	return accum.Results()
+}
+
 // NB(prateek): the returned fetchState, if valid, still holds the lock. Its ownership
 // is transferred to the calling function, and is expected to manage the lifecycle of
 // of the object (including releasing the lock/decRef'ing it).
@@ -1634,6 +1710,79 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) Delete(namespace ident.ID, ids ident.Iterator) (int64, error) {
+	var deleteIDs []ident.ID
+	for ids.Next() {
+		deleteIDs = append(deleteIDs, ids.Current())
+	}
+	if err := ids.Err(); err != nil {
+		return 0, err
+	}
+	return s.delete(namespace, deleteIDs, nil)
+}
+
+func (s *session) DeleteTagged(namespace ident.ID, q index.Query) (int64, error) {
+	return s.delete(namespace, nil, &q)
+}
+
+func (s *session) delete(
+	namespace ident.ID,
+	ids []ident.ID,
+	q *index.Query,
+) (int64, error) {
+	req, err := convert.ToRPCDeleteRequest(namespace, ids, q)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	d := &deleteOp{request: req}
+	d.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			res := result.(*rpc.DeleteResult_)
+			atomic.AddInt64(&deleted, res.NumSeries)
+		}
+		wg.Done()
+	}
+
+	s.state.RLock()
+	if s.state.status != statusOpen {
+		s.state.RUnlock()
+		return 0, errSessionStatusNotOpen
+	}
+	// Every host deletes the series that belong to the shards it owns so the
+	// request is sent to all of them rather than routed per shard.
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(d); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Errorf("failed to enqueue request: %v", err)
+		return 0, err
+	}
+
+	// Wait for series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+	xretry "github.com/m3db/m3x/retry"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAggregateQuery(t *testing.T) (index.Query, index.AggregateQueryOptions) {
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	end := time.Now().Truncate(time.Hour)
+	return index.Query{Query: q}, index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: end.Add(-time.Hour),
+			EndExclusive:   end,
+		},
+		Type: index.AggregateTagNamesAndValues,
+	}
+}
+
+func TestSessionAggregateNotOpenError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := newSession(newSessionTestOptions())
+	assert.NoError(t, err)
+
+	q, opts := testAggregateQuery(t)
+	_, _, err = s.Aggregate(ident.StringID("namespace"), q, opts)
+	assert.Error(t, err)
+	assert.Equal(t, errSessionStatusNotOpen, err)
+}
+
+func TestSessionAggregateMergesHostResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	values := [][]string{{"bar"}, {"baz"}, {"bar", "baz"}}
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			aggregate, ok := op.(*aggregateOp)
+			require.True(t, ok)
+			assert.Equal(t, []byte("metrics"), aggregate.request.NameSpace)
+			assert.Equal(t, rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE,
+				aggregate.request.AggregateQueryType)
+
+			elem := &rpc.AggregateQueryRawResultTagNameElement{TagName: []byte("foo")}
+			for _, v := range values[idx] {
+				elem.TagValues = append(elem.TagValues,
+					&rpc.AggregateQueryRawResultTagValueElement{TagValue: []byte(v)})
+			}
+			aggregate.completionFn(aggregateResultAccumulatorOpts{
+				host: session.state.queues[idx].Host(),
+				response: &rpc.AggregateQueryRawResult_{
+					Results:    []*rpc.AggregateQueryRawResultTagNameElement{elem},
+					Exhaustive: idx != 1,
+				},
+			}, nil)
+		},
+	})
+
+	require.NoError(t, session.Open())
+
+	q, aggOpts := testAggregateQuery(t)
+	results, exhaustive, err := s.Aggregate(ident.StringID("metrics"), q, aggOpts)
+	require.NoError(t, err)
+	assert.False(t, exhaustive)
+
+	tags := results.Tags()
+	require.Equal(t, 1, len(tags))
+	assert.Equal(t, "foo", string(tags[0].Name))
+	require.Equal(t, 2, len(tags[0].Values))
+	assert.Equal(t, "bar", string(tags[0].Values[0]))
+	assert.Equal(t, "baz", string(tags[0].Values[1]))
+
+	require.NoError(t, session.Close())
+}
+
+func TestSessionAggregateConsistencyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions().
+		SetFetchRetrier(xretry.NewRetrier(xretry.NewOptions().SetMaxRetries(0)))
+	s, err := newSession(opts)
+	require.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			aggregate, ok := op.(*aggregateOp)
+			require.True(t, ok)
+			aggregate.completionFn(aggregateResultAccumulatorOpts{
+				host: session.state.queues[idx].Host(),
+			}, errors.New("an error"))
+		},
+	})
+
+	require.NoError(t, session.Open())
+
+	q, aggOpts := testAggregateQuery(t)
+	_, _, err = s.Aggregate(ident.StringID("metrics"), q, aggOpts)
+	require.Error(t, err)
+
+	require.NoError(t, session.Close())
+}
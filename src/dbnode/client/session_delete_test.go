@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/m3ninx/idx"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionDeleteNotOpenError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := newSession(newSessionTestOptions())
+	assert.NoError(t, err)
+
+	_, err = s.Delete(ident.StringID("namespace"),
+		ident.NewIDsIterator(ident.StringID("foo")))
+	assert.Error(t, err)
+	assert.Equal(t, errSessionStatusNotOpen, err)
+}
+
+func TestSessionDeleteSumsHostResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := newSession(newSessionTestOptions())
+	require.NoError(t, err)
+	session := s.(*session)
+
+	enqueueFn := func(idx int, op op) {
+		d, ok := op.(*deleteOp)
+		require.True(t, ok)
+		assert.Equal(t, []byte("metrics"), d.request.NameSpace)
+		assert.Equal(t, [][]byte{[]byte("foo"), []byte("bar")}, d.request.Ids)
+		assert.Nil(t, d.request.Query)
+		d.completionFn(&rpc.DeleteResult_{NumSeries: 2}, nil)
+	}
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{enqueueFn})
+
+	require.NoError(t, session.Open())
+
+	deleted, err := s.Delete(ident.StringID("metrics"),
+		ident.NewIDsIterator(ident.StringID("foo"), ident.StringID("bar")))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*sessionTestReplicas), deleted)
+
+	require.NoError(t, session.Close())
+}
+
+func TestSessionDeleteTaggedHostError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s, err := newSession(newSessionTestOptions())
+	require.NoError(t, err)
+	session := s.(*session)
+
+	q, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	data, err := idx.Marshal(q)
+	require.NoError(t, err)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			d, ok := op.(*deleteOp)
+			require.True(t, ok)
+			assert.Nil(t, d.request.Ids)
+			assert.Equal(t, data, d.request.Query)
+			if idx == 1 {
+				d.completionFn(nil, errors.New("host unavailable"))
+				return
+			}
+			d.completionFn(&rpc.DeleteResult_{NumSeries: 1}, nil)
+		},
+	})
+
+	require.NoError(t, session.Open())
+
+	_, err = s.DeleteTagged(ident.StringID("metrics"), index.Query{Query: q})
+	require.Error(t, err)
+
+	require.NoError(t, session.Close())
+}
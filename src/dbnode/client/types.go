@@ -78,6 +78,19 @@ type Session interface {
 	// FetchTaggedIDs resolves the provided query to known IDs.
 	FetchTaggedIDs(namespace ident.ID, q index.Query, opts index.QueryOptions) (iter TaggedIDsIterator, exhaustive bool, err error)
 
+	// Aggregate resolves the provided query to the unique tag names, and optionally
+	// tag values, of the matching series.
+	Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) (results index.AggregateResults, exhaustive bool, err error)
+
+	// Delete removes the series with the given IDs from all replicas, returning
+	// the number of series deleted summed across replicas.
+	Delete(namespace ident.ID, ids ident.Iterator) (int64, error)
+
+	// DeleteTagged resolves the provided query to known IDs and removes those
+	// series from all replicas, returning the number of series deleted summed
+	// across replicas.
+	DeleteTagged(namespace ident.ID, q index.Query) (int64, error)
+
 	// ShardID returns the given shard for an ID for callers
 	// to easily discern what shard is failing when operations
 	// for given IDs begin failing
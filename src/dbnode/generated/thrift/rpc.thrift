@@ -32,6 +32,11 @@ enum ErrorType {
 	BAD_REQUEST
 }
 
+enum AggregateQueryType {
+	AGGREGATE_BY_TAG_NAME,
+	AGGREGATE_BY_TAG_NAME_VALUE
+}
+
 exception Error {
 	1: required ErrorType type = ErrorType.INTERNAL_ERROR
 	2: required string message
@@ -50,6 +55,7 @@ service Node {
 	void writeTagged(1: WriteTaggedRequest req) throws (1: Error err)
 
 	// Performant read/write endpoints
+	AggregateQueryRawResult aggregateRaw(1: AggregateQueryRawRequest req) throws (1: Error err)
 	FetchBatchRawResult fetchBatchRaw(1: FetchBatchRawRequest req) throws (1: Error err)
 	FetchBlocksRawResult fetchBlocksRaw(1: FetchBlocksRawRequest req) throws (1: Error err)
 
@@ -60,6 +66,7 @@ service Node {
 	void writeTaggedBatchRaw(1: WriteTaggedBatchRawRequest req) throws (1: WriteBatchRawErrors err)
 	void repair() throws (1: Error err)
 	TruncateResult truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteResult delete(1: DeleteRequest req) throws (1: Error err)
 
 	// Management endpoints
 	NodeHealthResult health() throws (1: Error err)
@@ -158,6 +165,31 @@ struct FetchTaggedIDResult {
 	5: optional Error err
 }
 
+struct AggregateQueryRawRequest {
+	1: required binary query
+	2: required i64 rangeStart
+	3: required i64 rangeEnd
+	4: required binary nameSpace
+	5: optional i64 limit
+	6: optional list<binary> tagNameFilter
+	7: optional AggregateQueryType aggregateQueryType = AggregateQueryType.AGGREGATE_BY_TAG_NAME_VALUE
+	8: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct AggregateQueryRawResult {
+	1: required list<AggregateQueryRawResultTagNameElement> results
+	2: required bool exhaustive
+}
+
+struct AggregateQueryRawResultTagNameElement {
+	1: required binary tagName
+	2: optional list<AggregateQueryRawResultTagValueElement> tagValues
+}
+
+struct AggregateQueryRawResultTagValueElement {
+	1: required binary tagValue
+}
+
 struct FetchBlocksRawRequest {
 	1: required binary nameSpace
 	2: required i32 shard
@@ -287,6 +319,16 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteRequest {
+	1: required binary nameSpace
+	2: optional list<binary> ids
+	3: optional binary query
+}
+
+struct DeleteResult {
+	1: required i64 numSeries
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
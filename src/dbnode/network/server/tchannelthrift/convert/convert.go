@@ -40,9 +40,11 @@ import (
 )
 
 var (
-	errUnknownTimeType  = errors.New("unknown time type")
-	errUnknownUnit      = errors.New("unknown unit")
-	errNilTaggedRequest = errors.New("nil write tagged request")
+	errUnknownTimeType      = errors.New("unknown time type")
+	errUnknownUnit          = errors.New("unknown unit")
+	errNilTaggedRequest     = errors.New("nil write tagged request")
+	errUnknownAggregateType = errors.New("unknown aggregate query type")
+	errEmptyDeleteRequest   = errors.New("delete request must specify ids or a query")
 
 	timeZero time.Time
 )
@@ -277,6 +279,211 @@ func ToRPCFetchTaggedRequest(
 	return request, nil
 }
 
+// FromRPCDeleteRequest converts the rpc request type for DeleteRequest into the
+// namespace, series IDs and optional index query it targets.
+func FromRPCDeleteRequest(
+	req *rpc.DeleteRequest, pools FetchTaggedConversionPools,
+) (ident.ID, []ident.ID, *index.Query, error) {
+	if len(req.Ids) == 0 && req.Query == nil {
+		return nil, nil, nil, errEmptyDeleteRequest
+	}
+
+	var query *index.Query
+	if req.Query != nil {
+		q, err := idx.Unmarshal(req.Query)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		query = &index.Query{Query: q}
+	}
+
+	newID := func(b []byte) ident.ID {
+		if pools != nil {
+			return pools.ID().BinaryID(pools.CheckedBytesWrapper().Get(b))
+		}
+		return ident.BytesID(b)
+	}
+
+	ids := make([]ident.ID, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		ids = append(ids, newID(id))
+	}
+
+	return newID(req.NameSpace), ids, query, nil
+}
+
+// ToRPCDeleteRequest converts the Go `client/` types into rpc request type for
+// DeleteRequest, a nil query deletes by IDs only.
+func ToRPCDeleteRequest(
+	ns ident.ID,
+	ids []ident.ID,
+	q *index.Query,
+) (rpc.DeleteRequest, error) {
+	request := rpc.DeleteRequest{
+		NameSpace: ns.Bytes(),
+	}
+
+	if len(ids) > 0 {
+		request.Ids = make([][]byte, 0, len(ids))
+		for _, id := range ids {
+			request.Ids = append(request.Ids, id.Bytes())
+		}
+	}
+
+	if q != nil {
+		query, err := idx.Marshal(q.Query)
+		if err != nil {
+			return rpc.DeleteRequest{}, err
+		}
+		request.Query = query
+	}
+
+	if len(request.Ids) == 0 && request.Query == nil {
+		return rpc.DeleteRequest{}, errEmptyDeleteRequest
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregateQueryRequest converts the rpc request type for AggregateQueryRawRequest into the Go `client/` types.
+func FromRPCAggregateQueryRequest(
+	req *rpc.AggregateQueryRawRequest, pools FetchTaggedConversionPools,
+) (ident.ID, index.Query, index.AggregateQueryOptions, error) {
+	start, rangeStartErr := ToTime(req.RangeStart, req.RangeTimeType)
+	if rangeStartErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeStartErr
+	}
+
+	end, rangeEndErr := ToTime(req.RangeEnd, req.RangeTimeType)
+	if rangeEndErr != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, rangeEndErr
+	}
+
+	aggType, err := FromRPCAggregateQueryType(req.AggregateQueryType)
+	if err != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: start,
+			EndExclusive:   end,
+		},
+		TagNameFilter: req.TagNameFilter,
+		Type:          aggType,
+	}
+	if l := req.Limit; l != nil {
+		opts.Limit = int(*l)
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return nil, index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	var ns ident.ID
+	if pools != nil {
+		nsBytes := pools.CheckedBytesWrapper().Get(req.NameSpace)
+		ns = pools.ID().BinaryID(nsBytes)
+	} else {
+		ns = ident.StringID(string(req.NameSpace))
+	}
+	return ns, index.Query{Query: q}, opts, nil
+}
+
+// ToRPCAggregateQueryRawRequest converts the Go `client/` types into rpc request type for AggregateQueryRawRequest.
+func ToRPCAggregateQueryRawRequest(
+	ns ident.ID,
+	q index.Query,
+	opts index.AggregateQueryOptions,
+) (rpc.AggregateQueryRawRequest, error) {
+	rangeStart, tsErr := ToValue(opts.StartInclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRawRequest{}, tsErr
+	}
+
+	rangeEnd, tsErr := ToValue(opts.EndExclusive, fetchTaggedTimeType)
+	if tsErr != nil {
+		return rpc.AggregateQueryRawRequest{}, tsErr
+	}
+
+	aggType, err := ToRPCAggregateQueryType(opts.Type)
+	if err != nil {
+		return rpc.AggregateQueryRawRequest{}, err
+	}
+
+	query, queryErr := idx.Marshal(q.Query)
+	if queryErr != nil {
+		return rpc.AggregateQueryRawRequest{}, queryErr
+	}
+
+	request := rpc.AggregateQueryRawRequest{
+		NameSpace:          ns.Bytes(),
+		RangeStart:         rangeStart,
+		RangeEnd:           rangeEnd,
+		RangeTimeType:      fetchTaggedTimeType,
+		Query:              query,
+		TagNameFilter:      opts.TagNameFilter,
+		AggregateQueryType: aggType,
+	}
+
+	if opts.Limit > 0 {
+		l := int64(opts.Limit)
+		request.Limit = &l
+	}
+
+	return request, nil
+}
+
+// FromRPCAggregateQueryType converts the rpc aggregate query type into the
+// index aggregate query type.
+func FromRPCAggregateQueryType(t rpc.AggregateQueryType) (index.AggregateQueryType, error) {
+	switch t {
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME:
+		return index.AggregateTagNames, nil
+	case rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE:
+		return index.AggregateTagNamesAndValues, nil
+	}
+	return 0, errUnknownAggregateType
+}
+
+// ToRPCAggregateQueryType converts the index aggregate query type into the
+// rpc aggregate query type.
+func ToRPCAggregateQueryType(t index.AggregateQueryType) (rpc.AggregateQueryType, error) {
+	switch t {
+	case index.AggregateTagNames:
+		return rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME, nil
+	case index.AggregateTagNamesAndValues:
+		return rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE, nil
+	}
+	return 0, errUnknownAggregateType
+}
+
+// ToRPCAggregateQueryRawResult converts the aggregate query results into the
+// rpc result type.
+func ToRPCAggregateQueryRawResult(
+	results index.AggregateResults,
+	exhaustive bool,
+) *rpc.AggregateQueryRawResult_ {
+	tags := results.Tags()
+	response := &rpc.AggregateQueryRawResult_{
+		Results:    make([]*rpc.AggregateQueryRawResultTagNameElement, 0, len(tags)),
+		Exhaustive: exhaustive,
+	}
+	for _, tag := range tags {
+		elem := &rpc.AggregateQueryRawResultTagNameElement{TagName: tag.Name}
+		if results.Type() == index.AggregateTagNamesAndValues {
+			elem.TagValues = make([]*rpc.AggregateQueryRawResultTagValueElement, 0, len(tag.Values))
+			for _, value := range tag.Values {
+				elem.TagValues = append(elem.TagValues,
+					&rpc.AggregateQueryRawResultTagValueElement{TagValue: value})
+			}
+		}
+		response.Results = append(response.Results, elem)
+	}
+	return response
+}
+
 // ToTagsIter returns a tag iterator over the given request.
 func ToTagsIter(r *rpc.WriteTaggedRequest) (ident.TagIterator, error) {
 	if r == nil {
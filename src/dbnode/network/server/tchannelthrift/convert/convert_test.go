@@ -150,6 +150,72 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestConvertAggregateRawQueryRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	opts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: time.Now().Add(-900 * time.Hour),
+			EndExclusive:   time.Now(),
+			Limit:          10,
+		},
+		TagNameFilter: [][]byte{[]byte("foo"), []byte("bar")},
+		Type:          index.AggregateTagNames,
+	}
+	var limit int64 = 10
+	requestSkeleton := &rpc.AggregateQueryRawRequest{
+		NameSpace:          ns.Bytes(),
+		RangeStart:         mustToRpcTime(t, opts.StartInclusive),
+		RangeEnd:           mustToRpcTime(t, opts.EndExclusive),
+		RangeTimeType:      rpc.TimeType_UNIX_NANOSECONDS,
+		Limit:              &limit,
+		TagNameFilter:      [][]byte{[]byte("foo"), []byte("bar")},
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME,
+	}
+	requireEqual := func(a, b interface{}) {
+		d := cmp.Diff(a, b)
+		assert.Equal(t, "", d, d)
+	}
+
+	type inputFn func(t *testing.T) (idx.Query, []byte)
+
+	for _, pools := range []struct {
+		name string
+		pool convert.FetchTaggedConversionPools
+	}{
+		{"nil pools", nil},
+		{"valid pools", newTestPools()},
+	} {
+		testCases := []struct {
+			name string
+			fn   inputFn
+		}{
+			{"Term Query", termQueryTestCase},
+			{"Regexp Query", regexpQueryTestCase},
+			{"Conjunction Query A", conjunctionQueryATestCase},
+		}
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("(%s pools) Forward %s", pools.name, tc.name), func(t *testing.T) {
+				q, rpcQ := tc.fn(t)
+				expectedReq := &(*requestSkeleton)
+				expectedReq.Query = rpcQ
+				observedReq, err := convert.ToRPCAggregateQueryRawRequest(ns, index.Query{Query: q}, opts)
+				require.NoError(t, err)
+				requireEqual(expectedReq, &observedReq)
+			})
+			t.Run(fmt.Sprintf("(%s pools) Backward %s", pools.name, tc.name), func(t *testing.T) {
+				expectedQuery, rpcQ := tc.fn(t)
+				rpcRequest := &(*requestSkeleton)
+				rpcRequest.Query = rpcQ
+				id, observedQuery, observedOpts, err := convert.FromRPCAggregateQueryRequest(rpcRequest, pools.pool)
+				require.NoError(t, err)
+				require.Equal(t, ns.String(), id.String())
+				require.True(t, index.NewQueryMatcher(index.Query{Query: expectedQuery}).Matches(observedQuery))
+				requireEqual(opts, observedOpts)
+			})
+		}
+	}
+}
+
 type testPools struct {
 	id      ident.Pool
 	wrapper xpool.CheckedBytesWrapperPool
@@ -171,3 +237,40 @@ var _ convert.FetchTaggedConversionPools = &testPools{}
 
 func (t *testPools) ID() ident.Pool                                     { return t.id }
 func (t *testPools) CheckedBytesWrapper() xpool.CheckedBytesWrapperPool { return t.wrapper }
+
+func TestConvertDeleteRequest(t *testing.T) {
+	ns := ident.StringID("abc")
+	ids := []ident.ID{ident.StringID("foo"), ident.StringID("bar")}
+	q, rpcQ := conjunctionQueryATestCase(t)
+
+	rpcReq, err := convert.ToRPCDeleteRequest(ns, ids, &index.Query{Query: q})
+	require.NoError(t, err)
+	require.Equal(t, ns.Bytes(), rpcReq.NameSpace)
+	require.Equal(t, [][]byte{[]byte("foo"), []byte("bar")}, rpcReq.Ids)
+	require.Equal(t, rpcQ, rpcReq.Query)
+
+	for _, pools := range []struct {
+		name string
+		pool convert.FetchTaggedConversionPools
+	}{
+		{"nil pools", nil},
+		{"valid pools", newTestPools()},
+	} {
+		t.Run(pools.name, func(t *testing.T) {
+			observedNs, observedIDs, observedQuery, err := convert.FromRPCDeleteRequest(&rpcReq, pools.pool)
+			require.NoError(t, err)
+			require.Equal(t, ns.String(), observedNs.String())
+			require.Equal(t, 2, len(observedIDs))
+			require.Equal(t, "foo", observedIDs[0].String())
+			require.Equal(t, "bar", observedIDs[1].String())
+			require.NotNil(t, observedQuery)
+			require.True(t, index.NewQueryMatcher(index.Query{Query: q}).Matches(*observedQuery))
+		})
+	}
+
+	_, err = convert.ToRPCDeleteRequest(ns, nil, nil)
+	require.Error(t, err)
+
+	_, _, _, err = convert.FromRPCDeleteRequest(&rpc.DeleteRequest{NameSpace: ns.Bytes()}, nil)
+	require.Error(t, err)
+}
@@ -42,6 +42,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/x/xio"
 	"github.com/m3db/m3/src/m3ninx/idx"
 	"github.com/m3db/m3x/checked"
+	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
 
@@ -1291,6 +1292,143 @@ func TestServiceFetchTaggedErrs(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestServiceAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	resMap := index.NewAggregateResults(ident.StringID(nsID), index.AggregateTagNamesAndValues)
+	resMap.Add([]byte("foo"), []byte("bar"))
+	resMap.Add([]byte("foo"), []byte("baz"))
+	resMap.Add([]byte("dzk"), []byte("baz"))
+
+	mockDB.EXPECT().AggregateQuery(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+				Limit:          10,
+			},
+			TagNameFilter: [][]byte{[]byte("foo"), []byte("dzk")},
+			Type:          index.AggregateTagNamesAndValues,
+		}).Return(index.AggregateQueryResult{Results: resMap, Exhaustive: true}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	var limit int64 = 10
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.AggregateRaw(tctx, &rpc.AggregateQueryRawRequest{
+		NameSpace:          []byte(nsID),
+		Query:              data,
+		RangeStart:         startNanos,
+		RangeEnd:           endNanos,
+		RangeTimeType:      rpc.TimeType_UNIX_NANOSECONDS,
+		TagNameFilter:      [][]byte{[]byte("foo"), []byte("dzk")},
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME_VALUE,
+		Limit:              &limit,
+	})
+	require.NoError(t, err)
+
+	require.True(t, r.Exhaustive)
+	require.Equal(t, 2, len(r.Results))
+	require.Equal(t, "dzk", string(r.Results[0].TagName))
+	require.Equal(t, 1, len(r.Results[0].TagValues))
+	require.Equal(t, "baz", string(r.Results[0].TagValues[0].TagValue))
+	require.Equal(t, "foo", string(r.Results[1].TagName))
+	require.Equal(t, 2, len(r.Results[1].TagValues))
+	require.Equal(t, "bar", string(r.Results[1].TagValues[0].TagValue))
+	require.Equal(t, "baz", string(r.Results[1].TagValues[1].TagValue))
+}
+
+func TestServiceAggregateNameOnly(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	start, end = start.Truncate(time.Second), end.Truncate(time.Second)
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+
+	resMap := index.NewAggregateResults(ident.StringID(nsID), index.AggregateTagNames)
+	resMap.Add([]byte("foo"), nil)
+	resMap.Add([]byte("dzk"), nil)
+
+	mockDB.EXPECT().AggregateQuery(
+		ctx,
+		ident.NewIDMatcher(nsID),
+		index.NewQueryMatcher(qry),
+		index.AggregateQueryOptions{
+			QueryOptions: index.QueryOptions{
+				StartInclusive: start,
+				EndExclusive:   end,
+			},
+			Type: index.AggregateTagNames,
+		}).Return(index.AggregateQueryResult{Results: resMap, Exhaustive: false}, nil)
+
+	startNanos, err := convert.ToValue(start, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	endNanos, err := convert.ToValue(end, rpc.TimeType_UNIX_NANOSECONDS)
+	require.NoError(t, err)
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+	r, err := service.AggregateRaw(tctx, &rpc.AggregateQueryRawRequest{
+		NameSpace:          []byte(nsID),
+		Query:              data,
+		RangeStart:         startNanos,
+		RangeEnd:           endNanos,
+		RangeTimeType:      rpc.TimeType_UNIX_NANOSECONDS,
+		AggregateQueryType: rpc.AggregateQueryType_AGGREGATE_BY_TAG_NAME,
+	})
+	require.NoError(t, err)
+
+	require.False(t, r.Exhaustive)
+	require.Equal(t, 2, len(r.Results))
+	require.Equal(t, "dzk", string(r.Results[0].TagName))
+	require.Nil(t, r.Results[0].TagValues)
+	require.Equal(t, "foo", string(r.Results[1].TagName))
+	require.Nil(t, r.Results[1].TagValues)
+}
+
 func TestServiceWrite(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1531,6 +1669,52 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+
+	service := NewService(mockDB, nil).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	nsID := "metrics"
+
+	req, err := idx.NewRegexpQuery([]byte("foo"), []byte("b.*"))
+	require.NoError(t, err)
+	qry := index.Query{Query: req}
+	data, err := idx.Marshal(req)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().
+		Delete(ctx, ident.NewIDMatcher(nsID), gomock.Any()).
+		Do(func(_ context.Context, _ ident.ID, ids []ident.ID) {
+			require.Equal(t, 2, len(ids))
+			assert.Equal(t, "foo", ids[0].String())
+			assert.Equal(t, "bar", ids[1].String())
+		}).
+		Return(int64(2), nil)
+	mockDB.EXPECT().
+		DeleteTagged(ctx, ident.NewIDMatcher(nsID), index.NewQueryMatcher(qry)).
+		Return(int64(3), nil)
+
+	r, err := service.Delete(tctx, &rpc.DeleteRequest{
+		NameSpace: []byte(nsID),
+		Ids:       [][]byte{[]byte("foo"), []byte("bar")},
+		Query:     data,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), r.NumSeries)
+
+	_, err = service.Delete(tctx, &rpc.DeleteRequest{NameSpace: []byte(nsID)})
+	require.Error(t, err)
+	assert.True(t, tterrors.IsBadRequestError(err.(*rpc.Error)))
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
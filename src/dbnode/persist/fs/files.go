@@ -119,7 +119,7 @@ func (f FileSetFilesSlice) Filepaths() []string {
 }
 
 // LatestVolumeForBlock returns the latest (highest index) FileSetFile in the
-// slice for a given block start, only applicable for index and snapshot file set files.
+// slice for a given block start that has a checkpoint file.
 func (f FileSetFilesSlice) LatestVolumeForBlock(blockStart time.Time) (FileSetFile, bool) {
 	// Make sure we're already sorted
 	f.sortByTimeAndVolumeIndexAscending()
@@ -285,6 +285,19 @@ func (a fileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
 	return ti.Equal(tj) && ii < ij
 }
 
+type dataFileSetFilesByTimeAndVolumeIndexAscending []string
+
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Len() int      { return len(a) }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a dataFileSetFilesByTimeAndVolumeIndexAscending) Less(i, j int) bool {
+	ti, ii, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[i])
+	tj, ij, _ := TimeAndVolumeIndexFromDataFileSetFilename(a[j])
+	if ti.Before(tj) {
+		return true
+	}
+	return ti.Equal(tj) && ii < ij
+}
+
 func componentsAndTimeFromFileName(fname string) ([]string, time.Time, error) {
 	components := strings.Split(filepath.Base(fname), separator)
 	if len(components) < 3 {
@@ -314,6 +327,20 @@ func TimeAndVolumeIndexFromFileSetFilename(fname string) (time.Time, int, error)
 	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
 }
 
+// TimeAndVolumeIndexFromDataFileSetFilename extracts the block start and volume
+// index from a data file set file name, the first volume of a block start has
+// no index in its file name.
+func TimeAndVolumeIndexFromDataFileSetFilename(fname string) (time.Time, int, error) {
+	components, t, err := componentsAndTimeFromFileName(fname)
+	if err != nil {
+		return timeZero, 0, err
+	}
+	if len(components) == 3 {
+		return t, 0, nil
+	}
+	return timeAndIndexFromFileName(fname, indexFileSetComponentPosition)
+}
+
 func timeAndIndexFromFileName(fname string, componentPosition int) (time.Time, int, error) {
 	components, t, err := componentsAndTimeFromFileName(fname)
 	if err != nil {
@@ -455,9 +482,9 @@ func forEachInfoFile(
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				checkpointFilePath = filesetPathFromTime(dir, t, checkpointFileSuffix)
-				digestsFilePath = filesetPathFromTime(dir, t, digestFileSuffix)
-				infoFilePath = filesetPathFromTime(dir, t, infoFileSuffix)
+				checkpointFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
+				digestsFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
+				infoFilePath = dataFilesetPathFromTimeAndIndex(dir, t, volume, infoFileSuffix)
 			case persist.FileSetIndexContentType:
 				checkpointFilePath = filesetPathFromTimeAndIndex(dir, t, volume, checkpointFileSuffix)
 				digestsFilePath = filesetPathFromTimeAndIndex(dir, t, volume, digestFileSuffix)
@@ -546,8 +573,9 @@ func (r readInfoFileResultError) Filepath() string {
 	return r.filepath
 }
 
-// ReadInfoFiles reads all the valid info entries. Even if ReadInfoFiles returns an error,
-// there may be some valid entries in the returned slice.
+// ReadInfoFiles reads all the valid info entries of the latest complete volume
+// for each block start. Even if ReadInfoFiles returns an error, there may be
+// some valid entries in the returned slice.
 func ReadInfoFiles(
 	filePathPrefix string,
 	namespace ident.ID,
@@ -555,7 +583,10 @@ func ReadInfoFiles(
 	readerBufferSize int,
 	decodingOpts msgpack.DecodingOptions,
 ) []ReadInfoFileResult {
-	var infoFileResults []ReadInfoFileResult
+	var (
+		infoFileResults []ReadInfoFileResult
+		lastBlockStart  time.Time
+	)
 	decoder := msgpack.NewDecoder(decodingOpts)
 	forEachInfoFile(
 		forEachInfoFileSelector{
@@ -569,13 +600,21 @@ func ReadInfoFiles(
 		func(filepath string, id FileSetFileIdentifier, data []byte) {
 			decoder.Reset(msgpack.NewDecoderStream(data))
 			info, err := decoder.DecodeIndexInfo()
-			infoFileResults = append(infoFileResults, ReadInfoFileResult{
+			result := ReadInfoFileResult{
 				Info: info,
 				Err: readInfoFileResultError{
 					err:      err,
 					filepath: filepath,
 				},
-			})
+			}
+			// Volumes are visited in ascending order, a later complete volume
+			// supersedes the earlier volumes for the same block start
+			if n := len(infoFileResults); n > 0 && id.BlockStart.Equal(lastBlockStart) {
+				infoFileResults[n-1] = result
+				return
+			}
+			lastBlockStart = id.BlockStart
+			infoFileResults = append(infoFileResults, result)
 		})
 	return infoFileResults
 }
@@ -643,41 +682,40 @@ func IndexSnapshotFiles(filePathPrefix string, namespace ident.ID) (FileSetFiles
 	})
 }
 
-// FileSetAt returns a FileSetFile for the given namespace/shard/blockStart combination if it exists.
+// FileSetAt returns the latest complete volume of the FileSetFile for the given
+// namespace/shard/blockStart combination if it exists.
 func FileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFile, bool, error) {
-	matched, err := filesetFiles(filesetFilesSelector{
+	matched, err := dataFileSetVolumesAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return FileSetFile{}, false, err
+	}
+
+	fileset, ok := matched.LatestVolumeForBlock(blockStart)
+	return fileset, ok, nil
+}
+
+func dataFileSetVolumesAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
 		fileSetType:    persist.FileSetFlushType,
 		contentType:    persist.FileSetDataContentType,
 		filePathPrefix: filePathPrefix,
 		namespace:      namespace,
 		shard:          shard,
-		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsPattern),
+		pattern:        filesetFileForTime(blockStart, anyLowerCaseCharsNumbersPattern),
 	})
-	if err != nil {
-		return FileSetFile{}, false, err
-	}
-
-	matched.sortByTimeAscending()
-	for i, fileset := range matched {
-		if fileset.ID.BlockStart.Equal(blockStart) {
-			nextIdx := i + 1
-			if nextIdx < len(matched) && matched[nextIdx].ID.BlockStart.Equal(blockStart) {
-				// Should never happen
-				return FileSetFile{}, false, fmt.Errorf(
-					"found multiple fileset files for blockStart: %d",
-					blockStart.Unix(),
-				)
-			}
-
-			if !fileset.HasCheckpointFile() {
-				continue
-			}
-
-			return fileset, true, nil
-		}
-	}
+}
 
-	return FileSetFile{}, false, nil
+// DataFiles returns a slice of all the FileSetFiles of all the volumes of the
+// flushed data for a given namespace and shard combination.
+func DataFiles(filePathPrefix string, namespace ident.ID, shard uint32) (FileSetFilesSlice, error) {
+	return filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetDataContentType,
+		filePathPrefix: filePathPrefix,
+		namespace:      namespace,
+		shard:          shard,
+		pattern:        filesetFilePattern,
+	})
 }
 
 // IndexFileSetsAt returns all FileSetFile(s) for the given namespace/blockStart combination.
@@ -708,17 +746,18 @@ func IndexFileSetsAt(filePathPrefix string, namespace ident.ID, blockStart time.
 	return filesets, nil
 }
 
-// DeleteFileSetAt deletes a FileSetFile for a given namespace/shard/blockStart combination if it exists.
+// DeleteFileSetAt deletes all the volumes of a FileSetFile for a given
+// namespace/shard/blockStart combination if it exists.
 func DeleteFileSetAt(filePathPrefix string, namespace ident.ID, shard uint32, t time.Time) error {
-	fileset, ok, err := FileSetAt(filePathPrefix, namespace, shard, t)
+	matched, err := dataFileSetVolumesAt(filePathPrefix, namespace, shard, t)
 	if err != nil {
 		return err
 	}
-	if !ok {
+	if _, ok := matched.LatestVolumeForBlock(t); !ok {
 		return fmt.Errorf("fileset for blockStart: %d does not exist", t.Unix())
 	}
 
-	return DeleteFiles(fileset.AbsoluteFilepaths)
+	return DeleteFiles(matched.Filepaths())
 }
 
 // DataFileSetsBefore returns all the flush data fileset files whose timestamps are earlier than a given time.
@@ -836,7 +875,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetDataContentType:
 			dir := ShardDataDirPath(args.filePathPrefix, args.namespace, args.shard)
 			byTimeAsc, err = findFiles(dir, args.pattern, func(files []string) sort.Interface {
-				return byTimeAscending(files)
+				return dataFileSetFilesByTimeAndVolumeIndexAscending(files)
 			})
 		case persist.FileSetIndexContentType:
 			dir := NamespaceIndexDataDirPath(args.filePathPrefix, args.namespace)
@@ -886,7 +925,7 @@ func filesetFiles(args filesetFilesSelector) (FileSetFilesSlice, error) {
 		case persist.FileSetFlushType:
 			switch args.contentType {
 			case persist.FileSetDataContentType:
-				currentFileBlockStart, err = TimeFromFileName(file)
+				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromDataFileSetFilename(file)
 			case persist.FileSetIndexContentType:
 				currentFileBlockStart, volumeIndex, err = TimeAndVolumeIndexFromFileSetFilename(file)
 			default:
@@ -1031,10 +1070,28 @@ func CommitLogsDirPath(prefix string) string {
 	return path.Join(prefix, commitLogsDirName)
 }
 
-// DataFileSetExistsAt determines whether data fileset files exist for the given namespace, shard, and block start.
+// DataFileSetExistsAt determines whether a complete volume of data fileset files exists for the given namespace, shard, and block start.
 func DataFileSetExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (bool, error) {
+	_, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	return ok, err
+}
+
+// latestDataFileSetVolumeIndex returns the index of the latest complete volume
+// of data fileset files for a block start, or the first volume if there are no
+// complete volumes.
+func latestDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	fileset, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil || !ok {
+		return 0, err
+	}
+	return fileset.ID.VolumeIndex, nil
+}
+
+// dataFileSetVolumeExistsAt determines whether a given volume of data fileset
+// files exists for the given namespace, shard, and block start.
+func dataFileSetVolumeExistsAt(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time, volumeIndex int) (bool, error) {
 	shardDir := ShardDataDirPath(filePathPrefix, namespace, shard)
-	checkpointPath := filesetPathFromTime(shardDir, blockStart, checkpointFileSuffix)
+	checkpointPath := dataFilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, checkpointFileSuffix)
 	return FileExists(checkpointPath)
 }
 
@@ -1085,6 +1142,19 @@ func NextSnapshotFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, s
 	return latestFile.ID.VolumeIndex + 1, nil
 }
 
+// NextDataFileSetVolumeIndex returns the next data file set volume index for a
+// given namespace/shard/blockStart combination.
+func NextDataFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, shard uint32, blockStart time.Time) (int, error) {
+	fileset, ok, err := FileSetAt(filePathPrefix, namespace, shard, blockStart)
+	if err != nil {
+		return -1, err
+	}
+	if !ok {
+		return 0, nil
+	}
+	return fileset.ID.VolumeIndex + 1, nil
+}
+
 // NextIndexFileSetVolumeIndex returns the next index file set index for a given
 // namespace/blockStart combination.
 func NextIndexFileSetVolumeIndex(filePathPrefix string, namespace ident.ID, blockStart time.Time) (int, error) {
@@ -1157,6 +1227,15 @@ func filesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix s
 	return path.Join(prefix, filesetFileForTime(t, fmt.Sprintf("%d%s%s", index, separator, suffix)))
 }
 
+// dataFilesetPathFromTimeAndIndex returns the path of a data fileset file, the
+// first volume keeps the file names used before volumes were introduced.
+func dataFilesetPathFromTimeAndIndex(prefix string, t time.Time, index int, suffix string) string {
+	if index == 0 {
+		return filesetPathFromTime(prefix, t, suffix)
+	}
+	return filesetPathFromTimeAndIndex(prefix, t, index, suffix)
+}
+
 func filesetIndexSegmentFileSuffixFromTime(
 	t time.Time,
 	segmentIndex int,
@@ -281,6 +281,25 @@ func TestTimeAndVolumeIndexFromFileSetFilename(t *testing.T) {
 	require.Equal(t, filesetPathFromTimeAndIndex("foo/bar", exp.t, exp.i, "data"), validName)
 }
 
+func TestTimeAndVolumeIndexFromDataFileSetFilename(t *testing.T) {
+	_, _, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar")
+	require.Error(t, err)
+
+	ts, i, err := TimeAndVolumeIndexFromDataFileSetFilename("foo/bar/fileset-21234567890-data.db")
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 0, i)
+
+	validName := "foo/bar/fileset-21234567890-2-data.db"
+	ts, i, err = TimeAndVolumeIndexFromDataFileSetFilename(validName)
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(0, 21234567890), ts)
+	require.Equal(t, 2, i)
+	require.Equal(t, dataFilesetPathFromTimeAndIndex("foo/bar", ts, i, "data"), validName)
+	require.Equal(t, "foo/bar/fileset-21234567890-data.db",
+		dataFilesetPathFromTimeAndIndex("foo/bar", ts, 0, "data"))
+}
+
 func TestFileExists(t *testing.T) {
 	dir := createTempDir(t)
 	defer os.RemoveAll(dir)
@@ -492,6 +511,44 @@ func TestNextSnapshotFileSetVolumeIndex(t *testing.T) {
 	}
 }
 
+func TestNextDataFileSetVolumeIndex(t *testing.T) {
+	var (
+		shard      = uint32(0)
+		dir        = createTempDir(t)
+		blockStart = time.Now().Truncate(time.Hour)
+		w          = newTestWriter(t, dir)
+		entries    = []testEntry{{"foo", nil, []byte{1, 2, 3}}}
+	)
+	defer os.RemoveAll(dir)
+
+	// Check increments properly
+	curr := -1
+	for i := 0; i <= 3; i++ {
+		index, err := NextDataFileSetVolumeIndex(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.Equal(t, curr+1, index)
+		curr = index
+
+		writeTestDataWithVolume(t, w, shard, blockStart, index, entries,
+			persist.FileSetFlushType)
+
+		fileset, ok, err := FileSetAt(dir, testNs1ID, shard, blockStart)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, index, fileset.ID.VolumeIndex)
+	}
+
+	files, err := DataFiles(dir, testNs1ID, shard)
+	require.NoError(t, err)
+	require.Equal(t, 4, len(files))
+
+	// Deleting the fileset removes all of its volumes
+	require.NoError(t, DeleteFileSetAt(dir, testNs1ID, shard, blockStart))
+	files, err = DataFiles(dir, testNs1ID, shard)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(files))
+}
+
 func TestNextIndexFileSetVolumeIndex(t *testing.T) {
 	// Make empty directory
 	dir := createTempDir(t)
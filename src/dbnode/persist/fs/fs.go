@@ -31,6 +31,7 @@ const (
 	filesetFilePrefix        = "fileset"
 	commitLogFilePrefix      = "commitlog"
 	segmentFileSetFilePrefix = "segment"
+	tombstonesFilePrefix     = "tombstones"
 	fileSuffix               = ".db"
 
 	anyLowerCaseCharsPattern        = "[a-z]*"
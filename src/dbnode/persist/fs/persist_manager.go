@@ -385,7 +385,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 		return prepared, err
 	}
 
-	var volumeIndex int
+	volumeIndex := opts.VolumeIndex
 	if opts.FileSetType == persist.FileSetSnapshotType {
 		// Need to work out the volume index for the next snapshot
 		volumeIndex, err = NextSnapshotFileSetVolumeIndex(pm.opts.FilePathPrefix(),
@@ -441,6 +441,7 @@ func (pm *persistManager) PrepareData(opts persist.DataPrepareOptions) (persist.
 
 	prepared.Persist = pm.persist
 	prepared.Close = pm.closeData
+	prepared.Abort = pm.abortData
 
 	return prepared, nil
 }
@@ -495,6 +496,10 @@ func (pm *persistManager) closeData() error {
 	return pm.dataPM.writer.Close()
 }
 
+func (pm *persistManager) abortData() error {
+	return pm.dataPM.writer.Abort()
+}
+
 // DoneData is called by the databaseFlushManager to finish the data persist process.
 func (pm *persistManager) DoneData() error {
 	pm.Lock()
@@ -527,7 +532,12 @@ func (pm *persistManager) dataFilesetExistsAt(prepareOpts persist.DataPrepareOpt
 		// already exist doesn't make much sense
 		return false, nil
 	case persist.FileSetFlushType:
-		return DataFileSetExistsAt(pm.filePathPrefix, nsID, shard, blockStart)
+		if prepareOpts.DeleteIfExists {
+			// Replacing the flushed data replaces all volumes of the block start
+			return DataFileSetExistsAt(pm.filePathPrefix, nsID, shard, blockStart)
+		}
+		return dataFileSetVolumeExistsAt(pm.filePathPrefix, nsID, shard, blockStart,
+			prepareOpts.VolumeIndex)
 	default:
 		return false, fmt.Errorf(
 			"unable to determine if fileset exists in persist manager for fileset type: %s",
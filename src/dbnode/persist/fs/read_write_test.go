@@ -341,6 +341,95 @@ func TestInfoReadWrite(t *testing.T) {
 	require.Equal(t, int64(len(entries)), infoFile.Entries)
 }
 
+func TestReadWriteDataVolumes(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	volumes := [][]testEntry{
+		{
+			{"foo", nil, []byte{1, 2, 3}},
+		},
+		{
+			{"foo", nil, []byte{1, 2, 3}},
+			{"bar", nil, []byte{4, 5, 6}},
+		},
+	}
+
+	w := newTestWriter(t, filePathPrefix)
+	for volume, entries := range volumes {
+		writeTestDataWithVolume(t, w, 0, testWriterStart, volume, entries,
+			persist.FileSetFlushType)
+	}
+
+	// Only the latest volume is read
+	r := newTestReader(t, filePathPrefix)
+	require.Equal(t, []string{"foo", "bar"}, readTestIDs(t, r, 0, testWriterStart))
+
+	readInfoFileResults := ReadInfoFiles(filePathPrefix, testNs1ID, 0, 16, nil)
+	require.Equal(t, 1, len(readInfoFileResults))
+	require.NoError(t, readInfoFileResults[0].Err.Error())
+	require.Equal(t, int64(len(volumes[1])), readInfoFileResults[0].Info.Entries)
+}
+
+func TestWriterAbortIsNotRead(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	require.NoError(t, w.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:   testNs1ID,
+			Shard:       0,
+			BlockStart:  testWriterStart,
+			VolumeIndex: 1,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+	}))
+	require.NoError(t, w.Write(ident.StringID("bar"), ident.Tags{},
+		bytesRefd([]byte{4, 5, 6}), digest.Checksum([]byte{4, 5, 6})))
+	require.NoError(t, w.Abort())
+
+	// The aborted volume has no checkpoint so the previous volume is read
+	r := newTestReader(t, filePathPrefix)
+	require.Equal(t, []string{"foo"}, readTestIDs(t, r, 0, testWriterStart))
+
+	next, err := NextDataFileSetVolumeIndex(filePathPrefix, testNs1ID, 0, testWriterStart)
+	require.NoError(t, err)
+	require.Equal(t, 1, next)
+}
+
+func readTestIDs(t *testing.T, r DataFileSetReader, shard uint32, timestamp time.Time) []string {
+	err := r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      shard,
+			BlockStart: timestamp,
+		},
+	})
+	require.NoError(t, err)
+
+	var ids []string
+	for i := 0; i < r.Entries(); i++ {
+		id, tags, data, _, err := r.Read()
+		require.NoError(t, err)
+		ids = append(ids, id.String())
+		id.Finalize()
+		tags.Close()
+		data.Finalize()
+	}
+	require.NoError(t, r.Close())
+	return ids
+}
+
 func TestReusingReaderWriter(t *testing.T) {
 	dir := createTempDir(t)
 	filePathPrefix := filepath.Join(dir, "")
@@ -107,6 +107,9 @@ type seekersByTime struct {
 	shard    uint32
 	accessed bool
 	seekers  map[xtime.UnixNano]seekersAndBloom
+	// invalidated holds the seekers of filesets that have been rewritten
+	// until all of them are returned and they can be closed
+	invalidated []seekersAndBloom
 }
 
 type seekerManagerPendingClose struct {
@@ -234,6 +237,16 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 
 	startNano := xtime.ToUnixNano(start)
 	seekersAndBloom, ok := byTime.seekers[startNano]
+	if ok && seekersAndBloom.returnSeeker(seeker) {
+		return nil
+	}
+	// The seeker may have been borrowed before its fileset was invalidated
+	for _, invalidated := range byTime.invalidated {
+		if invalidated.returnSeeker(seeker) {
+			return nil
+		}
+	}
+
 	// Should never happen - This either means that the caller (DataBlockRetriever) is trying to return seekers
 	// that it never requested, OR its trying to return seekers after the openCloseLoop has already
 	// determined that they were all no longer in use and safe to close. Either way it indicates there is
@@ -242,22 +255,57 @@ func (m *seekerManager) Return(shard uint32, start time.Time, seeker ConcurrentD
 		return errSeekersDontExist
 	}
 
-	found := false
-	for i, compareSeeker := range seekersAndBloom.seekers {
+	// Should never happen with a well behaved caller. Either they are trying to return a seeker
+	// that we're not managing, or they provided the wrong shard/start.
+	return errReturnedUnmanagedSeeker
+}
+
+// Invalidate drops the open seekers for a given shard and block start so
+// that the next borrow reopens the fileset, seekers still borrowed are
+// closed by the openCloseLoop once returned.
+func (m *seekerManager) Invalidate(shard uint32, start time.Time) error {
+	byTime := m.seekersByTime(shard)
+
+	byTime.Lock()
+	defer byTime.Unlock()
+
+	startNano := xtime.ToUnixNano(start)
+	for {
+		seekers, ok := byTime.seekers[startNano]
+		if !ok {
+			return nil
+		}
+		if seekers.wg != nil {
+			// Seekers are being opened, wait for that to complete
+			byTime.Unlock()
+			seekers.wg.Wait()
+			byTime.Lock()
+			continue
+		}
+		delete(byTime.seekers, startNano)
+		byTime.invalidated = append(byTime.invalidated, seekers)
+		return nil
+	}
+}
+
+func (s seekersAndBloom) returnSeeker(seeker ConcurrentDataFileSetSeeker) bool {
+	for i, compareSeeker := range s.seekers {
 		if seeker == compareSeeker.seeker {
-			found = true
 			compareSeeker.isBorrowed = false
-			seekersAndBloom.seekers[i] = compareSeeker
-			break
+			s.seekers[i] = compareSeeker
+			return true
 		}
 	}
-	// Should never happen with a well behaved caller. Either they are trying to return a seeker
-	// that we're not managing, or they provided the wrong shard/start.
-	if !found {
-		return errReturnedUnmanagedSeeker
-	}
+	return false
+}
 
-	return nil
+func (s seekersAndBloom) allReturned() bool {
+	for _, seeker := range s.seekers {
+		if seeker.isBorrowed {
+			return false
+		}
+	}
+	return true
 }
 
 // getOrOpenSeekersWithLock checks if the seekers are already open / initialized. If they are, then it
@@ -459,6 +507,13 @@ func (m *seekerManager) Close() error {
 				}
 			}
 		}
+		for _, invalidated := range byTime.invalidated {
+			if !invalidated.allReturned() {
+				byTime.Unlock()
+				m.Unlock()
+				return errCantCloseSeekerManagerWhileSeekersAreBorrowed
+			}
+		}
 		byTime.Unlock()
 	}
 
@@ -548,6 +603,24 @@ func (m *seekerManager) openCloseLoop() {
 			byTime.RUnlock()
 		}
 
+		// Close the seekers of rewritten filesets once they've all been returned
+		for _, byTime := range m.seekersByShardIdx {
+			byTime.Lock()
+			remaining := byTime.invalidated[:0]
+			for _, invalidated := range byTime.invalidated {
+				if invalidated.allReturned() {
+					closing = append(closing, invalidated.seekers...)
+					continue
+				}
+				remaining = append(remaining, invalidated)
+			}
+			for i := len(remaining); i < len(byTime.invalidated); i++ {
+				byTime.invalidated[i] = seekersAndBloom{}
+			}
+			byTime.invalidated = remaining
+			byTime.Unlock()
+		}
+
 		if len(shouldClose) > 0 {
 			for _, elem := range shouldClose {
 				byTime := m.seekersByShardIdx[elem.shard]
@@ -604,7 +677,18 @@ func (m *seekerManager) openCloseLoop() {
 				}
 			}
 		}
+		for _, invalidated := range byTime.invalidated {
+			for _, seeker := range invalidated.seekers {
+				err := seeker.seeker.Close()
+				if err != nil {
+					m.logger.
+						WithFields(log.NewField("err", err.Error())).
+						Error("err closing seeker in SeekerManager at end of openCloseLoop")
+				}
+			}
+		}
 		byTime.seekers = nil
+		byTime.invalidated = nil
 		byTime.Unlock()
 	}
 	m.seekersByShardIdx = nil
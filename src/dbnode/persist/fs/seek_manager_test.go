@@ -109,6 +109,61 @@ func TestSeekerManagerBorrowOpenSeekersLazy(t *testing.T) {
 	require.NoError(t, m.Close())
 }
 
+// TestSeekerManagerInvalidate tests that invalidating the seekers for a block
+// start allows borrowed seekers to still be returned and causes the next
+// Borrow() to open new seekers.
+func TestSeekerManagerInvalidate(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		shard            = uint32(2)
+		fetchConcurrency = NewBlockRetrieverOptions().FetchConcurrency()
+		opened           int
+	)
+	m := NewSeekerManager(nil, testDefaultOpts, fetchConcurrency).(*seekerManager)
+	m.newOpenSeekerFn = func(
+		shard uint32,
+		blockStart time.Time,
+	) (DataFileSetSeeker, error) {
+		opened++
+		mock := NewMockDataFileSetSeeker(ctrl)
+		mock.EXPECT().ConcurrentClone().Return(mock, nil).AnyTimes()
+		mock.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		mock.EXPECT().Close().Return(nil).Times(fetchConcurrency)
+		return mock, nil
+	}
+	m.sleepFn = func(_ time.Duration) {
+		time.Sleep(time.Millisecond)
+	}
+
+	metadata := testNs1Metadata(t)
+	require.NoError(t, m.Open(metadata))
+
+	seeker, err := m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 1, opened)
+
+	require.NoError(t, m.Invalidate(shard, time.Time{}))
+	byTime := m.seekersByTime(shard)
+	byTime.RLock()
+	_, ok := byTime.seekers[xtime.ToUnixNano(time.Time{})]
+	require.False(t, ok)
+	byTime.RUnlock()
+
+	// Seekers borrowed before the invalidation can still be returned
+	require.NoError(t, m.Return(shard, time.Time{}, seeker))
+
+	seeker, err = m.Borrow(shard, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, 2, opened)
+	require.NoError(t, m.Return(shard, time.Time{}, seeker))
+
+	require.NoError(t, m.Close())
+}
+
 // TestSeekerManagerOpenCloseLoop tests the openCloseLoop of the SeekerManager
 // by making sure that it makes the right decisions with regards to cleaning
 // up resources based on their state.
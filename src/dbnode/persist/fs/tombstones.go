@@ -0,0 +1,195 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3x/ident"
+)
+
+const (
+	tombstonesFileName = tombstonesFilePrefix + fileSuffix
+	// tombstonesDigestLen is the length of the digest trailing the tombstones file
+	tombstonesDigestLen = 4
+)
+
+var (
+	errTombstonesFileTooShort     = errors.New("tombstones file too short")
+	errTombstonesChecksumMismatch = errors.New("tombstones file checksum mismatch")
+	errTombstonesEntryCorrupt     = errors.New("tombstones file entry corrupt")
+)
+
+// Tombstone marks the data of a series written before DeletedAt as deleted,
+// MaskedBlockStarts are the block starts of the filesets that held the
+// series when it was deleted and have not been rewritten since. Rewritten is
+// set once the series has been written to again after it was deleted.
+type Tombstone struct {
+	ID                []byte
+	DeletedAt         time.Time
+	MaskedBlockStarts []time.Time
+	Rewritten         bool
+}
+
+// ShardTombstonesFilePath returns the path to the tombstones file for a given shard.
+func ShardTombstonesFilePath(prefix string, namespace ident.ID, shard uint32) string {
+	return path.Join(ShardDataDirPath(prefix, namespace, shard), tombstonesFileName)
+}
+
+// WriteTombstones replaces the tombstones persisted for a shard, the file is
+// written to a temporary path and renamed so that a partially written set of
+// tombstones is never observed. Writing an empty set removes the file.
+func WriteTombstones(
+	opts Options,
+	namespace ident.ID,
+	shard uint32,
+	tombstones []Tombstone,
+) error {
+	filePath := ShardTombstonesFilePath(opts.FilePathPrefix(), namespace, shard)
+	if len(tombstones) == 0 {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
+	shardDir := ShardDataDirPath(opts.FilePathPrefix(), namespace, shard)
+	if err := os.MkdirAll(shardDir, opts.NewDirectoryMode()); err != nil {
+		return err
+	}
+
+	var (
+		buf     []byte
+		scratch [binary.MaxVarintLen64]byte
+	)
+	for _, t := range tombstones {
+		n := binary.PutUvarint(scratch[:], uint64(len(t.ID)))
+		buf = append(buf, scratch[:n]...)
+		buf = append(buf, t.ID...)
+		n = binary.PutVarint(scratch[:], t.DeletedAt.UnixNano())
+		buf = append(buf, scratch[:n]...)
+		if t.Rewritten {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		n = binary.PutUvarint(scratch[:], uint64(len(t.MaskedBlockStarts)))
+		buf = append(buf, scratch[:n]...)
+		for _, blockStart := range t.MaskedBlockStarts {
+			n = binary.PutVarint(scratch[:], blockStart.UnixNano())
+			buf = append(buf, scratch[:n]...)
+		}
+	}
+	digestBuf := digest.NewBuffer()
+	digestBuf.WriteDigest(digest.Checksum(buf))
+	buf = append(buf, digestBuf...)
+
+	tmpFilePath := filePath + ".tmp"
+	fd, err := OpenWritable(tmpFilePath, opts.NewFileMode())
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(buf); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Sync(); err != nil {
+		fd.Close()
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, filePath)
+}
+
+// ReadTombstones returns the tombstones persisted for a shard, if no
+// tombstones have been written for the shard it returns no tombstones.
+func ReadTombstones(
+	filePathPrefix string,
+	namespace ident.ID,
+	shard uint32,
+) ([]Tombstone, error) {
+	filePath := ShardTombstonesFilePath(filePathPrefix, namespace, shard)
+	buf, err := ioutil.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buf) < tombstonesDigestLen {
+		return nil, errTombstonesFileTooShort
+	}
+	payload := buf[:len(buf)-tombstonesDigestLen]
+	expectedDigest := digest.ToBuffer(buf[len(payload):]).ReadDigest()
+	if digest.Checksum(payload) != expectedDigest {
+		return nil, errTombstonesChecksumMismatch
+	}
+
+	var tombstones []Tombstone
+	for len(payload) > 0 {
+		idLen, n := binary.Uvarint(payload)
+		if n <= 0 || uint64(len(payload)-n) < idLen {
+			return nil, errTombstonesEntryCorrupt
+		}
+		payload = payload[n:]
+		id := append([]byte(nil), payload[:idLen]...)
+		payload = payload[idLen:]
+
+		deletedAt, n := binary.Varint(payload)
+		if n <= 0 || len(payload) == n {
+			return nil, errTombstonesEntryCorrupt
+		}
+		rewritten := payload[n] == 1
+		payload = payload[n+1:]
+
+		numMasked, n := binary.Uvarint(payload)
+		if n <= 0 || uint64(len(payload)-n) < numMasked {
+			return nil, errTombstonesEntryCorrupt
+		}
+		payload = payload[n:]
+		var masked []time.Time
+		for i := uint64(0); i < numMasked; i++ {
+			blockStart, n := binary.Varint(payload)
+			if n <= 0 {
+				return nil, errTombstonesEntryCorrupt
+			}
+			payload = payload[n:]
+			masked = append(masked, time.Unix(0, blockStart))
+		}
+
+		tombstones = append(tombstones, Tombstone{
+			ID:                id,
+			DeletedAt:         time.Unix(0, deletedAt),
+			MaskedBlockStarts: masked,
+			Rewritten:         rewritten,
+		})
+	}
+	return tombstones, nil
+}
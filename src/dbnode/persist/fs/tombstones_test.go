@@ -0,0 +1,105 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3x/ident"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReadTombstones(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts      = testDefaultOpts.SetFilePathPrefix(dir)
+		namespace = ident.StringID("testns")
+		now       = time.Now().Truncate(time.Second)
+		expected  = []Tombstone{
+			{
+				ID:        []byte("foo"),
+				DeletedAt: now,
+				MaskedBlockStarts: []time.Time{
+					now.Truncate(time.Hour).Add(-2 * time.Hour),
+					now.Truncate(time.Hour).Add(-time.Hour),
+				},
+			},
+			{ID: []byte("bar"), DeletedAt: now.Add(-time.Hour), Rewritten: true},
+		}
+	)
+
+	tombstones, err := ReadTombstones(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+
+	require.NoError(t, WriteTombstones(opts, namespace, 1, expected))
+
+	tombstones, err = ReadTombstones(dir, namespace, 1)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), len(tombstones))
+	for i := range expected {
+		require.Equal(t, expected[i].ID, tombstones[i].ID)
+		require.True(t, expected[i].DeletedAt.Equal(tombstones[i].DeletedAt))
+		require.Equal(t, expected[i].Rewritten, tombstones[i].Rewritten)
+		require.Equal(t, len(expected[i].MaskedBlockStarts), len(tombstones[i].MaskedBlockStarts))
+		for j, blockStart := range expected[i].MaskedBlockStarts {
+			require.True(t, blockStart.Equal(tombstones[i].MaskedBlockStarts[j]))
+		}
+	}
+
+	// Other shards are unaffected
+	tombstones, err = ReadTombstones(dir, namespace, 2)
+	require.NoError(t, err)
+	require.Empty(t, tombstones)
+
+	// Writing no tombstones removes the file
+	require.NoError(t, WriteTombstones(opts, namespace, 1, nil))
+	_, err = os.Stat(ShardTombstonesFilePath(dir, namespace, 1))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestReadTombstonesChecksumMismatch(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts      = testDefaultOpts.SetFilePathPrefix(dir)
+		namespace = ident.StringID("testns")
+	)
+	require.NoError(t, WriteTombstones(opts, namespace, 1, []Tombstone{
+		{ID: []byte("foo"), DeletedAt: time.Now()},
+	}))
+
+	filePath := ShardTombstonesFilePath(dir, namespace, 1)
+	buf, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	buf[1] ^= 0xff
+	require.NoError(t, ioutil.WriteFile(filePath, buf, opts.NewFileMode()))
+
+	_, err = ReadTombstones(dir, namespace, 1)
+	require.Equal(t, errTombstonesChecksumMismatch, err)
+}
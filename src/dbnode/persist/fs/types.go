@@ -49,7 +49,7 @@ type FileSetFileIdentifier struct {
 	BlockStart         time.Time
 	// Only required for data content files
 	Shard uint32
-	// Required for snapshot files (index yes, data yes) and flush files (index yes, data no)
+	// Required for snapshot files (index yes, data yes) and flush files (index yes, data yes)
 	VolumeIndex int
 }
 
@@ -86,6 +86,10 @@ type DataFileSetWriter interface {
 	// WriteAll will write the id and all byte slices and returns an error on a write error.
 	// Callers must not call this method with a given ID more than once.
 	WriteAll(id ident.ID, tags ident.Tags, data []checked.Bytes, checksum uint32) error
+
+	// Abort closes the files without writing the checkpoint file so that the
+	// partially written file set is never read.
+	Abort() error
 }
 
 // DataFileSetReaderStatus describes the status of a file set reader
@@ -231,6 +235,10 @@ type DataFileSetSeekerManager interface {
 	// ConcurrentIDBloomFilter returns a concurrent ID bloom filter for a given
 	// shard and block start time
 	ConcurrentIDBloomFilter(shard uint32, start time.Time) (*ManagedConcurrentBloomFilter, error)
+
+	// Invalidate drops the open seekers for a given shard and block start
+	// time so that a rewritten fileset is reopened on the next borrow.
+	Invalidate(shard uint32, start time.Time) error
 }
 
 // DataBlockRetriever provides a block retriever for TSDB file sets
@@ -41,6 +41,9 @@ type DataCloser func() error
 type PreparedDataPersist struct {
 	Persist DataFn
 	Close   DataCloser
+	// Abort releases the prepared fileset without completing it, an aborted
+	// fileset is never read.
+	Abort DataCloser
 }
 
 // IndexFn is a function that persists a m3ninx MutableSegment.
@@ -96,8 +99,11 @@ type DataPrepareOptions struct {
 	NamespaceMetadata namespace.Metadata
 	BlockStart        time.Time
 	Shard             uint32
-	FileSetType       FileSetType
-	DeleteIfExists    bool
+	// VolumeIndex is only used for flush filesets, the volume index of a
+	// snapshot is determined by the snapshots already on disk.
+	VolumeIndex    int
+	FileSetType    FileSetType
+	DeleteIfExists bool
 	// Snapshot options are applicable to snapshots (index yes, data yes)
 	Snapshot DataPrepareSnapshotOptions
 }
@@ -283,6 +283,11 @@ type DatabaseBlockRetriever interface {
 		blockStart time.Time,
 		onRetrieve OnRetrieveBlock,
 	) (xio.BlockReader, error)
+
+	// InvalidateFileSet drops any open handles to the fileset for a given
+	// shard and start, it must be called after rewriting a fileset so
+	// that streams read the rewritten fileset.
+	InvalidateFileSet(shard uint32, blockStart time.Time) error
 }
 
 // DatabaseShardBlockRetriever is a block retriever bound to a shard.
@@ -45,6 +45,9 @@ var (
 	// errShardNotBootstrappedToFlush raised when trying to flush data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToFlush = errors.New("shard is not yet bootstrapped to flush")
 
+	// errShardNotBootstrappedToRepair raised when trying to load repaired blocks into an unbootstrapped shard.
+	errShardNotBootstrappedToRepair = errors.New("shard is not yet bootstrapped to repair")
+
 	// errShardNotBootstrappedToSnapshot raised when trying to snapshot data for a shard that's not yet bootstrapped.
 	errShardNotBootstrappedToSnapshot = errors.New("shard is not yet bootstrapped to snapshot")
 
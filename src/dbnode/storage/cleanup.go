@@ -302,6 +302,14 @@ func (m *cleanupManager) commitLogTimes(t time.Time) ([]commitlog.File, error) {
 				continue
 			}
 
+			// Cold writes may be for any block start in retention, the file has
+			// to be retained until the cold writes it holds are merged into the
+			// filesets as they are only replayed from the commit log.
+			if ns.Options().ColdWritesEnabled() &&
+				start.Add(duration).After(ns.ColdFlushedBefore()) {
+				return false, nil
+			}
+
 			if !needsFlush {
 				// Data has been flushed to disk so the commit log file is
 				// safe to clean up.
@@ -431,6 +431,7 @@ func newCleanupManagerCommitLogTimesTest(t *testing.T, ctrl *gomock.Controller)
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns := NewMockdatabaseNamespace(ctrl)
 	ns.EXPECT().Options().Return(no).AnyTimes()
@@ -457,6 +458,7 @@ func newCleanupManagerCommitLogTimesTestMultiNS(
 	)
 	no := namespace.NewMockOptions(ctrl)
 	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(false).AnyTimes()
 
 	ns1 := NewMockdatabaseNamespace(ctrl)
 	ns1.EXPECT().Options().Return(no).AnyTimes()
@@ -662,6 +664,46 @@ func TestCleanupManagerCommitLogTimesHandlesIsCapturedBySnapshotError(t *testing
 	require.Error(t, err)
 }
 
+func TestCleanupManagerCommitLogTimesRetainsUnflushedColdWrites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rOpts := retention.NewOptions().
+		SetRetentionPeriod(30 * time.Second).
+		SetBufferPast(0 * time.Second).
+		SetBufferFuture(0 * time.Second).
+		SetBlockSize(10 * time.Second)
+	no := namespace.NewMockOptions(ctrl)
+	no.EXPECT().RetentionOptions().Return(rOpts).AnyTimes()
+	no.EXPECT().ColdWritesEnabled().Return(true).AnyTimes()
+
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().Options().Return(no).AnyTimes()
+	ns.EXPECT().NeedsFlush(gomock.Any(), gomock.Any()).Return(false).AnyTimes()
+
+	// The cold writes made before time30 have been merged into the filesets
+	ns.EXPECT().ColdFlushedBefore().Return(time30).AnyTimes()
+
+	db := newMockdatabase(ctrl, ns)
+	mgr := newCleanupManager(db, tally.NoopScope).(*cleanupManager)
+	mgr.opts = mgr.opts.SetCommitLogOptions(
+		mgr.opts.CommitLogOptions().
+			SetBlockSize(rOpts.BlockSize()))
+	mgr.commitLogFilesFn = func(_ commitlog.Options) ([]commitlog.File, error) {
+		return []commitlog.File{
+			commitlog.File{Start: time10, Duration: commitLogBlockSize},
+			commitlog.File{Start: time20, Duration: commitLogBlockSize},
+			commitlog.File{Start: time30, Duration: commitLogBlockSize},
+		}, nil
+	}
+
+	filesToCleanup, err := mgr.commitLogTimes(currentTime)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(filesToCleanup))
+	require.True(t, contains(filesToCleanup, time10))
+	require.True(t, contains(filesToCleanup, time20))
+}
+
 func TestCleanupManagerCommitLogTimesMultiNS(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
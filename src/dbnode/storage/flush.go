@@ -117,6 +117,19 @@ func (m *flushManager) Flush(
 		multiErr = multiErr.Add(m.flushNamespaceWithTimes(ns, shardBootstrapTimes, flushTimes, flush))
 	}
 
+	// Merge the writes made for blocks that were already flushed into their
+	// filesets after flushing so that any block flushed above is included.
+	for _, ns := range namespaces {
+		if !ns.Options().ColdWritesEnabled() {
+			continue
+		}
+		if err := ns.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("namespace %s failed to cold flush data: %v",
+				ns.ID().String(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
 	// Perform two separate loops through all the namespaces so that we can emit better
 	// gauges I.E all the flushing for all the namespaces happens at once and then all
 	// the snapshotting for all the namespaces happens at once. This is also slightly
@@ -80,6 +80,10 @@ type nsIndex struct {
 	bufferPast      time.Duration
 	bufferFuture    time.Duration
 
+	// acceptsPastWrites is set when cold writes or repairs are enabled for
+	// the namespace, which index series older than the buffer past.
+	acceptsPastWrites bool
+
 	indexFilesetsBeforeFn indexFilesetsBeforeFn
 	deleteFilesFn         deleteFilesFn
 
@@ -221,6 +225,8 @@ func newNamespaceIndexWithOptions(
 		bufferPast:      nsMD.Options().RetentionOptions().BufferPast(),
 		bufferFuture:    nsMD.Options().RetentionOptions().BufferFuture(),
 
+		acceptsPastWrites: nsMD.Options().ColdWritesEnabled() || nsMD.Options().RepairEnabled(),
+
 		indexFilesetsBeforeFn: fs.IndexFileSetsBefore,
 		deleteFilesFn:         fs.DeleteFiles,
 
@@ -344,6 +350,10 @@ func (i *nsIndex) writeBatches(
 	now := i.nowFn()
 	futureLimit := now.Add(1 * i.bufferFuture)
 	pastLimit := now.Add(-1 * i.bufferPast)
+	if i.acceptsPastWrites {
+		// Cold writes and repairs index series for blocks already sealed
+		pastLimit = retention.FlushTimeStartForRetentionPeriod(i.retentionPeriod, i.blockSize, now)
+	}
 	writeBatchFn := i.writeBatchForBlockStartWithRLock
 	for _, batch := range batches {
 		// Ensure timestamp is not too old/new based on retention policies and that
@@ -721,21 +731,77 @@ func (i *nsIndex) Query(
 		return index.QueryResults{}, errDbIndexUnableToQueryClosed
 	}
 
-	// override query response limit if needed.
+	opts = i.limitQueryOptionsWithRLock(opts)
+	results := i.opts.IndexOptions().ResultsPool().Get()
+	results.Reset(i.nsMetadata.ID())
+	ctx.RegisterFinalizer(results)
+
+	exhaustive, err := i.queryBlocksWithRLock(opts, results.Size,
+		func(block index.Block) (bool, error) {
+			return block.Query(query, opts, results)
+		})
+	if err != nil {
+		return index.QueryResults{}, err
+	}
+
+	return index.QueryResults{
+		Exhaustive: exhaustive,
+		Results:    results,
+	}, nil
+}
+
+func (i *nsIndex) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	i.state.RLock()
+	defer i.state.RUnlock()
+	if !i.isOpenWithRLock() {
+		return index.AggregateQueryResult{}, errDbIndexUnableToQueryClosed
+	}
+
+	opts.QueryOptions = i.limitQueryOptionsWithRLock(opts.QueryOptions)
+	results := index.NewAggregateResults(i.nsMetadata.ID(), opts.Type)
+
+	exhaustive, err := i.queryBlocksWithRLock(opts.QueryOptions, results.Size,
+		func(block index.Block) (bool, error) {
+			return block.Aggregate(query, opts, results)
+		})
+	if err != nil {
+		return index.AggregateQueryResult{}, err
+	}
+
+	return index.AggregateQueryResult{
+		Exhaustive: exhaustive,
+		Results:    results,
+	}, nil
+}
+
+// limitQueryOptionsWithRLock overrides the query response limit if needed.
+func (i *nsIndex) limitQueryOptionsWithRLock(opts index.QueryOptions) index.QueryOptions {
 	if i.state.runtimeOpts.maxQueryLimit > 0 && (opts.Limit == 0 ||
 		int64(opts.Limit) > i.state.runtimeOpts.maxQueryLimit) {
 		i.logger.Debugf("overriding query response limit, requested: %d, max-allowed: %d",
 			opts.Limit, i.state.runtimeOpts.maxQueryLimit) // FOLLOWUP(prateek): log query too once it's serializable.
 		opts.Limit = int(i.state.runtimeOpts.maxQueryLimit)
 	}
+	return opts
+}
 
+// queryBlocksWithRLock executes the given block query against each of the
+// blocks overlapping the query range until either the range is covered or
+// the results hit the query limit, it returns whether the results are
+// exhaustive.
+func (i *nsIndex) queryBlocksWithRLock(
+	opts index.QueryOptions,
+	sizeFn func() int,
+	queryFn func(block index.Block) (exhaustive bool, err error),
+) (bool, error) {
 	var (
 		exhaustive = true
-		results    = i.opts.IndexOptions().ResultsPool().Get()
 		err        error
 	)
-	results.Reset(i.nsMetadata.ID())
-	ctx.RegisterFinalizer(results)
 
 	// Chunk the query request into bounds based on applicable blocks and
 	// execute the requests to each of them; and merge results.
@@ -747,7 +813,7 @@ func (i *nsIndex) Query(
 	for _, start := range i.state.blockStartsDescOrder {
 		block, ok := i.state.blocksByTime[start]
 		if !ok { // should never happen
-			return index.QueryResults{}, i.missingBlockInvariantError(start)
+			return false, i.missingBlockInvariantError(start)
 		}
 
 		// ensure the block has data requested by the query
@@ -757,14 +823,14 @@ func (i *nsIndex) Query(
 		}
 
 		// terminate early if we know we don't need any more results
-		if opts.Limit > 0 && results.Size() >= opts.Limit {
+		if opts.Limit > 0 && sizeFn() >= opts.Limit {
 			exhaustive = false
 			break
 		}
 
-		exhaustive, err = block.Query(query, opts, results)
+		exhaustive, err = queryFn(block)
 		if err != nil {
-			return index.QueryResults{}, err
+			return false, err
 		}
 
 		if !exhaustive {
@@ -783,10 +849,7 @@ func (i *nsIndex) Query(
 	// FOLLOWUP(prateek): do the above operation with controllable parallelism to optimize
 	// for latency at the cost of higher mem-usage.
 
-	return index.QueryResults{
-		Exhaustive: exhaustive,
-		Results:    results,
-	}, nil
+	return exhaustive, nil
 }
 
 // ensureBlockPresentWithRLock guarantees an index.Block exists for the specified
@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"sort"
+
+	"github.com/m3db/m3x/ident"
+)
+
+type aggregateResults struct {
+	nsID    ident.ID
+	aggType AggregateQueryType
+	size    int
+	tags    map[string]map[string]struct{}
+}
+
+// NewAggregateResults returns a new aggregate results object.
+func NewAggregateResults(
+	nsID ident.ID,
+	aggType AggregateQueryType,
+) AggregateResults {
+	return &aggregateResults{
+		nsID:    nsID,
+		aggType: aggType,
+		tags:    make(map[string]map[string]struct{}),
+	}
+}
+
+func (r *aggregateResults) Namespace() ident.ID {
+	return r.nsID
+}
+
+func (r *aggregateResults) Type() AggregateQueryType {
+	return r.aggType
+}
+
+func (r *aggregateResults) Size() int {
+	return r.size
+}
+
+func (r *aggregateResults) Add(name, value []byte) int {
+	// NB: the string conversions below take a copy of the provided bytes
+	// when inserting and are optimized away by the compiler for lookups.
+	values, ok := r.tags[string(name)]
+	if !ok {
+		values = make(map[string]struct{})
+		r.tags[string(name)] = values
+		if r.aggType == AggregateTagNames {
+			r.size++
+		}
+	}
+
+	if r.aggType == AggregateTagNames {
+		return r.size
+	}
+
+	if _, ok := values[string(value)]; !ok {
+		values[string(value)] = struct{}{}
+		r.size++
+	}
+	return r.size
+}
+
+func (r *aggregateResults) Tags() []AggregateTag {
+	tags := make([]AggregateTag, 0, len(r.tags))
+	for name, values := range r.tags {
+		tag := AggregateTag{Name: []byte(name)}
+		if len(values) > 0 {
+			tag.Values = make([][]byte, 0, len(values))
+			for value := range values {
+				tag.Values = append(tag.Values, []byte(value))
+			}
+			sort.Slice(tag.Values, func(i, j int) bool {
+				return string(tag.Values[i]) < string(tag.Values[j])
+			})
+		}
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return string(tags[i].Name) < string(tags[j].Name)
+	})
+	return tags
+}
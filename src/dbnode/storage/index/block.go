@@ -21,6 +21,7 @@
 package index
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"sync"
@@ -68,6 +69,10 @@ type block struct {
 	activeSegment       segment.MutableSegment
 	shardRangesSegments []blockShardRangesSegments
 
+	// coldSegment holds the series written once the block is sealed, i.e.
+	// cold writes and repaired series, until the next index flush evicts it.
+	coldSegment segment.MutableSegment
+
 	newExecutorFn newExecutorFn
 	startTime     time.Time
 	endTime       time.Time
@@ -129,7 +134,34 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 	b.Lock()
 	defer b.Unlock()
 
-	if b.state != blockStateOpen {
+	var writeSegment segment.MutableSegment
+	switch b.state {
+	case blockStateOpen:
+		// NB: an open block always has a valid activeSegment as it's only
+		// evicted once the block is sealed. the check below is additional paranoia.
+		if b.activeSegment == nil { // should never happen
+			err := b.openBlockHasNilActiveSegmentInvariantErrorWithRLock()
+			inserts.MarkUnmarkedEntriesError(err)
+			return WriteBatchResult{
+				NumError: int64(inserts.Len()),
+			}, err
+		}
+		writeSegment = b.activeSegment
+	case blockStateSealed:
+		// The sealed segments are immutable, writes that arrive after the block
+		// is sealed go to the cold segment which the next index flush evicts.
+		if b.coldSegment == nil {
+			seg, err := mem.NewSegment(postings.ID(0), b.opts.MemSegmentOptions())
+			if err != nil {
+				inserts.MarkUnmarkedEntriesError(err)
+				return WriteBatchResult{
+					NumError: int64(inserts.Len()),
+				}, err
+			}
+			b.coldSegment = seg
+		}
+		writeSegment = b.coldSegment
+	default:
 		err := b.writeBatchErrorInvalidState(b.state)
 		inserts.MarkUnmarkedEntriesError(err)
 		return WriteBatchResult{
@@ -137,17 +169,7 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 		}, err
 	}
 
-	// NB: we're guaranteed the block (i.e. has a valid activeSegment) because
-	// of the state check above. the if check below is additional paranoia.
-	if b.activeSegment == nil { // should never happen
-		err := b.openBlockHasNilActiveSegmentInvariantErrorWithRLock()
-		inserts.MarkUnmarkedEntriesError(err)
-		return WriteBatchResult{
-			NumError: int64(inserts.Len()),
-		}, err
-	}
-
-	err := b.activeSegment.InsertBatch(m3ninxindex.Batch{
+	err := writeSegment.InsertBatch(m3ninxindex.Batch{
 		Docs:                inserts.PendingDocs(),
 		AllowPartialUpdates: true,
 	})
@@ -181,16 +203,32 @@ func (b *block) WriteBatch(inserts *WriteBatch) (WriteBatchResult, error) {
 }
 
 func (b *block) executorWithRLock() (search.Executor, error) {
+	segmentReaders, err := b.segmentReadersWithRLock()
+	if err != nil {
+		return nil, err
+	}
+
+	readers := make([]m3ninxindex.Reader, 0, len(segmentReaders))
+	for _, reader := range segmentReaders {
+		readers = append(readers, reader)
+	}
+	return executor.NewExecutor(readers), nil
+}
+
+func (b *block) segmentReadersWithRLock() ([]segment.Reader, error) {
 	var expectedReaders int
 	if b.activeSegment != nil {
 		expectedReaders++
 	}
+	if b.coldSegment != nil {
+		expectedReaders++
+	}
 	for _, group := range b.shardRangesSegments {
 		expectedReaders += len(group.segments)
 	}
 
 	var (
-		readers = make([]m3ninxindex.Reader, 0, expectedReaders)
+		readers = make([]segment.Reader, 0, expectedReaders)
 		success = false
 	)
 
@@ -212,6 +250,15 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 		readers = append(readers, reader)
 	}
 
+	// include the series written after the block was sealed
+	if b.coldSegment != nil {
+		reader, err := b.coldSegment.Reader()
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+	}
+
 	// loop over the segments associated to shard time ranges
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -224,7 +271,7 @@ func (b *block) executorWithRLock() (search.Executor, error) {
 	}
 
 	success = true
-	return executor.NewExecutor(readers), nil
+	return readers, nil
 }
 
 func (b *block) Query(
@@ -270,6 +317,9 @@ func (b *block) Query(
 			break
 		}
 		d := iter.Current()
+		if opts.SeriesFilter != nil && !opts.SeriesFilter(d.ID) {
+			continue
+		}
 		_, size, err = results.Add(d)
 		if err != nil {
 			return false, err
@@ -292,6 +342,214 @@ func (b *block) Query(
 	return exhaustive, nil
 }
 
+func (b *block) Aggregate(
+	query Query,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	b.RLock()
+	defer b.RUnlock()
+	if b.state == blockStateClosed {
+		return false, errUnableToQueryBlockClosed
+	}
+
+	searcher, err := query.Query.SearchQuery().Searcher()
+	if err != nil {
+		return false, err
+	}
+
+	readers, err := b.segmentReadersWithRLock()
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		exhaustive = true
+		multiErr   xerrors.MultiError
+	)
+	for _, reader := range readers {
+		exhaustive, err = aggregateReader(reader, searcher, opts, results)
+		if err != nil || !exhaustive {
+			break
+		}
+	}
+
+	multiErr = multiErr.Add(err)
+	for _, reader := range readers {
+		multiErr = multiErr.Add(reader.Close())
+	}
+	if err := multiErr.FinalError(); err != nil {
+		return false, err
+	}
+	return exhaustive, nil
+}
+
+// aggregateReader walks the terms dictionary of the segment backing the given
+// reader and adds the tag names, and values if requested, which belong to at
+// least one of the documents matched by the searcher.
+func aggregateReader(
+	reader segment.Reader,
+	searcher search.Searcher,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	matched, err := searcher.Search(reader)
+	if err != nil {
+		return false, err
+	}
+	if opts.SeriesFilter != nil {
+		matched, err = filterSeries(reader, matched, opts.SeriesFilter)
+		if err != nil {
+			return false, err
+		}
+	}
+	if matched.IsEmpty() {
+		return true, nil
+	}
+
+	fields, err := reader.Fields()
+	if err != nil {
+		return false, err
+	}
+	fieldsCloser := safeCloser{closable: fields}
+	defer fieldsCloser.Close()
+
+	exhaustive := true
+	for exhaustive && fields.Next() {
+		field := fields.Current()
+		if bytes.Equal(field, ReservedFieldNameID) ||
+			!matchesTagNameFilter(field, opts.TagNameFilter) {
+			continue
+		}
+
+		exhaustive, err = aggregateTerms(reader, field, matched, opts, results)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := fields.Err(); err != nil {
+		return false, err
+	}
+
+	if err := fieldsCloser.Close(); err != nil {
+		return false, err
+	}
+
+	return exhaustive, nil
+}
+
+// filterSeries returns the postings list with the documents of the series
+// rejected by the filter removed.
+func filterSeries(
+	reader segment.Reader,
+	pl postings.List,
+	filter func(id []byte) bool,
+) (postings.List, error) {
+	var (
+		filtered postings.MutableList
+		iter     = pl.Iterator()
+	)
+	defer iter.Close()
+	for iter.Next() {
+		id := iter.Current()
+		d, err := reader.Doc(id)
+		if err != nil {
+			return nil, err
+		}
+		if filter(d.ID) {
+			continue
+		}
+		if filtered == nil {
+			filtered = pl.Clone()
+		}
+		filtered.RemoveRange(id, id+1)
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	if filtered == nil {
+		return pl, nil
+	}
+	return filtered, nil
+}
+
+func aggregateTerms(
+	reader segment.Reader,
+	field []byte,
+	matched postings.List,
+	opts AggregateQueryOptions,
+	results AggregateResults,
+) (bool, error) {
+	terms, err := reader.Terms(field)
+	if err != nil {
+		return false, err
+	}
+	termsCloser := safeCloser{closable: terms}
+	defer termsCloser.Close()
+
+	exhaustive := true
+	for terms.Next() {
+		term := terms.Current()
+		pl, err := reader.MatchTerm(field, term)
+		if err != nil {
+			return false, err
+		}
+		if !intersects(matched, pl) {
+			continue
+		}
+
+		if opts.Limit > 0 && results.Size() >= opts.Limit {
+			exhaustive = false
+			break
+		}
+
+		results.Add(field, term)
+		if results.Type() == AggregateTagNames {
+			// the tag name is known to match, no need to look at more values.
+			break
+		}
+	}
+
+	if err := terms.Err(); err != nil {
+		return false, err
+	}
+
+	if err := termsCloser.Close(); err != nil {
+		return false, err
+	}
+
+	return exhaustive, nil
+}
+
+func matchesTagNameFilter(name []byte, filter [][]byte) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if bytes.Equal(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersects returns whether the two postings lists share at least one ID.
+func intersects(a, b postings.List) bool {
+	if a.Len() > b.Len() {
+		a, b = b, a
+	}
+
+	iter := a.Iterator()
+	defer iter.Close()
+	for iter.Next() {
+		if b.Contains(iter.Current()) {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *block) AddResults(
 	results result.IndexBlock,
 ) error {
@@ -383,6 +641,12 @@ func (b *block) Tick(c context.Cancellable, tickStart time.Time) (BlockTickResul
 		result.NumDocs += b.activeSegment.Size()
 	}
 
+	// cold segment, only present if written to since the last index flush.
+	if b.coldSegment != nil {
+		result.NumSegments++
+		result.NumDocs += b.coldSegment.Size()
+	}
+
 	// any other segments
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
@@ -436,7 +700,8 @@ func (b *block) IsSealed() bool {
 func (b *block) NeedsMutableSegmentsEvicted() bool {
 	b.RLock()
 	defer b.RUnlock()
-	anyMutableSegmentNeedsEviction := b.activeSegment != nil && b.activeSegment.Size() > 0
+	anyMutableSegmentNeedsEviction := (b.activeSegment != nil && b.activeSegment.Size() > 0) ||
+		(b.coldSegment != nil && b.coldSegment.Size() > 0)
 
 	// can early terminate if we already know we need to flush.
 	if anyMutableSegmentNeedsEviction {
@@ -473,6 +738,14 @@ func (b *block) EvictMutableSegments() (EvictMutableSegmentResults, error) {
 		b.activeSegment = nil
 	}
 
+	// close the cold segment, the flush read its series from the shards.
+	if b.coldSegment != nil {
+		results.NumMutableSegments++
+		results.NumDocs += b.coldSegment.Size()
+		multiErr = multiErr.Add(b.coldSegment.Close())
+		b.coldSegment = nil
+	}
+
 	// close any other mutable segments too.
 	for idx := range b.shardRangesSegments {
 		segments := make([]segment.Segment, 0, len(b.shardRangesSegments[idx].segments))
@@ -508,6 +781,12 @@ func (b *block) Close() error {
 		b.activeSegment = nil
 	}
 
+	// close cold segment.
+	if b.coldSegment != nil {
+		multiErr = multiErr.Add(b.coldSegment.Close())
+		b.coldSegment = nil
+	}
+
 	// close any other added segments too.
 	for _, group := range b.shardRangesSegments {
 		for _, seg := range group.segments {
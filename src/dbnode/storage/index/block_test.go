@@ -21,6 +21,7 @@
 package index
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
@@ -133,8 +134,13 @@ func TestBlockWriteAfterSeal(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, b.Seal())
 
+	_, err = b.EvictMutableSegments()
+	require.NoError(t, err)
+	require.False(t, b.NeedsMutableSegmentsEvicted())
+
 	lifecycle := NewMockOnIndexSeries(ctrl)
 	lifecycle.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	lifecycle.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
 
 	batch := NewWriteBatch(WriteBatchOptions{
 		IndexBlockSize: blockSize,
@@ -142,25 +148,26 @@ func TestBlockWriteAfterSeal(t *testing.T) {
 	batch.Append(WriteBatchEntry{
 		Timestamp:     nowNotBlockStartAligned,
 		OnIndexSeries: lifecycle,
-	}, doc.Document{})
+	}, testDoc1())
 
 	res, err := b.WriteBatch(batch)
-	require.Error(t, err)
-	require.Equal(t, int64(0), res.NumSuccess)
-	require.Equal(t, int64(1), res.NumError)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), res.NumSuccess)
+	require.Equal(t, int64(0), res.NumError)
 
-	verified := 0
-	batch.ForEach(func(
-		idx int,
-		entry WriteBatchEntry,
-		doc doc.Document,
-		result WriteBatchEntryResult,
-	) {
-		verified++
-		require.Error(t, result.Err)
-		require.Equal(t, errUnableToWriteBlockSealed, result.Err)
-	})
-	require.Equal(t, 1, verified)
+	// The write is queryable and held until the next eviction
+	q := idx.NewTermQuery([]byte("bar"), []byte("baz"))
+	results := NewResults(testOpts)
+	_, err = b.Query(Query{q}, QueryOptions{}, results)
+	require.NoError(t, err)
+	require.Equal(t, 1, results.Size())
+	require.True(t, b.NeedsMutableSegmentsEvicted())
+
+	evictRes, err := b.EvictMutableSegments()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), evictRes.NumMutableSegments)
+	require.Equal(t, int64(1), evictRes.NumDocs)
+	require.False(t, b.NeedsMutableSegmentsEvicted())
 }
 
 func TestBlockWriteMockSegment(t *testing.T) {
@@ -493,11 +500,11 @@ func TestBlockQueryAddResultsSegmentsError(t *testing.T) {
 	b.shardRangesSegments = []blockShardRangesSegments{
 		blockShardRangesSegments{segments: []segment.Segment{seg2, seg3}}}
 
-	r1 := index.NewMockReader(ctrl)
+	r1 := segment.NewMockReader(ctrl)
 	seg1.EXPECT().Reader().Return(r1, nil)
 	r1.EXPECT().Close().Return(nil)
 
-	r2 := index.NewMockReader(ctrl)
+	r2 := segment.NewMockReader(ctrl)
 	seg2.EXPECT().Reader().Return(r2, nil)
 	r2.EXPECT().Close().Return(nil)
 
@@ -746,6 +753,51 @@ func TestBlockMockQueryLimitExhaustive(t *testing.T) {
 		ident.NewTagsIterator(t1)))
 }
 
+func TestBlockMockQuerySeriesFilterBeforeLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	blk, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+
+	b, ok := blk.(*block)
+	require.True(t, ok)
+
+	exec := search.NewMockExecutor(ctrl)
+	b.newExecutorFn = func() (search.Executor, error) {
+		return exec, nil
+	}
+
+	dIter := doc.NewMockIterator(ctrl)
+	gomock.InOrder(
+		exec.EXPECT().Execute(gomock.Any()).Return(dIter, nil),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Current().Return(testDoc1()),
+		dIter.EXPECT().Next().Return(true),
+		dIter.EXPECT().Current().Return(testDoc2()),
+		dIter.EXPECT().Next().Return(false),
+		dIter.EXPECT().Err().Return(nil),
+		dIter.EXPECT().Close().Return(nil),
+		exec.EXPECT().Close().Return(nil),
+	)
+	results := NewResults(testOpts)
+	exhaustive, err := b.Query(Query{}, QueryOptions{
+		Limit: 1,
+		SeriesFilter: func(id []byte) bool {
+			return !bytes.Equal(id, testDoc1().ID)
+		},
+	}, results)
+	require.NoError(t, err)
+	require.True(t, exhaustive)
+
+	rMap := results.Map()
+	require.Equal(t, 1, rMap.Len())
+	_, ok = rMap.Get(ident.StringID(string(testDoc2().ID)))
+	require.True(t, ok)
+}
+
 func TestBlockMockQueryMergeResultsMapLimit(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1456,6 +1508,144 @@ func TestBlockE2EInsertAddResultsMergeQuery(t *testing.T) {
 		ident.NewTagsIterator(t2)))
 }
 
+func TestBlockAggregateAfterClose(t *testing.T) {
+	testMD := newTestNSMetadata(t)
+	start := time.Now().Truncate(time.Hour)
+	b, err := NewBlock(start, testMD, testOpts)
+	require.NoError(t, err)
+	require.NoError(t, b.Close())
+
+	_, err = b.Aggregate(Query{}, AggregateQueryOptions{}, nil)
+	require.Error(t, err)
+}
+
+func TestBlockE2EInsertAddResultsAggregate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	testMD := newTestNSMetadata(t)
+	blockSize := time.Hour
+
+	now := time.Now()
+	blockStart := now.Truncate(blockSize)
+
+	nowNotBlockStartAligned := now.
+		Truncate(blockSize).
+		Add(time.Minute)
+
+	blk, err := NewBlock(blockStart, testMD, testOpts)
+	require.NoError(t, err)
+
+	h1 := NewMockOnIndexSeries(ctrl)
+	h1.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h1.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	h2 := NewMockOnIndexSeries(ctrl)
+	h2.EXPECT().OnIndexFinalize(xtime.ToUnixNano(blockStart))
+	h2.EXPECT().OnIndexSuccess(xtime.ToUnixNano(blockStart))
+
+	batch := NewWriteBatch(WriteBatchOptions{
+		IndexBlockSize: blockSize,
+	})
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h1,
+	}, testDoc1())
+	batch.Append(WriteBatchEntry{
+		Timestamp:     nowNotBlockStartAligned,
+		OnIndexSeries: h2,
+	}, testDoc2())
+
+	res, err := blk.WriteBatch(batch)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), res.NumSuccess)
+
+	seg := testSegment(t, doc.Document{
+		ID: []byte("other"),
+		Fields: []doc.Field{
+			doc.Field{Name: []byte("bar"), Value: []byte("qux")},
+			doc.Field{Name: []byte("why"), Value: []byte("not")},
+		},
+	})
+	require.NoError(t, blk.AddResults(
+		result.NewIndexBlock(blockStart, []segment.Segment{seg},
+			result.NewShardTimeRanges(blockStart, blockStart.Add(blockSize), 1, 2, 3))))
+
+	tag := func(name string, values ...string) AggregateTag {
+		t := AggregateTag{Name: []byte(name)}
+		for _, v := range values {
+			t.Values = append(t.Values, []byte(v))
+		}
+		return t
+	}
+
+	tests := []struct {
+		name       string
+		regexp     string
+		opts       AggregateQueryOptions
+		exhaustive bool
+		expected   []AggregateTag
+	}{
+		{
+			name:       "tag names and values",
+			regexp:     "b.*",
+			exhaustive: true,
+			expected:   []AggregateTag{tag("bar", "baz"), tag("some", "more")},
+		},
+		{
+			name:       "tag names and values across segments",
+			regexp:     ".*",
+			exhaustive: true,
+			expected: []AggregateTag{tag("bar", "baz", "qux"),
+				tag("some", "more"), tag("why", "not")},
+		},
+		{
+			name:       "tag names",
+			regexp:     ".*",
+			opts:       AggregateQueryOptions{Type: AggregateTagNames},
+			exhaustive: true,
+			expected:   []AggregateTag{tag("bar"), tag("some"), tag("why")},
+		},
+		{
+			name:       "tag name filter",
+			regexp:     ".*",
+			opts:       AggregateQueryOptions{TagNameFilter: [][]byte{[]byte("why")}},
+			exhaustive: true,
+			expected:   []AggregateTag{tag("why", "not")},
+		},
+		{
+			name:   "series filter",
+			regexp: ".*",
+			opts: AggregateQueryOptions{QueryOptions: QueryOptions{
+				SeriesFilter: func(id []byte) bool {
+					return !bytes.Equal(id, testDoc2().ID)
+				},
+			}},
+			exhaustive: true,
+			expected:   []AggregateTag{tag("bar", "baz", "qux"), tag("why", "not")},
+		},
+		{
+			name:     "limit",
+			regexp:   "b.*",
+			opts:     AggregateQueryOptions{QueryOptions: QueryOptions{Limit: 1}},
+			expected: []AggregateTag{tag("bar", "baz")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := idx.NewRegexpQuery([]byte("bar"), []byte(tt.regexp))
+			require.NoError(t, err)
+
+			results := NewAggregateResults(testMD.ID(), tt.opts.Type)
+			exhaustive, err := blk.Aggregate(Query{q}, tt.opts, results)
+			require.NoError(t, err)
+			require.Equal(t, tt.exhaustive, exhaustive)
+			require.Equal(t, tt.expected, results.Tags())
+		})
+	}
+}
+
 func testSegment(t *testing.T, docs ...doc.Document) segment.Segment {
 	seg, err := mem.NewSegment(0, testOpts.MemSegmentOptions())
 	require.NoError(t, err)
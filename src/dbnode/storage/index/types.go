@@ -62,6 +62,11 @@ type QueryOptions struct {
 	StartInclusive time.Time
 	EndExclusive   time.Time
 	Limit          int
+
+	// SeriesFilter if set restricts the results to the documents of the
+	// series for which it returns true, documents are filtered before the
+	// limit is applied.
+	SeriesFilter func(id []byte) bool
 }
 
 // QueryResults is the collection of results for a query.
@@ -96,6 +101,63 @@ type Results interface {
 	Add(d doc.Document) (added bool, size int, err error)
 }
 
+// AggregateQueryType specifies what an aggregate query collects.
+type AggregateQueryType byte
+
+const (
+	// AggregateTagNamesAndValues collects the unique tag names along with the
+	// unique values of each tag.
+	AggregateTagNamesAndValues AggregateQueryType = iota
+	// AggregateTagNames collects only the unique tag names.
+	AggregateTagNames
+)
+
+// AggregateQueryOptions enables users to specify constraints on aggregate
+// query execution.
+type AggregateQueryOptions struct {
+	QueryOptions
+
+	// TagNameFilter restricts the aggregation to the given tag names, if empty
+	// all tag names are aggregated.
+	TagNameFilter [][]byte
+	Type          AggregateQueryType
+}
+
+// AggregateQueryResult is the collection of results for an aggregate query.
+type AggregateQueryResult struct {
+	Results    AggregateResults
+	Exhaustive bool
+}
+
+// AggregateResults is a collection of the unique tag names, and optionally the
+// unique tag values, of the documents matching an aggregate query.
+type AggregateResults interface {
+	// Namespace returns the namespace associated with the result.
+	Namespace() ident.ID
+
+	// Type returns what the results are collecting.
+	Type() AggregateQueryType
+
+	// Size returns the number of unique tag names tracked when collecting only
+	// tag names, and the number of unique tag name and value pairs otherwise.
+	Size() int
+
+	// Add adds the tag name, and the tag value if the results are collecting
+	// tag values, to the results and returns the updated size. The provided
+	// bytes are copied so they may be modified after this function returns.
+	Add(name, value []byte) (size int)
+
+	// Tags returns the collected tags sorted by name, each with its values
+	// sorted.
+	Tags() []AggregateTag
+}
+
+// AggregateTag is a unique tag name along with its collected values.
+type AggregateTag struct {
+	Name   []byte
+	Values [][]byte
+}
+
 // ResultsAllocator allocates Results types.
 type ResultsAllocator func() Results
 
@@ -145,14 +207,23 @@ type Block interface {
 		results Results,
 	) (exhaustive bool, err error)
 
+	// Aggregate resolves the given query into the unique tag names, and
+	// optionally tag values, of the matching documents.
+	Aggregate(
+		query Query,
+		opts AggregateQueryOptions,
+		results AggregateResults,
+	) (exhaustive bool, err error)
+
 	// AddResults adds bootstrap results to the block, if c.
 	AddResults(results result.IndexBlock) error
 
 	// Tick does internal house keeping operations.
 	Tick(c context.Cancellable, tickStart time.Time) (BlockTickResult, error)
 
-	// Seal prevents the block from taking any more writes, but, it still permits
-	// addition of segments via Bootstrap().
+	// Seal prevents the block's segments from taking any more writes, but, it
+	// still permits addition of segments via Bootstrap(). Writes made after
+	// the block is sealed are held in a cold segment until the next eviction.
 	Seal() error
 
 	// IsSealed returns whether this block was sealed.
@@ -510,3 +510,92 @@ func TestNamespaceIndexBlockQuery(t *testing.T) {
 	_, err = idx.Query(ctx, q, qOpts)
 	require.NoError(t, err)
 }
+
+func TestNamespaceIndexBlockAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	retention := 2 * time.Hour
+	blockSize := time.Hour
+	now := time.Now().Truncate(blockSize).Add(10 * time.Minute)
+	t0 := now.Truncate(blockSize)
+	t0Nanos := xtime.ToUnixNano(t0)
+	t1 := t0.Add(1 * blockSize)
+	t1Nanos := xtime.ToUnixNano(t1)
+	t2 := t1.Add(1 * blockSize)
+	var nowLock sync.Mutex
+	nowFn := func() time.Time {
+		nowLock.Lock()
+		defer nowLock.Unlock()
+		return now
+	}
+	opts := testDatabaseOptions()
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(nowFn))
+
+	b0 := index.NewMockBlock(ctrl)
+	b0.EXPECT().StartTime().Return(t0).AnyTimes()
+	b0.EXPECT().EndTime().Return(t0.Add(blockSize)).AnyTimes()
+	b1 := index.NewMockBlock(ctrl)
+	b1.EXPECT().StartTime().Return(t1).AnyTimes()
+	b1.EXPECT().EndTime().Return(t1.Add(blockSize)).AnyTimes()
+	newBlockFn := func(ts time.Time, md namespace.Metadata, io index.Options) (index.Block, error) {
+		if ts.Equal(t0) {
+			return b0, nil
+		}
+		if ts.Equal(t1) {
+			return b1, nil
+		}
+		panic("should never get here")
+	}
+	md := testNamespaceMetadata(blockSize, retention)
+	idx, err := newNamespaceIndexWithNewBlockFn(md, newBlockFn, opts)
+	require.NoError(t, err)
+
+	seg1 := segment.NewMockSegment(ctrl)
+	seg2 := segment.NewMockSegment(ctrl)
+	seg3 := segment.NewMockSegment(ctrl)
+	bootstrapResults := result.IndexResults{
+		t0Nanos: result.NewIndexBlock(t0, []segment.Segment{seg1}, result.NewShardTimeRanges(t0, t1, 1, 2, 3)),
+		t1Nanos: result.NewIndexBlock(t1, []segment.Segment{seg2, seg3}, result.NewShardTimeRanges(t1, t2, 1, 2, 3)),
+	}
+
+	b0.EXPECT().AddResults(bootstrapResults[t0Nanos]).Return(nil)
+	b1.EXPECT().AddResults(bootstrapResults[t1Nanos]).Return(nil)
+	require.NoError(t, idx.Bootstrap(bootstrapResults))
+
+	// only queries as much as is needed (wrt to time)
+	ctx := context.NewContext()
+	q := index.Query{}
+	qOpts := index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   now.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+
+	// queries multiple blocks if needed
+	qOpts = index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   t2.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	b1.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(true, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+
+	// stops querying once a block returns non-exhaustive
+	qOpts = index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			StartInclusive: t0,
+			EndExclusive:   t0.Add(time.Minute),
+		},
+	}
+	b0.EXPECT().Aggregate(q, qOpts, gomock.Any()).Return(false, nil)
+	_, err = idx.AggregateQuery(ctx, q, qOpts)
+	require.NoError(t, err)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -55,6 +56,9 @@ import (
 var (
 	errNamespaceAlreadyClosed    = errors.New("namespace already closed")
 	errNamespaceIndexingDisabled = errors.New("namespace indexing is disabled")
+
+	errNamespaceDeleteQueryNotExhaustive = errors.New(
+		"delete query matched more series than the query limit, not all matching series were deleted")
 )
 
 type commitLogWriter interface {
@@ -95,6 +99,8 @@ var commitLogWriteNoOp = commitLogWriter(commitLogWriterFn(func(
 	return nil
 }))
 
+type newCommitLogIteratorFn func(opts commitlog.IteratorOpts) (commitlog.Iterator, error)
+
 type dbNamespace struct {
 	sync.RWMutex
 
@@ -110,9 +116,15 @@ type dbNamespace struct {
 	seriesOpts         series.Options
 	nowFn              clock.NowFn
 	snapshotFilesFn    snapshotFilesFn
+	newCommitLogIterFn newCommitLogIteratorFn
+	commitLogFilesFn   commitLogFilesFn
 	log                xlog.Logger
 	bootstrapState     BootstrapState
 
+	// coldFlushedBefore is the start of the last cold flush that merged
+	// the cold writes of all shards into their filesets.
+	coldFlushedBefore time.Time
+
 	// Contains an entry to all shards for fast shard lookup, an
 	// entry will be nil when this shard does not belong to current database
 	shards []databaseShard
@@ -145,6 +157,7 @@ type databaseNamespaceMetrics struct {
 	bootstrap           instrument.MethodMetrics
 	flush               instrument.MethodMetrics
 	flushIndex          instrument.MethodMetrics
+	coldFlush           instrument.MethodMetrics
 	snapshot            instrument.MethodMetrics
 	write               instrument.MethodMetrics
 	writeTagged         instrument.MethodMetrics
@@ -152,6 +165,8 @@ type databaseNamespaceMetrics struct {
 	fetchBlocks         instrument.MethodMetrics
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
+	aggregateQuery      instrument.MethodMetrics
+	delete              instrument.MethodMetrics
 	unfulfilled         tally.Counter
 	bootstrapStart      tally.Counter
 	bootstrapEnd        tally.Counter
@@ -215,6 +230,7 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		bootstrap:           instrument.NewMethodMetrics(scope, "bootstrap", samplingRate),
 		flush:               instrument.NewMethodMetrics(scope, "flush", samplingRate),
 		flushIndex:          instrument.NewMethodMetrics(scope, "flushIndex", samplingRate),
+		coldFlush:           instrument.NewMethodMetrics(scope, "coldFlush", samplingRate),
 		snapshot:            instrument.NewMethodMetrics(scope, "snapshot", samplingRate),
 		write:               instrument.NewMethodMetrics(scope, "write", samplingRate),
 		writeTagged:         instrument.NewMethodMetrics(scope, "write-tagged", samplingRate),
@@ -222,6 +238,8 @@ func newDatabaseNamespaceMetrics(scope tally.Scope, samplingRate float64) databa
 		fetchBlocks:         instrument.NewMethodMetrics(scope, "fetchBlocks", samplingRate),
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", samplingRate),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", samplingRate),
+		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", samplingRate),
+		delete:              instrument.NewMethodMetrics(scope, "delete", samplingRate),
 		unfulfilled:         scope.Counter("bootstrap.unfulfilled"),
 		bootstrapStart:      scope.Counter("bootstrap.start"),
 		bootstrapEnd:        scope.Counter("bootstrap.end"),
@@ -293,6 +311,7 @@ func newDatabaseNamespace(
 	tickWorkers.Init()
 
 	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
+		SetColdWritesEnabled(nopts.ColdWritesEnabled()).
 		SetStats(series.NewStats(scope))
 	if err := seriesOpts.Validate(); err != nil {
 		return nil, fmt.Errorf(
@@ -323,6 +342,8 @@ func newDatabaseNamespace(
 		seriesOpts:             seriesOpts,
 		nowFn:                  opts.ClockOptions().NowFn(),
 		snapshotFilesFn:        fs.SnapshotFiles,
+		newCommitLogIterFn:     commitlog.NewIterator,
+		commitLogFilesFn:       commitlog.Files,
 		log:                    logger,
 		increasingIndex:        increasingIndex,
 		commitLogWriter:        commitLogWriter,
@@ -596,11 +617,125 @@ func (n *dbNamespace) QueryIDs(
 		n.metrics.queryIDs.ReportError(n.nowFn().Sub(callStart))
 		return index.QueryResults{}, errNamespaceIndexingDisabled
 	}
+	// The index retains the documents of deleted series until the index
+	// blocks holding them expire, so they are excluded as the documents
+	// matching the query are collected
+	opts.SeriesFilter = n.deletedSeriesFilter()
 	res, err := n.reverseIndex.Query(ctx, query, opts)
 	n.metrics.queryIDs.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
 	return res, err
 }
 
+// deletedSeriesFilter returns an index series filter excluding the series
+// that have been deleted, or nil if no series have been deleted.
+func (n *dbNamespace) deletedSeriesFilter() func(id []byte) bool {
+	if !n.hasDeletedSeries() {
+		return nil
+	}
+	return func(id []byte) bool {
+		return !n.isSeriesDeleted(ident.BytesID(id))
+	}
+}
+
+func (n *dbNamespace) isSeriesDeleted(id ident.ID) bool {
+	shard, err := n.shardFor(id)
+	if err != nil {
+		return false
+	}
+	return shard.IsSeriesDeleted(id)
+}
+
+func (n *dbNamespace) hasDeletedSeries() bool {
+	for _, shard := range n.GetOwnedShards() {
+		if shard.HasDeletedSeries() {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *dbNamespace) AggregateQuery(
+	ctx context.Context,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (index.AggregateQueryResult, error) {
+	callStart := n.nowFn()
+	if n.reverseIndex == nil { // only happens if indexing is enabled.
+		n.metrics.aggregateQuery.ReportError(n.nowFn().Sub(callStart))
+		return index.AggregateQueryResult{}, errNamespaceIndexingDisabled
+	}
+	opts.SeriesFilter = n.deletedSeriesFilter()
+	res, err := n.reverseIndex.AggregateQuery(ctx, query, opts)
+	n.metrics.aggregateQuery.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return res, err
+}
+
+func (n *dbNamespace) Delete(
+	ctx context.Context,
+	ids []ident.ID,
+) (int64, error) {
+	callStart := n.nowFn()
+	idsByShard := make(map[databaseShard][]ident.ID)
+	for _, id := range ids {
+		shard, err := n.shardFor(id)
+		if err != nil {
+			// Deletes are sent to every replica, so skip the IDs belonging
+			// to shards that are not owned by this node
+			continue
+		}
+		idsByShard[shard] = append(idsByShard[shard], id)
+	}
+
+	var (
+		deleted  int64
+		multiErr = xerrors.NewMultiError()
+	)
+	for shard, shardIDs := range idsByShard {
+		numDeleted, err := shard.DeleteSeries(shardIDs, callStart)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		deleted += numDeleted
+	}
+
+	err := multiErr.FinalError()
+	n.metrics.delete.ReportSuccessOrError(err, n.nowFn().Sub(callStart))
+	return deleted, err
+}
+
+func (n *dbNamespace) DeleteTagged(
+	ctx context.Context,
+	query index.Query,
+) (int64, error) {
+	var (
+		now   = n.nowFn()
+		ropts = n.nopts.RetentionOptions()
+	)
+	// Match any series with data retained by the namespace
+	res, err := n.QueryIDs(ctx, query, index.QueryOptions{
+		StartInclusive: retention.FlushTimeStart(ropts, now),
+		EndExclusive:   now.Add(ropts.BufferFuture()),
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	ids := make([]ident.ID, 0, res.Results.Size())
+	for _, entry := range res.Results.Map().Iter() {
+		ids = append(ids, entry.Key())
+	}
+
+	deleted, err := n.Delete(ctx, ids)
+	if err != nil {
+		return deleted, err
+	}
+	if !res.Exhaustive {
+		return deleted, errNamespaceDeleteQueryNotExhaustive
+	}
+	return deleted, nil
+}
+
 func (n *dbNamespace) ReadEncoded(
 	ctx context.Context,
 	id ident.ID,
@@ -781,6 +916,10 @@ func (n *dbNamespace) Bootstrap(start time.Time, process bootstrap.Process) erro
 		multiErr = multiErr.Add(err)
 	}
 
+	if n.nopts.ColdWritesEnabled() {
+		multiErr = multiErr.Add(n.replayColdWrites(shards))
+	}
+
 	markAnyUnfulfilled := func(label string, unfulfilled result.ShardTimeRanges) {
 		shardsUnfulfilled := int64(len(unfulfilled))
 		n.metrics.unfulfilled.Inc(shardsUnfulfilled)
@@ -865,6 +1004,142 @@ func (n *dbNamespace) Flush(
 	return res
 }
 
+// replayColdWrites writes the datapoints retained in the commit log for the
+// block starts the shards have already flushed back into the shards, the
+// commit log bootstrapper only reads the ranges not fulfilled by filesets
+// so the cold writes not yet merged into the filesets are otherwise lost.
+func (n *dbNamespace) replayColdWrites(shards []databaseShard) error {
+	files, err := n.commitLogFilesFn(n.opts.CommitLogOptions())
+	if err != nil {
+		return err
+	}
+
+	var (
+		shardsByID = make(map[uint32]databaseShard, len(shards))
+		ctx        = n.opts.ContextPool().Get()
+		multiErr   = xerrors.NewMultiError()
+		replayed   int
+	)
+	for _, shard := range shards {
+		shardsByID[shard.ID()] = shard
+	}
+	// Each commit log file is replayed separately as the writes it holds were
+	// made no earlier than its start, which the shards use to tell apart the
+	// writes to deleted series made before and since they were deleted
+	for _, file := range files {
+		numReplayed, err := n.replayColdWritesFromFile(ctx, file, shardsByID)
+		multiErr = multiErr.Add(err)
+		replayed += numReplayed
+	}
+	ctx.BlockingClose()
+
+	n.log.WithFields(
+		xlog.NewField("numDatapoints", replayed),
+	).Infof("replayed commit log writes for flushed block starts")
+	return multiErr.FinalError()
+}
+
+func (n *dbNamespace) replayColdWritesFromFile(
+	ctx context.Context,
+	file commitlog.File,
+	shardsByID map[uint32]databaseShard,
+) (int, error) {
+	iter, err := n.newCommitLogIterFn(commitlog.IteratorOpts{
+		CommitLogOptions: n.opts.CommitLogOptions(),
+		FileFilterPredicate: func(f commitlog.File) bool {
+			return f.FilePath == file.FilePath
+		},
+		SeriesFilterPredicate: func(_ ident.ID, namespace ident.ID) bool {
+			return namespace.Equal(n.id)
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer iter.Close()
+
+	var (
+		blockSize = n.nopts.RetentionOptions().BlockSize()
+		multiErr  = xerrors.NewMultiError()
+		replayed  int
+	)
+	for iter.Next() {
+		series, dp, unit, annotation := iter.Current()
+		shard, ok := shardsByID[series.Shard]
+		if !ok {
+			continue
+		}
+		blockStart := dp.Timestamp.Truncate(blockSize)
+		if shard.FlushState(blockStart).Status != fileOpSuccess {
+			// Unflushed ranges are read by the commit log bootstrapper
+			continue
+		}
+		err := shard.ReplayColdWrite(ctx, series.ID, series.Tags, dp.Timestamp,
+			dp.Value, unit, annotation, file.Start)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		replayed++
+	}
+	multiErr = multiErr.Add(iter.Err())
+	return replayed, multiErr.FinalError()
+}
+
+func (n *dbNamespace) ColdFlush(flush persist.DataFlush) error {
+	// NB(rartoul): This value can be used for emitting metrics, but should not be used
+	// for business logic.
+	callStart := n.nowFn()
+	coldFlushStart := n.nowFn()
+
+	n.RLock()
+	if n.bootstrapState != Bootstrapped {
+		n.RUnlock()
+		n.metrics.coldFlush.ReportError(n.nowFn().Sub(callStart))
+		return errNamespaceNotBootstrapped
+	}
+	n.RUnlock()
+
+	if !n.nopts.FlushEnabled() || !n.nopts.ColdWritesEnabled() {
+		n.setColdFlushedBefore(coldFlushStart)
+		n.metrics.coldFlush.ReportSuccess(n.nowFn().Sub(callStart))
+		return nil
+	}
+
+	multiErr := xerrors.NewMultiError()
+	shards := n.GetOwnedShards()
+	for _, shard := range shards {
+		// NB: Proceed with the remaining shards if a shard fails, the cold
+		// writes of the failed shard are retained until the next flush.
+		if err := shard.ColdFlush(flush); err != nil {
+			detailedErr := fmt.Errorf("shard %d failed to cold flush data: %v",
+				shard.ID(), err)
+			multiErr = multiErr.Add(detailedErr)
+		}
+	}
+
+	res := multiErr.FinalError()
+	if res == nil {
+		// The cold writes made before the flush started are all persisted
+		n.setColdFlushedBefore(coldFlushStart)
+	}
+	n.metrics.coldFlush.ReportSuccessOrError(res, n.nowFn().Sub(callStart))
+	return res
+}
+
+func (n *dbNamespace) setColdFlushedBefore(t time.Time) {
+	n.Lock()
+	n.coldFlushedBefore = t
+	n.Unlock()
+}
+
+func (n *dbNamespace) ColdFlushedBefore() time.Time {
+	n.RLock()
+	t := n.coldFlushedBefore
+	n.RUnlock()
+	return t
+}
+
 func (n *dbNamespace) FlushIndex(
 	flush persist.IndexFlush,
 ) error {
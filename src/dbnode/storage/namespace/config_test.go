@@ -91,6 +91,7 @@ func TestMetadataConfig(t *testing.T) {
 		writesToCommitLog = true
 		cleanupEnabled    = false
 		repairEnabled     = false
+		coldWritesEnabled = true
 		retention         = retention.Configuration{
 			BlockSize:       time.Hour,
 			RetentionPeriod: time.Hour,
@@ -108,6 +109,7 @@ func TestMetadataConfig(t *testing.T) {
 			WritesToCommitLog: &writesToCommitLog,
 			CleanupEnabled:    &cleanupEnabled,
 			RepairEnabled:     &repairEnabled,
+			ColdWritesEnabled: &coldWritesEnabled,
 			Retention:         retention,
 			Index:             index,
 		}
@@ -123,6 +125,7 @@ func TestMetadataConfig(t *testing.T) {
 	require.Equal(t, writesToCommitLog, opts.WritesToCommitLog())
 	require.Equal(t, cleanupEnabled, opts.CleanupEnabled())
 	require.Equal(t, repairEnabled, opts.RepairEnabled())
+	require.Equal(t, coldWritesEnabled, opts.ColdWritesEnabled())
 	require.Equal(t, retention.Options(), opts.RetentionOptions())
 	require.Equal(t, index.Options(), opts.IndexOptions())
 }
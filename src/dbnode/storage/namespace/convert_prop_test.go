@@ -83,7 +83,7 @@ func genMap() gopter.Gen {
 func genMetadata() gopter.Gen {
 	return gopter.CombineGens(
 		gen.Identifier(),
-		gen.SliceOfN(7, gen.Bool()),
+		gen.SliceOfN(8, gen.Bool()),
 		genRetention(),
 	).Map(func(values []interface{}) namespace.Metadata {
 		var (
@@ -98,6 +98,7 @@ func genMetadata() gopter.Gen {
 			SetRepairEnabled(bools[3]).
 			SetWritesToCommitLog(bools[4]).
 			SetSnapshotEnabled(bools[5]).
+			SetColdWritesEnabled(bools[7]).
 			SetRetentionOptions(retention).
 			SetIndexOptions(namespace.NewIndexOptions().
 				SetEnabled(bools[6]).
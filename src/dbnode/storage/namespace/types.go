@@ -73,6 +73,14 @@ type Options interface {
 	// RepairEnabled returns whether the data for this namespace needs to be repaired
 	RepairEnabled() bool
 
+	// SetColdWritesEnabled sets whether writes older than the buffer past are accepted
+	// for any block within retention and merged into the flushed data on the next flush
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than the buffer past are accepted
+	// for any block within retention and merged into the flushed data on the next flush
+	ColdWritesEnabled() bool
+
 	// SetRetentionOptions sets the retention options for this namespace
 	SetRetentionOptions(value retention.Options) Options
 
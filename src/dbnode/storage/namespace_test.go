@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/sharding"
@@ -37,7 +38,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3cluster/shard"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
@@ -362,6 +365,121 @@ func TestNamespaceBootstrapAllShards(t *testing.T) {
 	require.Equal(t, BootstrapNotStarted, ns.bootstrapState)
 }
 
+func TestNamespaceBootstrapReplaysColdWrites(t *testing.T) {
+	ctrl := gomock.NewController(xtest.Reporter{t})
+	defer ctrl.Finish()
+
+	nopts := defaultTestNs1Opts.SetColdWritesEnabled(true)
+	ns, closer := newTestNamespaceWithIDOpts(t, defaultTestNs1ID, nopts)
+	defer closer()
+
+	var (
+		start          = time.Now()
+		blockSize      = nopts.RetentionOptions().BlockSize()
+		flushedStart   = start.Truncate(blockSize).Add(-2 * blockSize)
+		unflushedStart = flushedStart.Add(blockSize)
+		fooTags        = ident.NewTags(ident.StringTag("name", "foo"))
+		shards         = make([]*MockdatabaseShard, len(testShardIDs))
+	)
+	bs := bootstrap.NewMockProcess(ctrl)
+	bs.EXPECT().
+		Run(start, ns.metadata, sharding.IDs(testShardIDs)).
+		Return(bootstrap.ProcessResult{
+			DataResult:  result.NewDataBootstrapResult(),
+			IndexResult: result.NewIndexBootstrapResult(),
+		}, nil)
+	for i := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().IsBootstrapped().Return(false)
+		shard.EXPECT().ID().Return(testShardIDs[i].ID()).AnyTimes()
+		shard.EXPECT().Bootstrap(gomock.Any()).Return(nil)
+		ns.shards[testShardIDs[i].ID()] = shard
+		shards[i] = shard
+	}
+
+	// Only the datapoint for the flushed block start is replayed, the
+	// commit log bootstrapper reads the unflushed ranges
+	entries := []struct {
+		series    commitlog.Series
+		timestamp time.Time
+	}{
+		{
+			series:    commitlog.Series{ID: ident.StringID("foo"), Tags: fooTags, Shard: 0},
+			timestamp: flushedStart.Add(time.Minute),
+		},
+		{
+			series:    commitlog.Series{ID: ident.StringID("foo"), Tags: fooTags, Shard: 0},
+			timestamp: unflushedStart.Add(time.Minute),
+		},
+		{
+			series:    commitlog.Series{ID: ident.StringID("bar"), Shard: 42},
+			timestamp: flushedStart.Add(time.Minute),
+		},
+	}
+	iter := commitlog.NewMockIterator(ctrl)
+	for _, entry := range entries {
+		iter.EXPECT().Next().Return(true)
+		iter.EXPECT().Current().Return(entry.series,
+			ts.Datapoint{Timestamp: entry.timestamp, Value: 1}, xtime.Second, ts.Annotation(nil))
+	}
+	iter.EXPECT().Next().Return(false)
+	iter.EXPECT().Err().Return(nil)
+	iter.EXPECT().Close()
+	file := commitlog.File{FilePath: "commitlog-0-0.db", Start: start}
+	ns.commitLogFilesFn = func(commitlog.Options) ([]commitlog.File, error) {
+		return []commitlog.File{file}, nil
+	}
+	ns.newCommitLogIterFn = func(opts commitlog.IteratorOpts) (commitlog.Iterator, error) {
+		require.True(t, opts.FileFilterPredicate(file))
+		require.False(t, opts.FileFilterPredicate(commitlog.File{FilePath: "commitlog-0-1.db"}))
+		require.True(t, opts.SeriesFilterPredicate(ident.StringID("foo"), defaultTestNs1ID))
+		require.False(t, opts.SeriesFilterPredicate(ident.StringID("foo"), defaultTestNs2ID))
+		return iter, nil
+	}
+
+	shards[0].EXPECT().FlushState(flushedStart).Return(fileOpState{Status: fileOpSuccess})
+	shards[0].EXPECT().FlushState(unflushedStart).Return(fileOpState{Status: fileOpNotStarted})
+	shards[0].EXPECT().ReplayColdWrite(gomock.Any(), ident.NewIDMatcher("foo"), fooTags,
+		flushedStart.Add(time.Minute), 1.0, xtime.Second, nil, start).Return(nil)
+
+	require.NoError(t, ns.Bootstrap(start, bs))
+	require.Equal(t, Bootstrapped, ns.bootstrapState)
+}
+
+func TestNamespaceColdFlushSetsColdFlushedBefore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nopts := defaultTestNs1Opts.SetColdWritesEnabled(true)
+	ns, closer := newTestNamespaceWithIDOpts(t, defaultTestNs1ID, nopts)
+	defer closer()
+	ns.bootstrapState = Bootstrapped
+
+	coldFlushStart := time.Now()
+	ns.nowFn = func() time.Time { return coldFlushStart }
+
+	errs := []error{nil, errors.New("foo")}
+	for i := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(testShardIDs[i].ID()).AnyTimes()
+		shard.EXPECT().ColdFlush(gomock.Any()).Return(errs[i])
+		ns.shards[testShardIDs[i].ID()] = shard
+	}
+
+	// A failed shard retains its cold writes so they are not yet persisted
+	require.Error(t, ns.ColdFlush(nil))
+	require.True(t, ns.ColdFlushedBefore().IsZero())
+
+	for i := range testShardIDs {
+		shard := NewMockdatabaseShard(ctrl)
+		shard.EXPECT().ID().Return(testShardIDs[i].ID()).AnyTimes()
+		shard.EXPECT().ColdFlush(gomock.Any()).Return(nil)
+		ns.shards[testShardIDs[i].ID()] = shard
+	}
+	require.NoError(t, ns.ColdFlush(nil))
+	require.Equal(t, coldFlushStart, ns.ColdFlushedBefore())
+}
+
 func TestNamespaceBootstrapOnlyNonBootstrappedShards(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1126,6 +1244,148 @@ func TestNamespaceIndexQuery(t *testing.T) {
 	require.NoError(t, ns.Close())
 }
 
+func TestNamespaceIndexAggregateQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.AggregateQueryOptions{Type: index.AggregateTagNames}
+
+	idx.EXPECT().AggregateQuery(ctx, query, opts)
+	_, err := ns.AggregateQuery(ctx, query, opts)
+	require.NoError(t, err)
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
+func TestNamespaceIndexQueryFiltersDeletedSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().HasDeletedSeries().Return(true)
+	shard.EXPECT().IsSeriesDeleted(ident.NewIDMatcher("foo")).Return(true)
+	shard.EXPECT().IsSeriesDeleted(ident.NewIDMatcher("bar")).Return(false)
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.QueryOptions{Limit: 1}
+
+	// Deleted series are filtered before the limit is applied so that
+	// limited queries are not short of results
+	idx.EXPECT().Query(ctx, query, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ index.Query,
+			opts index.QueryOptions,
+		) (index.QueryResults, error) {
+			require.Equal(t, 1, opts.Limit)
+			require.NotNil(t, opts.SeriesFilter)
+			require.False(t, opts.SeriesFilter([]byte("foo")))
+			require.True(t, opts.SeriesFilter([]byte("bar")))
+			return index.QueryResults{}, nil
+		})
+	_, err := ns.QueryIDs(ctx, query, opts)
+	require.NoError(t, err)
+}
+
+func TestNamespaceIndexAggregateQueryFiltersDeletedSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().HasDeletedSeries().Return(true)
+	shard.EXPECT().IsSeriesDeleted(ident.NewIDMatcher("foo")).Return(true)
+	shard.EXPECT().IsSeriesDeleted(ident.NewIDMatcher("bar")).Return(false)
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	opts := index.AggregateQueryOptions{Type: index.AggregateTagNames}
+
+	idx.EXPECT().AggregateQuery(ctx, query, gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			_ index.Query,
+			opts index.AggregateQueryOptions,
+		) (index.AggregateQueryResult, error) {
+			require.NotNil(t, opts.SeriesFilter)
+			require.False(t, opts.SeriesFilter([]byte("foo")))
+			require.True(t, opts.SeriesFilter([]byte("bar")))
+			return index.AggregateQueryResult{}, nil
+		})
+	_, err := ns.AggregateQuery(ctx, query, opts)
+	require.NoError(t, err)
+}
+
+func TestNamespaceDelete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ns, closer := newTestNamespace(t)
+	defer closer()
+
+	// All IDs hash to the first shard
+	ids := []ident.ID{ident.StringID("foo"), ident.StringID("bar")}
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().DeleteSeries(ids, gomock.Any()).Return(int64(2), nil)
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	deleted, err := ns.Delete(context.NewContext(), ids)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), deleted)
+}
+
+func TestNamespaceDeleteTagged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMocknamespaceIndex(ctrl)
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().HasDeletedSeries().Return(false)
+	shard.EXPECT().DeleteSeries(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ids []ident.ID, _ time.Time) (int64, error) {
+			require.Equal(t, 1, len(ids))
+			require.Equal(t, "foo", ids[0].String())
+			return 1, nil
+		})
+	ns.shards[testShardIDs[0].ID()] = shard
+
+	results := index.NewResults(index.NewOptions())
+	_, _, err := results.Add(doc.Document{ID: []byte("foo")})
+	require.NoError(t, err)
+
+	ctx := context.NewContext()
+	query := index.Query{}
+	idx.EXPECT().Query(ctx, query, gomock.Any()).Return(index.QueryResults{
+		Exhaustive: false,
+		Results:    results,
+	}, nil)
+
+	// Not exhaustive so the series found are deleted but an error returned
+	deleted, err := ns.DeleteTagged(ctx, query)
+	require.Equal(t, errNamespaceDeleteQueryNotExhaustive, err)
+	require.Equal(t, int64(1), deleted)
+}
+
 func TestNamespaceTicksIndex(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -32,9 +33,13 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
+	"github.com/m3db/m3/src/dbnode/topology"
 	"github.com/m3db/m3x/context"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
@@ -51,14 +56,19 @@ var (
 
 type recordFn func(namespace ident.ID, shard databaseShard, diffRes repair.MetadataComparisonResult)
 
+type newPersistManagerFn func() (persist.Manager, error)
+
 type shardRepairer struct {
-	opts     Options
-	rpopts   repair.Options
-	client   client.AdminClient
-	recordFn recordFn
-	logger   xlog.Logger
-	scope    tally.Scope
-	nowFn    clock.NowFn
+	opts                Options
+	rpopts              repair.Options
+	resultOpts          result.Options
+	client              client.AdminClient
+	recordFn            recordFn
+	newPersistManagerFn newPersistManagerFn
+	logger              xlog.Logger
+	scope               tally.Scope
+	nowFn               clock.NowFn
+	sleepFn             sleepFn
 }
 
 func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer {
@@ -68,12 +78,21 @@ func newShardRepairer(opts Options, rpopts repair.Options) databaseShardRepairer
 	r := shardRepairer{
 		opts:   opts,
 		rpopts: rpopts,
-		client: rpopts.AdminClient(),
-		logger: iopts.Logger(),
-		scope:  scope,
-		nowFn:  opts.ClockOptions().NowFn(),
+		resultOpts: result.NewOptions().
+			SetInstrumentOptions(iopts).
+			SetDatabaseBlockOptions(opts.DatabaseBlockOptions()),
+		client:  rpopts.AdminClient(),
+		logger:  iopts.Logger(),
+		scope:   scope,
+		nowFn:   opts.ClockOptions().NowFn(),
+		sleepFn: time.Sleep,
 	}
 	r.recordFn = r.recordDifferences
+	r.newPersistManagerFn = func() (persist.Manager, error) {
+		// Repairs use a persist manager of their own so that rewriting
+		// filesets does not contend with the flush manager.
+		return fs.NewPersistManager(opts.CommitLogOptions().FilesystemOptions())
+	}
 
 	return r
 }
@@ -84,7 +103,7 @@ func (r shardRepairer) Options() repair.Options {
 
 func (r shardRepairer) Repair(
 	ctx context.Context,
-	namespace ident.ID,
+	nsMeta namespace.Metadata,
 	tr xtime.Range,
 	shard databaseShard,
 ) (repair.MetadataComparisonResult, error) {
@@ -122,7 +141,7 @@ func (r shardRepairer) Repair(
 
 	// Add peer metadata
 	level := r.rpopts.RepairConsistencyLevel()
-	peerIter, err := session.FetchBlocksMetadataFromPeers(namespace, shard.ID(), start, end,
+	peerIter, err := session.FetchBlocksMetadataFromPeers(nsMeta.ID(), shard.ID(), start, end,
 		level, result.NewOptions(), client.FetchBlocksMetadataEndpointV2)
 	if err != nil {
 		return repair.MetadataComparisonResult{}, err
@@ -133,11 +152,245 @@ func (r shardRepairer) Repair(
 
 	metadataRes := metadata.Compare()
 
-	r.recordFn(namespace, shard, metadataRes)
+	r.recordFn(nsMeta.ID(), shard, metadataRes)
+
+	if err := r.repairDifferences(session, nsMeta, shard, origin, metadataRes); err != nil {
+		return repair.MetadataComparisonResult{}, err
+	}
 
 	return metadataRes, nil
 }
 
+// repairDifferences streams the block replicas that differ from the local
+// blocks from peers, one block start at a time, and loads them into the shard.
+func (r shardRepairer) repairDifferences(
+	session client.AdminSession,
+	nsMeta namespace.Metadata,
+	shard databaseShard,
+	origin topology.Host,
+	diffRes repair.MetadataComparisonResult,
+) error {
+	replicasByStart, numReplicas := replicasToRepair(origin, diffRes)
+	if numReplicas == 0 {
+		return nil
+	}
+
+	var (
+		shardScope = r.scope.Tagged(map[string]string{
+			"namespace": nsMeta.ID().String(),
+			"shard":     strconv.Itoa(int(shard.ID())),
+		})
+		pendingBlocks  = shardScope.Gauge("pending-blocks")
+		repairedBlocks = shardScope.Counter("repaired-blocks")
+		failedBlocks   = shardScope.Counter("failed-blocks")
+		logger         = r.logger.WithFields(
+			xlog.NewField("namespace", nsMeta.ID().String()),
+			xlog.NewField("shard", shard.ID()),
+		)
+	)
+
+	pm, err := r.newPersistManagerFn()
+	if err != nil {
+		return err
+	}
+	flush, err := pm.StartDataPersist()
+	if err != nil {
+		return err
+	}
+
+	starts := make([]xtime.UnixNano, 0, len(replicasByStart))
+	for start := range replicasByStart {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i] < starts[j]
+	})
+
+	var (
+		multiErr   = xerrors.NewMultiError()
+		level      = r.rpopts.RepairConsistencyLevel()
+		batchSize  = r.rpopts.RepairFetchBatchSize()
+		began      = r.nowFn()
+		numFetched int
+		numPending = numReplicas
+	)
+	pendingBlocks.Update(float64(numPending))
+	for _, start := range starts {
+		var (
+			replicas   = replicasByStart[start]
+			repaired   = result.NewShardResult(0, r.resultOpts)
+			numBlocks  int
+			startErr   error
+			blockStart = start.ToTime()
+		)
+		for i := 0; i < len(replicas) && startErr == nil; i += batchSize {
+			batch := replicas[i:minInt(i+batchSize, len(replicas))]
+			r.throttle(began, numFetched)
+
+			iter, err := session.FetchBlocksFromPeers(nsMeta, shard.ID(), level,
+				batch, r.resultOpts)
+			if err == nil {
+				var n int
+				n, err = addRepairedBlocks(iter, diffRes, repaired)
+				numBlocks += n
+			}
+			if err != nil {
+				startErr = err
+			}
+
+			numFetched += len(batch)
+			numPending -= len(batch)
+			pendingBlocks.Update(float64(numPending))
+		}
+
+		if startErr == nil {
+			startErr = shard.LoadRepairedBlocks(repaired, flush)
+		} else {
+			repaired.Close()
+		}
+		if startErr != nil {
+			failedBlocks.Inc(int64(numBlocks))
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to repair block %s: %v", blockStart.String(), startErr))
+			continue
+		}
+
+		repairedBlocks.Inc(int64(numBlocks))
+		logger.WithFields(
+			xlog.NewField("blockStart", blockStart.String()),
+			xlog.NewField("numSeries", repaired.NumSeries()),
+			xlog.NewField("numBlocks", numBlocks),
+			xlog.NewField("numPendingBlocks", numPending),
+		).Infof("repaired blocks from peers")
+	}
+
+	multiErr = multiErr.Add(flush.DoneData())
+	return multiErr.FinalError()
+}
+
+// throttle sleeps as required to fetch no more blocks per second than the
+// repair options allow.
+func (r shardRepairer) throttle(began time.Time, numFetched int) {
+	maxBlocksPerSecond := r.rpopts.RepairMaxBlocksPerSecond()
+	if maxBlocksPerSecond <= 0 || numFetched == 0 {
+		return
+	}
+	target := time.Duration(float64(time.Second) * float64(numFetched) / float64(maxBlocksPerSecond))
+	if elapsed := r.nowFn().Sub(began); elapsed < target {
+		r.sleepFn(target - elapsed)
+	}
+}
+
+// replicasToRepair returns the peer block replicas to fetch to repair the
+// differences found by a metadata comparison, grouped by block start. Only
+// one replica is fetched for each distinct checksum that differs from the
+// local block.
+func replicasToRepair(
+	origin topology.Host,
+	diffRes repair.MetadataComparisonResult,
+) (map[xtime.UnixNano][]block.ReplicaMetadata, int) {
+	type blockKey struct {
+		id    string
+		start xtime.UnixNano
+	}
+
+	var (
+		replicasByStart = make(map[xtime.UnixNano][]block.ReplicaMetadata)
+		visited         = make(map[blockKey]struct{})
+		numReplicas     int
+	)
+	for _, diffs := range []repair.ReplicaSeriesMetadata{
+		diffRes.SizeDifferences,
+		diffRes.ChecksumDifferences,
+	} {
+		for _, entry := range diffs.Series().Iter() {
+			series := entry.Value()
+			for start, b := range series.Metadata.Blocks() {
+				key := blockKey{id: series.ID.String(), start: start}
+				if _, ok := visited[key]; ok {
+					continue
+				}
+				visited[key] = struct{}{}
+
+				var (
+					hosts     = b.Metadata()
+					checksums = make(map[uint32]struct{}, len(hosts))
+				)
+				for _, hm := range hosts {
+					if hm.Host.ID() == origin.ID() && hm.Checksum != nil {
+						checksums[*hm.Checksum] = struct{}{}
+					}
+				}
+				for _, hm := range hosts {
+					if hm.Host.ID() == origin.ID() {
+						continue
+					}
+					if hm.Size == 0 && hm.Checksum == nil {
+						// Peer holds no data for the block
+						continue
+					}
+					if hm.Checksum != nil {
+						if _, ok := checksums[*hm.Checksum]; ok {
+							continue
+						}
+						checksums[*hm.Checksum] = struct{}{}
+					}
+					replicasByStart[start] = append(replicasByStart[start], block.ReplicaMetadata{
+						Host: hm.Host,
+						Metadata: block.NewMetadata(series.ID, series.Tags, b.Start(),
+							hm.Size, hm.Checksum, time.Time{}),
+					})
+					numReplicas++
+				}
+			}
+		}
+	}
+	return replicasByStart, numReplicas
+}
+
+// addRepairedBlocks adds the blocks streamed from peers to the repaired
+// shard result, merging the replicas fetched for the same series.
+func addRepairedBlocks(
+	iter client.PeerBlocksIter,
+	diffRes repair.MetadataComparisonResult,
+	repaired result.ShardResult,
+) (int, error) {
+	var numBlocks int
+	for iter.Next() {
+		_, id, b := iter.Current()
+		series, ok := diffRes.ChecksumDifferences.Series().Get(id)
+		if !ok {
+			series, ok = diffRes.SizeDifferences.Series().Get(id)
+		}
+		if !ok {
+			// Should never happen, only blocks with differences are fetched
+			b.Close()
+			continue
+		}
+
+		numBlocks++
+		if existing, ok := repaired.AllSeries().Get(series.ID); ok {
+			if current, ok := existing.Blocks.BlockAt(b.StartTime()); ok {
+				if err := current.Merge(b); err != nil {
+					return numBlocks, err
+				}
+				continue
+			}
+			existing.Blocks.AddBlock(b)
+			continue
+		}
+		repaired.AddBlock(series.ID, series.Tags, b)
+	}
+	return numBlocks, iter.Err()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 func (r shardRepairer) recordDifferences(
 	namespace ident.ID,
 	shard databaseShard,
@@ -139,13 +139,19 @@ func (m replicaSeriesMetadata) NumBlocks() int64 {
 	return numBlocks
 }
 
-func (m replicaSeriesMetadata) GetOrAdd(id ident.ID) ReplicaBlocksMetadata {
+func (m replicaSeriesMetadata) GetOrAdd(id ident.ID, tags ident.Tags) ReplicaBlocksMetadata {
 	blocks, exists := m.values.Get(id)
 	if exists {
+		if len(blocks.Tags.Values()) == 0 && len(tags.Values()) > 0 {
+			// Not all hosts return tags, keep the first ones seen
+			blocks.Tags = tags
+			m.values.Set(id, blocks)
+		}
 		return blocks.Metadata
 	}
 	blocks = ReplicaSeriesBlocksMetadata{
 		ID:       id,
+		Tags:     tags,
 		Metadata: NewReplicaBlocksMetadata(),
 	}
 	m.values.Set(id, blocks)
@@ -177,7 +183,7 @@ func NewReplicaMetadataComparer(replicas int, opts Options) ReplicaMetadataCompa
 func (m replicaMetadataComparer) AddLocalMetadata(origin topology.Host, localIter block.FilteredBlocksMetadataIter) error {
 	for localIter.Next() {
 		id, block := localIter.Current()
-		blocks := m.metadata.GetOrAdd(id)
+		blocks := m.metadata.GetOrAdd(id, block.Tags)
 		blocks.GetOrAdd(block.Start, m.hostBlockMetadataSlicePool).Add(HostBlockMetadata{
 			Host:     origin,
 			Size:     block.Size,
@@ -191,7 +197,7 @@ func (m replicaMetadataComparer) AddLocalMetadata(origin topology.Host, localIte
 func (m replicaMetadataComparer) AddPeerMetadata(peerIter client.PeerBlockMetadataIter) error {
 	for peerIter.Next() {
 		peer, peerBlock := peerIter.Current()
-		blocks := m.metadata.GetOrAdd(peerBlock.ID)
+		blocks := m.metadata.GetOrAdd(peerBlock.ID, peerBlock.Tags)
 		blocks.GetOrAdd(peerBlock.Start, m.hostBlockMetadataSlicePool).Add(HostBlockMetadata{
 			Host:     peer,
 			Size:     peerBlock.Size,
@@ -251,13 +257,13 @@ func (m replicaMetadataComparer) Compare() MetadataComparisonResult {
 			// If only a subset of hosts in the replica set have sizes, or the sizes differ,
 			// we record this block
 			if !(numHostsWithSize == m.replicas && sameSize) {
-				sizeDiff.GetOrAdd(series.ID).Add(b)
+				sizeDiff.GetOrAdd(series.ID, series.Tags).Add(b)
 			}
 
 			// If only a subset of hosts in the replica set have checksums, or the checksums
 			// differ, we record this block
 			if !(numHostsWithChecksum == m.replicas && sameChecksum) {
-				checkSumDiff.GetOrAdd(series.ID).Add(b)
+				checkSumDiff.GetOrAdd(series.ID, series.Tags).Add(b)
 			}
 		}
 	}
@@ -94,15 +94,22 @@ func TestReplicaSeriesMetadataGetOrAdd(t *testing.T) {
 	m := NewReplicaSeriesMetadata()
 
 	// Add a series
-	m.GetOrAdd(ident.StringID("foo"))
+	m.GetOrAdd(ident.StringID("foo"), ident.Tags{})
 	series := m.Series()
 	require.Equal(t, 1, series.Len())
 	_, exists := series.Get(ident.StringID("foo"))
 	require.True(t, exists)
 
 	// Add the same series and check we don't add new series
-	m.GetOrAdd(ident.StringID("foo"))
+	tags := ident.NewTags(ident.StringTag("bar", "baz"))
+	m.GetOrAdd(ident.StringID("foo"), tags)
 	require.Equal(t, 1, m.Series().Len())
+
+	// Check the tags are kept once seen
+	m.GetOrAdd(ident.StringID("foo"), ident.Tags{})
+	entry, exists := m.Series().Get(ident.StringID("foo"))
+	require.True(t, exists)
+	require.True(t, tags.Equal(entry.Tags))
 }
 
 type testBlock struct {
@@ -253,7 +260,7 @@ func TestReplicaMetadataComparerCompare(t *testing.T) {
 			ckSum := input.checksum
 			checkSum = &ckSum
 		}
-		metadata.GetOrAdd(ident.StringID(input.id)).GetOrAdd(input.ts, testHostBlockMetadataSlicePool()).Add(HostBlockMetadata{
+		metadata.GetOrAdd(ident.StringID(input.id), ident.Tags{}).GetOrAdd(input.ts, testHostBlockMetadataSlicePool()).Add(HostBlockMetadata{
 			Host:     input.host,
 			Size:     input.size,
 			Checksum: checkSum,
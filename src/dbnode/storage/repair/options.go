@@ -37,6 +37,8 @@ const (
 	defaultRepairThrottle         = 90 * time.Second
 	defaultRepairMaxRetries       = 3
 	defaultRepairShardConcurrency = 1
+	defaultRepairFetchBatchSize   = 1024
+	defaultRepairMaxBlocksPerSec  = 10000
 )
 
 var (
@@ -49,6 +51,8 @@ var (
 	errRepairCheckIntervalTooBig    = errors.New("repair check interval too big in repair options")
 	errInvalidRepairThrottle        = errors.New("invalid repair throttle in repair options")
 	errInvalidRepairMaxRetries      = errors.New("invalid repair max retries in repair options")
+	errInvalidRepairFetchBatchSize  = errors.New("invalid repair fetch batch size in repair options")
+	errInvalidRepairMaxBlocksPerSec = errors.New("invalid repair max blocks per second in repair options")
 	errNoHostBlockMetadataSlicePool = errors.New("no host block metadata pool in repair options")
 )
 
@@ -62,6 +66,8 @@ type options struct {
 	repairCheckInterval        time.Duration
 	repairThrottle             time.Duration
 	repairMaxRetries           int
+	repairFetchBatchSize       int
+	repairMaxBlocksPerSecond   int
 	hostBlockMetadataSlicePool HostBlockMetadataSlicePool
 }
 
@@ -76,6 +82,8 @@ func NewOptions() Options {
 		repairCheckInterval:        defaultRepairCheckInterval,
 		repairThrottle:             defaultRepairThrottle,
 		repairMaxRetries:           defaultRepairMaxRetries,
+		repairFetchBatchSize:       defaultRepairFetchBatchSize,
+		repairMaxBlocksPerSecond:   defaultRepairMaxBlocksPerSec,
 		hostBlockMetadataSlicePool: NewHostBlockMetadataSlicePool(nil, 0),
 	}
 }
@@ -170,6 +178,26 @@ func (o *options) RepairMaxRetries() int {
 	return o.repairMaxRetries
 }
 
+func (o *options) SetRepairFetchBatchSize(value int) Options {
+	opts := *o
+	opts.repairFetchBatchSize = value
+	return &opts
+}
+
+func (o *options) RepairFetchBatchSize() int {
+	return o.repairFetchBatchSize
+}
+
+func (o *options) SetRepairMaxBlocksPerSecond(value int) Options {
+	opts := *o
+	opts.repairMaxBlocksPerSecond = value
+	return &opts
+}
+
+func (o *options) RepairMaxBlocksPerSecond() int {
+	return o.repairMaxBlocksPerSecond
+}
+
 func (o *options) SetHostBlockMetadataSlicePool(value HostBlockMetadataSlicePool) Options {
 	opts := *o
 	opts.hostBlockMetadataSlicePool = value
@@ -208,6 +236,12 @@ func (o *options) Validate() error {
 	if o.repairMaxRetries < 0 {
 		return errInvalidRepairMaxRetries
 	}
+	if o.repairFetchBatchSize <= 0 {
+		return errInvalidRepairFetchBatchSize
+	}
+	if o.repairMaxBlocksPerSecond < 0 {
+		return errInvalidRepairMaxBlocksPerSec
+	}
 	if o.hostBlockMetadataSlicePool == nil {
 		return errNoHostBlockMetadataSlicePool
 	}
@@ -106,7 +106,7 @@ type ReplicaSeriesMetadata interface {
 	Series() *Map
 
 	// GetOrAdd returns the series metadata for an id, creating one if it doesn't exist
-	GetOrAdd(id ident.ID) ReplicaBlocksMetadata
+	GetOrAdd(id ident.ID, tags ident.Tags) ReplicaBlocksMetadata
 
 	// Close performs cleanup
 	Close()
@@ -115,6 +115,7 @@ type ReplicaSeriesMetadata interface {
 // ReplicaSeriesBlocksMetadata represents series metadata and an associated ID.
 type ReplicaSeriesBlocksMetadata struct {
 	ID       ident.ID
+	Tags     ident.Tags
 	Metadata ReplicaBlocksMetadata
 }
 
@@ -206,6 +207,23 @@ type Options interface {
 	// MaxRepairRetries returns the max number of retries for a block start
 	RepairMaxRetries() int
 
+	// SetRepairFetchBatchSize sets the number of block replicas to fetch
+	// from peers in each batch when repairing a shard
+	SetRepairFetchBatchSize(value int) Options
+
+	// RepairFetchBatchSize returns the number of block replicas to fetch
+	// from peers in each batch when repairing a shard
+	RepairFetchBatchSize() int
+
+	// SetRepairMaxBlocksPerSecond sets the max number of block replicas
+	// fetched from peers per second when repairing a shard, zero means
+	// the fetches are not rate limited
+	SetRepairMaxBlocksPerSecond(value int) Options
+
+	// RepairMaxBlocksPerSecond returns the max number of block replicas
+	// fetched from peers per second when repairing a shard
+	RepairMaxBlocksPerSecond() int
+
 	// SetHostBlockMetadataSlicePool sets the hostBlockMetadataSlice pool
 	SetHostBlockMetadataSlicePool(value HostBlockMetadataSlicePool) Options
 
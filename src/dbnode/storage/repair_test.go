@@ -27,11 +27,14 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/repair"
 	"github.com/m3db/m3/src/dbnode/topology"
+	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
@@ -232,7 +235,6 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		SetInstrumentOptions(iopts.SetMetricsScope(tally.NoopScope))
 
 	var (
-		namespace       = ident.StringID("testNamespace")
 		start           = now
 		end             = now.Add(rtopts.BlockSize())
 		repairTimeRange = xtime.Range{Start: start, End: end}
@@ -243,6 +245,9 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		}
 	)
 
+	nsMeta, err := namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
+	require.NoError(t, err)
+
 	sizes := []int64{1, 2, 3}
 	checksums := []uint32{4, 5, 6}
 	lastRead := now.Add(-time.Minute)
@@ -291,7 +296,7 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 		peerIter.EXPECT().Err().Return(nil),
 	)
 	session.EXPECT().
-		FetchBlocksMetadataFromPeers(namespace, shardID, start, end,
+		FetchBlocksMetadataFromPeers(nsMeta.ID(), shardID, start, end,
 			rpOpts.RepairConsistencyLevel(), gomock.Any(), client.FetchBlocksMetadataEndpointV2).
 		Return(peerIter, nil)
 
@@ -310,8 +315,9 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 	}
 
 	ctx := context.NewContext()
-	repairer.Repair(ctx, namespace, repairTimeRange, shard)
-	require.Equal(t, namespace, resNamespace)
+	_, err = repairer.Repair(ctx, nsMeta, repairTimeRange, shard)
+	require.NoError(t, err)
+	require.Equal(t, nsMeta.ID(), resNamespace)
 	require.Equal(t, resShard, shard)
 	require.Equal(t, int64(2), resDiff.NumSeries)
 	require.Equal(t, int64(3), resDiff.NumBlocks)
@@ -332,6 +338,123 @@ func TestDatabaseShardRepairerRepair(t *testing.T) {
 	require.Equal(t, expected, block.Metadata())
 }
 
+func TestDatabaseShardRepairerRepairDifferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		origin    = topology.NewHost("0", "addr0")
+		peer1     = topology.NewHost("1", "addr1")
+		peer2     = topology.NewHost("2", "addr2")
+		opts      = testDatabaseOptions()
+		blockSize = defaultTestRetentionOpts.BlockSize()
+		start     = time.Now().Truncate(blockSize).Add(-4 * blockSize)
+		next      = start.Add(blockSize)
+		shardID   = uint32(0)
+		shard     = NewMockdatabaseShard(ctrl)
+		session   = client.NewMockAdminSession(ctrl)
+		flush     = persist.NewMockDataFlush(ctrl)
+		pm        = persist.NewMockManager(ctrl)
+		pool      = repair.NewHostBlockMetadataSlicePool(nil, 0)
+		checksums = []uint32{1, 2, 3, 4, 5}
+		diffs     = repair.NewReplicaSeriesMetadata()
+	)
+	nsMeta, err := namespace.NewMetadata(ident.StringID("testNamespace"), namespace.NewOptions())
+	require.NoError(t, err)
+
+	addDiff := func(id string, t time.Time, host topology.Host, checksum *uint32) {
+		diffs.GetOrAdd(ident.StringID(id), ident.NewTags(ident.StringTag("name", id))).
+			GetOrAdd(t, pool).
+			Add(repair.HostBlockMetadata{Host: host, Size: 1, Checksum: checksum})
+	}
+	// Both peers hold the same block which differs from the local block
+	addDiff("foo", start, origin, &checksums[0])
+	addDiff("foo", start, peer1, &checksums[1])
+	addDiff("foo", start, peer2, &checksums[1])
+	// Block missing locally
+	addDiff("bar", start, peer1, &checksums[2])
+	// Only one of the peers differs from the local block
+	addDiff("baz", next, origin, &checksums[3])
+	addDiff("baz", next, peer1, &checksums[3])
+	addDiff("baz", next, peer2, &checksums[4])
+
+	diffRes := repair.MetadataComparisonResult{
+		SizeDifferences:     repair.NewReplicaSeriesMetadata(),
+		ChecksumDifferences: diffs,
+	}
+
+	var fetched []block.ReplicaMetadata
+	session.EXPECT().
+		FetchBlocksFromPeers(nsMeta, shardID, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ namespace.Metadata,
+			_ uint32,
+			_ topology.ReadConsistencyLevel,
+			metadatas []block.ReplicaMetadata,
+			_ result.Options,
+		) (client.PeerBlocksIter, error) {
+			require.Equal(t, 1, len(metadatas))
+			replica := metadatas[0]
+			fetched = append(fetched, replica)
+
+			b := block.NewDatabaseBlock(replica.Start, blockSize, ts.Segment{},
+				opts.DatabaseBlockOptions())
+			iter := client.NewMockPeerBlocksIter(ctrl)
+			gomock.InOrder(
+				iter.EXPECT().Next().Return(true),
+				iter.EXPECT().Current().Return(replica.Host, replica.ID, b),
+				iter.EXPECT().Next().Return(false),
+				iter.EXPECT().Err().Return(nil),
+			)
+			return iter, nil
+		}).
+		Times(3)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+
+	var loaded []result.ShardResult
+	shard.EXPECT().
+		LoadRepairedBlocks(gomock.Any(), flush).
+		Do(func(repaired result.ShardResult, _ persist.DataFlush) {
+			loaded = append(loaded, repaired)
+		}).
+		Return(nil).
+		Times(2)
+	pm.EXPECT().StartDataPersist().Return(flush, nil)
+	flush.EXPECT().DoneData().Return(nil)
+
+	rpOpts := testRepairOptions(ctrl).
+		SetRepairFetchBatchSize(1).
+		SetRepairMaxBlocksPerSecond(1)
+	repairer := newShardRepairer(opts, rpOpts).(shardRepairer)
+	repairer.newPersistManagerFn = func() (persist.Manager, error) {
+		return pm, nil
+	}
+	now := time.Now()
+	repairer.nowFn = func() time.Time { return now }
+	var slept []time.Duration
+	repairer.sleepFn = func(d time.Duration) {
+		slept = append(slept, d)
+	}
+
+	require.NoError(t, repairer.repairDifferences(session, nsMeta, shard, origin, diffRes))
+
+	// One replica fetched per distinct checksum, rate limited to a block per second
+	require.Equal(t, 3, len(fetched))
+	require.Equal(t, []time.Duration{time.Second, 2 * time.Second}, slept)
+	require.Equal(t, "baz", fetched[2].ID.String())
+	require.Equal(t, peer2.ID(), fetched[2].Host.ID())
+
+	// Blocks are loaded into the shard one block start at a time
+	require.Equal(t, 2, len(loaded))
+	require.Equal(t, int64(2), loaded[0].NumSeries())
+	require.Equal(t, int64(1), loaded[1].NumSeries())
+	series, ok := loaded[0].AllSeries().Get(ident.StringID("bar"))
+	require.True(t, ok)
+	require.True(t, ident.NewTags(ident.StringTag("name", "bar")).Equal(series.Tags))
+	_, ok = series.Blocks.BlockAt(start)
+	require.True(t, ok)
+}
+
 func TestRepairerRepairTimes(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/clock"
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -102,6 +103,19 @@ type databaseBuffer interface {
 
 	Bootstrap(bl block.DatabaseBlock) error
 
+	// ReadColdEncoded returns the streams of the writes made for a block
+	// start after it was drained from the buffer.
+	ReadColdEncoded(ctx context.Context, blockStart time.Time) []xio.BlockReader
+
+	// ColdBlock returns a block holding a copy of the cold writes for a block
+	// start and the version of the writes it holds, the block is nil if there
+	// are no cold writes for the block start.
+	ColdBlock(blockStart time.Time) (block.DatabaseBlock, int, error)
+
+	// ReleaseColdBlock removes the cold writes for a block start if none were
+	// made since the given version, returning them as a block.
+	ReleaseColdBlock(blockStart time.Time, version int) (block.DatabaseBlock, error)
+
 	Reset(opts Options)
 }
 
@@ -127,6 +141,20 @@ type dbBuffer struct {
 	blockSize         time.Duration
 	bufferPast        time.Duration
 	bufferFuture      time.Duration
+
+	// coldWritesEnabled allows writes older than the buffer past, the writes
+	// for block starts already drained from the buckets are held in the cold
+	// buckets until they are merged into the flushed data.
+	coldWritesEnabled bool
+	coldBuckets       map[xtime.UnixNano]*coldBucket
+}
+
+// coldBucket holds the cold writes for a block start, the version is
+// incremented with each write so that the writes are only released once
+// all of them have been persisted.
+type coldBucket struct {
+	bucket  dbBufferBucket
+	version int
 }
 
 type databaseBufferDrainFn func(b block.DatabaseBlock)
@@ -147,6 +175,11 @@ func (b *dbBuffer) Reset(opts Options) {
 	b.blockSize = ropts.BlockSize()
 	b.bufferPast = ropts.BufferPast()
 	b.bufferFuture = ropts.BufferFuture()
+	b.coldWritesEnabled = opts.ColdWritesEnabled()
+	for start, cold := range b.coldBuckets {
+		cold.bucket.finalize()
+		delete(b.coldBuckets, start)
+	}
 	// Avoid capturing any variables with callback
 	b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketResetStart)
 }
@@ -189,7 +222,10 @@ func (b *dbBuffer) Write(
 		return m3dberrors.ErrTooFuture
 	}
 	if !pastLimit.Before(timestamp) {
-		return m3dberrors.ErrTooPast
+		if !b.coldWritesEnabled {
+			return m3dberrors.ErrTooPast
+		}
+		return b.writeCold(now, timestamp, value, unit, annotation)
 	}
 
 	bucketStart := timestamp.Truncate(b.blockSize)
@@ -202,6 +238,46 @@ func (b *dbBuffer) Write(
 	return b.buckets[idx].write(timestamp, value, unit, annotation)
 }
 
+func (b *dbBuffer) writeCold(
+	now time.Time,
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+) error {
+	bucketStart := timestamp.Truncate(b.blockSize)
+	if bucketStart.Before(retention.FlushTimeStart(b.opts.RetentionOptions(), now)) {
+		return m3dberrors.ErrTooPast
+	}
+
+	idx := b.writableBucketIdx(timestamp)
+	if b.buckets[idx].start.Before(bucketStart) {
+		// Needs reset
+		b.DrainAndReset()
+	}
+	if b.buckets[idx].start.Equal(bucketStart) && !b.buckets[idx].drained {
+		// The block is still buffered, no need to keep the write separately
+		return b.buckets[idx].write(timestamp, value, unit, annotation)
+	}
+
+	startNano := xtime.ToUnixNano(bucketStart)
+	cold, ok := b.coldBuckets[startNano]
+	if !ok {
+		cold = &coldBucket{}
+		cold.bucket.opts = b.opts
+		cold.bucket.resetTo(bucketStart)
+		if b.coldBuckets == nil {
+			b.coldBuckets = make(map[xtime.UnixNano]*coldBucket)
+		}
+		b.coldBuckets[startNano] = cold
+	}
+	if err := cold.bucket.write(timestamp, value, unit, annotation); err != nil {
+		return err
+	}
+	cold.version++
+	return nil
+}
+
 func (b *dbBuffer) writableBucketIdx(t time.Time) int {
 	return int(t.Truncate(b.blockSize).UnixNano() / int64(b.blockSize) % bucketsLen)
 }
@@ -211,6 +287,9 @@ func (b *dbBuffer) IsEmpty() bool {
 	for i := range b.buckets {
 		canReadAny = canReadAny || b.buckets[i].canRead()
 	}
+	for _, cold := range b.coldBuckets {
+		canReadAny = canReadAny || cold.bucket.canRead()
+	}
 	return !canReadAny
 }
 
@@ -226,6 +305,11 @@ func (b *dbBuffer) Stats() bufferStats {
 		}
 		stats.wiredBlocks++
 	}
+	for _, cold := range b.coldBuckets {
+		if cold.bucket.canRead() {
+			stats.wiredBlocks++
+		}
+	}
 	return stats
 }
 
@@ -244,11 +328,41 @@ func bucketNeedsDrain(now time.Time, b *dbBuffer, idx int, start time.Time) int
 func (b *dbBuffer) Tick() bufferTickResult {
 	// Avoid capturing any variables with callback
 	mergedOutOfOrder := b.computedForEachBucketAsc(computeAndResetBucketIdx, bucketTick)
+	mergedOutOfOrder += b.tickColdBuckets()
 	return bufferTickResult{
 		mergedOutOfOrderBlocks: mergedOutOfOrder,
 	}
 }
 
+func (b *dbBuffer) tickColdBuckets() int {
+	if len(b.coldBuckets) == 0 {
+		return 0
+	}
+
+	var (
+		mergedOutOfOrderBlocks int
+		earliest               = retention.FlushTimeStart(b.opts.RetentionOptions(), b.nowFn())
+	)
+	for start, cold := range b.coldBuckets {
+		if start.ToTime().Before(earliest) {
+			// Out of retention, the writes will never be flushed
+			cold.bucket.finalize()
+			delete(b.coldBuckets, start)
+			continue
+		}
+
+		r, err := cold.bucket.merge()
+		if err != nil {
+			log := b.opts.InstrumentOptions().Logger()
+			log.Errorf("buffer cold writes merge encode error: %v", err)
+		}
+		if r.merges > 0 {
+			mergedOutOfOrderBlocks++
+		}
+	}
+	return mergedOutOfOrderBlocks
+}
+
 func bucketTick(now time.Time, b *dbBuffer, idx int, start time.Time) int {
 	// Perform a drain and reset if necessary
 	mergedOutOfOrderBlocks := bucketDrainAndReset(now, b, idx, start)
@@ -333,6 +447,59 @@ func (b *dbBuffer) Bootstrap(bl block.DatabaseBlock) error {
 	return nil
 }
 
+func (b *dbBuffer) ReadColdEncoded(ctx context.Context, blockStart time.Time) []xio.BlockReader {
+	cold, ok := b.coldBuckets[xtime.ToUnixNano(blockStart)]
+	if !ok || !cold.bucket.canRead() {
+		return nil
+	}
+	return cold.bucket.streams(ctx)
+}
+
+func (b *dbBuffer) ColdBlock(blockStart time.Time) (block.DatabaseBlock, int, error) {
+	cold, ok := b.coldBuckets[xtime.ToUnixNano(blockStart)]
+	if !ok || !cold.bucket.canRead() {
+		return nil, 0, nil
+	}
+
+	// Merge so that the writes can be copied as a single stream, cold buckets
+	// never hold bootstrapped blocks so this always leaves a single encoder.
+	if _, err := cold.bucket.merge(); err != nil {
+		return nil, 0, err
+	}
+	stream := cold.bucket.encoders[0].encoder.Stream()
+	if stream == nil {
+		return nil, 0, nil
+	}
+	// The stream holds a copy of the encoded data, the block takes ownership
+	// of it rather than the stream.
+	segment, err := stream.Segment()
+	if err != nil {
+		return nil, 0, err
+	}
+	bopts := b.opts.DatabaseBlockOptions()
+	return block.NewDatabaseBlock(blockStart, b.blockSize, segment, bopts), cold.version, nil
+}
+
+func (b *dbBuffer) ReleaseColdBlock(blockStart time.Time, version int) (block.DatabaseBlock, error) {
+	startNano := xtime.ToUnixNano(blockStart)
+	cold, ok := b.coldBuckets[startNano]
+	if !ok || cold.version != version {
+		return nil, nil
+	}
+
+	delete(b.coldBuckets, startNano)
+	if !cold.bucket.canRead() {
+		cold.bucket.finalize()
+		return nil, nil
+	}
+	result, err := cold.bucket.discardMerged()
+	cold.bucket.finalize()
+	if err != nil {
+		return nil, err
+	}
+	return result.block, nil
+}
+
 // forEachBucketAsc iterates over the buckets in time ascending order
 // to read bucket data
 func (b *dbBuffer) forEachBucketAsc(fn func(*dbBufferBucket)) {
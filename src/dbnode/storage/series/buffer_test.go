@@ -103,6 +103,72 @@ func TestBufferWriteTooPast(t *testing.T) {
 	assert.True(t, xerrors.IsInvalidParams(err))
 }
 
+func TestBufferWriteColdWrites(t *testing.T) {
+	opts := newBufferTestOptions().SetColdWritesEnabled(true)
+	rops := opts.RetentionOptions()
+	curr := time.Now().Truncate(rops.BlockSize())
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	buffer := newDatabaseBuffer(nil).(*dbBuffer)
+	buffer.Reset(opts)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	// Writes for a block that is still buffered are written to its bucket
+	buffered := []value{{curr.Add(-rops.BufferPast()), 1, xtime.Second, nil}}
+	require.NoError(t, buffer.Write(ctx, buffered[0].timestamp, buffered[0].value,
+		buffered[0].unit, buffered[0].annotation))
+	assertValuesEqual(t, buffered, buffer.ReadEncoded(ctx, timeZero, timeDistantFuture), opts)
+	require.Equal(t, 0, len(buffer.coldBuckets))
+
+	// Writes for a block no longer buffered are held as cold writes
+	coldStart := curr.Add(-3 * rops.BlockSize())
+	cold := []value{
+		{coldStart.Add(secs(2)), 2, xtime.Second, nil},
+		{coldStart.Add(secs(1)), 3, xtime.Second, nil},
+	}
+	require.NoError(t, buffer.Write(ctx, cold[0].timestamp, cold[0].value,
+		cold[0].unit, cold[0].annotation))
+	assertValuesEqual(t, buffered, buffer.ReadEncoded(ctx, timeZero, timeDistantFuture), opts)
+	assertValuesEqual(t, cold[:1], [][]xio.BlockReader{buffer.ReadColdEncoded(ctx, coldStart)}, opts)
+	require.Equal(t, 2, buffer.Stats().wiredBlocks)
+
+	// Writes before retention are still rejected
+	err := buffer.Write(ctx, curr.Add(-rops.RetentionPeriod()-rops.BlockSize()), 4, xtime.Second, nil)
+	assert.Error(t, err)
+	assert.True(t, xerrors.IsInvalidParams(err))
+
+	b, version, err := buffer.ColdBlock(coldStart)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+	require.Equal(t, 1, version)
+	b.Close()
+
+	// Not released if written to since the version returned
+	require.NoError(t, buffer.Write(ctx, cold[1].timestamp, cold[1].value,
+		cold[1].unit, cold[1].annotation))
+	b, err = buffer.ReleaseColdBlock(coldStart, version)
+	require.NoError(t, err)
+	require.Nil(t, b)
+
+	b, version, err = buffer.ColdBlock(coldStart)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+	b.Close()
+
+	b, err = buffer.ReleaseColdBlock(coldStart, version)
+	require.NoError(t, err)
+	require.NotNil(t, b)
+	stream, err := b.Stream(ctx)
+	require.NoError(t, err)
+	expected := []value{cold[1], cold[0]}
+	assertValuesEqual(t, expected, [][]xio.BlockReader{{stream}}, opts)
+	require.Equal(t, 0, len(buffer.coldBuckets))
+	require.Nil(t, buffer.ReadColdEncoded(ctx, coldStart))
+}
+
 func TestBufferWriteRead(t *testing.T) {
 	opts := newBufferTestOptions()
 	rops := opts.RetentionOptions()
@@ -35,6 +35,7 @@ type options struct {
 	clockOpts                     clock.Options
 	instrumentOpts                instrument.Options
 	retentionOpts                 retention.Options
+	coldWritesEnabled             bool
 	blockOpts                     block.Options
 	cachePolicy                   CachePolicy
 	contextPool                   context.Pool
@@ -106,6 +107,16 @@ func (o *options) RetentionOptions() retention.Options {
 	return o.retentionOpts
 }
 
+func (o *options) SetColdWritesEnabled(value bool) Options {
+	opts := *o
+	opts.coldWritesEnabled = value
+	return &opts
+}
+
+func (o *options) ColdWritesEnabled() bool {
+	return o.coldWritesEnabled
+}
+
 func (o *options) SetDatabaseBlockOptions(value block.Options) Options {
 	opts := *o
 	opts.blockOpts = value
@@ -117,6 +117,13 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 
 	first, last := alignedStart, alignedEnd
 	for blockAt := first; !blockAt.After(last); blockAt = blockAt.Add(size) {
+		var blockReaders []xio.BlockReader
+		if seriesBuffer != nil {
+			// Cold writes are read alongside the block they were written
+			// for so that they are merged with it when iterated
+			blockReaders = seriesBuffer.ReadColdEncoded(ctx, blockAt)
+		}
+
 		if seriesBlocks != nil {
 			if block, ok := seriesBlocks.BlockAt(blockAt); ok {
 				// Block served from in-memory or in-memory metadata
@@ -126,13 +133,16 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 					return nil, err
 				}
 				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
+					blockReaders = append(blockReaders, streamedBlock)
 					// NB(r): Mark this block as read now
 					block.SetLastReadTime(now)
 					if r.onRead != nil {
 						r.onRead.OnReadBlock(block)
 					}
 				}
+				if len(blockReaders) > 0 {
+					results = append(results, blockReaders)
+				}
 				continue
 			}
 		}
@@ -150,10 +160,13 @@ func (r Reader) readersWithBlocksMapAndBuffer(
 					return nil, err
 				}
 				if streamedBlock.IsNotEmpty() {
-					results = append(results, []xio.BlockReader{streamedBlock})
+					blockReaders = append(blockReaders, streamedBlock)
 				}
 			}
 		}
+		if len(blockReaders) > 0 {
+			results = append(results, blockReaders)
+		}
 	}
 
 	if seriesBuffer != nil {
@@ -193,6 +206,13 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 		onRetrieve block.OnRetrieveBlock
 	)
 	for _, start := range starts {
+		var coldReaders []xio.BlockReader
+		if seriesBuffer != nil {
+			// Cold writes are fetched as part of the block they were
+			// written for so that they are merged with it
+			coldReaders = seriesBuffer.ReadColdEncoded(ctx, start)
+		}
+
 		if seriesBlocks != nil {
 			if b, exists := seriesBlocks.BlockAt(start); exists {
 				streamedBlock, err := b.Stream(ctx)
@@ -203,9 +223,11 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 					res = append(res, r)
 				}
 				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
+					b := append(coldReaders, streamedBlock)
 					r := block.NewFetchBlockResult(start, b, nil)
 					res = append(res, r)
+				} else if err == nil && len(coldReaders) > 0 {
+					res = append(res, block.NewFetchBlockResult(start, coldReaders, nil))
 				}
 				continue
 			}
@@ -224,14 +246,19 @@ func (r Reader) fetchBlocksWithBlocksMapAndBuffer(
 						fmt.Errorf("unable to retrieve block stream for series %s time %v: %v",
 							r.id.String(), start, err))
 					res = append(res, r)
+					continue
 				}
 				if streamedBlock.IsNotEmpty() {
-					b := []xio.BlockReader{streamedBlock}
+					b := append(coldReaders, streamedBlock)
 					r := block.NewFetchBlockResult(start, b, nil)
 					res = append(res, r)
+					continue
 				}
 			}
 		}
+		if len(coldReaders) > 0 {
+			res = append(res, block.NewFetchBlockResult(start, coldReaders, nil))
+		}
 	}
 
 	if seriesBuffer != nil && !seriesBuffer.IsEmpty() {
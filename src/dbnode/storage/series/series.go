@@ -513,6 +513,51 @@ func (s *dbSeries) Bootstrap(bootstrappedBlocks block.DatabaseSeriesBlocks) (Boo
 	return result, multiErr.FinalError()
 }
 
+func (s *dbSeries) LoadBlock(b block.DatabaseBlock) error {
+	s.Lock()
+	defer s.Unlock()
+
+	min, _, err := s.buffer.MinMax()
+	if err != nil {
+		return err
+	}
+
+	// Same as for bootstrapped blocks, a block for a time that is still
+	// buffered is merged when the buffer bucket drains.
+	start := b.StartTime()
+	if !start.Before(min) {
+		return s.buffer.Bootstrap(b)
+	}
+
+	return s.loadFlushableBlockWithLock(b)
+}
+
+// loadFlushableBlockWithLock merges a block for a block start that is no
+// longer buffered, dropping any block held for it that is backed by the
+// fileset as it is rewritten to include the loaded data.
+func (s *dbSeries) loadFlushableBlockWithLock(b block.DatabaseBlock) error {
+	start := b.StartTime()
+	existing, ok := s.blocks.BlockAt(start)
+	if ok && (existing.WasRetrievedFromDisk() || !existing.IsRetrieved()) {
+		// The block is backed by a fileset which the caller rewrites to
+		// include the loaded data, drop it so it is read again from disk.
+		s.blocks.RemoveBlockAt(start)
+		if !(s.opts.CachePolicy() == CacheLRU && existing.WasRetrievedFromDisk()) {
+			// Blocks retrieved with the LRU policy are closed by the WiredList
+			existing.Close()
+		}
+		b.Close()
+		return nil
+	}
+	if !ok && s.blockRetriever != nil && s.blockRetriever.IsBlockRetrievable(start) {
+		// Only the loaded data would be held in memory, leave the block
+		// to be read from the rewritten fileset.
+		b.Close()
+		return nil
+	}
+	return s.mergeBlockWithLock(b)
+}
+
 func (s *dbSeries) OnRetrieveBlock(
 	id ident.ID,
 	tags ident.TagIterator,
@@ -716,6 +761,39 @@ func (s *dbSeries) Snapshot(
 	return persistFn(s.id, s.tags, segment, digest.SegmentChecksum(segment))
 }
 
+func (s *dbSeries) ColdWrites(blockStarts []time.Time) ([]ColdWritesBlock, error) {
+	// Need a write lock as copying the cold writes merges them
+	s.Lock()
+	defer s.Unlock()
+
+	var result []ColdWritesBlock
+	for _, blockStart := range blockStarts {
+		b, version, err := s.buffer.ColdBlock(blockStart)
+		if err != nil {
+			for _, r := range result {
+				r.Block.Close()
+			}
+			return nil, err
+		}
+		if b == nil {
+			continue
+		}
+		result = append(result, ColdWritesBlock{Block: b, Version: version})
+	}
+	return result, nil
+}
+
+func (s *dbSeries) ReleaseColdWrites(blockStart time.Time, version int) error {
+	s.Lock()
+	defer s.Unlock()
+
+	b, err := s.buffer.ReleaseColdBlock(blockStart, version)
+	if err != nil || b == nil {
+		return err
+	}
+	return s.loadFlushableBlockWithLock(b)
+}
+
 func (s *dbSeries) Close() {
 	s.Lock()
 	defer s.Unlock()
@@ -175,6 +175,49 @@ func TestSeriesWriteFlushRead(t *testing.T) {
 	assertValuesEqual(t, data, results, opts)
 }
 
+func TestSeriesWriteColdRead(t *testing.T) {
+	opts := newSeriesTestOptions().SetColdWritesEnabled(true)
+	blockSize := opts.RetentionOptions().BlockSize()
+	curr := time.Now().Truncate(blockSize)
+	start := curr
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	_, err := series.Bootstrap(nil)
+	assert.NoError(t, err)
+
+	data := []value{
+		{start.Add(secs(10)), 1, xtime.Second, nil},
+		{start.Add(secs(30)), 2, xtime.Second, nil},
+		{start.Add(secs(50)), 3, xtime.Second, nil},
+	}
+	for _, v := range []value{data[0], data[2]} {
+		curr = v.timestamp
+		ctx := context.NewContext()
+		assert.NoError(t, series.Write(ctx, v.timestamp, v.value, xtime.Second, v.annotation))
+		ctx.Close()
+	}
+
+	// Drain the block once it is no longer buffered
+	curr = start.Add(3 * blockSize)
+	_, err = series.Tick()
+	require.NoError(t, err)
+	_, ok := series.blocks.BlockAt(start)
+	require.True(t, ok)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	require.NoError(t, series.Write(ctx, data[1].timestamp, data[1].value,
+		xtime.Second, data[1].annotation))
+
+	// Cold writes are merged with the block on read
+	results, err := series.ReadEncoded(ctx, start, start.Add(blockSize))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(results))
+	assertValuesEqual(t, data, results, opts)
+}
+
 func TestSeriesReadEndBeforeStart(t *testing.T) {
 	opts := newSeriesTestOptions()
 	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
@@ -631,6 +674,67 @@ func TestSeriesBootstrapWithError(t *testing.T) {
 	require.Equal(t, 1, series.blocks.Len())
 }
 
+func TestSeriesLoadBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSeriesTestOptions().SetCachePolicy(CacheRecentlyRead)
+	blockSize := opts.RetentionOptions().BlockSize()
+	curr := time.Now().Truncate(blockSize)
+	opts = opts.SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+		return curr
+	}))
+	series := NewDatabaseSeries(ident.StringID("foo"), ident.Tags{}, opts).(*dbSeries)
+	blockRetriever := NewMockQueryableBlockRetriever(ctrl)
+	series.blockRetriever = blockRetriever
+	_, err := series.Bootstrap(nil)
+	require.NoError(t, err)
+
+	var (
+		unflushedStart = curr.Add(-2 * blockSize)
+		cachedStart    = curr.Add(-3 * blockSize)
+		flushedStart   = curr.Add(-4 * blockSize)
+	)
+
+	// Loaded block for an unflushed start is merged with the existing block
+	existing := block.NewMockDatabaseBlock(ctrl)
+	existing.EXPECT().StartTime().Return(unflushedStart).AnyTimes()
+	existing.EXPECT().SetOnEvictedFromWiredList(gomock.Any())
+	series.addBlockWithLock(existing)
+
+	loaded := block.NewMockDatabaseBlock(ctrl)
+	loaded.EXPECT().StartTime().Return(unflushedStart).AnyTimes()
+	existing.EXPECT().WasRetrievedFromDisk().Return(false)
+	existing.EXPECT().IsRetrieved().Return(true)
+	existing.EXPECT().Merge(loaded).Return(nil)
+	require.NoError(t, series.LoadBlock(loaded))
+
+	// Loaded block for a start cached from disk drops the cached block
+	cached := block.NewMockDatabaseBlock(ctrl)
+	cached.EXPECT().StartTime().Return(cachedStart).AnyTimes()
+	cached.EXPECT().SetOnEvictedFromWiredList(gomock.Any())
+	series.addBlockWithLock(cached)
+
+	loaded = block.NewMockDatabaseBlock(ctrl)
+	loaded.EXPECT().StartTime().Return(cachedStart).AnyTimes()
+	cached.EXPECT().WasRetrievedFromDisk().Return(true).AnyTimes()
+	cached.EXPECT().Close()
+	loaded.EXPECT().Close()
+	require.NoError(t, series.LoadBlock(loaded))
+	_, ok := series.blocks.BlockAt(cachedStart)
+	require.False(t, ok)
+
+	// Loaded block for a flushed start not held in memory is left on disk
+	loaded = block.NewMockDatabaseBlock(ctrl)
+	loaded.EXPECT().StartTime().Return(flushedStart).AnyTimes()
+	blockRetriever.EXPECT().IsBlockRetrievable(flushedStart).Return(true)
+	loaded.EXPECT().Close()
+	require.NoError(t, series.LoadBlock(loaded))
+	_, ok = series.blocks.BlockAt(flushedStart)
+	require.False(t, ok)
+	require.Equal(t, 1, series.blocks.Len())
+}
+
 func TestSeriesFetchBlocks(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -656,6 +760,9 @@ func TestSeriesFetchBlocks(t *testing.T) {
 
 	// Set up the buffer
 	buffer := NewMockdatabaseBuffer(ctrl)
+	for _, start := range starts {
+		buffer.EXPECT().ReadColdEncoded(ctx, start).Return(nil)
+	}
 	buffer.EXPECT().IsEmpty().Return(false)
 	buffer.EXPECT().
 		FetchBlocks(ctx, starts).
@@ -91,6 +91,12 @@ type DatabaseSeries interface {
 	// Bootstrap merges the raw series bootstrapped along with any buffered data
 	Bootstrap(blocks block.DatabaseSeriesBlocks) (BootstrapResult, error)
 
+	// LoadBlock merges a block loaded from outside the write path, such as
+	// a block repaired from peers, with the data the series holds for the
+	// same block start. If the block start has already been flushed the
+	// caller is responsible for rewriting the fileset with the loaded data.
+	LoadBlock(block block.DatabaseBlock) error
+
 	// Flush flushes the data blocks of this series for a given start time
 	Flush(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) (FlushOutcome, error)
 
@@ -98,6 +104,14 @@ type DatabaseSeries interface {
 	// not been rotated into a block yet
 	Snapshot(ctx context.Context, blockStart time.Time, persistFn persist.DataFn) error
 
+	// ColdWrites returns the writes made for the given block starts after they
+	// were drained from the buffer, as a block per block start
+	ColdWrites(blockStarts []time.Time) ([]ColdWritesBlock, error)
+
+	// ReleaseColdWrites releases the cold writes for a block start once they
+	// have been persisted, unless more were made since the given version
+	ReleaseColdWrites(blockStart time.Time, version int) error
+
 	// Close will close the series and if pooled returned to the pool
 	Close()
 
@@ -182,6 +196,13 @@ const (
 	FlushOutcomeFlushedToDisk
 )
 
+// ColdWritesBlock is a block holding a copy of the cold writes of a series
+// for a block start and the version of the writes it holds.
+type ColdWritesBlock struct {
+	Block   block.DatabaseBlock
+	Version int
+}
+
 // BootstrapResult contains information about the result of bootstrapping a series.
 // It is returned from the series Bootstrap method primarily so the caller can aggregate
 // and emit metrics instead of the series itself having to store additional fields (which
@@ -214,6 +235,12 @@ type Options interface {
 	// RetentionOptions returns the retention options
 	RetentionOptions() retention.Options
 
+	// SetColdWritesEnabled sets whether writes older than the buffer past are accepted
+	SetColdWritesEnabled(value bool) Options
+
+	// ColdWritesEnabled returns whether writes older than the buffer past are accepted
+	ColdWritesEnabled() bool
+
 	// SetDatabaseBlockOptions sets the database block options
 	SetDatabaseBlockOptions(value block.Options) Options
 
@@ -31,6 +31,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/clock"
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/proto/pagetoken"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
@@ -83,6 +84,8 @@ type filesetBeforeFn func(
 
 type snapshotFilesFn func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error)
 
+type dataFilesFn func(filePathPrefix string, namespace ident.ID, shard uint32) (fs.FileSetFilesSlice, error)
+
 type tickPolicy int
 
 const (
@@ -151,15 +154,20 @@ type dbShard struct {
 	insertQueue              *dbShardInsertQueue
 	lookup                   *shardMap
 	list                     *list.List
+	deletedEntries           map[*lookup.Entry]*list.Element
 	bootstrapState           BootstrapState
 	filesetBeforeFn          filesetBeforeFn
 	deleteFilesFn            deleteFilesFn
 	snapshotFilesFn          snapshotFilesFn
+	dataFilesFn              dataFilesFn
+	newReaderFn              fsNewReaderFn
 	sleepFn                  func(time.Duration)
 	identifierPool           ident.Pool
 	contextPool              context.Pool
 	flushState               shardFlushState
+	fileOpsLock              sync.Mutex
 	snapshotState            shardSnapshotState
+	tombstones               *shardTombstones
 	tickWg                   *sync.WaitGroup
 	runtimeOptsListenClosers []xclose.SimpleCloser
 	currRuntimeOptions       dbShardRuntimeOptions
@@ -191,6 +199,9 @@ type dbShardMetrics struct {
 	insertAsyncWriteErrors        tally.Counter
 	seriesBootstrapBlocksToBuffer tally.Counter
 	seriesBootstrapBlocksMerged   tally.Counter
+	deletedSeries                 tally.Counter
+	tombstonedWrites              tally.Counter
+	coldFlushedBlocks             tally.Counter
 }
 
 func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
@@ -211,6 +222,9 @@ func newDatabaseShardMetrics(scope tally.Scope) dbShardMetrics {
 		}).Counter("insert-async.errors"),
 		seriesBootstrapBlocksToBuffer: seriesBootstrapScope.Counter("blocks-to-buffer"),
 		seriesBootstrapBlocksMerged:   seriesBootstrapScope.Counter("blocks-merged"),
+		deletedSeries:                 scope.Counter("deleted-series"),
+		tombstonedWrites:              scope.Counter("tombstoned-writes"),
+		coldFlushedBlocks:             scope.Counter("cold-flushed-blocks"),
 	}
 }
 
@@ -266,13 +280,17 @@ func newDatabaseShard(
 		reverseIndex:       reverseIndex,
 		lookup:             newShardMap(shardMapOptions{}),
 		list:               list.New(),
+		deletedEntries:     make(map[*lookup.Entry]*list.Element),
 		filesetBeforeFn:    fs.DataFileSetsBefore,
 		deleteFilesFn:      fs.DeleteFiles,
 		snapshotFilesFn:    fs.SnapshotFiles,
+		dataFilesFn:        fs.DataFiles,
+		newReaderFn:        fs.NewReader,
 		sleepFn:            time.Sleep,
 		identifierPool:     opts.IdentifierPool(),
 		contextPool:        opts.ContextPool(),
 		flushState:         newShardFlushState(),
+		tombstones:         newShardTombstones(),
 		tickWg:             &sync.WaitGroup{},
 		logger:             opts.InstrumentOptions().Logger(),
 		metrics:            newDatabaseShardMetrics(scope),
@@ -300,6 +318,18 @@ func newDatabaseShard(
 		s.setBlockRetriever(blockRetriever)
 	}
 
+	// Load any tombstones left by series deleted before the shard was
+	// last closed so their data remains masked from this point on, if
+	// they cannot be read the shard refuses reads and bootstrap until
+	// they can be
+	if err := s.loadTombstones(); err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", shard),
+			xlog.NewField("namespace", namespaceMetadata.ID()),
+			xlog.NewField("error", err),
+		).Error("unable to read shard tombstones")
+	}
+
 	s.metrics.create.Inc(1)
 
 	return s
@@ -342,6 +372,10 @@ func (s *dbShard) Stream(
 	blockStart time.Time,
 	onRetrieve block.OnRetrieveBlock,
 ) (xio.BlockReader, error) {
+	if tombstone, ok := s.tombstones.get(id); ok && tombstone.masksBlockStart(blockStart) {
+		// The fileset only holds data of the series from before it was deleted
+		return xio.EmptyBlockReader, nil
+	}
 	return s.DatabaseBlockRetriever.Stream(ctx, s.shard, id, blockStart, onRetrieve)
 }
 
@@ -576,7 +610,13 @@ func (s *dbShard) isClosingWithLock() bool {
 
 func (s *dbShard) Tick(c context.Cancellable, tickStart time.Time) (tickResult, error) {
 	s.removeAnyFlushStatesTooEarly(tickStart)
-	return s.tickAndExpire(c, tickPolicyRegular)
+	r, err := s.tickAndExpire(c, tickPolicyRegular)
+	if err != nil {
+		return r, err
+	}
+	// Deleted series written to since the last tick are persisted as
+	// rewritten in a single batch rather than on each write
+	return r, s.persistDirtyTombstones()
 }
 
 func (s *dbShard) tickAndExpire(
@@ -657,7 +697,12 @@ func (s *dbShard) tickAndExpire(
 			)
 			switch policy {
 			case tickPolicyRegular:
-				result, err = entry.Series.Tick()
+				if s.isDeletedEntry(entry) {
+					// Deleted series are purged regardless of the data they hold
+					err = series.ErrSeriesAllDatapointsExpired
+				} else {
+					result, err = entry.Series.Tick()
+				}
 			case tickPolicyCloseShard:
 				err = series.ErrSeriesAllDatapointsExpired
 			}
@@ -711,9 +756,13 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 	for _, entry := range expiredEntries {
 		series := entry.Series
 		id := series.ID()
-		elem, exists := s.lookup.Get(id)
-		if !exists {
-			continue
+		elem, deleted := s.deletedEntries[entry]
+		if !deleted {
+			var exists bool
+			elem, exists = s.lookup.Get(id)
+			if !exists || elem.Value.(*lookup.Entry) != entry {
+				continue
+			}
 		}
 
 		count := entry.ReaderWriterCount()
@@ -732,7 +781,7 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 		}
 		// If there have been datapoints written to the series since its
 		// last empty check, we don't remove it.
-		if !series.IsEmpty() {
+		if !deleted && !series.IsEmpty() {
 			continue
 		}
 		// NB(xichen): if we get here, we are guaranteed that there can be
@@ -740,7 +789,11 @@ func (s *dbShard) purgeExpiredSeries(expiredEntries []*lookup.Entry) {
 		// safe to remove it.
 		series.Close()
 		s.list.Remove(elem)
-		s.lookup.Delete(id)
+		if deleted {
+			delete(s.deletedEntries, entry)
+		} else {
+			s.lookup.Delete(id)
+		}
 	}
 	s.Unlock()
 }
@@ -755,7 +808,7 @@ func (s *dbShard) WriteTagged(
 	annotation []byte,
 ) error {
 	return s.writeAndIndex(ctx, id, tags, timestamp,
-		value, unit, annotation, true)
+		value, unit, annotation, true, true)
 }
 
 func (s *dbShard) Write(
@@ -767,7 +820,33 @@ func (s *dbShard) Write(
 	annotation []byte,
 ) error {
 	return s.writeAndIndex(ctx, id, ident.EmptyTagIterator, timestamp,
-		value, unit, annotation, false)
+		value, unit, annotation, false, true)
+}
+
+func (s *dbShard) ReplayColdWrite(
+	ctx context.Context,
+	id ident.ID,
+	tags ident.Tags,
+	timestamp time.Time,
+	value float64,
+	unit xtime.Unit,
+	annotation []byte,
+	writtenSince time.Time,
+) error {
+	if tombstone, ok := s.tombstones.get(id); ok &&
+		tombstone.deletedAt.After(writtenSince) {
+		// The datapoint may have been written before the series was
+		// deleted, drop it
+		s.metrics.tombstonedWrites.Inc(1)
+		return nil
+	}
+
+	tagsIter := s.identifierPool.TagsIterator()
+	tagsIter.Reset(tags)
+	defer tagsIter.Close()
+	shouldReverseIndex := s.reverseIndex != nil
+	return s.writeAndIndex(ctx, id, tagsIter, timestamp,
+		value, unit, annotation, shouldReverseIndex, false)
 }
 
 func (s *dbShard) writeAndIndex(
@@ -779,7 +858,12 @@ func (s *dbShard) writeAndIndex(
 	unit xtime.Unit,
 	annotation []byte,
 	shouldReverseIndex bool,
+	shouldWriteCommitLog bool,
 ) error {
+	if tombstone, ok := s.tombstones.get(id); ok && !tombstone.rewritten {
+		s.tombstones.markRewritten(id)
+	}
+
 	// Prepare write
 	entry, opts, err := s.tryRetrieveWritableSeries(id)
 	if err != nil {
@@ -873,6 +957,10 @@ func (s *dbShard) writeAndIndex(
 		commitLogSeriesUniqueIndex = result.entry.Index
 	}
 
+	if !shouldWriteCommitLog {
+		return nil
+	}
+
 	// Write commit log
 	series := commitlog.Series{
 		UniqueIndex: commitLogSeriesUniqueIndex,
@@ -896,6 +984,10 @@ func (s *dbShard) ReadEncoded(
 	id ident.ID,
 	start, end time.Time,
 ) ([][]xio.BlockReader, error) {
+	if err := s.loadTombstones(); err != nil {
+		return nil, xerrors.NewRetryableError(err)
+	}
+
 	s.RLock()
 	entry, _, err := s.lookupEntryWithLock(id)
 	if entry != nil {
@@ -1306,23 +1398,11 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 			// this method (insertSeriesBatch) via `entryRefCountIncremented` mechanism.
 			entry.OnIndexPrepare()
 
-			id := entry.Series.ID()
-			tags := entry.Series.Tags().Values()
-
-			var d doc.Document
-			d.ID = id.Bytes() // IDs from shard entries are always set NoFinalize
-			d.Fields = make(doc.Fields, 0, len(tags))
-			for _, tag := range tags {
-				d.Fields = append(d.Fields, doc.Field{
-					Name:  tag.Name.Bytes(),  // Tags from shard entries are always set NoFinalize
-					Value: tag.Value.Bytes(), // Tags from shard entries are always set NoFinalize
-				})
-			}
 			indexBatch.Append(index.WriteBatchEntry{
 				Timestamp:     pendingIndex.timestamp,
 				OnIndexSeries: entry,
 				EnqueuedAt:    pendingIndex.enqueuedAt,
-			}, d)
+			}, entryDocument(entry))
 		}
 
 		if inserts[i].opts.hasPendingRetrievedBlock {
@@ -1347,11 +1427,31 @@ func (s *dbShard) insertSeriesBatch(inserts []dbShardInsert) error {
 	return err
 }
 
+func entryDocument(entry *lookup.Entry) doc.Document {
+	id := entry.Series.ID()
+	tags := entry.Series.Tags().Values()
+
+	var d doc.Document
+	d.ID = id.Bytes() // IDs from shard entries are always set NoFinalize
+	d.Fields = make(doc.Fields, 0, len(tags))
+	for _, tag := range tags {
+		d.Fields = append(d.Fields, doc.Field{
+			Name:  tag.Name.Bytes(),  // Tags from shard entries are always set NoFinalize
+			Value: tag.Value.Bytes(), // Tags from shard entries are always set NoFinalize
+		})
+	}
+	return d
+}
+
 func (s *dbShard) FetchBlocks(
 	ctx context.Context,
 	id ident.ID,
 	starts []time.Time,
 ) ([]block.FetchBlockResult, error) {
+	if err := s.loadTombstones(); err != nil {
+		return nil, xerrors.NewRetryableError(err)
+	}
+
 	s.RLock()
 	entry, _, err := s.lookupEntryWithLock(id)
 	if entry != nil {
@@ -1478,6 +1578,10 @@ func (s *dbShard) FetchBlocksMetadataV2(
 	encodedPageToken PageToken,
 	opts block.FetchBlocksMetadataOptions,
 ) (block.FetchBlocksMetadataResults, PageToken, error) {
+	if err := s.loadTombstones(); err != nil {
+		return nil, nil, xerrors.NewRetryableError(err)
+	}
+
 	token := new(pagetoken.PageToken)
 	if encodedPageToken != nil {
 		if err := proto.Unmarshal(encodedPageToken, token); err != nil {
@@ -1719,6 +1823,34 @@ func (s *dbShard) Bootstrap(
 	s.bootstrapState = Bootstrapping
 	s.Unlock()
 
+	// The shard cannot be bootstrapped without the tombstones, otherwise the
+	// data of deleted series would be bootstrapped too
+	if err := s.loadTombstones(); err != nil {
+		s.Lock()
+		s.bootstrapState = BootstrapNotStarted
+		s.Unlock()
+		return err
+	}
+
+	// The bootstrapped blocks of deleted series read from filesets are told
+	// apart from those recovered from the commit log by the flushed block starts
+	flushedStarts := make(map[xtime.UnixNano]struct{})
+	starts, err := s.flushedBlockStarts()
+	if err != nil {
+		s.logger.WithFields(
+			xlog.NewField("shard", s.ID()),
+			xlog.NewField("namespace", s.namespace.ID()),
+			xlog.NewField("error", err.Error()),
+		).Error("unable to read flushed block starts in shard bootstrap")
+	}
+	for _, start := range starts {
+		flushedStarts[xtime.ToUnixNano(start)] = struct{}{}
+	}
+	isFlushed := func(blockStart time.Time) bool {
+		_, ok := flushedStarts[xtime.ToUnixNano(blockStart)]
+		return ok
+	}
+
 	var (
 		shardBootstrapResult = dbShardBootstrapResult{}
 		multiErr             = xerrors.NewMultiError()
@@ -1726,6 +1858,21 @@ func (s *dbShard) Bootstrap(
 	for _, elem := range bootstrappedSeries.Iter() {
 		dbBlocks := elem.Value()
 
+		if tombstone, ok := s.tombstones.get(dbBlocks.ID); ok {
+			// Drop the bootstrapped data masked by the series deletion
+			err := filterTombstonedBlocks(dbBlocks.Blocks, tombstone, isFlushed, s.opts)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			if dbBlocks.Blocks == nil || dbBlocks.Blocks.Len() == 0 {
+				continue
+			}
+			if !tombstone.rewritten {
+				s.tombstones.markRewritten(dbBlocks.ID)
+			}
+		}
+
 		// First lookup if series already exists
 		entry, _, err := s.tryRetrieveWritableSeries(dbBlocks.ID)
 		if err != nil {
@@ -1829,6 +1976,9 @@ func (s *dbShard) Flush(
 	}
 	s.RUnlock()
 
+	s.fileOpsLock.Lock()
+	defer s.fileOpsLock.Unlock()
+
 	prepareOpts := persist.DataPrepareOptions{
 		NamespaceMetadata: s.namespace,
 		Shard:             s.ID(),
@@ -1850,6 +2000,11 @@ func (s *dbShard) Flush(
 	flushResult := dbShardFlushResult{}
 	s.forEachShardEntry(func(entry *lookup.Entry) bool {
 		curr := entry.Series
+		if s.isDeletedEntry(entry) {
+			// The series was deleted while referenced and its data is masked,
+			// a series written to since it was deleted only holds new data
+			return true
+		}
 		// Use a temporary context here so the stream readers can be returned to
 		// the pool after we finish fetching flushing the series.
 		tmpCtx.Reset()
@@ -1877,6 +2032,104 @@ func (s *dbShard) Flush(
 	return s.markFlushStateSuccessOrError(blockStart, multiErr.FinalError())
 }
 
+func (s *dbShard) ColdFlush(flush persist.DataFlush) error {
+	// We don't flush data when the shard is still bootstrapping
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToFlush
+	}
+	s.RUnlock()
+
+	s.fileOpsLock.Lock()
+	defer s.fileOpsLock.Unlock()
+
+	var flushedStarts []time.Time
+	s.flushState.RLock()
+	for start, state := range s.flushState.statesByTime {
+		if state.Status == fileOpSuccess {
+			flushedStarts = append(flushedStarts, start.ToTime())
+		}
+	}
+	s.flushState.RUnlock()
+	if len(flushedStarts) == 0 {
+		return nil
+	}
+
+	type coldWritesVersion struct {
+		id      ident.ID
+		version int
+	}
+	var (
+		multiErr   = xerrors.NewMultiError()
+		resultOpts = result.NewOptions().
+				SetDatabaseBlockOptions(s.opts.DatabaseBlockOptions())
+		coldWrites = make(map[xtime.UnixNano]result.ShardResult)
+		versions   = make(map[xtime.UnixNano][]coldWritesVersion)
+	)
+	s.forEachShardEntry(func(entry *lookup.Entry) bool {
+		curr := entry.Series
+		if s.isDeletedEntry(entry) {
+			return true
+		}
+		blocks, err := curr.ColdWrites(flushedStarts)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			return true
+		}
+		for _, b := range blocks {
+			start := xtime.ToUnixNano(b.Block.StartTime())
+			res, ok := coldWrites[start]
+			if !ok {
+				res = result.NewShardResult(0, resultOpts)
+				coldWrites[start] = res
+			}
+			res.AddBlock(curr.ID(), curr.Tags(), b.Block)
+			versions[start] = append(versions[start], coldWritesVersion{
+				id:      curr.ID(),
+				version: b.Version,
+			})
+		}
+		return true
+	})
+
+	for start, res := range coldWrites {
+		blockStart := start.ToTime()
+		err := s.rewriteFileSet(blockStart, res, flush)
+		res.Close()
+		if err != nil {
+			// The cold writes are kept and retried on the next flush
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to rewrite fileset for block %s: %v", blockStart.String(), err))
+			continue
+		}
+		if s.DatabaseBlockRetriever != nil {
+			err := s.DatabaseBlockRetriever.InvalidateFileSet(s.shard, blockStart)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+		}
+		s.tombstones.unmaskBlockStart(blockStart)
+
+		for _, v := range versions[start] {
+			entry, _, err := s.tryRetrieveWritableSeries(v.id)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+			if entry == nil {
+				continue
+			}
+			multiErr = multiErr.Add(entry.Series.ReleaseColdWrites(blockStart, v.version))
+			entry.DecrementReaderWriterCount()
+		}
+		s.metrics.coldFlushedBlocks.Inc(int64(len(versions[start])))
+	}
+
+	return multiErr.FinalError()
+}
+
 func (s *dbShard) Snapshot(
 	blockStart time.Time,
 	snapshotTime time.Time,
@@ -2072,6 +2325,9 @@ func (s *dbShard) CleanupSnapshots(earliestToRetain time.Time) error {
 }
 
 func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
+	s.fileOpsLock.Lock()
+	defer s.fileOpsLock.Unlock()
+
 	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
 	multiErr := xerrors.NewMultiError()
 	expired, err := s.filesetBeforeFn(filePathPrefix, s.namespace.ID(), s.ID(), earliestToRetain)
@@ -2084,15 +2340,536 @@ func (s *dbShard) CleanupExpiredFileSets(earliestToRetain time.Time) error {
 	if err := s.deleteFilesFn(expired); err != nil {
 		multiErr = multiErr.Add(err)
 	}
+	if err := s.cleanupSupersededFileSetVolumes(); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+	// Tombstones are no longer required once the data they mask has expired
+	if s.tombstones.expire(earliestToRetain) {
+		multiErr = multiErr.Add(s.persistTombstones())
+	}
 	return multiErr.FinalError()
 }
 
+// cleanupSupersededFileSetVolumes removes the fileset volumes of each block
+// start other than the latest complete volume, which holds all of the data
+// flushed for the block start.
+func (s *dbShard) cleanupSupersededFileSetVolumes() error {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	dataFiles, err := s.dataFilesFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return err
+	}
+
+	var filesToDelete []string
+	for _, curr := range dataFiles {
+		latest, ok := dataFiles.LatestVolumeForBlock(curr.ID.BlockStart)
+		if !ok || latest.ID.VolumeIndex == curr.ID.VolumeIndex {
+			// Incomplete volumes are kept until a complete volume supersedes them
+			continue
+		}
+		filesToDelete = append(filesToDelete, curr.AbsoluteFilepaths...)
+	}
+
+	return s.deleteFilesFn(filesToDelete)
+}
+
+func (s *dbShard) DeleteSeries(ids []ident.ID, deletedAt time.Time) (int64, error) {
+	// The tombstones must be loaded before new ones are persisted, otherwise
+	// the tombstones file would be overwritten without them
+	if err := s.loadTombstones(); err != nil {
+		return 0, err
+	}
+
+	// Hold the file ops lock so no fileset is written while the filesets
+	// holding the data of the series being deleted are determined
+	s.fileOpsLock.Lock()
+	defer s.fileOpsLock.Unlock()
+
+	maskedBlockStarts, err := s.flushedBlockStarts()
+	if err != nil {
+		return 0, err
+	}
+
+	var cachesAllSeries bool
+	switch s.opts.SeriesCachePolicy() {
+	case series.CacheAll, series.CacheAllMetadata:
+		cachesAllSeries = true
+	}
+
+	var (
+		tombstoned = make([]ident.ID, 0, len(ids))
+		closing    []*lookup.Entry
+		numDeleted int64
+	)
+	s.Lock()
+	// Once bootstrapped every series with data is in the lookup if all
+	// series are cached, otherwise a series may only have data on disk
+	unknownHaveNoData := cachesAllSeries && s.bootstrapState == Bootstrapped
+	for _, id := range ids {
+		elem, exists := s.lookup.Get(id)
+		if !exists {
+			if unknownHaveNoData {
+				continue
+			}
+			if tombstone, ok := s.tombstones.get(id); !ok || tombstone.rewritten {
+				numDeleted++
+			}
+			tombstoned = append(tombstoned, id)
+			continue
+		}
+		numDeleted++
+		tombstoned = append(tombstoned, id)
+		entry := elem.Value.(*lookup.Entry)
+		s.lookup.Delete(id)
+		// If the series is referenced, e.g. by a tick or flush iterating
+		// the shard, the list element must remain until the next tick
+		// purges it, otherwise it is safe to release immediately.
+		if entry.ReaderWriterCount() > 0 {
+			s.deletedEntries[entry] = elem
+			continue
+		}
+		s.list.Remove(elem)
+		closing = append(closing, entry)
+	}
+	s.tombstones.add(tombstoned, deletedAt, maskedBlockStarts)
+	s.Unlock()
+
+	for _, entry := range closing {
+		entry.Series.Close()
+	}
+
+	if len(tombstoned) > 0 {
+		if err := s.persistTombstones(); err != nil {
+			return 0, err
+		}
+	}
+
+	s.metrics.deletedSeries.Inc(numDeleted)
+	return numDeleted, nil
+}
+
+// flushedBlockStarts returns the block starts with a complete fileset.
+func (s *dbShard) flushedBlockStarts() ([]time.Time, error) {
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	dataFiles, err := s.dataFilesFn(filePathPrefix, s.namespace.ID(), s.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		starts []time.Time
+		seen   = make(map[xtime.UnixNano]struct{})
+	)
+	for _, curr := range dataFiles {
+		start := xtime.ToUnixNano(curr.ID.BlockStart)
+		if _, ok := seen[start]; ok || !curr.HasCheckpointFile() {
+			continue
+		}
+		seen[start] = struct{}{}
+		starts = append(starts, curr.ID.BlockStart)
+	}
+	return starts, nil
+}
+
+func (s *dbShard) isDeletedEntry(entry *lookup.Entry) bool {
+	s.RLock()
+	_, deleted := s.deletedEntries[entry]
+	s.RUnlock()
+	return deleted
+}
+
+func (s *dbShard) IsSeriesDeleted(id ident.ID) bool {
+	tombstone, ok := s.tombstones.get(id)
+	return ok && !tombstone.rewritten
+}
+
+func (s *dbShard) HasDeletedSeries() bool {
+	return s.tombstones.hasDeleted()
+}
+
+// loadTombstones reads the tombstones persisted before the shard was opened
+// if they have not been read yet.
+func (s *dbShard) loadTombstones() error {
+	if s.tombstones.isLoaded() {
+		return nil
+	}
+	// Hold the persist lock so the tombstones file is not rewritten while
+	// it is being read
+	s.tombstones.persistLock.Lock()
+	defer s.tombstones.persistLock.Unlock()
+	filePathPrefix := s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+	tombstones, err := fs.ReadTombstones(filePathPrefix, s.namespace.ID(), s.shard)
+	if err != nil {
+		return fmt.Errorf("unable to read shard tombstones: %v", err)
+	}
+	s.tombstones.load(tombstones)
+	return nil
+}
+
+func (s *dbShard) persistTombstones() error {
+	s.tombstones.persistLock.Lock()
+	defer s.tombstones.persistLock.Unlock()
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	err := fs.WriteTombstones(fsOpts, s.namespace.ID(), s.shard,
+		s.tombstones.persistable())
+	if err != nil {
+		// Retry persisting the tombstones on the next tick
+		s.tombstones.markDirty()
+	}
+	return err
+}
+
+func (s *dbShard) persistDirtyTombstones() error {
+	if !s.tombstones.isDirty() {
+		return nil
+	}
+	return s.persistTombstones()
+}
+
 func (s *dbShard) Repair(
 	ctx context.Context,
 	tr xtime.Range,
 	repairer databaseShardRepairer,
 ) (repair.MetadataComparisonResult, error) {
-	return repairer.Repair(ctx, s.namespace.ID(), tr, s)
+	return repairer.Repair(ctx, s.namespace, tr, s)
+}
+
+func (s *dbShard) LoadRepairedBlocks(
+	repaired result.ShardResult,
+	flush persist.DataFlush,
+) error {
+	s.RLock()
+	if s.bootstrapState != Bootstrapped {
+		s.RUnlock()
+		return errShardNotBootstrappedToRepair
+	}
+	s.RUnlock()
+
+	// Hold the file ops lock so the flushes and cleanups run by the
+	// mediator cannot write or remove volumes while they are rewritten
+	s.fileOpsLock.Lock()
+	defer s.fileOpsLock.Unlock()
+
+	var (
+		multiErr      = xerrors.NewMultiError()
+		flushedStarts = make(map[xtime.UnixNano]struct{})
+	)
+	// NB: Deletes are sent to every replica and peers mask the data of
+	// deleted series when serving it, so the repaired blocks of a deleted
+	// series only hold data written since it was deleted.
+	for _, elem := range repaired.AllSeries().Iter() {
+		dbBlocks := elem.Value()
+		for start := range dbBlocks.Blocks.AllBlocks() {
+			if s.FlushState(start.ToTime()).Status == fileOpSuccess {
+				flushedStarts[start] = struct{}{}
+			}
+		}
+	}
+
+	// Rewrite the filesets before loading the blocks into the series as the
+	// series take ownership of the blocks and close those read from disk.
+	for start := range flushedStarts {
+		blockStart := start.ToTime()
+		if err := s.rewriteFileSet(blockStart, repaired, flush); err != nil {
+			multiErr = multiErr.Add(fmt.Errorf(
+				"failed to rewrite fileset for block %s: %v", blockStart.String(), err))
+			continue
+		}
+		if s.DatabaseBlockRetriever != nil {
+			err := s.DatabaseBlockRetriever.InvalidateFileSet(s.shard, blockStart)
+			if err != nil {
+				multiErr = multiErr.Add(err)
+				continue
+			}
+		}
+		s.tombstones.unmaskBlockStart(blockStart)
+	}
+
+	var indexBatch *index.WriteBatch
+	if s.reverseIndex != nil {
+		indexBatch = index.NewWriteBatch(index.WriteBatchOptions{
+			IndexBlockSize: s.namespace.Options().IndexOptions().BlockSize(),
+		})
+	}
+
+	for _, elem := range repaired.AllSeries().Iter() {
+		dbBlocks := elem.Value()
+		if dbBlocks.Blocks.Len() == 0 {
+			continue
+		}
+
+		entry, _, err := s.tryRetrieveWritableSeries(dbBlocks.ID)
+		if err != nil {
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		// Series are only held in memory for data not yet flushed
+		loadBlocks := entry != nil || hasUnflushedBlocks(dbBlocks.Blocks, flushedStarts)
+		if entry == nil {
+			if !loadBlocks && indexBatch == nil {
+				dbBlocks.Blocks.Close()
+				continue
+			}
+			// NB: Series only known to peers are still inserted so they can be
+			// indexed, the tick expires them if they hold no data in memory.
+			entry, err = s.insertSeriesSync(dbBlocks.ID, newTagsArg(dbBlocks.Tags),
+				insertSyncIncReaderWriterCount)
+			if err != nil {
+				dbBlocks.Blocks.Close()
+				multiErr = multiErr.Add(err)
+				continue
+			}
+		}
+
+		if indexBatch != nil {
+			for start := range dbBlocks.Blocks.AllBlocks() {
+				timestamp := start.ToTime()
+				if !entry.NeedsIndexUpdate(s.reverseIndex.BlockStartForWriteTime(timestamp)) {
+					continue
+				}
+				entry.OnIndexPrepare()
+				indexBatch.Append(index.WriteBatchEntry{
+					Timestamp:     timestamp,
+					OnIndexSeries: entry,
+					EnqueuedAt:    s.nowFn(),
+				}, entryDocument(entry))
+			}
+		}
+
+		if tombstone, ok := s.tombstones.get(dbBlocks.ID); ok && !tombstone.rewritten {
+			s.tombstones.markRewritten(dbBlocks.ID)
+		}
+
+		if loadBlocks {
+			for _, b := range dbBlocks.Blocks.AllBlocks() {
+				if err := entry.Series.LoadBlock(b); err != nil {
+					multiErr = multiErr.Add(err)
+				}
+			}
+		} else {
+			dbBlocks.Blocks.Close()
+		}
+
+		entry.DecrementReaderWriterCount()
+	}
+
+	if indexBatch != nil && indexBatch.Len() > 0 {
+		multiErr = multiErr.Add(s.reverseIndex.WriteBatch(indexBatch))
+	}
+
+	return multiErr.FinalError()
+}
+
+func hasUnflushedBlocks(
+	blocks block.DatabaseSeriesBlocks,
+	flushedStarts map[xtime.UnixNano]struct{},
+) bool {
+	for start := range blocks.AllBlocks() {
+		if _, ok := flushedStarts[start]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// rewriteFileSet writes a new volume of the fileset of a flushed block start
+// with the blocks for the start merged into the latest volume on disk. The new
+// volume only supersedes the latest volume once it is complete, if the rewrite
+// fails it is aborted and the latest volume continues to be read.
+func (s *dbShard) rewriteFileSet(
+	blockStart time.Time,
+	blocks result.ShardResult,
+	flush persist.DataFlush,
+) error {
+	fsOpts := s.opts.CommitLogOptions().FilesystemOptions()
+	volumeIndex, err := fs.NextDataFileSetVolumeIndex(fsOpts.FilePathPrefix(),
+		s.namespace.ID(), s.ID(), blockStart)
+	if err != nil {
+		return err
+	}
+
+	reader, err := s.newReaderFn(s.opts.BytesPool(), fsOpts)
+	if err != nil {
+		return err
+	}
+
+	openOpts := fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  s.namespace.ID(),
+			Shard:      s.ID(),
+			BlockStart: blockStart,
+		},
+	}
+	if err := reader.Open(openOpts); err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	prepared, err := flush.PrepareData(persist.DataPrepareOptions{
+		NamespaceMetadata: s.namespace,
+		Shard:             s.ID(),
+		BlockStart:        blockStart,
+		VolumeIndex:       volumeIndex,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		blockErr error
+		merged   = make(map[string]struct{})
+	)
+	for {
+		id, tagsIter, data, checksum, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			blockErr = err // Need to call prepared.Abort, avoid return
+			break
+		}
+
+		if tombstone, ok := s.tombstones.get(id); ok && tombstone.masksBlockStart(blockStart) {
+			// Drop the data written before the series was deleted, any
+			// written since is in the blocks being merged
+			id.Finalize()
+			tagsIter.Close()
+			data.Finalize()
+			continue
+		}
+
+		// NB: The IDs and tags are referenced by the writer until closed
+		// so are left to be garbage collected rather than finalized.
+		segment := ts.NewSegment(data, nil, ts.FinalizeHead)
+		tags, err := convert.TagsFromTagsIter(id, tagsIter, s.identifierPool)
+		tagsIter.Close()
+		if err != nil {
+			segment.Finalize()
+			blockErr = err
+			break
+		}
+
+		if dbBlocks, ok := blocks.AllSeries().Get(id); ok {
+			if b, ok := dbBlocks.Blocks.BlockAt(blockStart); ok {
+				mergedSegment, err := s.mergeSegmentWithBlock(blockStart, segment, b)
+				segment.Finalize()
+				if err != nil {
+					blockErr = err
+					break
+				}
+				segment = mergedSegment
+				checksum = digest.SegmentChecksum(segment)
+				merged[id.String()] = struct{}{}
+			}
+		}
+
+		err = prepared.Persist(id, tags, segment, checksum)
+		segment.Finalize()
+		if err != nil {
+			blockErr = err
+			break
+		}
+	}
+
+	// Write the series missing from the fileset altogether
+	for _, elem := range blocks.AllSeries().Iter() {
+		if blockErr != nil {
+			break
+		}
+		dbBlocks := elem.Value()
+		if _, ok := merged[dbBlocks.ID.String()]; ok {
+			continue
+		}
+		b, ok := dbBlocks.Blocks.BlockAt(blockStart)
+		if !ok {
+			continue
+		}
+		blockErr = s.persistBlock(dbBlocks.ID, dbBlocks.Tags, b, prepared.Persist)
+	}
+
+	if blockErr != nil {
+		// Abort rather than close so the incomplete volume is never read
+		if err := prepared.Abort(); err != nil {
+			s.logger.WithFields(
+				xlog.NewField("blockStart", blockStart.String()),
+				xlog.NewField("volumeIndex", volumeIndex),
+				xlog.NewField("error", err.Error()),
+			).Error("unable to abort fileset rewrite")
+		}
+		return blockErr
+	}
+	return prepared.Close()
+}
+
+func (s *dbShard) persistBlock(
+	id ident.ID,
+	tags ident.Tags,
+	b block.DatabaseBlock,
+	persistFn persist.DataFn,
+) error {
+	ctx := s.contextPool.Get()
+	defer ctx.BlockingClose()
+
+	stream, err := b.Stream(ctx)
+	if err != nil {
+		return err
+	}
+	if stream.SegmentReader == nil {
+		return nil
+	}
+	segment, err := stream.Segment()
+	if err != nil {
+		return err
+	}
+	checksum, err := b.Checksum()
+	if err != nil {
+		return err
+	}
+	return persistFn(id, tags, segment, checksum)
+}
+
+// mergeSegmentWithBlock re-encodes a segment read from a fileset together
+// with a block for the same series.
+func (s *dbShard) mergeSegmentWithBlock(
+	blockStart time.Time,
+	segment ts.Segment,
+	b block.DatabaseBlock,
+) (ts.Segment, error) {
+	var (
+		bopts   = s.opts.DatabaseBlockOptions()
+		encoder = bopts.EncoderPool().Get()
+		iter    = s.opts.MultiReaderIteratorPool().Get()
+		ctx     = s.contextPool.Get()
+	)
+	defer func() {
+		iter.Close()
+		ctx.BlockingClose()
+	}()
+
+	stream, err := b.Stream(ctx)
+	if err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	readers := []xio.SegmentReader{xio.NewSegmentReader(segment)}
+	if stream.SegmentReader != nil {
+		readers = append(readers, stream.SegmentReader)
+	}
+
+	encoder.Reset(blockStart, bopts.DatabaseBlockAllocSize())
+	iter.Reset(readers, blockStart, b.BlockSize())
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return ts.Segment{}, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return ts.Segment{}, err
+	}
+	return encoder.Discard(), nil
 }
 
 func (s *dbShard) BootstrapState() BootstrapState {
@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/m3ninx/doc"
+	m3ninxidx "github.com/m3db/m3/src/m3ninx/idx"
 	xclock "github.com/m3db/m3x/clock"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
@@ -40,6 +41,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 )
 
 func TestShardInsertNamespaceIndex(t *testing.T) {
@@ -100,6 +102,58 @@ func TestShardInsertNamespaceIndex(t *testing.T) {
 	require.Equal(t, []byte("value"), indexWrites[0].Fields[0].Value)
 }
 
+func TestShardColdWriteQueryIDs(t *testing.T) {
+	opts := testDatabaseOptions()
+	nopts := defaultTestNs1Opts.
+		SetColdWritesEnabled(true).
+		SetIndexOptions(defaultTestNs1Opts.IndexOptions().SetEnabled(true))
+	md, err := namespace.NewMetadata(defaultTestNs1ID, nopts)
+	require.NoError(t, err)
+
+	idx, err := newNamespaceIndex(md, opts)
+	require.NoError(t, err)
+	defer idx.Close()
+
+	nsReaderMgr := newNamespaceReaderManager(md, tally.NoopScope, opts)
+	seriesOpts := NewSeriesOptionsFromOptions(opts, nopts.RetentionOptions()).
+		SetColdWritesEnabled(true)
+	shard := newDatabaseShard(md, 0, nil, nsReaderMgr, &testIncreasingIndex{},
+		commitLogWriteNoOp, idx, true, opts, seriesOpts).(*dbShard)
+	shard.SetRuntimeOptions(runtime.NewOptions().SetWriteNewSeriesAsync(false))
+	defer shard.Close()
+
+	var (
+		ctx       = context.NewContext()
+		blockSize = nopts.RetentionOptions().BlockSize()
+		now       = time.Now()
+		coldTime  = now.Truncate(blockSize).Add(-2 * blockSize).Add(time.Minute)
+		tags      = ident.NewTags(ident.StringTag("name", "cold"))
+	)
+	defer ctx.Close()
+
+	// The first write allocates the index block which the tick seals, so
+	// the second write is made to a sealed index block
+	require.NoError(t, shard.WriteTagged(ctx, ident.StringID("foo"),
+		ident.NewTagsIterator(tags), coldTime, 1.0, xtime.Second, nil))
+	_, err = idx.Tick(context.NewNoOpCanncellable(), now)
+	require.NoError(t, err)
+	require.NoError(t, shard.WriteTagged(ctx, ident.StringID("bar"),
+		ident.NewTagsIterator(tags), coldTime, 2.0, xtime.Second, nil))
+
+	res, err := idx.Query(ctx, index.Query{
+		Query: m3ninxidx.NewTermQuery([]byte("name"), []byte("cold")),
+	}, index.QueryOptions{
+		StartInclusive: coldTime.Truncate(blockSize),
+		EndExclusive:   now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.Results.Size())
+	for _, id := range []string{"foo", "bar"} {
+		_, ok := res.Results.Map().Get(ident.StringID(id))
+		require.True(t, ok)
+	}
+}
+
 func TestShardAsyncInsertNamespaceIndex(t *testing.T) {
 	defer leaktest.CheckTimeout(t, 2*time.Second)()
 
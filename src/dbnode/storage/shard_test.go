@@ -30,21 +30,26 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/fs/commitlog"
 	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/dbnode/storage/namespace"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/storage/series/lookup"
 	"github.com/m3db/m3/src/dbnode/ts"
 	xmetrics "github.com/m3db/m3/src/dbnode/x/metrics"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3x/checked"
 	"github.com/m3db/m3x/context"
 	"github.com/m3db/m3x/ident"
+	"github.com/m3db/m3x/pool"
 	xtest "github.com/m3db/m3x/test"
 	xtime "github.com/m3db/m3x/time"
 
@@ -877,6 +882,39 @@ func TestShardCleanupExpiredFileSets(t *testing.T) {
 	require.Equal(t, []string{defaultTestNs1ID.String(), "0"}, deletedFiles)
 }
 
+func TestShardCleanupExpiredFileSetsWaitsForFileOps(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.filesetBeforeFn = func(_ string, namespace ident.ID, shardID uint32, t time.Time) ([]string, error) {
+		return []string{namespace.String()}, nil
+	}
+	deleted := make(chan struct{}, 1)
+	shard.deleteFilesFn = func(files []string) error {
+		if len(files) > 0 {
+			deleted <- struct{}{}
+		}
+		return nil
+	}
+
+	// Simulate a repair rewriting a fileset while the cleanup runs
+	shard.fileOpsLock.Lock()
+	done := make(chan error, 1)
+	go func() {
+		done <- shard.CleanupExpiredFileSets(time.Now())
+	}()
+
+	select {
+	case <-deleted:
+		require.FailNow(t, "cleanup removed files while a fileset was being rewritten")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	shard.fileOpsLock.Unlock()
+	require.NoError(t, <-done)
+	<-deleted
+}
+
 func TestShardCleanupSnapshot(t *testing.T) {
 	var (
 		opts                = testDatabaseOptions()
@@ -1251,3 +1289,361 @@ func TestShardIterateBatchSize(t *testing.T) {
 
 	require.True(t, shardIterateBatchMinSize < iterateBatchSize(2000))
 }
+
+func TestShardLoadRepairedBlocks(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+
+	var (
+		fsOpts         = opts.CommitLogOptions().FilesystemOptions()
+		blockSize      = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart   = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		unflushedStart = flushedStart.Add(blockSize)
+		fooOnDisk      = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 1}}
+		fooRepaired    = []ts.Datapoint{{Timestamp: flushedStart.Add(2 * time.Minute), Value: 2}}
+		barRepaired    = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 3}}
+		bazRepaired    = []ts.Datapoint{{Timestamp: unflushedStart.Add(time.Minute), Value: 4}}
+	)
+
+	// Write the fileset for the flushed block start
+	testWriteFileSet(t, opts, shard, flushedStart, map[string][]ts.Datapoint{
+		"foo": fooOnDisk,
+	})
+	shard.markFlushStateSuccess(flushedStart)
+
+	repaired := result.NewShardResult(0, result.NewOptions())
+	repaired.AddBlock(ident.StringID("foo"), ident.Tags{},
+		testEncodedBlock(t, opts, flushedStart, blockSize, fooRepaired))
+	repaired.AddBlock(ident.StringID("bar"), ident.Tags{},
+		testEncodedBlock(t, opts, flushedStart, blockSize, barRepaired))
+	repaired.AddBlock(ident.StringID("baz"), ident.Tags{},
+		testEncodedBlock(t, opts, unflushedStart, blockSize, bazRepaired))
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.NoError(t, shard.LoadRepairedBlocks(repaired, flush))
+	require.NoError(t, flush.DoneData())
+
+	// The fileset is rewritten with the repaired blocks merged in
+	onDisk := testReadFileSet(t, opts, shard, flushedStart)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": append(fooOnDisk, fooRepaired...),
+		"bar": barRepaired,
+	}, onDisk)
+
+	// Only the series with unflushed blocks are held in memory
+	require.Equal(t, int64(1), shard.NumSeries())
+	ctx := context.NewContext()
+	defer ctx.Close()
+	readers, err := shard.ReadEncoded(ctx, ident.StringID("baz"),
+		unflushedStart, unflushedStart.Add(blockSize))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(readers))
+}
+
+func TestShardLoadRepairedBlocksIndexesPeerOnlySeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	var (
+		indexBlockSize = defaultTestNs1Opts.IndexOptions().BlockSize()
+		indexed        []doc.Document
+		timestamps     []time.Time
+	)
+	idx := NewMocknamespaceIndex(ctrl)
+	idx.EXPECT().BlockStartForWriteTime(gomock.Any()).DoAndReturn(
+		func(t time.Time) xtime.UnixNano {
+			return xtime.ToUnixNano(t.Truncate(indexBlockSize))
+		}).AnyTimes()
+	idx.EXPECT().WriteBatch(gomock.Any()).DoAndReturn(
+		func(batch *index.WriteBatch) error {
+			indexed = append(indexed, batch.PendingDocs()...)
+			for _, e := range batch.PendingEntries() {
+				timestamps = append(timestamps, e.Timestamp)
+				blockStart := xtime.ToUnixNano(e.Timestamp.Truncate(indexBlockSize))
+				e.OnIndexSeries.OnIndexSuccess(blockStart)
+				e.OnIndexSeries.OnIndexFinalize(blockStart)
+			}
+			return nil
+		})
+
+	shard := testDatabaseShardWithIndexFn(t, opts, idx)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+
+	var (
+		fsOpts       = opts.CommitLogOptions().FilesystemOptions()
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		barRepaired  = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 3}}
+	)
+
+	testWriteFileSet(t, opts, shard, flushedStart, map[string][]ts.Datapoint{})
+	shard.markFlushStateSuccess(flushedStart)
+
+	repaired := result.NewShardResult(0, result.NewOptions())
+	repaired.AddBlock(ident.StringID("bar"), ident.NewTags(ident.StringTag("name", "bar")),
+		testEncodedBlock(t, opts, flushedStart, blockSize, barRepaired))
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.NoError(t, shard.LoadRepairedBlocks(repaired, flush))
+	require.NoError(t, flush.DoneData())
+
+	// The series only known to the peer is indexed for the repaired block
+	require.Equal(t, 1, len(indexed))
+	require.Equal(t, "bar", string(indexed[0].ID))
+	require.Equal(t, []doc.Field{{Name: []byte("name"), Value: []byte("bar")}},
+		[]doc.Field(indexed[0].Fields))
+	require.Equal(t, []time.Time{flushedStart}, timestamps)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"bar": barRepaired,
+	}, testReadFileSet(t, opts, shard, flushedStart))
+}
+
+// readErrDataFileSetReader fails to read once a number of entries are read.
+type readErrDataFileSetReader struct {
+	fs.DataFileSetReader
+	remaining int
+}
+
+func (r *readErrDataFileSetReader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, error) {
+	if r.remaining == 0 {
+		return nil, nil, nil, 0, errors.New("read error")
+	}
+	r.remaining--
+	return r.DataFileSetReader.Read()
+}
+
+func TestShardLoadRepairedBlocksReadErrorKeepsFileSet(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+
+	var (
+		fsOpts       = opts.CommitLogOptions().FilesystemOptions()
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		onDisk       = map[string][]ts.Datapoint{
+			"foo": {{Timestamp: flushedStart.Add(time.Minute), Value: 1}},
+			"bar": {{Timestamp: flushedStart.Add(time.Minute), Value: 2}},
+		}
+		bazRepaired = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 3}}
+	)
+
+	testWriteFileSet(t, opts, shard, flushedStart, onDisk)
+	shard.markFlushStateSuccess(flushedStart)
+
+	// Fail the read of the second entry of the fileset
+	shard.newReaderFn = func(
+		bytesPool pool.CheckedBytesPool,
+		opts fs.Options,
+	) (fs.DataFileSetReader, error) {
+		reader, err := fs.NewReader(bytesPool, opts)
+		return &readErrDataFileSetReader{DataFileSetReader: reader, remaining: 1}, err
+	}
+
+	repaired := result.NewShardResult(0, result.NewOptions())
+	repaired.AddBlock(ident.StringID("baz"), ident.Tags{},
+		testEncodedBlock(t, opts, flushedStart, blockSize, bazRepaired))
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.Error(t, shard.LoadRepairedBlocks(repaired, flush))
+	require.NoError(t, flush.DoneData())
+
+	// The aborted rewrite leaves the flushed volume to be read
+	fileset, ok, err := fs.FileSetAt(fsOpts.FilePathPrefix(), shard.namespace.ID(),
+		shard.ID(), flushedStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 0, fileset.ID.VolumeIndex)
+	require.Equal(t, onDisk, testReadFileSet(t, opts, shard, flushedStart))
+}
+
+func TestShardColdFlush(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+	shard.seriesOpts = shard.seriesOpts.SetColdWritesEnabled(true)
+
+	var (
+		fsOpts       = opts.CommitLogOptions().FilesystemOptions()
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		fooOnDisk    = []ts.Datapoint{{Timestamp: flushedStart.Add(2 * time.Minute), Value: 1}}
+		fooCold      = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 2}}
+		barCold      = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 3}}
+	)
+
+	testWriteFileSet(t, opts, shard, flushedStart, map[string][]ts.Datapoint{
+		"foo": fooOnDisk,
+	})
+	shard.markFlushStateSuccess(flushedStart)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"),
+		fooCold[0].Timestamp, fooCold[0].Value, xtime.Second, nil))
+	require.NoError(t, shard.Write(ctx, ident.StringID("bar"),
+		barCold[0].Timestamp, barCold[0].Value, xtime.Second, nil))
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.NoError(t, shard.ColdFlush(flush))
+	require.NoError(t, flush.DoneData())
+
+	// A new fileset volume is written with the cold writes merged in
+	fileset, ok, err := fs.FileSetAt(fsOpts.FilePathPrefix(), shard.namespace.ID(),
+		shard.ID(), flushedStart)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, fileset.ID.VolumeIndex)
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": append(fooCold, fooOnDisk...),
+		"bar": barCold,
+	}, testReadFileSet(t, opts, shard, flushedStart))
+
+	// The cold writes are released once flushed
+	for _, id := range []string{"foo", "bar"} {
+		entry, _, err := shard.lookupEntryWithLock(ident.StringID(id))
+		require.NoError(t, err)
+		coldWrites, err := entry.Series.ColdWrites([]time.Time{flushedStart})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(coldWrites))
+	}
+
+	// The superseded volume is removed by cleanup
+	require.NoError(t, shard.CleanupExpiredFileSets(flushedStart))
+	dataFiles, err := fs.DataFiles(fsOpts.FilePathPrefix(), shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(dataFiles))
+	require.Equal(t, 1, dataFiles[0].ID.VolumeIndex)
+}
+
+func TestShardReplayColdWrite(t *testing.T) {
+	opts := testDatabaseOptions()
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+	shard.seriesOpts = shard.seriesOpts.SetColdWritesEnabled(true)
+	shard.commitLogWriter = commitLogWriterFn(func(
+		ctx context.Context,
+		series commitlog.Series,
+		datapoint ts.Datapoint,
+		unit xtime.Unit,
+		annotation ts.Annotation,
+	) error {
+		require.FailNow(t, "replayed writes are already in the commit log")
+		return nil
+	})
+
+	var (
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		tags         = ident.NewTags(ident.StringTag("name", "foo"))
+	)
+	shard.markFlushStateSuccess(flushedStart)
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+	require.NoError(t, shard.ReplayColdWrite(ctx, ident.StringID("foo"), tags,
+		flushedStart.Add(time.Minute), 1.0, xtime.Second, nil, flushedStart))
+
+	// The replayed write is held as a cold write until the next cold flush
+	entry, _, err := shard.lookupEntryWithLock(ident.StringID("foo"))
+	require.NoError(t, err)
+	require.True(t, entry.Series.Tags().Equal(tags))
+	coldWrites, err := entry.Series.ColdWrites([]time.Time{flushedStart})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(coldWrites))
+}
+
+func testWriteFileSet(
+	t *testing.T,
+	opts Options,
+	shard *dbShard,
+	blockStart time.Time,
+	datapoints map[string][]ts.Datapoint,
+) {
+	var (
+		fsOpts    = opts.CommitLogOptions().FilesystemOptions()
+		blockSize = shard.namespace.Options().RetentionOptions().BlockSize()
+	)
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+		BlockSize: blockSize,
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  shard.namespace.ID(),
+			Shard:      shard.ID(),
+			BlockStart: blockStart,
+		},
+	}))
+	ctx := context.NewContext()
+	defer ctx.BlockingClose()
+	for id, dps := range datapoints {
+		stream, err := testEncodedBlock(t, opts, blockStart, blockSize, dps).Stream(ctx)
+		require.NoError(t, err)
+		segment, err := stream.Segment()
+		require.NoError(t, err)
+		data := checked.NewBytes(segment.Head.Bytes(), nil)
+		data.IncRef()
+		require.NoError(t, writer.Write(ident.StringID(id), ident.Tags{}, data,
+			digest.SegmentChecksum(segment)))
+	}
+	require.NoError(t, writer.Close())
+}
+
+func testReadFileSet(
+	t *testing.T,
+	opts Options,
+	shard *dbShard,
+	blockStart time.Time,
+) map[string][]ts.Datapoint {
+	var (
+		fsOpts    = opts.CommitLogOptions().FilesystemOptions()
+		blockSize = shard.namespace.Options().RetentionOptions().BlockSize()
+	)
+	reader, err := fs.NewReader(opts.BytesPool(), fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:  shard.namespace.ID(),
+			Shard:      shard.ID(),
+			BlockStart: blockStart,
+		},
+	}))
+	onDisk := make(map[string][]ts.Datapoint)
+	for i := 0; i < reader.Entries(); i++ {
+		id, tagsIter, data, _, err := reader.Read()
+		require.NoError(t, err)
+		tagsIter.Close()
+		b := block.NewDatabaseBlock(blockStart, blockSize,
+			ts.NewSegment(data, nil, ts.FinalizeHead), opts.DatabaseBlockOptions())
+		onDisk[id.String()] = testBlockDatapoints(t, opts, b)
+	}
+	require.NoError(t, reader.Close())
+	return onDisk
+}
@@ -0,0 +1,333 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/ident"
+)
+
+// shardTombstones tracks the series deleted from a shard, the data of a
+// deleted series that existed when it was deleted is masked from reads,
+// bootstrap and flush until it ages out of retention. Data written to the
+// series since it was deleted is not masked regardless of its timestamp.
+type shardTombstones struct {
+	sync.RWMutex
+	byID map[string]shardTombstone
+	// loaded is set once the tombstones persisted before the shard was
+	// opened have been read
+	loaded bool
+	// dirty is set when the tombstones have changed since they were last
+	// persisted
+	dirty bool
+
+	// persistLock serializes writes of the tombstones file
+	persistLock sync.Mutex
+}
+
+type shardTombstone struct {
+	deletedAt time.Time
+	// maskedBlockStarts are the block starts of the filesets that held the
+	// series when it was deleted, each is unmasked once its fileset has been
+	// rewritten without the series data written before the deletion
+	maskedBlockStarts []time.Time
+	// rewritten is set once the series is written to after being deleted,
+	// at which point it is visible to index queries again
+	rewritten bool
+}
+
+func newShardTombstones() *shardTombstones {
+	return &shardTombstones{
+		byID: make(map[string]shardTombstone),
+	}
+}
+
+func (t *shardTombstones) load(tombstones []fs.Tombstone) {
+	t.Lock()
+	defer t.Unlock()
+	if t.loaded {
+		return
+	}
+	t.loaded = true
+	for _, tombstone := range tombstones {
+		t.byID[string(tombstone.ID)] = shardTombstone{
+			deletedAt:         tombstone.DeletedAt,
+			maskedBlockStarts: tombstone.MaskedBlockStarts,
+			rewritten:         tombstone.Rewritten,
+		}
+	}
+}
+
+func (t *shardTombstones) isLoaded() bool {
+	t.RLock()
+	loaded := t.loaded
+	t.RUnlock()
+	return loaded
+}
+
+func (t *shardTombstones) get(id ident.ID) (shardTombstone, bool) {
+	t.RLock()
+	if len(t.byID) == 0 {
+		t.RUnlock()
+		return shardTombstone{}, false
+	}
+	tombstone, ok := t.byID[string(id.Bytes())]
+	t.RUnlock()
+	return tombstone, ok
+}
+
+func (t *shardTombstones) add(
+	ids []ident.ID,
+	deletedAt time.Time,
+	maskedBlockStarts []time.Time,
+) {
+	t.Lock()
+	for _, id := range ids {
+		t.byID[id.String()] = shardTombstone{
+			deletedAt:         deletedAt,
+			maskedBlockStarts: maskedBlockStarts,
+		}
+	}
+	t.Unlock()
+}
+
+// unmaskBlockStart unmasks a block start for all tombstones once its fileset
+// has been rewritten without the data masked by them.
+func (t *shardTombstones) unmaskBlockStart(blockStart time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	for id, tombstone := range t.byID {
+		if !tombstone.masksBlockStart(blockStart) {
+			continue
+		}
+		// The block starts are shared between tombstones and read without
+		// the lock held so are copied rather than modified in place
+		masked := make([]time.Time, 0, len(tombstone.maskedBlockStarts)-1)
+		for _, start := range tombstone.maskedBlockStarts {
+			if !start.Equal(blockStart) {
+				masked = append(masked, start)
+			}
+		}
+		tombstone.maskedBlockStarts = masked
+		t.byID[id] = tombstone
+		t.dirty = true
+	}
+}
+
+// markRewritten marks a deleted series as written to since it was deleted,
+// the change is persisted with the next batch of tombstones.
+func (t *shardTombstones) markRewritten(id ident.ID) {
+	t.Lock()
+	defer t.Unlock()
+	tombstone, ok := t.byID[string(id.Bytes())]
+	if !ok || tombstone.rewritten {
+		return
+	}
+	tombstone.rewritten = true
+	t.byID[string(id.Bytes())] = tombstone
+	t.dirty = true
+}
+
+func (t *shardTombstones) markDirty() {
+	t.Lock()
+	t.dirty = true
+	t.Unlock()
+}
+
+func (t *shardTombstones) isDirty() bool {
+	t.RLock()
+	dirty := t.dirty
+	t.RUnlock()
+	return dirty
+}
+
+// hasDeleted returns whether any deleted series has not been written to
+// since it was deleted.
+func (t *shardTombstones) hasDeleted() bool {
+	t.RLock()
+	defer t.RUnlock()
+	for _, tombstone := range t.byID {
+		if !tombstone.rewritten {
+			return true
+		}
+	}
+	return false
+}
+
+// expire removes the tombstones which no longer mask any retained data,
+// returning true if any were removed.
+func (t *shardTombstones) expire(earliestToRetain time.Time) bool {
+	t.Lock()
+	defer t.Unlock()
+	expired := false
+	for id, tombstone := range t.byID {
+		if !tombstone.deletedAt.After(earliestToRetain) {
+			delete(t.byID, id)
+			expired = true
+		}
+	}
+	return expired
+}
+
+// persistable returns the tombstones sorted by series ID and clears the
+// dirty flag.
+func (t *shardTombstones) persistable() []fs.Tombstone {
+	t.Lock()
+	t.dirty = false
+	tombstones := make([]fs.Tombstone, 0, len(t.byID))
+	for id, tombstone := range t.byID {
+		tombstones = append(tombstones, fs.Tombstone{
+			ID:                []byte(id),
+			DeletedAt:         tombstone.deletedAt,
+			MaskedBlockStarts: tombstone.maskedBlockStarts,
+			Rewritten:         tombstone.rewritten,
+		})
+	}
+	t.Unlock()
+	sort.Slice(tombstones, func(i, j int) bool {
+		return bytes.Compare(tombstones[i].ID, tombstones[j].ID) < 0
+	})
+	return tombstones
+}
+
+// masksBlockStart returns whether the fileset of a block start holds data
+// of the series written before it was deleted.
+func (t shardTombstone) masksBlockStart(blockStart time.Time) bool {
+	for _, start := range t.maskedBlockStarts {
+		if start.Equal(blockStart) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterTombstonedBlocks removes the bootstrapped data of a deleted series
+// written before it was deleted. Blocks read from a masked fileset are
+// dropped and blocks read from a fileset written since are kept. The
+// remaining blocks are recovered from the commit log, snapshots or peers
+// which do not record when each datapoint was written, so the datapoints
+// with a timestamp before the deletion are dropped from those.
+func filterTombstonedBlocks(
+	blocks block.DatabaseSeriesBlocks,
+	tombstone shardTombstone,
+	isFlushed func(blockStart time.Time) bool,
+	opts Options,
+) error {
+	if blocks == nil {
+		return nil
+	}
+
+	var (
+		deletedAt = tombstone.deletedAt
+		spanning  []block.DatabaseBlock
+	)
+	for _, b := range blocks.AllBlocks() {
+		start := b.StartTime()
+		if tombstone.masksBlockStart(start) {
+			blocks.RemoveBlockAt(start)
+			b.Close()
+			continue
+		}
+		if isFlushed(start) {
+			continue
+		}
+		if !start.Add(b.BlockSize()).After(deletedAt) {
+			blocks.RemoveBlockAt(start)
+			b.Close()
+			continue
+		}
+		if start.Before(deletedAt) {
+			spanning = append(spanning, b)
+		}
+	}
+	for _, b := range spanning {
+		filtered, err := filterTombstonedBlock(b, deletedAt, opts)
+		if err != nil {
+			return err
+		}
+		blocks.RemoveBlockAt(b.StartTime())
+		b.Close()
+		if filtered != nil {
+			blocks.AddBlock(filtered)
+		}
+	}
+	return nil
+}
+
+func filterTombstonedBlock(
+	b block.DatabaseBlock,
+	deletedAt time.Time,
+	opts Options,
+) (block.DatabaseBlock, error) {
+	var (
+		bopts   = opts.DatabaseBlockOptions()
+		start   = b.StartTime()
+		encoder = bopts.EncoderPool().Get()
+		iter    = opts.MultiReaderIteratorPool().Get()
+		ctx     = opts.ContextPool().Get()
+		encoded bool
+	)
+	defer func() {
+		iter.Close()
+		ctx.Close()
+	}()
+
+	encoder.Reset(start, bopts.DatabaseBlockAllocSize())
+	stream, err := b.Stream(ctx)
+	if err != nil {
+		encoder.Close()
+		return nil, err
+	}
+	if stream.SegmentReader == nil {
+		encoder.Close()
+		return nil, nil
+	}
+
+	iter.Reset([]xio.SegmentReader{stream.SegmentReader}, start, b.BlockSize())
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if dp.Timestamp.Before(deletedAt) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+		encoded = true
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return nil, err
+	}
+
+	if !encoded {
+		encoder.Close()
+		return nil, nil
+	}
+	return block.NewDatabaseBlock(start, b.BlockSize(), encoder.Discard(), bopts), nil
+}
@@ -0,0 +1,442 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/bootstrap/result"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3x/context"
+	"github.com/m3db/m3x/ident"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDatabaseOptionsWithTempDir(t *testing.T) (Options, func()) {
+	dir, err := ioutil.TempDir("", "shard-tombstones")
+	require.NoError(t, err)
+
+	opts := testDatabaseOptions()
+	clOpts := opts.CommitLogOptions()
+	fsOpts := clOpts.FilesystemOptions().SetFilePathPrefix(dir)
+	opts = opts.SetCommitLogOptions(clOpts.SetFilesystemOptions(fsOpts))
+	return opts, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestShardDeleteSeries(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	now := shard.nowFn()
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"), now, 1.0, xtime.Second, nil))
+	require.NoError(t, shard.Write(ctx, ident.StringID("bar"), now, 2.0, xtime.Second, nil))
+	require.Equal(t, int64(2), shard.NumSeries())
+
+	deletedAt := now.Add(time.Second)
+	deleted, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, deletedAt)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	require.Equal(t, int64(1), shard.NumSeries())
+	require.True(t, shard.IsSeriesDeleted(ident.StringID("foo")))
+	require.False(t, shard.IsSeriesDeleted(ident.StringID("bar")))
+
+	// Reads of the deleted range return nothing
+	readers, err := shard.ReadEncoded(ctx, ident.StringID("foo"), now.Add(-time.Minute), deletedAt)
+	require.NoError(t, err)
+	require.Empty(t, readers)
+
+	// Writes since the series was deleted recreate it and are not masked
+	// regardless of their timestamp
+	require.NoError(t, shard.Write(ctx, ident.StringID("foo"), now, 3.0, xtime.Second, nil))
+	require.Equal(t, int64(2), shard.NumSeries())
+	require.False(t, shard.IsSeriesDeleted(ident.StringID("foo")))
+	readers, err = shard.ReadEncoded(ctx, ident.StringID("foo"), now.Add(-time.Minute), deletedAt)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(readers))
+
+	// The rewritten tombstone is persisted by the next tick
+	require.True(t, shard.tombstones.isDirty())
+	_, err = shard.Tick(context.NewNoOpCanncellable(), shard.nowFn())
+	require.NoError(t, err)
+	require.False(t, shard.tombstones.isDirty())
+
+	// Tombstones are persisted and loaded by the shard when reopened
+	tombstones, err := fs.ReadTombstones(
+		opts.CommitLogOptions().FilesystemOptions().FilePathPrefix(),
+		shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tombstones))
+	require.Equal(t, []byte("foo"), tombstones[0].ID)
+	require.True(t, deletedAt.Equal(tombstones[0].DeletedAt))
+	require.True(t, tombstones[0].Rewritten)
+
+	reopened := testDatabaseShard(t, opts)
+	defer reopened.Close()
+	tombstone, ok := reopened.tombstones.get(ident.StringID("foo"))
+	require.True(t, ok)
+	require.True(t, deletedAt.Equal(tombstone.deletedAt))
+	require.True(t, tombstone.rewritten)
+}
+
+func TestShardDeleteSeriesCountsOnlyDeletedSeries(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	for _, policy := range []series.CachePolicy{series.CacheAll, series.CacheRecentlyRead} {
+		shard := testDatabaseShard(t, opts.SetSeriesCachePolicy(policy))
+		shard.bootstrapState = Bootstrapped
+
+		ctx := context.NewContext()
+		now := shard.nowFn()
+		require.NoError(t, shard.Write(ctx, ident.StringID("foo"), now, 1.0, xtime.Second, nil))
+		ctx.Close()
+
+		// Unknown series only have data on disk if not all series are cached
+		ids := []ident.ID{ident.StringID("foo"), ident.StringID("unknown")}
+		deleted, err := shard.DeleteSeries(ids, now)
+		require.NoError(t, err)
+		_, tombstoned := shard.tombstones.get(ident.StringID("unknown"))
+		if policy == series.CacheAll {
+			require.Equal(t, int64(1), deleted)
+			require.False(t, tombstoned)
+		} else {
+			require.Equal(t, int64(2), deleted)
+			require.True(t, tombstoned)
+		}
+
+		// Series already deleted are not counted again
+		deleted, err = shard.DeleteSeries(ids, now)
+		require.NoError(t, err)
+		require.Equal(t, int64(0), deleted)
+
+		require.NoError(t, shard.Close())
+		require.NoError(t, fs.WriteTombstones(opts.CommitLogOptions().FilesystemOptions(),
+			shard.namespace.ID(), shard.ID(), nil))
+	}
+}
+
+func TestShardUnreadableTombstonesRefuseReadsAndBootstrap(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	var (
+		filePathPrefix = opts.CommitLogOptions().FilesystemOptions().FilePathPrefix()
+		filePath       = fs.ShardTombstonesFilePath(filePathPrefix, defaultTestNs1ID, 0)
+	)
+	require.NoError(t, os.MkdirAll(path.Dir(filePath), opts.CommitLogOptions().
+		FilesystemOptions().NewDirectoryMode()))
+	require.NoError(t, ioutil.WriteFile(filePath, []byte("x"), 0644))
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	// The deleted series would be visible again if the shard was read from,
+	// bootstrapped or deleted from without the tombstones
+	now := shard.nowFn()
+	_, err := shard.ReadEncoded(ctx, ident.StringID("foo"), now.Add(-time.Minute), now)
+	require.Error(t, err)
+	err = shard.Bootstrap(result.NewMap(result.MapOptions{}))
+	require.Error(t, err)
+	require.Equal(t, BootstrapNotStarted, shard.BootstrapState())
+	_, err = shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, now)
+	require.Error(t, err)
+
+	// Once the tombstones can be read the shard serves reads and bootstraps
+	require.NoError(t, fs.WriteTombstones(opts.CommitLogOptions().FilesystemOptions(),
+		defaultTestNs1ID, 0, []fs.Tombstone{{ID: []byte("foo"), DeletedAt: now}}))
+	_, err = shard.ReadEncoded(ctx, ident.StringID("foo"), now.Add(-time.Minute), now)
+	require.NoError(t, err)
+	require.NoError(t, shard.Bootstrap(result.NewMap(result.MapOptions{})))
+	require.True(t, shard.IsSeriesDeleted(ident.StringID("foo")))
+}
+
+func TestShardDeleteSeriesReferencedPurgedOnTick(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	// Simulate a reader holding a reference to the series
+	addTestSeriesWithCount(shard, ident.StringID("foo"), 1)
+
+	_, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, shard.nowFn())
+	require.NoError(t, err)
+
+	// Removed from the lookup immediately but retained in the list until
+	// it is no longer referenced
+	require.Equal(t, 0, shard.lookup.Len())
+	require.Equal(t, 1, shard.list.Len())
+	require.Equal(t, 1, len(shard.deletedEntries))
+
+	r, err := shard.Tick(context.NewNoOpCanncellable(), shard.nowFn())
+	require.NoError(t, err)
+	require.Equal(t, 1, r.expiredSeries)
+	require.Equal(t, 1, shard.list.Len())
+
+	for entry := range shard.deletedEntries {
+		entry.DecrementReaderWriterCount()
+	}
+
+	_, err = shard.Tick(context.NewNoOpCanncellable(), shard.nowFn())
+	require.NoError(t, err)
+	require.Equal(t, 0, shard.list.Len())
+	require.Equal(t, 0, len(shard.deletedEntries))
+}
+
+func TestShardCleanupExpiredFileSetsExpiresTombstones(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.filesetBeforeFn = func(string, ident.ID, uint32, time.Time) ([]string, error) {
+		return nil, nil
+	}
+
+	now := shard.nowFn()
+	_, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, now)
+	require.NoError(t, err)
+	_, err = shard.DeleteSeries([]ident.ID{ident.StringID("bar")}, now.Add(time.Hour))
+	require.NoError(t, err)
+
+	require.NoError(t, shard.CleanupExpiredFileSets(now))
+	_, ok := shard.tombstones.get(ident.StringID("foo"))
+	require.False(t, ok)
+	_, ok = shard.tombstones.get(ident.StringID("bar"))
+	require.True(t, ok)
+
+	tombstones, err := fs.ReadTombstones(
+		opts.CommitLogOptions().FilesystemOptions().FilePathPrefix(),
+		shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tombstones))
+	require.Equal(t, []byte("bar"), tombstones[0].ID)
+}
+
+func TestShardDeleteSeriesMasksFlushedFileSets(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	var (
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+	)
+	testWriteFileSet(t, opts, shard, flushedStart, map[string][]ts.Datapoint{
+		"foo": {{Timestamp: flushedStart.Add(time.Minute), Value: 1}},
+	})
+
+	_, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, shard.nowFn())
+	require.NoError(t, err)
+
+	tombstone, ok := shard.tombstones.get(ident.StringID("foo"))
+	require.True(t, ok)
+	require.Equal(t, 1, len(tombstone.maskedBlockStarts))
+	require.True(t, tombstone.masksBlockStart(flushedStart))
+	require.False(t, tombstone.masksBlockStart(flushedStart.Add(blockSize)))
+
+	// The masked fileset is not read from
+	ctx := context.NewContext()
+	defer ctx.Close()
+	reader, err := shard.Stream(ctx, ident.StringID("foo"), flushedStart, nil)
+	require.NoError(t, err)
+	require.True(t, reader.IsEmpty())
+
+	tombstones, err := fs.ReadTombstones(
+		opts.CommitLogOptions().FilesystemOptions().FilePathPrefix(),
+		shard.namespace.ID(), shard.ID())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(tombstones))
+	require.Equal(t, 1, len(tombstones[0].MaskedBlockStarts))
+	require.True(t, flushedStart.Equal(tombstones[0].MaskedBlockStarts[0]))
+}
+
+func TestShardColdFlushDropsTombstonedData(t *testing.T) {
+	opts, cleanup := testDatabaseOptionsWithTempDir(t)
+	defer cleanup()
+	// The series are only on disk, which is not the case once bootstrapped
+	// if all series are cached
+	opts = opts.SetSeriesCachePolicy(series.CacheRecentlyRead)
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+	shard.bootstrapState = Bootstrapped
+	shard.seriesOpts = shard.seriesOpts.SetColdWritesEnabled(true)
+
+	var (
+		fsOpts       = opts.CommitLogOptions().FilesystemOptions()
+		blockSize    = shard.namespace.Options().RetentionOptions().BlockSize()
+		flushedStart = shard.nowFn().Truncate(blockSize).Add(-3 * blockSize)
+		deletedAt    = shard.nowFn()
+		fooOnDisk    = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 1}}
+		fooCold      = []ts.Datapoint{{Timestamp: flushedStart.Add(2 * time.Minute), Value: 2}}
+		barOnDisk    = []ts.Datapoint{{Timestamp: flushedStart.Add(time.Minute), Value: 3}}
+	)
+	testWriteFileSet(t, opts, shard, flushedStart, map[string][]ts.Datapoint{
+		"foo": fooOnDisk,
+		"bar": barOnDisk,
+	})
+	shard.markFlushStateSuccess(flushedStart)
+
+	_, err := shard.DeleteSeries([]ident.ID{ident.StringID("foo")}, deletedAt)
+	require.NoError(t, err)
+
+	// A replayed write that may have been made before the deletion is
+	// dropped while one made since is kept
+	ctx := context.NewContext()
+	defer ctx.Close()
+	require.NoError(t, shard.ReplayColdWrite(ctx, ident.StringID("foo"), ident.Tags{},
+		flushedStart.Add(3*time.Minute), 4, xtime.Second, nil, deletedAt.Add(-time.Minute)))
+	require.NoError(t, shard.ReplayColdWrite(ctx, ident.StringID("foo"), ident.Tags{},
+		fooCold[0].Timestamp, fooCold[0].Value, xtime.Second, nil, deletedAt))
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	flush, err := pm.StartDataPersist()
+	require.NoError(t, err)
+	require.NoError(t, shard.ColdFlush(flush))
+	require.NoError(t, flush.DoneData())
+
+	// The rewritten fileset only holds the data written since the deletion
+	// and is no longer masked
+	require.Equal(t, map[string][]ts.Datapoint{
+		"foo": fooCold,
+		"bar": barOnDisk,
+	}, testReadFileSet(t, opts, shard, flushedStart))
+	tombstone, ok := shard.tombstones.get(ident.StringID("foo"))
+	require.True(t, ok)
+	require.False(t, tombstone.masksBlockStart(flushedStart))
+	require.True(t, shard.tombstones.isDirty())
+}
+
+func TestFilterTombstonedBlocks(t *testing.T) {
+	opts := testDatabaseOptions()
+	var (
+		blockSize = time.Hour
+		start     = time.Now().Truncate(blockSize).Add(-5 * blockSize)
+		deletedAt = start.Add(3 * blockSize).Add(30 * time.Minute)
+		blocks    = block.NewDatabaseSeriesBlocks(0)
+		tombstone = shardTombstone{
+			deletedAt:         deletedAt,
+			maskedBlockStarts: []time.Time{start},
+		}
+		isFlushed = func(blockStart time.Time) bool {
+			return blockStart.Before(start.Add(2 * blockSize))
+		}
+	)
+	for i := 0; i < 5; i++ {
+		blockStart := start.Add(time.Duration(i) * blockSize)
+		blocks.AddBlock(testEncodedBlock(t, opts, blockStart, blockSize, []ts.Datapoint{
+			{Timestamp: blockStart.Add(10 * time.Minute), Value: 1},
+			{Timestamp: blockStart.Add(40 * time.Minute), Value: 2},
+		}))
+	}
+
+	require.NoError(t, filterTombstonedBlocks(blocks, tombstone, isFlushed, opts))
+	require.Equal(t, 3, blocks.Len())
+
+	// The block read from the masked fileset is dropped
+	_, ok := blocks.BlockAt(start)
+	require.False(t, ok)
+
+	// The block read from a fileset written since the deletion is kept
+	flushed, ok := blocks.BlockAt(start.Add(blockSize))
+	require.True(t, ok)
+	require.Equal(t, 2, len(testBlockDatapoints(t, opts, flushed)))
+
+	// The unflushed blocks ending before the deletion are dropped and only
+	// the datapoints since the deletion remain in the block spanning it
+	_, ok = blocks.BlockAt(start.Add(2 * blockSize))
+	require.False(t, ok)
+	spanning, ok := blocks.BlockAt(start.Add(3 * blockSize))
+	require.True(t, ok)
+	require.Equal(t, []ts.Datapoint{
+		{Timestamp: start.Add(3 * blockSize).Add(40 * time.Minute), Value: 2},
+	}, testBlockDatapoints(t, opts, spanning))
+
+	last, ok := blocks.BlockAt(start.Add(4 * blockSize))
+	require.True(t, ok)
+	require.Equal(t, 2, len(testBlockDatapoints(t, opts, last)))
+}
+
+func testEncodedBlock(
+	t *testing.T,
+	opts Options,
+	start time.Time,
+	blockSize time.Duration,
+	dps []ts.Datapoint,
+) block.DatabaseBlock {
+	bopts := opts.DatabaseBlockOptions()
+	encoder := bopts.EncoderPool().Get()
+	encoder.Reset(start, 0)
+	for _, dp := range dps {
+		require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+	}
+	return block.NewDatabaseBlock(start, blockSize, encoder.Discard(), bopts)
+}
+
+func testBlockDatapoints(
+	t *testing.T,
+	opts Options,
+	b block.DatabaseBlock,
+) []ts.Datapoint {
+	ctx := context.NewContext()
+	defer ctx.Close()
+
+	stream, err := b.Stream(ctx)
+	require.NoError(t, err)
+
+	iter := opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	iter.Reset([]xio.SegmentReader{stream.SegmentReader}, b.StartTime(), b.BlockSize())
+
+	var dps []ts.Datapoint
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		dps = append(dps, ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+	}
+	require.NoError(t, iter.Err())
+	return dps
+}
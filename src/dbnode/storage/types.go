@@ -111,6 +111,15 @@ type Database interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the unique tag names, and
+	// optionally tag values, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
 	// ReadEncoded retrieves encoded segments for an ID
 	ReadEncoded(
 		ctx context.Context,
@@ -169,6 +178,22 @@ type Database interface {
 	// Truncate truncates data for the given namespace
 	Truncate(namespace ident.ID) (int64, error)
 
+	// Delete deletes the series with the given IDs from the namespace,
+	// returning the number of series deleted.
+	Delete(
+		ctx context.Context,
+		namespace ident.ID,
+		ids []ident.ID,
+	) (int64, error)
+
+	// DeleteTagged deletes the series matching the query from the namespace,
+	// returning the number of series deleted.
+	DeleteTagged(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+	) (int64, error)
+
 	// BootstrapState captures and returns a snapshot of the databases' bootstrap state.
 	BootstrapState() DatabaseBootstrapState
 }
@@ -254,6 +279,21 @@ type databaseNamespace interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the unique tag names, and
+	// optionally tag values, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
+	// Delete deletes the series with the given IDs, tombstoning any of their
+	// data that has been persisted.
+	Delete(ctx context.Context, ids []ident.ID) (int64, error)
+
+	// DeleteTagged deletes the series matching the query.
+	DeleteTagged(ctx context.Context, query index.Query) (int64, error)
+
 	// ReadEncoded reads data for given id within [start, end)
 	ReadEncoded(
 		ctx context.Context,
@@ -304,6 +344,14 @@ type databaseNamespace interface {
 		flush persist.IndexFlush,
 	) error
 
+	// ColdFlush merges the writes made for already flushed block starts
+	// into their filesets.
+	ColdFlush(flush persist.DataFlush) error
+
+	// ColdFlushedBefore returns the system time before which all the cold
+	// writes made to the namespace have been merged into their filesets.
+	ColdFlushedBefore() time.Time
+
 	// Snapshot snapshots unflushed in-memory data
 	Snapshot(blockStart, snapshotTime time.Time, flush persist.DataFlush) error
 
@@ -417,6 +465,20 @@ type databaseShard interface {
 		bootstrappedSeries *result.Map,
 	) error
 
+	// ReplayColdWrite writes a datapoint read back from the commit log for
+	// a flushed block start, the datapoint is not written to the commit log.
+	// The datapoint was written no earlier than writtenSince.
+	ReplayColdWrite(
+		ctx context.Context,
+		id ident.ID,
+		tags ident.Tags,
+		timestamp time.Time,
+		value float64,
+		unit xtime.Unit,
+		annotation []byte,
+		writtenSince time.Time,
+	) error
+
 	// Flush flushes the series' in this shard.
 	Flush(
 		blockStart time.Time,
@@ -426,6 +488,10 @@ type databaseShard interface {
 	// Snapshot snapshot's the unflushed series' in this shard.
 	Snapshot(blockStart, snapshotStart time.Time, flush persist.DataFlush) error
 
+	// ColdFlush rewrites the filesets of the flushed block starts the series'
+	// in this shard have cold writes for, merging in the cold writes.
+	ColdFlush(flush persist.DataFlush) error
+
 	// FlushState returns the flush state for this shard at block start.
 	FlushState(blockStart time.Time) fileOpState
 
@@ -435,15 +501,32 @@ type databaseShard interface {
 	// CleanupSnapshots cleans up snapshot files.
 	CleanupSnapshots(earliestToRetain time.Time) error
 
-	// CleanupExpiredFileSets removes expired fileset files.
+	// CleanupExpiredFileSets removes expired fileset files and the fileset
+	// volumes superseded by a later volume.
 	CleanupExpiredFileSets(earliestToRetain time.Time) error
 
+	// DeleteSeries removes the series with the given IDs from the shard and
+	// tombstones their datapoints with a timestamp before deletedAt.
+	DeleteSeries(ids []ident.ID, deletedAt time.Time) (int64, error)
+
+	// IsSeriesDeleted returns whether the series has been deleted and not
+	// written to since.
+	IsSeriesDeleted(id ident.ID) bool
+
+	// HasDeletedSeries returns whether any series has been deleted and not
+	// written to since.
+	HasDeletedSeries() bool
+
 	// Repair repairs the shard data for a given time.
 	Repair(
 		ctx context.Context,
 		tr xtime.Range,
 		repairer databaseShardRepairer,
 	) (repair.MetadataComparisonResult, error)
+
+	// LoadRepairedBlocks merges blocks fetched from peers during a repair
+	// into the shard, rewriting the filesets of block starts already flushed.
+	LoadRepairedBlocks(repaired result.ShardResult, flush persist.DataFlush) error
 }
 
 // namespaceIndex indexes namespace writes.
@@ -466,6 +549,14 @@ type namespaceIndex interface {
 		opts index.QueryOptions,
 	) (index.QueryResults, error)
 
+	// AggregateQuery resolves the given query into the unique tag names, and
+	// optionally tag values, of the matching series.
+	AggregateQuery(
+		ctx context.Context,
+		query index.Query,
+		opts index.AggregateQueryOptions,
+	) (index.AggregateQueryResult, error)
+
 	// Bootstrap bootstraps the index the provided segments.
 	Bootstrap(
 		bootstrapResults result.IndexResults,
@@ -585,7 +676,7 @@ type databaseShardRepairer interface {
 	// Repair repairs the data for a given namespace and shard
 	Repair(
 		ctx context.Context,
-		namespace ident.ID,
+		nsMeta namespace.Metadata,
 		tr xtime.Range,
 		shard databaseShard,
 	) (repair.MetadataComparisonResult, error)
@@ -192,7 +192,7 @@ func (r *fsSegment) ContainsID(docID []byte) (bool, error) {
 	return exists, fstCloser.Close()
 }
 
-func (r *fsSegment) Reader() (index.Reader, error) {
+func (r *fsSegment) Reader() (sgmt.Reader, error) {
 	r.RLock()
 	defer r.RUnlock()
 	if r.closed {
@@ -506,7 +506,7 @@ type fsSegmentReader struct {
 	fsSegment *fsSegment
 }
 
-var _ index.Reader = &fsSegmentReader{}
+var _ sgmt.Reader = &fsSegmentReader{}
 
 func (sr *fsSegmentReader) MatchTerm(field []byte, term []byte) (postings.List, error) {
 	sr.RLock()
@@ -562,6 +562,24 @@ func (sr *fsSegmentReader) AllDocs() (index.IDDocIterator, error) {
 	return sr.fsSegment.AllDocs()
 }
 
+func (sr *fsSegmentReader) Fields() (sgmt.FieldsIterator, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.Fields()
+}
+
+func (sr *fsSegmentReader) Terms(field []byte) (sgmt.TermsIterator, error) {
+	sr.RLock()
+	defer sr.RUnlock()
+	if sr.closed {
+		return nil, errReaderClosed
+	}
+	return sr.fsSegment.Terms(field)
+}
+
 func (sr *fsSegmentReader) Close() error {
 	sr.Lock()
 	defer sr.Unlock()
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/m3ninx/doc"
 	"github.com/m3db/m3/src/m3ninx/index"
+	sgmt "github.com/m3db/m3/src/m3ninx/index/segment"
 	"github.com/m3db/m3/src/m3ninx/postings"
 )
 
@@ -48,7 +49,7 @@ type readerDocRange struct {
 	endExclusive   postings.ID
 }
 
-func newReader(s ReadableSegment, l readerDocRange, p postings.Pool) index.Reader {
+func newReader(s ReadableSegment, l readerDocRange, p postings.Pool) sgmt.Reader {
 	return &reader{
 		segment: s,
 		limits:  l,
@@ -134,6 +135,29 @@ func (r *reader) AllDocs() (index.IDDocIterator, error) {
 	return r.getDocIterWithLock(pi), nil
 }
 
+func (r *reader) Fields() (sgmt.FieldsIterator, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	// NB: the terms dictionary is not bounded by the reader's limits so the
+	// fields and terms returned may include those of documents inserted after
+	// the reader was created.
+	return r.segment.fields()
+}
+
+func (r *reader) Terms(field []byte) (sgmt.TermsIterator, error) {
+	r.RLock()
+	defer r.RUnlock()
+	if r.closed {
+		return nil, errSegmentReaderClosed
+	}
+
+	return r.segment.terms(field)
+}
+
 func (r *reader) getDocIterWithLock(iter postings.Iterator) index.IDDocIterator {
 	return index.NewIDDocIterator(r, iter)
 }
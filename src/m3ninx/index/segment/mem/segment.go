@@ -299,7 +299,7 @@ func (s *segment) storeDocWithStateLock(id postings.ID, d doc.Document) {
 	}
 }
 
-func (s *segment) Reader() (index.Reader, error) {
+func (s *segment) Reader() (sgmt.Reader, error) {
 	s.state.RLock()
 	defer s.state.RUnlock()
 	if s.state.closed {
@@ -360,6 +360,26 @@ func (s *segment) getDoc(id postings.ID) (doc.Document, error) {
 	return d, nil
 }
 
+func (s *segment) fields() (sgmt.FieldsIterator, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.Fields(), nil
+}
+
+func (s *segment) terms(field []byte) (sgmt.TermsIterator, error) {
+	s.state.RLock()
+	defer s.state.RUnlock()
+	if s.state.closed {
+		return nil, sgmt.ErrClosed
+	}
+
+	return s.termsDict.Terms(field), nil
+}
+
 func (s *segment) Close() error {
 	s.state.Lock()
 	defer s.state.Unlock()
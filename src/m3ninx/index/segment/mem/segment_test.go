@@ -785,6 +785,50 @@ func TestSegmentTerms(t *testing.T) {
 	}
 }
 
+func TestSegmentReaderFieldsAndTermsUnsealed(t *testing.T) {
+	segment, err := NewSegment(0, testOptions)
+	require.NoError(t, err)
+
+	knownsFields := map[string]map[string]struct{}{}
+	for _, d := range testDocuments {
+		for _, f := range d.Fields {
+			knownVals, ok := knownsFields[string(f.Name)]
+			if !ok {
+				knownVals = make(map[string]struct{})
+				knownsFields[string(f.Name)] = knownVals
+			}
+			knownVals[string(f.Value)] = struct{}{}
+		}
+		_, err = segment.Insert(d)
+		require.NoError(t, err)
+	}
+
+	r, err := segment.Reader()
+	require.NoError(t, err)
+
+	fieldsIter, err := r.Fields()
+	require.NoError(t, err)
+	fields := map[string]struct{}{}
+	for _, f := range toSlice(t, fieldsIter) {
+		fields[string(f)] = struct{}{}
+	}
+
+	for field, expectedTerms := range knownsFields {
+		require.Contains(t, fields, field)
+
+		termsIter, err := r.Terms([]byte(field))
+		require.NoError(t, err)
+		for _, term := range toSlice(t, termsIter) {
+			delete(expectedTerms, string(term))
+		}
+		require.Empty(t, expectedTerms)
+	}
+
+	require.NoError(t, r.Close())
+	_, err = r.Fields()
+	require.Error(t, err)
+}
+
 func TestSegmentReaderMatchRegex(t *testing.T) {
 	docs := testDocuments
 	segment, err := NewSegment(0, testOptions)
@@ -67,4 +67,11 @@ type ReadableSegment interface {
 
 	// getDoc returns the document associated with the given ID.
 	getDoc(id postings.ID) (doc.Document, error)
+
+	// fields returns an iterator over the fields known at the time of the call.
+	fields() (sgmt.FieldsIterator, error)
+
+	// terms returns an iterator over the terms of the given field known at the
+	// time of the call.
+	terms(field []byte) (sgmt.TermsIterator, error)
 }
@@ -42,7 +42,7 @@ type Segment interface {
 	ContainsID(docID []byte) (bool, error)
 
 	// Reader returns a point-in-time accessor to search the segment.
-	Reader() (index.Reader, error)
+	Reader() (Reader, error)
 
 	// Fields returns an iterator over the list of known fields.
 	Fields() (FieldsIterator, error)
@@ -54,6 +54,18 @@ type Segment interface {
 	Close() error
 }
 
+// Reader is a point-in-time accessor to search a segment which can also
+// iterate the segment's terms dictionary.
+type Reader interface {
+	index.Reader
+
+	// Fields returns an iterator over the list of known fields.
+	Fields() (FieldsIterator, error)
+
+	// Terms returns an iterator over the known terms values for the given field.
+	Terms(field []byte) (TermsIterator, error)
+}
+
 // OrderedBytesIterator iterates over a collection of []bytes in lexicographical order.
 type OrderedBytesIterator interface {
 	// Next returns a bool indicating if there are any more elements.
@@ -61,6 +61,16 @@ func WriteJSONResponse(w http.ResponseWriter, data interface{}, logger *zap.Logg
 	w.Write(jsonData)
 }
 
+// SetWarningsHeader sets the warnings header to the given warnings, the
+// header is left unset if there are none.
+func SetWarningsHeader(w http.ResponseWriter, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	w.Header().Set(WarningsHeader, strings.Join(warnings, ", "))
+}
+
 // WriteProtoMsgJSONResponse writes a protobuf message to the ResponseWriter. This uses jsonpb
 // for json marshalling, which encodes fields with default values, even with the omitempty tag.
 func WriteProtoMsgJSONResponse(w http.ResponseWriter, data proto.Message, logger *zap.Logger) {
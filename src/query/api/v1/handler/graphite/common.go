@@ -0,0 +1,98 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package graphite contains the graphite compatible render and find handlers.
+package graphite
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+)
+
+const (
+	fromParam  = "from"
+	untilParam = "until"
+
+	// defaultLookback is how far back requests go when from is not set,
+	// this matches the default of graphite
+	defaultLookback = 24 * time.Hour
+
+	formatErrStr = "error parsing param: %s, error: %v"
+)
+
+// parseTimeRange parses the from and until params of a request
+func parseTimeRange(r *http.Request, now time.Time) (time.Time, time.Time, *handler.ParseError) {
+	from, err := parseTime(r.FormValue(fromParam), now, now.Add(-defaultLookback))
+	if err != nil {
+		return from, now, handler.NewParseError(fmt.Errorf(formatErrStr, fromParam, err), http.StatusBadRequest)
+	}
+
+	until, err := parseTime(r.FormValue(untilParam), now, now)
+	if err != nil {
+		return from, until, handler.NewParseError(fmt.Errorf(formatErrStr, untilParam, err), http.StatusBadRequest)
+	}
+
+	if !from.Before(until) {
+		return from, until, handler.NewParseError(
+			fmt.Errorf("%s: from must be before until", handler.ErrInvalidParams),
+			http.StatusBadRequest,
+		)
+	}
+
+	return from, until, nil
+}
+
+// parseTime parses a graphite time, which is either "now", an interval
+// relative to now such as "-1h" or "now-1h", or seconds since the epoch
+func parseTime(str string, now, defaultTime time.Time) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return defaultTime, nil
+	}
+
+	if str == "now" {
+		return now, nil
+	}
+
+	if strings.HasPrefix(str, "now") {
+		str = str[len("now"):]
+	}
+
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		offset, err := graphite.ParseInterval(str)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(offset), nil
+	}
+
+	seconds, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time: %s", str)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// FindURL is the url for the graphite metrics find endpoint
+	FindURL = handler.RoutePrefixV1 + "/graphite/metrics/find"
+
+	queryParam = "query"
+)
+
+// FindHTTPMethods are the HTTP methods used with this resource.
+var FindHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+type findHandler struct {
+	store storage.Storage
+}
+
+// findResult is a node of the graphite metrics tree in the treejson format
+type findResult struct {
+	ID            string   `json:"id"`
+	Text          string   `json:"text"`
+	Leaf          int      `json:"leaf"`
+	Expandable    int      `json:"expandable"`
+	AllowChildren int      `json:"allowChildren"`
+	Context       struct{} `json:"context"`
+}
+
+// NewFindHandler returns a new instance of the find handler.
+func NewFindHandler(store storage.Storage) http.Handler {
+	return &findHandler{store: store}
+}
+
+func (h *findHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	query := r.FormValue(queryParam)
+	if query == "" {
+		handler.Error(w, fmt.Errorf("%s: missing '%s'", handler.ErrInvalidParams, queryParam), http.StatusBadRequest)
+		return
+	}
+
+	matchers, err := graphite.NodeMatchers(query)
+	if err != nil {
+		handler.Error(w, fmt.Errorf(formatErrStr, queryParam, err), http.StatusBadRequest)
+		return
+	}
+
+	from, until, rErr := parseTimeRange(r, time.Now())
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	// The matched node is a leaf of the series which end there and a branch
+	// of those with further nodes
+	depth := len(matchers)
+	leaves, leafWarnings, err := h.completeNode(ctx, matchers, depth, false, from, until)
+	if err != nil {
+		logger.Error("unable to find leaves", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	branches, branchWarnings, err := h.completeNode(ctx, matchers, depth, true, from, until)
+	if err != nil {
+		logger.Error("unable to find branches", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	warnings := append(leafWarnings, branchWarnings...)
+	handler.SetWarningsHeader(w, warnings.Strings())
+	handler.WriteJSONResponse(w, findResults(query, leaves, branches), logger)
+}
+
+// completeNode returns the values of the last node matched by the matchers
+// for the series with or without a node after it, along with the warnings
+// of any stores left out of the values
+func (h *findHandler) completeNode(
+	ctx context.Context,
+	matchers models.Matchers,
+	depth int,
+	hasChildren bool,
+	from, until time.Time,
+) ([]string, storage.Warnings, error) {
+	childMatcher, err := graphite.AbsentMatcher(depth)
+	if hasChildren {
+		childMatcher, err = graphite.PresentMatcher(depth)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tagMatchers := make(models.Matchers, 0, len(matchers)+1)
+	tagMatchers = append(tagMatchers, matchers...)
+	tagMatchers = append(tagMatchers, childMatcher)
+
+	name := graphite.TagName(depth - 1)
+	result, err := h.store.CompleteTags(ctx, &storage.CompleteTagsQuery{
+		FilterNameTags: [][]byte{[]byte(name)},
+		TagMatchers:    tagMatchers,
+		Start:          from,
+		End:            until,
+	}, &storage.FetchOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var values []string
+	for _, tag := range result.CompletedTags {
+		if string(tag.Name) != name {
+			continue
+		}
+
+		for _, value := range tag.Values {
+			values = append(values, string(value))
+		}
+	}
+
+	return values, result.Warnings, nil
+}
+
+// findResults builds the tree nodes for the values of the last node of the
+// query; the other nodes of each ID are taken from the query as is
+func findResults(query string, leaves, branches []string) []findResult {
+	prefix := ""
+	if idx := strings.LastIndex(query, "."); idx >= 0 {
+		prefix = query[:idx+1]
+	}
+
+	results := make([]findResult, 0, len(leaves)+len(branches))
+	for _, leaf := range leaves {
+		results = append(results, findResult{
+			ID:   prefix + leaf,
+			Text: leaf,
+			Leaf: 1,
+		})
+	}
+
+	for _, branch := range branches {
+		results = append(results, findResult{
+			ID:            prefix + branch,
+			Text:          branch,
+			Expandable:    1,
+			AllowChildren: 1,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Text < results[j].Text
+	})
+
+	return results
+}
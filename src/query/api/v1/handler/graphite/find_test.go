@@ -0,0 +1,151 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pathStorage completes tags over a fixed set of graphite paths, a negated
+// matcher on an absent tag matches as it does for the index
+type pathStorage struct {
+	mock.Storage
+	series []models.Tags
+}
+
+func newPathStorage(t *testing.T, paths ...string) *pathStorage {
+	s := &pathStorage{Storage: mock.NewMockStorage()}
+	for _, path := range paths {
+		tags, err := graphite.TagsFromPath(path)
+		require.NoError(t, err)
+		s.series = append(s.series, tags)
+	}
+
+	return s
+}
+
+func matches(tags models.Tags, matchers models.Matchers) bool {
+	for _, m := range matchers {
+		value, ok := tags.Get(m.Name)
+		negated := m.Type == models.MatchNotEqual || m.Type == models.MatchNotRegexp
+		if !ok {
+			if negated {
+				continue
+			}
+
+			return false
+		}
+
+		if !m.Matches(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *pathStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	_ *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	builder := storage.NewCompleteTagsResultBuilder(false)
+	for _, tags := range s.series {
+		if !matches(tags, query.TagMatchers) {
+			continue
+		}
+
+		for _, name := range query.FilterNameTags {
+			if value, ok := tags.Get(string(name)); ok {
+				err := builder.Add(&storage.CompleteTagsResult{
+					CompletedTags: []storage.CompletedTag{{Name: name, Values: [][]byte{[]byte(value)}}},
+				})
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	result := builder.Build()
+	return &result, nil
+}
+
+func TestFind(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newPathStorage(t,
+		"foo.bar.baz",
+		"foo.bar",
+		"foo.qux",
+		"foo.quz.a",
+		"other.bar",
+	)
+
+	req := httptest.NewRequest(http.MethodGet, FindURL+"?query=foo.*", nil)
+	res := httptest.NewRecorder()
+	NewFindHandler(store).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `[`+
+		`{"id":"foo.bar","text":"bar","leaf":1,"expandable":0,"allowChildren":0,"context":{}},`+
+		`{"id":"foo.bar","text":"bar","leaf":0,"expandable":1,"allowChildren":1,"context":{}},`+
+		`{"id":"foo.qux","text":"qux","leaf":1,"expandable":0,"allowChildren":0,"context":{}},`+
+		`{"id":"foo.quz","text":"quz","leaf":0,"expandable":1,"allowChildren":1,"context":{}}`+
+		`]`, res.Body.String())
+}
+
+func TestFindRoot(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req := httptest.NewRequest(http.MethodGet, FindURL+"?query=*", nil)
+	res := httptest.NewRecorder()
+	NewFindHandler(newPathStorage(t, "foo.bar", "other")).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `[`+
+		`{"id":"foo","text":"foo","leaf":0,"expandable":1,"allowChildren":1,"context":{}},`+
+		`{"id":"other","text":"other","leaf":1,"expandable":0,"allowChildren":0,"context":{}}`+
+		`]`, res.Body.String())
+}
+
+func TestFindErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	for _, query := range []string{"", "foo..bar", "foo.{bar"} {
+		req := httptest.NewRequest(http.MethodGet, FindURL, nil)
+		req.URL.RawQuery = "query=" + query
+		res := httptest.NewRecorder()
+		NewFindHandler(newPathStorage(t)).ServeHTTP(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code, query)
+	}
+}
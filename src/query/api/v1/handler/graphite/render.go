@@ -0,0 +1,211 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	graphiteparser "github.com/m3db/m3/src/query/parser/graphite"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// RenderURL is the url for the graphite render endpoint
+	RenderURL = handler.RoutePrefixV1 + "/graphite/render"
+
+	targetParam        = "target"
+	formatParam        = "format"
+	maxDataPointsParam = "maxDataPoints"
+
+	jsonFormat = "json"
+
+	// minStep is the finest resolution series are rendered at
+	minStep = 10 * time.Second
+
+	// defaultMaxDataPoints bounds the number of datapoints of each series,
+	// coarsening the step of requests over long time ranges
+	defaultMaxDataPoints = 1440
+)
+
+// RenderHTTPMethods are the HTTP methods used with this resource.
+var RenderHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+type renderHandler struct {
+	engine *executor.Engine
+}
+
+type renderRequest struct {
+	targets []string
+	params  models.RequestParams
+}
+
+// NewRenderHandler returns a new instance of the render handler.
+func NewRenderHandler(engine *executor.Engine) http.Handler {
+	return &renderHandler{engine: engine}
+}
+
+func (h *renderHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	req, rErr := parseRenderRequest(r, time.Now())
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	results := make([]*ts.Series, 0, len(req.targets))
+	for _, target := range req.targets {
+		p, err := graphiteparser.Parse(target)
+		if err != nil {
+			handler.Error(w, fmt.Errorf("invalid target %s: %v", target, err), http.StatusBadRequest)
+			return
+		}
+
+		params := req.params
+		params.Query = target
+		series, err := native.ReadParsed(ctx, h.engine, w, p, params)
+		if err != nil {
+			logger.Error("unable to render target", zap.String("target", target), zap.Error(err))
+			handler.Error(w, err, http.StatusBadRequest)
+			return
+		}
+
+		results = append(results, series...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	renderSeriesJSON(w, results)
+}
+
+func parseRenderRequest(r *http.Request, now time.Time) (renderRequest, *handler.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return renderRequest{}, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	req := renderRequest{
+		targets: r.Form[targetParam],
+		params:  models.RequestParams{Now: now},
+	}
+
+	if len(req.targets) == 0 {
+		return req, handler.NewParseError(
+			fmt.Errorf("%s: missing '%s'", handler.ErrInvalidParams, targetParam),
+			http.StatusBadRequest,
+		)
+	}
+
+	if format := r.FormValue(formatParam); format != "" && format != jsonFormat {
+		return req, handler.NewParseError(
+			fmt.Errorf("%s: unsupported '%s': %s", handler.ErrInvalidParams, formatParam, format),
+			http.StatusBadRequest,
+		)
+	}
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return req, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	req.params.Timeout = timeout
+
+	sp, err := prometheus.ParseStoragePolicy(r)
+	if err != nil {
+		return req, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	req.params.StoragePolicy = sp
+
+	from, until, rErr := parseTimeRange(r, now)
+	if rErr != nil {
+		return req, rErr
+	}
+	req.params.Start = from
+	req.params.End = until
+
+	maxDataPoints := defaultMaxDataPoints
+	if str := r.FormValue(maxDataPointsParam); str != "" {
+		maxDataPoints, err = strconv.Atoi(str)
+		if err != nil || maxDataPoints <= 0 {
+			return req, handler.NewParseError(
+				fmt.Errorf("%s: invalid '%s': %s", handler.ErrInvalidParams, maxDataPointsParam, str),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	req.params.Step = renderStep(until.Sub(from), maxDataPoints)
+	return req, nil
+}
+
+// renderStep returns the step for a time range, which is the finest whole
+// number of seconds keeping series within maxDataPoints
+func renderStep(timeRange time.Duration, maxDataPoints int) time.Duration {
+	step := timeRange / time.Duration(maxDataPoints)
+	if rounded := step.Truncate(time.Second); rounded < step {
+		step = rounded + time.Second
+	}
+
+	if step < minStep {
+		return minStep
+	}
+
+	return step
+}
+
+// renderSeriesJSON writes series in the graphite json render format, the
+// writer renders points with no value as null; points are not clipped to the start of
+// the request since summarized buckets may begin before it
+func renderSeriesJSON(w io.Writer, series []*ts.Series) {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+	for _, s := range series {
+		jw.BeginObject()
+		jw.BeginObjectField("target")
+		jw.WriteString(s.Name())
+
+		jw.BeginObjectField("datapoints")
+		jw.BeginArray()
+		vals := s.Values()
+		for i := 0; i < vals.Len(); i++ {
+			dp := vals.DatapointAt(i)
+			jw.BeginArray()
+			jw.WriteFloat64(dp.Value)
+			jw.WriteInt(int(dp.Timestamp.Unix()))
+			jw.EndArray()
+		}
+		jw.EndArray()
+
+		jw.EndObject()
+	}
+	jw.EndArray()
+	jw.Close()
+}
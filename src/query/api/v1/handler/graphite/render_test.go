@@ -0,0 +1,169 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRenderRequest(values url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, RenderURL, nil)
+	req.URL.RawQuery = values.Encode()
+	return req
+}
+
+func newRenderStorage() mock.Storage {
+	bounds := block.Bounds{
+		Start:    time.Unix(1500000000, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	metas := []block.SeriesMeta{
+		{Name: "a", Tags: models.Tags{{Name: "__g0__", Value: "foo"}, {Name: "__g1__", Value: "a"}}},
+		{Name: "b", Tags: models.Tags{{Name: "__g0__", Value: "foo"}, {Name: "__g1__", Value: "b"}}},
+	}
+
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, [][]float64{
+		{1, math.NaN(), 3},
+		{2, 2, math.NaN()},
+	})
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	return store
+}
+
+func TestRender(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewRenderHandler(executor.NewEngine(newRenderStorage()))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, newRenderRequest(url.Values{
+		"target": []string{"foo.*", "sumSeries(foo.*)"},
+		"from":   []string{"1500000000"},
+		"until":  []string{"1500000180"},
+	}))
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, "application/json", res.Header().Get("Content-Type"))
+	assert.Equal(t, `[`+
+		`{"target":"foo.a","datapoints":[[1.000000,1500000000],[null,1500000060],[3.000000,1500000120]]},`+
+		`{"target":"foo.b","datapoints":[[2.000000,1500000000],[2.000000,1500000060],[null,1500000120]]},`+
+		`{"target":"sumSeries(foo.*)","datapoints":[[3.000000,1500000000],[2.000000,1500000060],[3.000000,1500000120]]}`+
+		`]`, res.Body.String())
+}
+
+func TestRenderSeriesLists(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	// Both paths read the same series from the test storage
+	h := NewRenderHandler(executor.NewEngine(newRenderStorage()))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, newRenderRequest(url.Values{
+		"target": []string{"sumSeries(foo.*, bar.*)"},
+		"from":   []string{"1500000000"},
+		"until":  []string{"1500000180"},
+	}))
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `[`+
+		`{"target":"sumSeries(foo.*,bar.*)","datapoints":[[6.000000,1500000000],[4.000000,1500000060],[6.000000,1500000120]]}`+
+		`]`, res.Body.String())
+}
+
+func TestRenderErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewRenderHandler(executor.NewEngine(newRenderStorage()))
+	for _, values := range []url.Values{
+		{},
+		{"target": []string{"foo.*"}, "format": []string{"png"}},
+		{"target": []string{"foo.*"}, "from": []string{"yesterday"}},
+		{"target": []string{"foo.*"}, "from": []string{"-1h"}, "until": []string{"-2h"}},
+		{"target": []string{"foo.*"}, "maxDataPoints": []string{"0"}},
+		{"target": []string{"sumSeries(foo.*"}},
+		{"target": []string{"noSuchFunction(foo.*)"}},
+	} {
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, newRenderRequest(values))
+		assert.Equal(t, http.StatusBadRequest, res.Code, "%v", values)
+	}
+}
+
+func TestParseRenderRequest(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	req, err := parseRenderRequest(newRenderRequest(url.Values{
+		"target":        []string{"foo.*"},
+		"from":          []string{"-7d"},
+		"maxDataPoints": []string{"100"},
+	}), now)
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{"foo.*"}, req.targets)
+	assert.Equal(t, now.Add(-7*24*time.Hour), req.params.Start)
+	assert.Equal(t, now, req.params.End)
+	assert.Equal(t, 7*24*time.Hour/100, req.params.Step)
+
+	// Short ranges are not rendered finer than the minimum step
+	req, err = parseRenderRequest(newRenderRequest(url.Values{
+		"target": []string{"foo.*"},
+		"from":   []string{"-5min"},
+	}), now)
+	require.Nil(t, err)
+	assert.Equal(t, minStep, req.params.Step)
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	defaultTime := time.Unix(1, 0)
+	for _, test := range []struct {
+		str      string
+		expected time.Time
+	}{
+		{str: "", expected: defaultTime},
+		{str: "now", expected: now},
+		{str: "-1h", expected: now.Add(-time.Hour)},
+		{str: "now-30min", expected: now.Add(-30 * time.Minute)},
+		{str: "1400000000", expected: time.Unix(1400000000, 0)},
+	} {
+		parsed, err := parseTime(test.str, now, defaultTime)
+		require.NoError(t, err, test.str)
+		assert.Equal(t, test.expected, parsed, test.str)
+	}
+
+	_, err := parseTime("12:00_20180101", now, defaultTime)
+	assert.Error(t, err)
+}
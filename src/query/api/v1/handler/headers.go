@@ -32,4 +32,8 @@ const (
 
 	// DeprecatedHeader is the M3 deprecated header
 	DeprecatedHeader = "M3-Deprecated"
+
+	// StoragePolicyHeader is the M3 storage policy header to restrict a
+	// query to the namespace with a storage policy, e.g. "1m:40d"
+	StoragePolicyHeader = "M3-Storage-Policy"
 )
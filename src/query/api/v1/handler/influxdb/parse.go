@@ -0,0 +1,269 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package influxdb
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	xtime "github.com/m3db/m3x/time"
+)
+
+var (
+	errMissingMeasurement = errors.New("missing measurement")
+	errMissingFields      = errors.New("missing fields")
+	errMissingTagValue    = errors.New("missing tag value")
+	errMissingFieldValue  = errors.New("missing field value")
+	errEmptyKey           = errors.New("empty tag or field key")
+	errUnterminatedString = errors.New("unterminated string field value")
+)
+
+// point is a single line of the line protocol.
+type point struct {
+	measurement string
+	tags        models.Tags
+	fields      []field
+	timestamp   time.Time
+}
+
+// field is a numeric field of a point, string fields are not stored.
+type field struct {
+	key   string
+	value float64
+}
+
+// precision is the unit of the timestamps of a write request.
+type precision struct {
+	duration time.Duration
+	unit     xtime.Unit
+}
+
+// parsePrecision parses the precision query parameter of a write request,
+// timestamps are in nanoseconds if it is unset.
+func parsePrecision(str string) (precision, error) {
+	switch str {
+	case "", "n", "ns":
+		return precision{duration: time.Nanosecond, unit: xtime.Nanosecond}, nil
+	case "u", "us", "µ":
+		return precision{duration: time.Microsecond, unit: xtime.Microsecond}, nil
+	case "ms":
+		return precision{duration: time.Millisecond, unit: xtime.Millisecond}, nil
+	case "s":
+		return precision{duration: time.Second, unit: xtime.Second}, nil
+	case "m":
+		return precision{duration: time.Minute, unit: xtime.Second}, nil
+	case "h":
+		return precision{duration: time.Hour, unit: xtime.Second}, nil
+	default:
+		return precision{}, fmt.Errorf("invalid precision: %s", str)
+	}
+}
+
+// parseLine parses a line of the form
+// "measurement[,tag=value...] field=value[,field=value...] [timestamp]",
+// the timestamp is in units of the precision and defaults to now.
+func parseLine(line []byte, p precision, now time.Time) (point, error) {
+	var (
+		result point
+		i      int
+	)
+
+	result.measurement, i = scanKey(line, 0, ", ")
+	if result.measurement == "" {
+		return point{}, errMissingMeasurement
+	}
+
+	for i < len(line) && line[i] == ',' {
+		var name, value string
+		name, i = scanKey(line, i+1, ",= ")
+		if name == "" {
+			return point{}, errEmptyKey
+		}
+		if i >= len(line) || line[i] != '=' {
+			return point{}, errMissingTagValue
+		}
+		value, i = scanKey(line, i+1, ", ")
+		if value == "" {
+			return point{}, errMissingTagValue
+		}
+		result.tags = append(result.tags, models.Tag{Name: name, Value: value})
+	}
+
+	i = skipSpaces(line, i)
+	if i >= len(line) {
+		return point{}, errMissingFields
+	}
+
+	for {
+		var (
+			key   string
+			value float64
+			ok    bool
+			err   error
+		)
+		key, i = scanKey(line, i, ",= ")
+		if key == "" {
+			return point{}, errEmptyKey
+		}
+		if i >= len(line) || line[i] != '=' {
+			return point{}, errMissingFieldValue
+		}
+		value, ok, i, err = scanFieldValue(line, i+1)
+		if err != nil {
+			return point{}, fmt.Errorf("invalid value for field %s: %v", key, err)
+		}
+		if ok {
+			result.fields = append(result.fields, field{key: key, value: value})
+		}
+
+		if i >= len(line) || line[i] != ',' {
+			break
+		}
+		i++
+	}
+
+	i = skipSpaces(line, i)
+	if i >= len(line) {
+		result.timestamp = now
+		return result, nil
+	}
+
+	timestamp, err := strconv.ParseInt(string(line[i:]), 10, 64)
+	if err != nil {
+		return point{}, fmt.Errorf("invalid timestamp: %s", line[i:])
+	}
+	result.timestamp = time.Unix(0, timestamp*int64(p.duration))
+	return result, nil
+}
+
+// scanKey scans a measurement, tag or field key from the start of line up
+// to the first unescaped stop character, returning the unescaped key and the
+// index of the stop character.
+func scanKey(line []byte, start int, stops string) (string, int) {
+	var (
+		buf     []byte
+		escaped bool
+		i       = start
+	)
+	for ; i < len(line); i++ {
+		c := line[i]
+		if c == '\\' && i+1 < len(line) && isEscapable(line[i+1], stops) {
+			if !escaped {
+				buf = append(buf[:0], line[start:i]...)
+				escaped = true
+			}
+			i++
+			buf = append(buf, line[i])
+			continue
+		}
+		if isStop(c, stops) {
+			break
+		}
+		if escaped {
+			buf = append(buf, c)
+		}
+	}
+
+	if escaped {
+		return string(buf), i
+	}
+	return string(line[start:i]), i
+}
+
+// scanFieldValue scans a field value from the start of line, returning the
+// value, whether it is numeric and the index following it. Integers, unsigned
+// integers and floats are stored as is, booleans as 1 or 0 and strings are
+// skipped.
+func scanFieldValue(line []byte, start int) (float64, bool, int, error) {
+	if start >= len(line) {
+		return 0, false, start, errMissingFieldValue
+	}
+
+	if line[start] == '"' {
+		for i := start + 1; i < len(line); i++ {
+			switch line[i] {
+			case '\\':
+				i++
+			case '"':
+				return 0, false, i + 1, nil
+			}
+		}
+		return 0, false, len(line), errUnterminatedString
+	}
+
+	end := start
+	for end < len(line) && !isStop(line[end], ", ") {
+		end++
+	}
+
+	str := string(line[start:end])
+	switch str {
+	case "":
+		return 0, false, end, errMissingFieldValue
+	case "t", "T", "true", "True", "TRUE":
+		return 1, true, end, nil
+	case "f", "F", "false", "False", "FALSE":
+		return 0, true, end, nil
+	}
+
+	var (
+		value float64
+		err   error
+	)
+	switch str[len(str)-1] {
+	case 'i':
+		var v int64
+		v, err = strconv.ParseInt(str[:len(str)-1], 10, 64)
+		value = float64(v)
+	case 'u':
+		var v uint64
+		v, err = strconv.ParseUint(str[:len(str)-1], 10, 64)
+		value = float64(v)
+	default:
+		value, err = strconv.ParseFloat(str, 64)
+	}
+	if err != nil {
+		return 0, false, end, fmt.Errorf("invalid number: %s", str)
+	}
+	return value, true, end, nil
+}
+
+func isStop(c byte, stops string) bool {
+	for i := 0; i < len(stops); i++ {
+		if c == stops[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func isEscapable(c byte, stops string) bool {
+	return c == '\\' || c == '=' || isStop(c, stops)
+}
+
+func skipSpaces(line []byte, i int) int {
+	for i < len(line) && line[i] == ' ' {
+		i++
+	}
+	return i
+}
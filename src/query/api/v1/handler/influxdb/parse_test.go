@@ -0,0 +1,122 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package influxdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePrecision(t *testing.T) {
+	for str, expected := range map[string]precision{
+		"":   {duration: time.Nanosecond, unit: xtime.Nanosecond},
+		"u":  {duration: time.Microsecond, unit: xtime.Microsecond},
+		"ms": {duration: time.Millisecond, unit: xtime.Millisecond},
+		"s":  {duration: time.Second, unit: xtime.Second},
+		"h":  {duration: time.Hour, unit: xtime.Second},
+	} {
+		p, err := parsePrecision(str)
+		require.NoError(t, err, str)
+		assert.Equal(t, expected, p, str)
+	}
+
+	_, err := parsePrecision("d")
+	assert.Error(t, err)
+}
+
+func TestParseLine(t *testing.T) {
+	var (
+		now     = time.Unix(1600000000, 0)
+		seconds = precision{duration: time.Second, unit: xtime.Second}
+	)
+
+	tests := []struct {
+		line     string
+		expected point
+	}{
+		{
+			line: "cpu,host=a,region=us-west usage_idle=92.5,usage_user=3i 1500000000",
+			expected: point{
+				measurement: "cpu",
+				tags:        models.Tags{{Name: "host", Value: "a"}, {Name: "region", Value: "us-west"}},
+				fields:      []field{{key: "usage_idle", value: 92.5}, {key: "usage_user", value: 3}},
+				timestamp:   time.Unix(1500000000, 0),
+			},
+		},
+		{
+			line: "mem free=10u,ok=true,down=F",
+			expected: point{
+				measurement: "mem",
+				fields:      []field{{key: "free", value: 10}, {key: "ok", value: 1}, {key: "down", value: 0}},
+				timestamp:   now,
+			},
+		},
+		{
+			line: `disk\ io,path=/var\,log\ dir,mode\=x=rw read=1,msg="a, \"quoted\" b=c",write=2 1500000000`,
+			expected: point{
+				measurement: "disk io",
+				tags:        models.Tags{{Name: "path", Value: "/var,log dir"}, {Name: "mode=x", Value: "rw"}},
+				fields:      []field{{key: "read", value: 1}, {key: "write", value: 2}},
+				timestamp:   time.Unix(1500000000, 0),
+			},
+		},
+		{
+			line: `status message="only a string"`,
+			expected: point{
+				measurement: "status",
+				timestamp:   now,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		pt, err := parseLine([]byte(tt.line), seconds, now)
+		require.NoError(t, err, tt.line)
+		assert.Equal(t, tt.expected, pt, tt.line)
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	for _, line := range []string{
+		",host=a value=1",
+		"cpu",
+		"cpu,host=a",
+		"cpu,host value=1",
+		"cpu,host= value=1",
+		"cpu,=a value=1",
+		"cpu value",
+		"cpu value=",
+		"cpu =1",
+		"cpu value=abc",
+		"cpu value=1.5i",
+		`cpu value="unterminated`,
+		"cpu value=1 yesterday",
+		"cpu value=1 1500000000 extra",
+	} {
+		_, err := parseLine([]byte(line), precision{duration: time.Nanosecond}, time.Now())
+		assert.Error(t, err, line)
+	}
+}
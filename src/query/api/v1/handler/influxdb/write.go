@@ -0,0 +1,305 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package influxdb
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	xsync "github.com/m3db/m3x/sync"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// WriteURL is the url for the influxdb line protocol write handler
+	WriteURL = handler.RoutePrefixV1 + "/influxdb/write"
+
+	// WriteHTTPMethod is the HTTP method used with this resource.
+	WriteHTTPMethod = http.MethodPost
+
+	precisionParam = "precision"
+
+	// maxLineSize is the maximum size of a single line
+	maxLineSize = 1 << 20
+
+	// maxReportedPointErrors is the maximum number of points which failed to
+	// be written that are listed in the error of a request
+	maxReportedPointErrors = 10
+)
+
+var (
+	errEmptyBody = errors.New("empty request body")
+)
+
+// WriteHandler represents a handler for the influxdb line protocol write
+// endpoint.
+type WriteHandler struct {
+	store      storage.Appender
+	workerPool xsync.PooledWorkerPool
+	metrics    writeMetrics
+	nowFn      func() time.Time
+}
+
+// NewWriteHandler returns a new instance of handler, the points of each
+// request are written concurrently using the worker pool.
+func NewWriteHandler(
+	store storage.Appender,
+	workerPool xsync.PooledWorkerPool,
+	scope tally.Scope,
+) http.Handler {
+	return &WriteHandler{
+		store:      store,
+		workerPool: workerPool,
+		metrics:    newWriteMetrics(scope),
+		nowFn:      time.Now,
+	}
+}
+
+type writeMetrics struct {
+	writeSuccess      tally.Counter
+	writeErrorsServer tally.Counter
+	writeErrorsClient tally.Counter
+	linesMalformed    tally.Counter
+	pointsFailed      tally.Counter
+}
+
+func newWriteMetrics(scope tally.Scope) writeMetrics {
+	return writeMetrics{
+		writeSuccess:      scope.Counter("write.success"),
+		writeErrorsServer: scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
+		writeErrorsClient: scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+		linesMalformed:    scope.Counter("write.malformed-lines"),
+		pointsFailed:      scope.Counter("write.failed-points"),
+	}
+}
+
+// lineError is the error parsing a single line of a write request.
+type lineError struct {
+	line int
+	err  error
+}
+
+func (e lineError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+// pointWrite is the write of a field of a point.
+type pointWrite struct {
+	line  int
+	field string
+	query *storage.WriteQuery
+}
+
+// pointError is the error writing a field of a point.
+type pointError struct {
+	line  int
+	field string
+	err   error
+}
+
+func (e pointError) Error() string {
+	return fmt.Sprintf("line %d field %s: %v", e.line, e.field, e.err)
+}
+
+// ServeHTTP writes the points of all the well formed lines of a request,
+// a request with malformed lines is a partial write which fails with the
+// errors of each malformed line.
+func (h *WriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	points, lineErrs, rErr := h.parseRequest(r)
+	if rErr != nil {
+		h.metrics.writeErrorsClient.Inc(1)
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if pointErrs := h.write(r.Context(), points); len(pointErrs) > 0 {
+		err := failedWriteError(pointErrs, len(points))
+		h.metrics.writeErrorsServer.Inc(1)
+		h.metrics.pointsFailed.Inc(int64(len(pointErrs)))
+		logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	if len(lineErrs) > 0 {
+		h.metrics.writeErrorsClient.Inc(1)
+		h.metrics.linesMalformed.Inc(int64(len(lineErrs)))
+		handler.Error(w, partialWriteError(lineErrs), http.StatusBadRequest)
+		return
+	}
+
+	h.metrics.writeSuccess.Inc(1)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func partialWriteError(lineErrs []lineError) error {
+	msgs := make([]string, 0, len(lineErrs))
+	for _, err := range lineErrs {
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Errorf("partial write: %d malformed lines: %s",
+		len(lineErrs), strings.Join(msgs, "; "))
+}
+
+// failedWriteError is the error of a request with points which failed to be
+// written, listing the first of them.
+func failedWriteError(pointErrs []pointError, numPoints int) error {
+	msgs := make([]string, 0, maxReportedPointErrors+1)
+	for i, err := range pointErrs {
+		if i == maxReportedPointErrors {
+			msgs = append(msgs, fmt.Sprintf("and %d more", len(pointErrs)-i))
+			break
+		}
+		msgs = append(msgs, err.Error())
+	}
+	return fmt.Errorf("failed to write %d of %d points: %s",
+		len(pointErrs), numPoints, strings.Join(msgs, "; "))
+}
+
+func (h *WriteHandler) parseRequest(
+	r *http.Request,
+) ([]pointWrite, []lineError, *handler.ParseError) {
+	if r.Body == nil {
+		return nil, nil, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+	defer r.Body.Close()
+
+	p, err := parsePrecision(r.URL.Query().Get(precisionParam))
+	if err != nil {
+		return nil, nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, nil, handler.NewParseError(err, http.StatusBadRequest)
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+
+	var (
+		now      = h.nowFn()
+		scanner  = bufio.NewScanner(body)
+		writes   []pointWrite
+		lineErrs []lineError
+		lineNum  int
+	)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+	for scanner.Scan() {
+		lineNum++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		pt, err := parseLine(line, p, now)
+		if err != nil {
+			lineErrs = append(lineErrs, lineError{line: lineNum, err: err})
+			continue
+		}
+		writes = append(writes, newPointWrites(lineNum, pt, p)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return writes, lineErrs, nil
+}
+
+// newPointWrites returns a write for each field of a point, to a series
+// named measurement_field with the tags of the point.
+func newPointWrites(line int, pt point, p precision) []pointWrite {
+	writes := make([]pointWrite, 0, len(pt.fields))
+	for _, f := range pt.fields {
+		tags := make(models.Tags, 0, len(pt.tags)+1)
+		tags = append(tags, pt.tags...)
+		tags = append(tags, models.Tag{
+			Name:  models.MetricName,
+			Value: pt.measurement + "_" + f.key,
+		})
+
+		writes = append(writes, pointWrite{
+			line:  line,
+			field: f.key,
+			query: &storage.WriteQuery{
+				Tags: models.Normalize(tags),
+				Datapoints: ts.Datapoints{
+					{Timestamp: pt.timestamp, Value: f.value},
+				},
+				Unit: p.unit,
+				Attributes: storage.Attributes{
+					MetricsType: storage.UnaggregatedMetricsType,
+				},
+			},
+		})
+	}
+	return writes
+}
+
+// write writes the points concurrently, returning the error of each point
+// which failed to be written in the order of the request.
+func (h *WriteHandler) write(ctx context.Context, writes []pointWrite) []pointError {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(writes))
+	)
+	for i, write := range writes {
+		i, write := i, write // Capture for goroutine
+
+		wg.Add(1)
+		h.workerPool.Go(func() {
+			errs[i] = h.store.Write(ctx, write.query)
+			wg.Done()
+		})
+	}
+
+	wg.Wait()
+
+	var pointErrs []pointError
+	for i, err := range errs {
+		if err != nil {
+			pointErrs = append(pointErrs, pointError{
+				line:  writes[i].line,
+				field: writes[i].field,
+				err:   err,
+			})
+		}
+	}
+
+	return pointErrs
+}
@@ -0,0 +1,201 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package influxdb
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func sortedWrites(store mock.Storage) []*storage.WriteQuery {
+	writes := store.Writes()
+	sort.Slice(writes, func(i, j int) bool {
+		return writes[i].Tags.ID() < writes[j].Tags.ID()
+	})
+	return writes
+}
+
+func TestWrite(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	body := strings.Join([]string{
+		"cpu,host=a usage_idle=90,usage_user=5 1500000000000",
+		"",
+		"# comment",
+		"cpu,host=b usage_idle=80 1500000010000",
+	}, "\n")
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL+"?db=telegraf&precision=ms", strings.NewReader(body))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	require.Equal(t, http.StatusNoContent, res.Code, res.Body.String())
+
+	writes := sortedWrites(store)
+	require.Len(t, writes, 3)
+
+	assert.Equal(t, models.Tags{
+		{Name: models.MetricName, Value: "cpu_usage_idle"},
+		{Name: "host", Value: "a"},
+	}, writes[0].Tags)
+	assert.Equal(t, time.Unix(1500000000, 0), writes[0].Datapoints[0].Timestamp)
+	assert.Equal(t, 90.0, writes[0].Datapoints[0].Value)
+	assert.Equal(t, storage.UnaggregatedMetricsType, writes[0].Attributes.MetricsType)
+
+	assert.Equal(t, "b", writes[1].Tags[1].Value)
+	assert.Equal(t, time.Unix(1500000010, 0), writes[1].Datapoints[0].Timestamp)
+
+	assert.Equal(t, "cpu_usage_user", writes[2].Tags[0].Value)
+	assert.Equal(t, 5.0, writes[2].Datapoints[0].Value)
+}
+
+func TestWriteGzip(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte("mem free=1i 1500000000\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	store := mock.NewMockStorage()
+	h := NewWriteHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL+"?precision=s", &buf)
+	req.Header.Set("Content-Encoding", "gzip")
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	require.Equal(t, http.StatusNoContent, res.Code, res.Body.String())
+
+	writes := store.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, time.Unix(1500000000, 0), writes[0].Datapoints[0].Timestamp)
+}
+
+func TestWritePartial(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	body := "cpu value=1 1\ncpu\ncpu value=2 2\ncpu value=x 3\n"
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, strings.NewReader(body))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "partial write: 2 malformed lines")
+	assert.Contains(t, res.Body.String(), "line 2: ")
+	assert.Contains(t, res.Body.String(), "line 4: ")
+
+	// The well formed lines are written
+	assert.Len(t, store.Writes(), 2)
+}
+
+func TestWriteErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL+"?precision=d", strings.NewReader("cpu value=1"))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	store.SetWriteResult(errors.New("write failed"))
+	req = httptest.NewRequest(WriteHTTPMethod, WriteURL, strings.NewReader("cpu value=1"))
+	res = httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Contains(t, res.Body.String(), "write failed")
+}
+
+// failingAppender fails the writes of series with the given names
+type failingAppender struct {
+	sync.Mutex
+	names  map[string]bool
+	writes int
+}
+
+func (a *failingAppender) Write(_ context.Context, query *storage.WriteQuery) error {
+	a.Lock()
+	defer a.Unlock()
+
+	a.writes++
+	if name, _ := query.Tags.Get(models.MetricName); a.names[name] {
+		return errors.New("write failed")
+	}
+	return nil
+}
+
+func TestWriteReportsFailedPoints(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := &failingAppender{names: map[string]bool{"cpu_user": true, "mem_free": true}}
+	h := NewWriteHandler(store, test.NewWorkerPool(t, 2), tally.NoopScope)
+
+	body := strings.Join([]string{
+		"cpu idle=90,user=5 1",
+		"mem free=1i,used=2i 2",
+		"disk free=3i 3",
+	}, "\n")
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, strings.NewReader(body))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	// The other points are still written
+	assert.Equal(t, 5, store.writes)
+	require.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Contains(t, res.Body.String(),
+		"failed to write 2 of 5 points: line 1 field user: write failed; line 2 field free: write failed")
+}
+
+func TestFailedWriteErrorTruncated(t *testing.T) {
+	pointErrs := make([]pointError, 0, maxReportedPointErrors+3)
+	for i := 0; i < cap(pointErrs); i++ {
+		pointErrs = append(pointErrs, pointError{line: i + 1, field: "value", err: errors.New("failed")})
+	}
+
+	err := failedWriteError(pointErrs, 20)
+	assert.Contains(t, err.Error(), "failed to write 13 of 20 points: line 1 field value: failed;")
+	assert.Contains(t, err.Error(), "line 10 field value: failed; and 3 more")
+	assert.NotContains(t, err.Error(), "line 11")
+}
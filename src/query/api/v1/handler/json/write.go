@@ -21,10 +21,15 @@
 package json
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/models"
@@ -32,6 +37,8 @@ import (
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
+	xsync "github.com/m3db/m3x/sync"
 	xtime "github.com/m3db/m3x/time"
 
 	"go.uber.org/zap"
@@ -45,26 +52,73 @@ const (
 	JSONWriteHTTPMethod = http.MethodPost
 )
 
+var (
+	errEmptyBody         = errors.New("empty request body")
+	errMissingTags       = errors.New("missing tags")
+	errMissingDatapoints = errors.New("missing datapoints")
+)
+
 // WriteJSONHandler represents a handler for the write json endpoint
 type WriteJSONHandler struct {
-	store storage.Storage
+	store      storage.Appender
+	workerPool xsync.PooledWorkerPool
 }
 
-// NewWriteJSONHandler returns a new instance of handler.
-func NewWriteJSONHandler(store storage.Storage) http.Handler {
+// NewWriteJSONHandler returns a new instance of handler, the series of each
+// request are written concurrently using the worker pool.
+func NewWriteJSONHandler(
+	store storage.Appender,
+	workerPool xsync.PooledWorkerPool,
+) http.Handler {
 	return &WriteJSONHandler{
-		store: store,
+		store:      store,
+		workerPool: workerPool,
 	}
 }
 
-// WriteQuery represents the write request from the user
-// NB(braskin): support only writing one datapoint for now
+// WriteQuery represents a write request of a single datapoint from the user
 type WriteQuery struct {
 	Tags      map[string]string `json:"tags" validate:"nonzero"`
 	Timestamp string            `json:"timestamp" validate:"nonzero"`
 	Value     float64           `json:"value" validate:"nonzero"`
 }
 
+// WriteSeries represents a series of a batch write request from the user
+type WriteSeries struct {
+	Tags       map[string]string `json:"tags" validate:"nonzero"`
+	Datapoints []WriteDatapoint  `json:"datapoints" validate:"nonzero"`
+	// Unit is the time unit of the datapoints, such as "s" or "ms",
+	// defaulting to milliseconds
+	Unit       string `json:"unit"`
+	Annotation string `json:"annotation"`
+	// StoragePolicy is the resolution and retention of the aggregated
+	// namespace to write to, such as "1m:40d", the series is written
+	// unaggregated if it is not set
+	StoragePolicy string `json:"storagePolicy"`
+}
+
+// WriteDatapoint represents a datapoint of a series from the user
+type WriteDatapoint struct {
+	Timestamp string  `json:"timestamp" validate:"nonzero"`
+	Value     float64 `json:"value"`
+}
+
+// WriteResult is the result of a write request
+type WriteResult struct {
+	Success int          `json:"success"`
+	Failed  int          `json:"failed"`
+	Errors  []WriteError `json:"errors"`
+}
+
+// WriteError is the error writing a series of a write request
+type WriteError struct {
+	// Index is the index of the series in the request
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// ServeHTTP writes each series of a request concurrently, the request fails
+// with the error of each series that is invalid or can not be written.
 func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	req, rErr := h.parseRequest(r)
 	if rErr != nil {
@@ -72,42 +126,143 @@ func (h *WriteJSONHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeQuery, err := newStorageWriteQuery(req)
-	if err != nil {
-		logging.WithContext(r.Context()).Error("Parsing error", zap.Any("err", err))
-		handler.Error(w, err, http.StatusInternalServerError)
+	var (
+		result      = WriteResult{Errors: []WriteError{}}
+		serverError bool
+	)
+	for i, err := range h.write(r.Context(), req) {
+		if err == nil {
+			result.Success++
+			continue
+		}
+
+		if _, ok := err.(invalidSeriesError); !ok {
+			serverError = true
+			logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
+		}
+
+		result.Failed++
+		result.Errors = append(result.Errors, WriteError{Index: i, Error: err.Error()})
 	}
 
-	if err := h.store.Write(r.Context(), writeQuery); err != nil {
-		logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
-		handler.Error(w, err, http.StatusInternalServerError)
+	code := http.StatusOK
+	switch {
+	case serverError:
+		code = http.StatusInternalServerError
+	case result.Failed > 0:
+		code = http.StatusBadRequest
 	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
 }
 
-func newStorageWriteQuery(req *WriteQuery) (*storage.WriteQuery, error) {
-	parsedTime, err := util.ParseTimeString(req.Timestamp)
-	if err != nil {
-		return nil, err
+// invalidSeriesError is the error for a series which can not be written,
+// as opposed to one which failed to be written
+type invalidSeriesError struct {
+	err error
+}
+
+func (e invalidSeriesError) Error() string {
+	return e.err.Error()
+}
+
+func newStorageWriteQuery(series WriteSeries) (*storage.WriteQuery, error) {
+	if len(series.Tags) == 0 {
+		return nil, invalidSeriesError{err: errMissingTags}
+	}
+
+	if len(series.Datapoints) == 0 {
+		return nil, invalidSeriesError{err: errMissingDatapoints}
+	}
+
+	datapoints := make(ts.Datapoints, 0, len(series.Datapoints))
+	for _, dp := range series.Datapoints {
+		parsedTime, err := util.ParseTimeString(dp.Timestamp)
+		if err != nil {
+			return nil, invalidSeriesError{err: err}
+		}
+
+		datapoints = append(datapoints, ts.Datapoint{
+			Timestamp: parsedTime,
+			Value:     dp.Value,
+		})
+	}
+
+	unit := xtime.Millisecond
+	if series.Unit != "" {
+		d, err := time.ParseDuration("1" + series.Unit)
+		if err == nil {
+			unit, err = xtime.UnitFromDuration(d)
+		}
+		if err != nil {
+			return nil, invalidSeriesError{err: fmt.Errorf("invalid unit: %s", series.Unit)}
+		}
+	}
+
+	attributes := storage.Attributes{
+		MetricsType: storage.UnaggregatedMetricsType,
+	}
+	if series.StoragePolicy != "" {
+		sp, err := policy.ParseStoragePolicy(series.StoragePolicy)
+		if err != nil {
+			return nil, invalidSeriesError{err: fmt.Errorf("invalid storage policy: %v", err)}
+		}
+
+		attributes = storage.Attributes{
+			MetricsType: storage.AggregatedMetricsType,
+			Resolution:  sp.Resolution().Window,
+			Retention:   sp.Retention().Duration(),
+		}
+	}
+
+	var annotation []byte
+	if series.Annotation != "" {
+		annotation = []byte(series.Annotation)
 	}
 
 	return &storage.WriteQuery{
-		Tags: models.FromMap(req.Tags),
-		Datapoints: ts.Datapoints{
-			{
-				Timestamp: parsedTime,
-				Value:     req.Value,
-			},
-		},
-		Unit:       xtime.Millisecond,
-		Annotation: nil,
+		Tags:       models.FromMap(series.Tags),
+		Datapoints: datapoints,
+		Unit:       unit,
+		Annotation: annotation,
+		Attributes: attributes,
 	}, nil
 }
 
-func (h *WriteJSONHandler) parseRequest(r *http.Request) (*WriteQuery, *handler.ParseError) {
+// write writes each series concurrently returning the error of each
+func (h *WriteJSONHandler) write(ctx context.Context, req []WriteSeries) []error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(req))
+	)
+	for i, series := range req {
+		writeQuery, err := newStorageWriteQuery(series)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		i := i // Capture for goroutine
+		wg.Add(1)
+		h.workerPool.Go(func() {
+			errs[i] = h.store.Write(ctx, writeQuery)
+			wg.Done()
+		})
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// parseRequest parses either an array of series or a single datapoint,
+// which is returned as a series with one datapoint
+func (h *WriteJSONHandler) parseRequest(r *http.Request) ([]WriteSeries, *handler.ParseError) {
 	body := r.Body
 	if r.Body == nil {
-		err := fmt.Errorf("empty request body")
-		return nil, handler.NewParseError(err, http.StatusBadRequest)
+		return nil, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
 	}
 
 	defer body.Close()
@@ -117,8 +272,30 @@ func (h *WriteJSONHandler) parseRequest(r *http.Request) (*WriteQuery, *handler.
 		return nil, handler.NewParseError(err, http.StatusInternalServerError)
 	}
 
-	var writeQuery *WriteQuery
-	json.Unmarshal(js, &writeQuery)
+	js = bytes.TrimSpace(js)
+	if len(js) == 0 {
+		return nil, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+
+	if js[0] == '[' {
+		var series []WriteSeries
+		if err := json.Unmarshal(js, &series); err != nil {
+			return nil, handler.NewParseError(err, http.StatusBadRequest)
+		}
+
+		return series, nil
+	}
+
+	var writeQuery WriteQuery
+	if err := json.Unmarshal(js, &writeQuery); err != nil {
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
 
-	return writeQuery, nil
+	return []WriteSeries{{
+		Tags: writeQuery.Tags,
+		Datapoints: []WriteDatapoint{{
+			Timestamp: writeQuery.Timestamp,
+			Value:     writeQuery.Value,
+		}},
+	}}, nil
 }
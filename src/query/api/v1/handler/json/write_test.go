@@ -22,14 +22,26 @@ package json
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
+	"sort"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/local"
 	"github.com/m3db/m3/src/query/util/logging"
+	xtime "github.com/m3db/m3x/time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,8 +63,10 @@ func TestJSONWriteParsing(t *testing.T) {
 
 	r, err := jsonWrite.parseRequest(req)
 	require.Nil(t, err, "unable to parse request")
-	require.Equal(t, 10.0, r.Value)
-	require.Equal(t, map[string]string{"tag_one": "val_one", "tag_two": "val_two"}, r.Tags)
+	require.Len(t, r, 1)
+	require.Len(t, r[0].Datapoints, 1)
+	require.Equal(t, 10.0, r[0].Datapoints[0].Value)
+	require.Equal(t, map[string]string{"tag_one": "val_one", "tag_two": "val_two"}, r[0].Tags)
 }
 
 func TestJSONWrite(t *testing.T) {
@@ -70,9 +84,133 @@ func TestJSONWrite(t *testing.T) {
 	r, rErr := jsonWrite.parseRequest(req)
 	require.Nil(t, rErr, "unable to parse request")
 
-	writeQuery, err := newStorageWriteQuery(r)
+	writeQuery, err := newStorageWriteQuery(r[0])
 	require.NoError(t, err)
 
 	writeErr := jsonWrite.store.Write(context.TODO(), writeQuery)
 	require.NoError(t, writeErr)
 }
+
+func serveJSONWrite(h http.Handler, body string) (*httptest.ResponseRecorder, WriteResult) {
+	req := httptest.NewRequest(JSONWriteHTTPMethod, WriteJSONURL, strings.NewReader(body))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	var result WriteResult
+	json.Unmarshal(res.Body.Bytes(), &result)
+	return res, result
+}
+
+func TestJSONWriteBatch(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteJSONHandler(store, test.NewWorkerPool(t, 4))
+
+	res, result := serveJSONWrite(h, `[
+		{
+			"tags": {"__name__": "cpu", "host": "a"},
+			"datapoints": [
+				{"timestamp": "1534952005", "value": 1},
+				{"timestamp": "1534952015", "value": 2}
+			]
+		},
+		{
+			"tags": {"__name__": "mem", "host": "a"},
+			"datapoints": [{"timestamp": "2018-08-22T15:33:25Z", "value": 3}],
+			"unit": "s",
+			"annotation": "backfill",
+			"storagePolicy": "1m:40d"
+		}
+	]`)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, WriteResult{Success: 2, Errors: []WriteError{}}, result)
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+	sort.Slice(writes, func(i, j int) bool {
+		return writes[i].Tags.ID() < writes[j].Tags.ID()
+	})
+
+	assert.Equal(t, models.Tags{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "a"}}, writes[0].Tags)
+	require.Len(t, writes[0].Datapoints, 2)
+	assert.Equal(t, time.Unix(1534952015, 0), writes[0].Datapoints[1].Timestamp)
+	assert.Equal(t, 2.0, writes[0].Datapoints[1].Value)
+	assert.Equal(t, xtime.Millisecond, writes[0].Unit)
+	assert.Nil(t, writes[0].Annotation)
+	assert.Equal(t, storage.Attributes{MetricsType: storage.UnaggregatedMetricsType}, writes[0].Attributes)
+
+	assert.Equal(t, xtime.Second, writes[1].Unit)
+	assert.Equal(t, []byte("backfill"), writes[1].Annotation)
+	assert.Equal(t, storage.Attributes{
+		MetricsType: storage.AggregatedMetricsType,
+		Resolution:  time.Minute,
+		Retention:   40 * 24 * time.Hour,
+	}, writes[1].Attributes)
+}
+
+func TestJSONWriteBatchInvalidSeries(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteJSONHandler(store, test.NewWorkerPool(t, 4))
+
+	res, result := serveJSONWrite(h, `[
+		{"tags": {"__name__": "cpu"}, "datapoints": [{"timestamp": "1534952005", "value": 1}]},
+		{"datapoints": [{"timestamp": "1534952005", "value": 1}]},
+		{"tags": {"__name__": "cpu"}},
+		{"tags": {"__name__": "cpu"}, "datapoints": [{"timestamp": "yesterday", "value": 1}]},
+		{"tags": {"__name__": "cpu"}, "datapoints": [{"timestamp": "1534952005", "value": 1}], "unit": "fortnight"},
+		{"tags": {"__name__": "cpu"}, "datapoints": [{"timestamp": "1534952005", "value": 1}], "storagePolicy": "1m"}
+	]`)
+	require.Equal(t, http.StatusBadRequest, res.Code, res.Body.String())
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 5, result.Failed)
+
+	indices := make([]int, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		indices = append(indices, err.Index)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, indices)
+	assert.Equal(t, errMissingTags.Error(), result.Errors[0].Error)
+	assert.Equal(t, errMissingDatapoints.Error(), result.Errors[1].Error)
+
+	// The valid series is still written
+	assert.Len(t, store.Writes(), 1)
+}
+
+func TestJSONWriteErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteJSONHandler(store, test.NewWorkerPool(t, 4))
+
+	res, _ := serveJSONWrite(h, "")
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	res, _ = serveJSONWrite(h, "[{")
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	store.SetWriteResult(errors.New("write failed"))
+	res, result := serveJSONWrite(h, generateJSONWriteRequest())
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Equal(t, []WriteError{{Index: 0, Error: "write failed"}}, result.Errors)
+}
+
+func TestJSONWriteBatchLargerThanWorkerPool(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewWriteJSONHandler(store, test.NewWorkerPool(t, 2))
+
+	series := make([]string, 0, 50)
+	for i := 0; i < cap(series); i++ {
+		series = append(series, fmt.Sprintf(
+			`{"tags": {"__name__": "cpu", "host": "%d"}, "datapoints": [{"timestamp": "1534952005", "value": 1}]}`, i))
+	}
+
+	res, result := serveJSONWrite(h, "["+strings.Join(series, ",")+"]")
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, 50, result.Success)
+	assert.Len(t, store.Writes(), 50)
+}
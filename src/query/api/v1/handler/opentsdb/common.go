@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package opentsdb contains the OpenTSDB compatible put and query handlers.
+package opentsdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/parser/opentsdb"
+)
+
+const (
+	// maxSecondsTimestamp is the largest timestamp in seconds, larger
+	// timestamps are in milliseconds
+	maxSecondsTimestamp = 9999999999
+
+	relativeTimeSuffix = "-ago"
+	absoluteTimeLayout = "2006/01/02-15:04:05"
+)
+
+// parseTimestamp parses a timestamp in seconds or milliseconds
+func parseTimestamp(timestamp int64) (time.Time, error) {
+	if timestamp <= 0 {
+		return time.Time{}, fmt.Errorf("invalid timestamp: %d", timestamp)
+	}
+
+	if timestamp > maxSecondsTimestamp {
+		return time.Unix(0, timestamp*int64(time.Millisecond)), nil
+	}
+
+	return time.Unix(timestamp, 0), nil
+}
+
+// parseTime parses an OpenTSDB query time, which is either a timestamp in
+// seconds or milliseconds, a time relative to now such as "1h-ago" or an
+// absolute time in UTC such as "2018/07/01-12:00:00"
+func parseTime(str string, now time.Time) (time.Time, error) {
+	str = strings.TrimSpace(str)
+	if strings.HasSuffix(str, relativeTimeSuffix) {
+		interval, err := opentsdb.ParseInterval(strings.TrimSuffix(str, relativeTimeSuffix))
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return now.Add(-interval), nil
+	}
+
+	if timestamp, err := strconv.ParseInt(str, 10, 64); err == nil {
+		return parseTimestamp(timestamp)
+	}
+
+	for _, layout := range []string{absoluteTimeLayout, "2006/01/02-15:04", "2006/01/02"} {
+		if t, err := time.Parse(layout, str); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time: %s", str)
+}
+
+// jsonTime is a time in a json request body, which is either a number or a
+// string parsed as a query time
+type jsonTime string
+
+func (t *jsonTime) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err == nil {
+		*t = jsonTime(str)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return fmt.Errorf("invalid time: %s", data)
+	}
+
+	*t = jsonTime(n.String())
+	return nil
+}
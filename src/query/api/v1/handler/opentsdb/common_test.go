@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	for str, expected := range map[string]time.Time{
+		"1500000000":          time.Unix(1500000000, 0),
+		"1500000000500":       time.Unix(1500000000, int64(500*time.Millisecond)),
+		"1h-ago":              now.Add(-time.Hour),
+		"2d-ago":              now.Add(-48 * time.Hour),
+		"2017/07/14-02:40:00": time.Unix(1500000000, 0).UTC(),
+		"2017/07/14":          time.Date(2017, 7, 14, 0, 0, 0, 0, time.UTC),
+	} {
+		parsed, err := parseTime(str, now)
+		require.NoError(t, err, str)
+		assert.True(t, expected.Equal(parsed), str)
+	}
+
+	for _, str := range []string{"", "-1", "0", "1x-ago", "yesterday"} {
+		_, err := parseTime(str, now)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestJSONTime(t *testing.T) {
+	var times []jsonTime
+	require.NoError(t, json.Unmarshal([]byte(`[1500000000, "1h-ago"]`), &times))
+	assert.Equal(t, []jsonTime{"1500000000", "1h-ago"}, times)
+
+	assert.Error(t, json.Unmarshal([]byte(`[true]`), &times))
+}
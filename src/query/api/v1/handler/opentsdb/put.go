@@ -0,0 +1,308 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+const (
+	// PutURL is the url for the put handler, OpenTSDB clients expect the
+	// endpoint without the version prefix of the other endpoints
+	PutURL = "/api/put"
+
+	// PutHTTPMethod is the HTTP method used with this resource.
+	PutHTTPMethod = http.MethodPost
+
+	summaryParam = "summary"
+	detailsParam = "details"
+)
+
+var (
+	errEmptyBody     = errors.New("empty request body")
+	errMissingMetric = errors.New("missing metric")
+)
+
+// PutHandler represents a handler for the OpenTSDB put endpoint.
+type PutHandler struct {
+	store      storage.Appender
+	workerPool xsync.PooledWorkerPool
+	metrics    putMetrics
+}
+
+// NewPutHandler returns a new instance of handler, the datapoints of each
+// request are written concurrently using the worker pool.
+func NewPutHandler(
+	store storage.Appender,
+	workerPool xsync.PooledWorkerPool,
+	scope tally.Scope,
+) http.Handler {
+	return &PutHandler{
+		store:      store,
+		workerPool: workerPool,
+		metrics:    newPutMetrics(scope),
+	}
+}
+
+type putMetrics struct {
+	writeSuccess      tally.Counter
+	writeErrorsServer tally.Counter
+	writeErrorsClient tally.Counter
+	datapointsFailed  tally.Counter
+}
+
+func newPutMetrics(scope tally.Scope) putMetrics {
+	return putMetrics{
+		writeSuccess:      scope.Counter("write.success"),
+		writeErrorsServer: scope.Tagged(map[string]string{"code": "5XX"}).Counter("write.errors"),
+		writeErrorsClient: scope.Tagged(map[string]string{"code": "4XX"}).Counter("write.errors"),
+		datapointsFailed:  scope.Counter("write.failed-datapoints"),
+	}
+}
+
+// Datapoint is a datapoint of a put request.
+type Datapoint struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Value     interface{}       `json:"value"`
+	Tags      map[string]string `json:"tags"`
+}
+
+// putError is the error writing a single datapoint of a put request.
+type putError struct {
+	Datapoint Datapoint `json:"datapoint"`
+	Error     string    `json:"error"`
+}
+
+// putResult is the result of a put request returned with the summary or
+// details params.
+type putResult struct {
+	Success int        `json:"success"`
+	Failed  int        `json:"failed"`
+	Errors  []putError `json:"errors,omitempty"`
+}
+
+// ServeHTTP writes each valid datapoint of a request, the request fails if
+// any datapoint is invalid or can not be written.
+func (h *PutHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	datapoints, rErr := parsePutRequest(r)
+	if rErr != nil {
+		h.metrics.writeErrorsClient.Inc(1)
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	var (
+		result      = putResult{Errors: []putError{}}
+		serverError bool
+	)
+	for i, err := range h.write(r.Context(), datapoints) {
+		if err == nil {
+			result.Success++
+			continue
+		}
+
+		if _, ok := err.(invalidDatapointError); !ok {
+			serverError = true
+			logging.WithContext(r.Context()).Error("Write error", zap.Any("err", err))
+		}
+
+		result.Failed++
+		result.Errors = append(result.Errors, putError{
+			Datapoint: datapoints[i],
+			Error:     err.Error(),
+		})
+	}
+
+	code := http.StatusNoContent
+	switch {
+	case serverError:
+		h.metrics.writeErrorsServer.Inc(1)
+		code = http.StatusInternalServerError
+	case result.Failed > 0:
+		h.metrics.writeErrorsClient.Inc(1)
+		code = http.StatusBadRequest
+	default:
+		h.metrics.writeSuccess.Inc(1)
+	}
+	h.metrics.datapointsFailed.Inc(int64(result.Failed))
+
+	query := r.URL.Query()
+	_, details := query[detailsParam]
+	_, summary := query[summaryParam]
+	switch {
+	case details:
+	case summary:
+		result.Errors = nil
+	case result.Failed > 0:
+		handler.Error(w, fmt.Errorf("%d of %d datapoints failed to write: %s",
+			result.Failed, len(datapoints), result.Errors[0].Error), code)
+		return
+	default:
+		w.WriteHeader(code)
+		return
+	}
+
+	if code == http.StatusNoContent {
+		code = http.StatusOK
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(result)
+}
+
+// parsePutRequest parses a single datapoint or an array of datapoints
+func parsePutRequest(r *http.Request) ([]Datapoint, *handler.ParseError) {
+	if r.Body == nil {
+		return nil, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+	defer r.Body.Close()
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return nil, handler.NewParseError(err, http.StatusInternalServerError)
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return nil, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
+	}
+
+	var datapoints []Datapoint
+	if body[0] == '[' {
+		err = json.Unmarshal(body, &datapoints)
+	} else {
+		datapoints = make([]Datapoint, 1)
+		err = json.Unmarshal(body, &datapoints[0])
+	}
+
+	if err != nil {
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	return datapoints, nil
+}
+
+// invalidDatapointError is the error for a datapoint which can not be
+// written, as opposed to one which failed to be written
+type invalidDatapointError struct {
+	err error
+}
+
+func (e invalidDatapointError) Error() string {
+	return e.err.Error()
+}
+
+// newWriteQuery returns the write of a datapoint to a series named after
+// its metric with its tags
+func newWriteQuery(dp Datapoint) (*storage.WriteQuery, error) {
+	if dp.Metric == "" {
+		return nil, invalidDatapointError{err: errMissingMetric}
+	}
+
+	timestamp, err := parseTimestamp(dp.Timestamp)
+	if err != nil {
+		return nil, invalidDatapointError{err: err}
+	}
+
+	value, err := parseValue(dp.Value)
+	if err != nil {
+		return nil, invalidDatapointError{err: err}
+	}
+
+	unit := xtime.Second
+	if dp.Timestamp > maxSecondsTimestamp {
+		unit = xtime.Millisecond
+	}
+
+	tags := models.FromMap(dp.Tags)
+	tags = append(tags, models.Tag{Name: models.MetricName, Value: dp.Metric})
+	return &storage.WriteQuery{
+		Tags: models.Normalize(tags),
+		Datapoints: ts.Datapoints{
+			{Timestamp: timestamp, Value: value},
+		},
+		Unit: unit,
+		Attributes: storage.Attributes{
+			MetricsType: storage.UnaggregatedMetricsType,
+		},
+	}, nil
+}
+
+// parseValue parses the value of a datapoint, which is either a number or a
+// string containing a number
+func parseValue(v interface{}) (float64, error) {
+	switch value := v.(type) {
+	case float64:
+		return value, nil
+	case string:
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid value: %v", v)
+}
+
+// write writes each datapoint returning the error of each
+func (h *PutHandler) write(ctx context.Context, datapoints []Datapoint) []error {
+	var (
+		wg   sync.WaitGroup
+		errs = make([]error, len(datapoints))
+	)
+	for i, dp := range datapoints {
+		write, err := newWriteQuery(dp)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+
+		i := i // Capture for goroutine
+		wg.Add(1)
+		h.workerPool.Go(func() {
+			errs[i] = h.store.Write(ctx, write)
+			wg.Done()
+		})
+	}
+
+	wg.Wait()
+
+	return errs
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func servePut(h http.Handler, url, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(PutHTTPMethod, url, strings.NewReader(body))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	return res
+}
+
+func TestPutSingle(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewPutHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	res := servePut(h, PutURL, `{"metric": "sys.cpu", "timestamp": 1500000000, "value": 42.5, "tags": {"host": "a"}}`)
+	require.Equal(t, http.StatusNoContent, res.Code, res.Body.String())
+
+	writes := store.Writes()
+	require.Len(t, writes, 1)
+	assert.Equal(t, models.Tags{
+		{Name: models.MetricName, Value: "sys.cpu"},
+		{Name: "host", Value: "a"},
+	}, writes[0].Tags)
+	assert.Equal(t, time.Unix(1500000000, 0), writes[0].Datapoints[0].Timestamp)
+	assert.Equal(t, 42.5, writes[0].Datapoints[0].Value)
+	assert.Equal(t, xtime.Second, writes[0].Unit)
+}
+
+func TestPutBatch(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewPutHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	res := servePut(h, PutURL+"?summary", `[
+		{"metric": "sys.cpu", "timestamp": 1500000000000, "value": 1, "tags": {"host": "a"}},
+		{"metric": "sys.cpu", "timestamp": 1500000000, "value": "2", "tags": {"host": "b"}}
+	]`)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.JSONEq(t, `{"success": 2, "failed": 0}`, res.Body.String())
+
+	writes := store.Writes()
+	require.Len(t, writes, 2)
+	sort.Slice(writes, func(i, j int) bool {
+		return writes[i].Datapoints[0].Value < writes[j].Datapoints[0].Value
+	})
+	assert.Equal(t, xtime.Millisecond, writes[0].Unit)
+	assert.Equal(t, time.Unix(1500000000, 0), writes[0].Datapoints[0].Timestamp)
+	assert.Equal(t, 2.0, writes[1].Datapoints[0].Value)
+}
+
+func TestPutInvalidDatapoints(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewPutHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	body := `[
+		{"metric": "sys.cpu", "timestamp": 1500000000, "value": 1, "tags": {"host": "a"}},
+		{"metric": "", "timestamp": 1500000000, "value": 1, "tags": {"host": "a"}},
+		{"metric": "sys.cpu", "timestamp": 1500000000, "value": "x", "tags": {"host": "a"}}
+	]`
+
+	res := servePut(h, PutURL+"?details", body)
+	require.Equal(t, http.StatusBadRequest, res.Code)
+
+	var result putResult
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &result))
+	assert.Equal(t, 1, result.Success)
+	assert.Equal(t, 2, result.Failed)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, errMissingMetric.Error(), result.Errors[0].Error)
+	assert.Equal(t, "invalid value: x", result.Errors[1].Error)
+	assert.Equal(t, "x", result.Errors[1].Datapoint.Value)
+
+	// Without details only the first error is returned
+	res = servePut(h, PutURL, body)
+	require.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "2 of 3 datapoints failed to write: missing metric")
+
+	assert.Len(t, store.Writes(), 2)
+}
+
+func TestPutErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	h := NewPutHandler(store, test.NewWorkerPool(t, 4), tally.NoopScope)
+
+	assert.Equal(t, http.StatusBadRequest, servePut(h, PutURL, "").Code)
+	assert.Equal(t, http.StatusBadRequest, servePut(h, PutURL, "{").Code)
+
+	store.SetWriteResult(errors.New("write failed"))
+	res := servePut(h, PutURL, `{"metric": "sys.cpu", "timestamp": 1500000000, "value": 1}`)
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Contains(t, res.Body.String(), "write failed")
+}
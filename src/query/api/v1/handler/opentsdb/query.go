@@ -0,0 +1,281 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/opentsdb"
+	"github.com/m3db/m3/src/query/ts"
+	xjson "github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// QueryURL is the url for the query handler
+	QueryURL = "/api/query"
+
+	startParam        = "start"
+	endParam          = "end"
+	metricQueryParam  = "m"
+	msResolutionParam = "ms"
+
+	// minStep is the finest resolution of queries without downsampling
+	minStep = 10 * time.Second
+
+	// maxDataPoints bounds the number of datapoints of each series of queries
+	// without downsampling, coarsening their step over long time ranges
+	maxDataPoints = 1440
+)
+
+// QueryHTTPMethods are the HTTP methods used with this resource.
+var QueryHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+type queryHandler struct {
+	engine *executor.Engine
+	nowFn  func() time.Time
+}
+
+// queryRequest is the json body of a query request
+type queryRequest struct {
+	Start        jsonTime         `json:"start"`
+	End          jsonTime         `json:"end"`
+	Queries      []opentsdb.Query `json:"queries"`
+	MsResolution bool             `json:"msResolution"`
+}
+
+// NewQueryHandler returns a new instance of the query handler.
+func NewQueryHandler(engine *executor.Engine) http.Handler {
+	return &queryHandler{
+		engine: engine,
+		nowFn:  time.Now,
+	}
+}
+
+func (h *queryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	req, params, rErr := parseQueryRequest(r, h.nowFn())
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	results := make([][]*ts.Series, 0, len(req.Queries))
+	for _, q := range req.Queries {
+		p, err := opentsdb.Parse(q)
+		if err != nil {
+			handler.Error(w, fmt.Errorf("invalid query for metric %s: %v", q.Metric, err), http.StatusBadRequest)
+			return
+		}
+
+		queryParams := params
+		queryParams.Query = p.String()
+		queryParams.Step = queryStep(q, params.End.Sub(params.Start))
+		series, err := native.ReadParsed(ctx, h.engine, w, p, queryParams)
+		if err != nil {
+			logger.Error("unable to execute query", zap.String("query", p.String()), zap.Error(err))
+			handler.Error(w, err, http.StatusBadRequest)
+			return
+		}
+
+		results = append(results, series)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	renderQueryResultsJSON(w, req, results)
+}
+
+// parseQueryRequest parses the json body of a POST request, or the start,
+// end and m params of a GET request
+func parseQueryRequest(
+	r *http.Request,
+	now time.Time,
+) (queryRequest, models.RequestParams, *handler.ParseError) {
+	var (
+		req    queryRequest
+		params = models.RequestParams{Now: now}
+	)
+
+	if r.Method == http.MethodPost {
+		if r.Body == nil {
+			return req, params, handler.NewParseError(errEmptyBody, http.StatusBadRequest)
+		}
+		defer r.Body.Close()
+
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return req, params, handler.NewParseError(err, http.StatusBadRequest)
+		}
+	} else {
+		query := r.URL.Query()
+		req.Start = jsonTime(query.Get(startParam))
+		req.End = jsonTime(query.Get(endParam))
+		_, req.MsResolution = query[msResolutionParam]
+		for _, m := range query[metricQueryParam] {
+			q, err := opentsdb.ParseMetricQuery(m)
+			if err != nil {
+				return req, params, handler.NewParseError(
+					fmt.Errorf("%s: invalid '%s': %v", handler.ErrInvalidParams, metricQueryParam, err),
+					http.StatusBadRequest,
+				)
+			}
+
+			req.Queries = append(req.Queries, q)
+		}
+	}
+
+	if len(req.Queries) == 0 {
+		return req, params, handler.NewParseError(
+			fmt.Errorf("%s: missing queries", handler.ErrInvalidParams),
+			http.StatusBadRequest,
+		)
+	}
+
+	if req.Start == "" {
+		return req, params, handler.NewParseError(
+			fmt.Errorf("%s: missing '%s'", handler.ErrInvalidParams, startParam),
+			http.StatusBadRequest,
+		)
+	}
+
+	start, err := parseTime(string(req.Start), now)
+	if err != nil {
+		return req, params, handler.NewParseError(
+			fmt.Errorf("%s: invalid '%s': %v", handler.ErrInvalidParams, startParam, err),
+			http.StatusBadRequest,
+		)
+	}
+
+	end := now
+	if req.End != "" {
+		end, err = parseTime(string(req.End), now)
+		if err != nil {
+			return req, params, handler.NewParseError(
+				fmt.Errorf("%s: invalid '%s': %v", handler.ErrInvalidParams, endParam, err),
+				http.StatusBadRequest,
+			)
+		}
+	}
+
+	if !start.Before(end) {
+		return req, params, handler.NewParseError(
+			fmt.Errorf("%s: start must be before end", handler.ErrInvalidParams),
+			http.StatusBadRequest,
+		)
+	}
+	params.Start = start
+	params.End = end
+
+	timeout, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return req, params, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	params.Timeout = timeout
+
+	return req, params, nil
+}
+
+// queryStep returns the step of a query, which is its downsample interval
+// or the finest whole number of seconds keeping series within maxDataPoints
+func queryStep(q opentsdb.Query, timeRange time.Duration) time.Duration {
+	if downsample, err := opentsdb.ParseDownsample(q.Downsample); err == nil {
+		return downsample.Interval
+	}
+
+	step := timeRange / maxDataPoints
+	if rounded := step.Truncate(time.Second); rounded < step {
+		step = rounded + time.Second
+	}
+
+	if step < minStep {
+		return minStep
+	}
+
+	return step
+}
+
+// renderQueryResultsJSON writes the series of each query in the OpenTSDB
+// query response format, points with no value are omitted. Which tags were
+// aggregated away is not known after aggregation so aggregateTags is always
+// empty.
+func renderQueryResultsJSON(w io.Writer, req queryRequest, results [][]*ts.Series) {
+	jw := xjson.NewWriter(w)
+	jw.BeginArray()
+	for i, series := range results {
+		for _, s := range series {
+			jw.BeginObject()
+			jw.BeginObjectField("metric")
+			jw.WriteString(req.Queries[i].Metric)
+
+			jw.BeginObjectField("tags")
+			jw.BeginObject()
+			for _, tag := range s.Tags {
+				if tag.Name == models.MetricName {
+					continue
+				}
+
+				jw.BeginObjectField(tag.Name)
+				jw.WriteString(tag.Value)
+			}
+			jw.EndObject()
+
+			jw.BeginObjectField("aggregateTags")
+			jw.BeginArray()
+			jw.EndArray()
+
+			jw.BeginObjectField("dps")
+			jw.BeginObject()
+			vals := s.Values()
+			for j := 0; j < vals.Len(); j++ {
+				dp := vals.DatapointAt(j)
+				if math.IsNaN(dp.Value) {
+					continue
+				}
+
+				timestamp := dp.Timestamp.Unix()
+				if req.MsResolution {
+					timestamp = dp.Timestamp.UnixNano() / int64(time.Millisecond)
+				}
+
+				jw.BeginObjectField(strconv.FormatInt(timestamp, 10))
+				jw.WriteFloat64(dp.Value)
+			}
+			jw.EndObject()
+
+			jw.EndObject()
+		}
+	}
+	jw.EndArray()
+	jw.Close()
+}
@@ -0,0 +1,127 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/opentsdb"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQueryStorage() mock.Storage {
+	bounds := block.Bounds{
+		Start:    time.Unix(1500000000, 0),
+		Duration: 3 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	metas := []block.SeriesMeta{
+		{Name: "a", Tags: models.Tags{{Name: models.MetricName, Value: "sys.cpu"}, {Name: "host", Value: "a"}}},
+		{Name: "b", Tags: models.Tags{{Name: models.MetricName, Value: "sys.cpu"}, {Name: "host", Value: "b"}}},
+	}
+
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, [][]float64{
+		{1, math.NaN(), 3},
+		{2, 2, math.NaN()},
+	})
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	return store
+}
+
+func TestQueryGet(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewQueryHandler(executor.NewEngine(newQueryStorage()))
+	req := httptest.NewRequest(http.MethodGet, QueryURL, nil)
+	req.URL.RawQuery = url.Values{
+		"start": []string{"1500000000"},
+		"end":   []string{"1500000180"},
+		"m":     []string{"none:sys.cpu{host=*}"},
+	}.Encode()
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.JSONEq(t, `[
+		{"metric": "sys.cpu", "tags": {"host": "a"}, "aggregateTags": [], "dps": {"1500000000": 1, "1500000120": 3}},
+		{"metric": "sys.cpu", "tags": {"host": "b"}, "aggregateTags": [], "dps": {"1500000000": 2, "1500000060": 2}}
+	]`, res.Body.String())
+}
+
+func TestQueryPost(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewQueryHandler(executor.NewEngine(newQueryStorage()))
+	req := httptest.NewRequest(http.MethodPost, QueryURL, strings.NewReader(`{
+		"start": 1500000000,
+		"end": "1500000180",
+		"msResolution": true,
+		"queries": [{"aggregator": "sum", "metric": "sys.cpu"}]
+	}`))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.JSONEq(t, `[
+		{"metric": "sys.cpu", "tags": {}, "aggregateTags": [], "dps": {"1500000000000": 3, "1500000060000": 2, "1500000120000": 3}}
+	]`, res.Body.String())
+}
+
+func TestQueryErrors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	h := NewQueryHandler(executor.NewEngine(newQueryStorage()))
+	for _, body := range []string{
+		`{`,
+		`{"start": "1h-ago", "queries": []}`,
+		`{"queries": [{"aggregator": "sum", "metric": "sys.cpu"}]}`,
+		`{"start": "yesterday", "queries": [{"aggregator": "sum", "metric": "sys.cpu"}]}`,
+		`{"start": 1500000180, "end": 1500000000, "queries": [{"aggregator": "sum", "metric": "sys.cpu"}]}`,
+		`{"start": "1h-ago", "queries": [{"aggregator": "p99", "metric": "sys.cpu"}]}`,
+	} {
+		req := httptest.NewRequest(http.MethodPost, QueryURL, strings.NewReader(body))
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code, body)
+	}
+}
+
+func TestQueryStep(t *testing.T) {
+	assert.Equal(t, 5*time.Minute, queryStep(opentsdb.Query{Downsample: "5m-avg"}, time.Hour))
+	assert.Equal(t, minStep, queryStep(opentsdb.Query{}, time.Hour))
+	assert.Equal(t, time.Minute, queryStep(opentsdb.Query{}, 24*time.Hour))
+}
@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3metrics/policy"
 
 	"github.com/golang/snappy"
 )
@@ -63,6 +64,22 @@ func ParsePromCompressedRequest(r *http.Request) ([]byte, *handler.ParseError) {
 	return reqBuf, nil
 }
 
+// ParseStoragePolicy parses the storage policy to restrict a request to,
+// returning the empty storage policy if none is set
+func ParseStoragePolicy(r *http.Request) (policy.StoragePolicy, error) {
+	str := r.Header.Get(handler.StoragePolicyHeader)
+	if str == "" {
+		return policy.EmptyStoragePolicy, nil
+	}
+
+	sp, err := policy.ParseStoragePolicy(str)
+	if err != nil {
+		return policy.EmptyStoragePolicy, fmt.Errorf("%s: invalid '%s': %v", handler.ErrInvalidParams, handler.StoragePolicyHeader, err)
+	}
+
+	return sp, nil
+}
+
 // ParseRequestTimeout parses the input request timeout with a default
 func ParseRequestTimeout(r *http.Request) (time.Duration, error) {
 	timeout := r.Header.Get("timeout")
@@ -26,7 +26,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3metrics/policy"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -75,3 +77,22 @@ func TestTimeoutParse(t *testing.T) {
 	_, err = ParseRequestTimeout(req)
 	assert.Error(t, err)
 }
+
+func TestStoragePolicyParse(t *testing.T) {
+	req, _ := http.NewRequest("POST", "dummy", nil)
+	req.Header.Add(handler.StoragePolicyHeader, "1m:40d")
+
+	sp, err := ParseStoragePolicy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, sp.Resolution().Window)
+	assert.Equal(t, 40*24*time.Hour, sp.Retention().Duration())
+
+	req.Header.Del(handler.StoragePolicyHeader)
+	sp, err = ParseStoragePolicy(req)
+	assert.NoError(t, err)
+	assert.Equal(t, policy.EmptyStoragePolicy, sp)
+
+	req.Header.Add(handler.StoragePolicyHeader, "invalid")
+	_, err = ParseStoragePolicy(req)
+	assert.Error(t, err)
+}
@@ -21,8 +21,10 @@
 package native
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
@@ -30,8 +32,11 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/functions/utils"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util"
 	"github.com/m3db/m3/src/query/util/json"
@@ -46,7 +51,18 @@ const (
 	queryParam        = "query"
 	stepParam         = "step"
 	debugParam        = "debug"
+	explainParam      = "explain"
 	endExclusiveParam = "end-exclusive"
+	timeParam         = "time"
+	matchParam        = "match[]"
+
+	// instantQueryStep is the step used to evaluate instant queries, which
+	// only produce a value at a single timestamp
+	instantQueryStep = time.Second
+
+	// defaultMetadataLookback is how far back series metadata is searched
+	// when no start time is provided
+	defaultMetadataLookback = time.Hour
 
 	formatErrStr = "error parsing param: %s, error: %v"
 )
@@ -91,6 +107,12 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	}
 	params.Timeout = t
 
+	sp, err := prometheus.ParseStoragePolicy(r)
+	if err != nil {
+		return params, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	params.StoragePolicy = sp
+
 	start, err := parseTime(r, startParam)
 	if err != nil {
 		return params, handler.NewParseError(fmt.Errorf(formatErrStr, startParam, err), http.StatusBadRequest)
@@ -115,15 +137,7 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	}
 	params.Query = query
 
-	// Skip debug if unable to parse debug param
-	debugVal := r.FormValue(debugParam)
-	if debugVal != "" {
-		debug, err := strconv.ParseBool(r.FormValue(debugParam))
-		if err != nil {
-			logging.WithContext(r.Context()).Warn("unable to parse debug flag", zap.Any("error", err))
-		}
-		params.Debug = debug
-	}
+	params.Debug = parseDebugFlag(r)
 
 	// Default to including end if unable to parse the flag
 	endExclusiveVal := r.FormValue(endExclusiveParam)
@@ -140,8 +154,193 @@ func parseParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
 	return params, nil
 }
 
+// parseInstantaneousParams parses all params from the GET or POST request
+// for a query evaluated at a single timestamp
+func parseInstantaneousParams(r *http.Request) (models.RequestParams, *handler.ParseError) {
+	params := models.RequestParams{
+		Now:        time.Now(),
+		Step:       instantQueryStep,
+		IncludeEnd: true,
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return params, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	t, err := prometheus.ParseRequestTimeout(r)
+	if err != nil {
+		return params, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	params.Timeout = t
+
+	sp, err := prometheus.ParseStoragePolicy(r)
+	if err != nil {
+		return params, handler.NewParseError(err, http.StatusBadRequest)
+	}
+	params.StoragePolicy = sp
+
+	// Default to evaluating the query at the current time
+	instant, err := parseTime(r, timeParam)
+	if err == errors.ErrNotFound {
+		instant = params.Now
+	} else if err != nil {
+		return params, handler.NewParseError(fmt.Errorf(formatErrStr, timeParam, err), http.StatusBadRequest)
+	}
+	params.Start = instant
+	params.End = instant
+
+	query, err := parseQuery(r)
+	if err != nil {
+		return params, handler.NewParseError(fmt.Errorf(formatErrStr, queryParam, err), http.StatusBadRequest)
+	}
+	params.Query = query
+	params.Debug = parseDebugFlag(r)
+
+	return params, nil
+}
+
+// parseDebugFlag parses the debug flag, defaulting to false if unable to parse it
+func parseDebugFlag(r *http.Request) bool {
+	return parseBoolFlag(r, debugParam)
+}
+
+func parseExplainFlag(r *http.Request) bool {
+	return parseBoolFlag(r, explainParam)
+}
+
+func parseBoolFlag(r *http.Request, param string) bool {
+	val := r.FormValue(param)
+	if val == "" {
+		return false
+	}
+
+	flag, err := strconv.ParseBool(val)
+	if err != nil {
+		logging.WithContext(r.Context()).Warn("unable to parse flag",
+			zap.String("param", param), zap.Any("error", err))
+	}
+
+	return flag
+}
+
+// parseMatchQueries parses the series selectors and time bounds of a metadata
+// request into a fetch query per selector; if no selectors are given, the
+// default matchers are used instead
+func parseMatchQueries(
+	r *http.Request,
+	defaultMatchers models.Matchers,
+) ([]*storage.FetchQuery, *handler.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	end, err := parseTime(r, endParam)
+	if err == errors.ErrNotFound {
+		end = time.Now()
+	} else if err != nil {
+		return nil, handler.NewParseError(fmt.Errorf(formatErrStr, endParam, err), http.StatusBadRequest)
+	}
+
+	start, err := parseTime(r, startParam)
+	if err == errors.ErrNotFound {
+		start = end.Add(-defaultMetadataLookback)
+	} else if err != nil {
+		return nil, handler.NewParseError(fmt.Errorf(formatErrStr, startParam, err), http.StatusBadRequest)
+	}
+
+	if start.After(end) {
+		err := fmt.Errorf("start: %v cannot be after end: %v", start, end)
+		return nil, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	selectors := r.Form[matchParam]
+	if len(selectors) == 0 {
+		return []*storage.FetchQuery{{
+			TagMatchers: defaultMatchers,
+			Start:       start,
+			End:         end,
+		}}, nil
+	}
+
+	queries := make([]*storage.FetchQuery, 0, len(selectors))
+	for _, selector := range selectors {
+		matchers, err := promql.ParseMatchers(selector)
+		if err != nil {
+			return nil, handler.NewParseError(fmt.Errorf(formatErrStr, matchParam, err), http.StatusBadRequest)
+		}
+
+		queries = append(queries, &storage.FetchQuery{
+			Raw:         selector,
+			TagMatchers: matchers,
+			Start:       start,
+			End:         end,
+		})
+	}
+
+	return queries, nil
+}
+
+// completeMatchingTags returns the unique tag names, and unless nameOnly is
+// set the unique values of the filtered tag names, of the series matched by
+// any of the queries
+func completeMatchingTags(
+	ctx context.Context,
+	querier storage.Querier,
+	queries []*storage.FetchQuery,
+	nameOnly bool,
+	filterNameTags [][]byte,
+) (storage.CompleteTagsResult, error) {
+	builder := storage.NewCompleteTagsResultBuilder(nameOnly)
+	for _, query := range queries {
+		result, err := querier.CompleteTags(ctx, &storage.CompleteTagsQuery{
+			CompleteNameOnly: nameOnly,
+			FilterNameTags:   filterNameTags,
+			TagMatchers:      query.TagMatchers,
+			Start:            query.Start,
+			End:              query.End,
+		}, &storage.FetchOptions{})
+		if err != nil {
+			return storage.CompleteTagsResult{}, err
+		}
+
+		if err := builder.Add(result); err != nil {
+			return storage.CompleteTagsResult{}, err
+		}
+	}
+
+	return builder.Build(), nil
+}
+
+// writeMetadataResponse writes a successful Prometheus metadata response
+func writeMetadataResponse(
+	w http.ResponseWriter,
+	data interface{},
+	warnings storage.Warnings,
+	logger *zap.Logger,
+) {
+	response := metadataResponse{
+		Status:   "success",
+		Data:     data,
+		Warnings: warnings.Strings(),
+	}
+
+	handler.SetWarningsHeader(w, response.Warnings)
+	handler.WriteJSONResponse(w, response, logger)
+}
+
+type metadataResponse struct {
+	Status   string      `json:"status"`
+	Data     interface{} `json:"data"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
 func parseQuery(r *http.Request) (string, error) {
-	queries, ok := r.URL.Query()[queryParam]
+	if err := r.ParseForm(); err != nil {
+		return "", err
+	}
+
+	// NB: the form contains both the URL query and any form-encoded POST body
+	queries, ok := r.Form[queryParam]
 	if !ok || len(queries) == 0 || queries[0] == "" {
 		return "", errors.ErrNoQueryFound
 	}
@@ -154,7 +353,7 @@ func parseQuery(r *http.Request) (string, error) {
 	return queries[0], nil
 }
 
-func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestParams) {
+func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestParams, warnings []string) {
 	jw := json.NewWriter(w)
 	jw.BeginObject()
 
@@ -205,13 +404,114 @@ func renderResultsJSON(w io.Writer, series []*ts.Series, params models.RequestPa
 		if ok {
 			jw.BeginObjectField("step_size_ms")
 			jw.WriteInt(int(fixedStep.Resolution() / time.Millisecond))
-			jw.EndObject()
 		}
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+
+	writeWarnings(jw, warnings)
+
+	jw.EndObject()
+	jw.Close()
+}
+
+// writeWarnings writes the warnings field of a response, the field is left
+// out if there are none.
+func writeWarnings(jw *json.Writer, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+
+	jw.BeginObjectField("warnings")
+	jw.BeginArray()
+	for _, warning := range warnings {
+		jw.WriteString(warning)
+	}
+	jw.EndArray()
+}
+
+func writeInstantValue(jw *json.Writer, instant time.Time, value float64) {
+	jw.BeginArray()
+	jw.WriteFloat64(float64(instant.UnixNano()) / float64(time.Second))
+	jw.WriteString(utils.FormatFloat(value))
+	jw.EndArray()
+}
+
+func renderInstantVectorResultsJSON(w io.Writer, series []*ts.Series, instant time.Time, warnings []string) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("resultType")
+	jw.WriteString("vector")
+
+	jw.BeginObjectField("result")
+	jw.BeginArray()
+	for _, s := range series {
+		// Series without a value at the instant are not part of the result
+		dp, ok := executor.InstantValue(s, instant)
+		if !ok {
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("metric")
+		jw.BeginObject()
+		for _, t := range s.Tags {
+			jw.BeginObjectField(t.Name)
+			jw.WriteString(t.Value)
+		}
+		jw.EndObject()
+
+		jw.BeginObjectField("value")
+		writeInstantValue(jw, instant, dp.Value)
+		jw.EndObject()
 	}
 	jw.EndArray()
 
 	jw.EndObject()
 
+	writeWarnings(jw, warnings)
+
+	jw.EndObject()
+	jw.Close()
+}
+
+func renderScalarResultJSON(w io.Writer, series []*ts.Series, instant time.Time, warnings []string) {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	jw.BeginObjectField("resultType")
+	jw.WriteString("scalar")
+
+	// A scalar evaluates to a single series without tags
+	value := math.NaN()
+	if len(series) > 0 {
+		if dp, ok := executor.InstantValue(series[0], instant); ok {
+			value = dp.Value
+		}
+	}
+
+	jw.BeginObjectField("result")
+	writeInstantValue(jw, instant, value)
+
+	jw.EndObject()
+
+	writeWarnings(jw, warnings)
+
 	jw.EndObject()
 	jw.Close()
 }
@@ -23,6 +23,7 @@ package native
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/url"
 	"testing"
@@ -120,7 +121,7 @@ func TestRenderResultsJSON(t *testing.T) {
 		}),
 	}
 
-	renderResultsJSON(buffer, series, params)
+	renderResultsJSON(buffer, series, params, nil)
 
 	expected := mustPrettyJSON(t, `
 	{
@@ -170,6 +171,142 @@ func TestRenderResultsJSON(t *testing.T) {
 	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
 }
 
+func TestInstantaneousParamParsing(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, "1535948880")
+	req.URL.RawQuery = vals.Encode()
+
+	r, err := parseInstantaneousParams(req)
+	require.Nil(t, err, "unable to parse request")
+	require.Equal(t, promQuery, r.Query)
+	assert.Equal(t, time.Unix(1535948880, 0), r.Start)
+	assert.Equal(t, r.Start, r.End)
+	assert.Equal(t, instantQueryStep, r.Step)
+	assert.True(t, r.IncludeEnd)
+}
+
+func TestInstantaneousParamParsingDefaultsToNow(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	req.URL.RawQuery = vals.Encode()
+
+	r, err := parseInstantaneousParams(req)
+	require.Nil(t, err, "unable to parse request")
+	assert.Equal(t, r.Now, r.Start)
+	assert.Equal(t, r.Now, r.End)
+}
+
+func TestInstantaneousParamParsingInvalidTime(t *testing.T) {
+	req, _ := http.NewRequest("GET", PromReadInstantURL, nil)
+	vals := url.Values{}
+	vals.Add(queryParam, promQuery)
+	vals.Add(timeParam, "foo")
+	req.URL.RawQuery = vals.Encode()
+
+	_, err := parseInstantaneousParams(req)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code())
+}
+
+func TestRenderInstantVectorResultsJSON(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+
+	buffer := bytes.NewBuffer(nil)
+	series := []*ts.Series{
+		ts.NewSeries("foo", ts.NewFixedStepValues(10*time.Second, 2, 1, start), models.Tags{
+			models.Tag{Name: "bar", Value: "baz"},
+		}),
+		ts.NewSeries("bar", ts.NewFixedStepValues(10*time.Second, 2, math.NaN(), start), models.Tags{
+			models.Tag{Name: "baz", Value: "bar"},
+		}),
+	}
+
+	renderInstantVectorResultsJSON(buffer, series, start.Add(10*time.Second), nil)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "vector",
+			"result": [
+				{
+					"metric": {
+						"bar": "baz"
+					},
+					"value": [1535948890, "1"]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, buffer.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestRenderScalarResultJSON(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+
+	buffer := bytes.NewBuffer(nil)
+	series := []*ts.Series{
+		ts.NewSeries("", ts.NewFixedStepValues(time.Second, 1, 3.5, start), models.EmptyTags()),
+	}
+
+	renderScalarResultJSON(buffer, series, start, nil)
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "scalar",
+			"result": [1535948880, "3.5"]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, buffer.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestParseMatchQueries(t *testing.T) {
+	vals := url.Values{}
+	vals.Add(matchParam, "up")
+	vals.Add(matchParam, `{job="api"}`)
+	vals.Add(startParam, "1535948880")
+	vals.Add(endParam, "1535949880")
+	req, _ := http.NewRequest("GET", ListTagsURL+"?"+vals.Encode(), nil)
+
+	queries, err := parseMatchQueries(req, nil)
+	require.Nil(t, err)
+	require.Len(t, queries, 2)
+	assert.Equal(t, "up", queries[0].Raw)
+	assert.Equal(t, `{job="api"}`, queries[1].Raw)
+	for _, q := range queries {
+		require.Len(t, q.TagMatchers, 1)
+		assert.Equal(t, time.Unix(1535948880, 0), q.Start)
+		assert.Equal(t, time.Unix(1535949880, 0), q.End)
+	}
+}
+
+func TestParseMatchQueriesDefaults(t *testing.T) {
+	req, _ := http.NewRequest("GET", ListTagsURL, nil)
+	defaultMatchers := models.Matchers{{Type: models.MatchEqual, Name: "foo", Value: "bar"}}
+
+	queries, err := parseMatchQueries(req, defaultMatchers)
+	require.Nil(t, err)
+	require.Len(t, queries, 1)
+	assert.Equal(t, defaultMatchers, queries[0].TagMatchers)
+	assert.Equal(t, defaultMetadataLookback, queries[0].End.Sub(queries[0].Start))
+}
+
+func TestParseMatchQueriesStartAfterEnd(t *testing.T) {
+	req, _ := http.NewRequest("GET", ListTagsURL+"?start=1535949880&end=1535948880", nil)
+	_, err := parseMatchQueries(req, nil)
+	require.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, err.Code())
+}
+
 func mustPrettyJSON(t *testing.T, str string) string {
 	var unmarshalled map[string]interface{}
 	err := json.Unmarshal([]byte(str), &unmarshalled)
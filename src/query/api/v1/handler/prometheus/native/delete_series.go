@@ -0,0 +1,103 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromDeleteSeriesURL is the url for deleting the series matching
+	// selectors, this matches the default URL for the delete series endpoint
+	// found on a Prometheus server
+	PromDeleteSeriesURL = handler.RoutePrefixV1 + "/admin/tsdb/delete_series"
+)
+
+var (
+	// PromDeleteSeriesHTTPMethods are the HTTP methods for this handler.
+	PromDeleteSeriesHTTPMethods = []string{
+		http.MethodPost,
+		http.MethodPut,
+	}
+)
+
+// PromDeleteSeriesHandler represents a handler for the prometheus delete series endpoint.
+type PromDeleteSeriesHandler struct {
+	store storage.Storage
+}
+
+// NewPromDeleteSeriesHandler returns a new instance of handler.
+func NewPromDeleteSeriesHandler(storage storage.Storage) http.Handler {
+	return &PromDeleteSeriesHandler{store: storage}
+}
+
+func (h *PromDeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	if err := r.ParseForm(); err != nil {
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if len(r.Form[matchParam]) == 0 {
+		handler.Error(w, errNoMatchers, http.StatusBadRequest)
+		return
+	}
+
+	// Deletes always remove all data of the matched series since tombstones
+	// are not scoped to a time range
+	for _, param := range []string{startParam, endParam} {
+		if r.FormValue(param) != "" {
+			err := fmt.Errorf("deleting a time range is not supported")
+			handler.Error(w, fmt.Errorf(formatErrStr, param, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	queries, rErr := parseMatchQueries(r, nil)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	for _, query := range queries {
+		err := h.store.Delete(ctx, &storage.DeleteQuery{
+			Raw:         query.Raw,
+			TagMatchers: query.TagMatchers,
+		})
+		if err != nil {
+			logger.Error("unable to delete series",
+				zap.String("match", query.Raw), zap.Error(err))
+			handler.Error(w, err, http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
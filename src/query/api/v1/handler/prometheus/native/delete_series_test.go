@@ -0,0 +1,95 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDeleteSeriesRequest(vals url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, PromDeleteSeriesURL, strings.NewReader(vals.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func TestPromDeleteSeries(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(matchParam, `up{instance="a"}`)
+	vals.Add(matchParam, `{job="b"}`)
+	store := mock.NewMockStorage()
+	res := httptest.NewRecorder()
+	NewPromDeleteSeriesHandler(store).ServeHTTP(res, newDeleteSeriesRequest(vals))
+
+	require.Equal(t, http.StatusNoContent, res.Code, res.Body.String())
+	deletes := store.Deletes()
+	require.Equal(t, 2, len(deletes))
+	assert.Equal(t, `up{instance="a"}`, deletes[0].Raw)
+	require.Equal(t, 2, len(deletes[0].TagMatchers))
+	assert.Equal(t, models.MatchEqual, deletes[0].TagMatchers[0].Type)
+	assert.Equal(t, `{job="b"}`, deletes[1].Raw)
+	require.Equal(t, 1, len(deletes[1].TagMatchers))
+	assert.Equal(t, "job", deletes[1].TagMatchers[0].Name)
+	assert.Equal(t, "b", deletes[1].TagMatchers[0].Value)
+}
+
+func TestPromDeleteSeriesStorageError(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(matchParam, "up")
+	store := mock.NewMockStorage()
+	store.SetDeleteResult(errors.New("delete failed"))
+	res := httptest.NewRecorder()
+	NewPromDeleteSeriesHandler(store).ServeHTTP(res, newDeleteSeriesRequest(vals))
+
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+}
+
+func TestPromDeleteSeriesBadRequest(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	for _, vals := range []url.Values{
+		{},
+		{matchParam: []string{"up{"}},
+		{matchParam: []string{"up"}, startParam: []string{"0"}},
+		{matchParam: []string{"up"}, endParam: []string{"0"}},
+	} {
+		store := mock.NewMockStorage()
+		res := httptest.NewRecorder()
+		NewPromDeleteSeriesHandler(store).ServeHTTP(res, newDeleteSeriesRequest(vals))
+		assert.Equal(t, http.StatusBadRequest, res.Code, vals.Encode())
+		assert.Empty(t, store.Deletes())
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/plan"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+type explainResponse struct {
+	Status string      `json:"status"`
+	Data   explainData `json:"data"`
+}
+
+type explainData struct {
+	Query        string              `json:"query"`
+	DAG          explainDAG          `json:"dag"`
+	LogicalPlan  explainPlan         `json:"logicalPlan"`
+	PhysicalPlan explainPhysicalPlan `json:"physicalPlan"`
+	Execution    explainExecution    `json:"execution"`
+}
+
+type explainDAG struct {
+	Nodes []explainNode `json:"nodes"`
+	Edges []explainEdge `json:"edges"`
+}
+
+type explainNode struct {
+	ID          parser.NodeID   `json:"id"`
+	Op          string          `json:"op"`
+	Description string          `json:"description"`
+	Parents     []parser.NodeID `json:"parents,omitempty"`
+	Children    []parser.NodeID `json:"children,omitempty"`
+}
+
+type explainEdge struct {
+	Parent parser.NodeID `json:"parent"`
+	Child  parser.NodeID `json:"child"`
+}
+
+type explainPlan struct {
+	Pipeline []parser.NodeID `json:"pipeline"`
+	Steps    []explainNode   `json:"steps"`
+}
+
+type explainPhysicalPlan struct {
+	explainPlan
+	Result parser.NodeID `json:"result"`
+	// Start is shifted back from the start of the query by the largest
+	// range and offset of the query, so the data they need is fetched
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Now   time.Time `json:"now"`
+	Step  string    `json:"step"`
+}
+
+type explainExecution struct {
+	Duration string             `json:"duration"`
+	Series   int                `json:"series"`
+	Nodes    []explainNodeStats `json:"nodes"`
+}
+
+type explainNodeStats struct {
+	ID         parser.NodeID `json:"id"`
+	Op         string        `json:"op"`
+	Blocks     int           `json:"blocks"`
+	Series     int           `json:"series"`
+	Datapoints int           `json:"datapoints"`
+	Duration   string        `json:"duration"`
+}
+
+// explain executes a query, responding with an explanation of how it was
+// planned and executed in place of its results
+func explain(
+	ctx context.Context,
+	engine *executor.Engine,
+	w http.ResponseWriter,
+	params models.RequestParams,
+) {
+	logger := logging.WithContext(ctx)
+	query, err := promql.Parse(params.Query)
+	if err != nil {
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	series, explanation, err := ExplainParsed(ctx, engine, w, query, params)
+	if err != nil {
+		logger.Error("unable to explain query", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	data := newExplainData(params.Query, explanation)
+	data.Execution.Duration = time.Since(start).String()
+	data.Execution.Series = len(series)
+	handler.WriteJSONResponse(w, explainResponse{Status: "success", Data: data}, logger)
+}
+
+func newExplainData(query string, explanation *executor.Explanation) explainData {
+	data := explainData{
+		Query: query,
+		DAG: explainDAG{
+			Nodes: make([]explainNode, 0, len(explanation.Nodes)),
+			Edges: make([]explainEdge, 0, len(explanation.Edges)),
+		},
+	}
+
+	for _, node := range explanation.Nodes {
+		data.DAG.Nodes = append(data.DAG.Nodes, newExplainNode(node))
+	}
+
+	for _, edge := range explanation.Edges {
+		data.DAG.Edges = append(data.DAG.Edges, explainEdge{Parent: edge.ParentID, Child: edge.ChildID})
+	}
+
+	lp := explanation.LogicalPlan
+	data.LogicalPlan = newExplainPlan(lp.Pipeline, func(ID parser.NodeID) (plan.LogicalStep, bool) {
+		step, ok := lp.Steps[ID]
+		return step, ok
+	})
+
+	pp := explanation.PhysicalPlan
+	data.PhysicalPlan = explainPhysicalPlan{
+		explainPlan: newExplainPlan(pp.Pipeline(), pp.Step),
+		Result:      pp.ResultStep.Parent,
+		Start:       pp.TimeSpec.Start,
+		End:         pp.TimeSpec.End,
+		Now:         pp.TimeSpec.Now,
+		Step:        pp.TimeSpec.Step.String(),
+	}
+
+	stats := explanation.Stats.Snapshot()
+	data.Execution.Nodes = make([]explainNodeStats, 0, len(stats))
+	for _, ID := range pp.Pipeline() {
+		nodeStats, ok := stats[ID]
+		if !ok {
+			// The node was not executed
+			continue
+		}
+
+		var op string
+		if step, ok := pp.Step(ID); ok {
+			op = step.Transform.Op.OpType()
+		}
+
+		data.Execution.Nodes = append(data.Execution.Nodes, explainNodeStats{
+			ID:         ID,
+			Op:         op,
+			Blocks:     nodeStats.Blocks,
+			Series:     nodeStats.Series,
+			Datapoints: nodeStats.Datapoints,
+			Duration:   nodeStats.Duration.String(),
+		})
+	}
+
+	return data
+}
+
+func newExplainPlan(
+	pipeline []parser.NodeID,
+	stepFn func(ID parser.NodeID) (plan.LogicalStep, bool),
+) explainPlan {
+	explained := explainPlan{
+		Pipeline: pipeline,
+		Steps:    make([]explainNode, 0, len(pipeline)),
+	}
+
+	for _, ID := range pipeline {
+		step, ok := stepFn(ID)
+		if !ok {
+			continue
+		}
+
+		node := newExplainNode(step.Transform)
+		node.Parents = step.Parents
+		node.Children = step.Children
+		explained.Steps = append(explained.Steps, node)
+	}
+
+	return explained
+}
+
+func newExplainNode(node parser.Node) explainNode {
+	return explainNode{
+		ID:          node.ID,
+		Op:          node.Op.OpType(),
+		Description: node.Op.String(),
+	}
+}
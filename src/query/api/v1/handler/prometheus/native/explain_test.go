@@ -0,0 +1,110 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromReadExplain(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+
+	vals := defaultParams()
+	vals.Set(queryParam, "sum(http_requests_total offset 5m)")
+	vals.Set(explainParam, "true")
+	req := httptest.NewRequest(http.MethodGet, PromReadURL+"?"+vals.Encode(), nil)
+	res := httptest.NewRecorder()
+	NewPromReadHandler(executor.NewEngine(mockStorage)).ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+
+	var resp explainResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &resp))
+	assert.Equal(t, "success", resp.Status)
+
+	data := resp.Data
+	assert.Equal(t, "sum(http_requests_total offset 5m)", data.Query)
+	require.Len(t, data.DAG.Nodes, 2)
+	require.Len(t, data.DAG.Edges, 1)
+	fetch, sum := data.DAG.Nodes[0], data.DAG.Nodes[1]
+	assert.Equal(t, "fetch", fetch.Op)
+	assert.Equal(t, "sum", sum.Op)
+	assert.Equal(t, explainEdge{Parent: fetch.ID, Child: sum.ID}, data.DAG.Edges[0])
+
+	require.Len(t, data.LogicalPlan.Steps, 2)
+	assert.Equal(t, []string{string(sum.ID)}, idStrings(data.LogicalPlan.Steps[0].Children))
+
+	// The fetch is shifted back by the offset of the query
+	start, err := time.Parse(time.RFC3339, vals.Get(startParam))
+	require.NoError(t, err)
+	assert.True(t, start.Add(-5*time.Minute).Equal(data.PhysicalPlan.Start),
+		"unexpected physical plan start: %v", data.PhysicalPlan.Start)
+	assert.Equal(t, sum.ID, data.PhysicalPlan.Result)
+	assert.Equal(t, "10s", data.PhysicalPlan.Step)
+
+	assert.Equal(t, 1, data.Execution.Series)
+	require.Len(t, data.Execution.Nodes, 2)
+	fetchStats, sumStats := data.Execution.Nodes[0], data.Execution.Nodes[1]
+	assert.Equal(t, explainNodeStats{
+		ID:         fetch.ID,
+		Op:         "fetch",
+		Blocks:     1,
+		Series:     2,
+		Datapoints: 10,
+		Duration:   fetchStats.Duration,
+	}, fetchStats)
+	assert.Equal(t, explainNodeStats{
+		ID:         sum.ID,
+		Op:         "sum",
+		Blocks:     1,
+		Series:     1,
+		Datapoints: 5,
+		Duration:   sumStats.Duration,
+	}, sumStats)
+}
+
+func idStrings(IDs []parser.NodeID) []string {
+	strs := make([]string, 0, len(IDs))
+	for _, ID := range IDs {
+		strs = append(strs, string(ID))
+	}
+
+	return strs
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ListTagsURL is the url for listing tag names, this matches the default
+	// URL for the label names endpoint found on a Prometheus server
+	ListTagsURL = handler.RoutePrefixV1 + "/labels"
+
+	// ListTagsHTTPMethod is the HTTP method used with this resource.
+	ListTagsHTTPMethod = http.MethodGet
+)
+
+// ListTagsHandler represents a handler for the list tags endpoint.
+type ListTagsHandler struct {
+	store storage.Storage
+}
+
+// NewListTagsHandler returns a new instance of handler.
+func NewListTagsHandler(storage storage.Storage) http.Handler {
+	return &ListTagsHandler{store: storage}
+}
+
+func (h *ListTagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	// Without selectors, match every series with a name
+	matchAll, err := models.NewMatcher(models.MatchRegexp, models.MetricName, ".+")
+	if err != nil {
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	queries, rErr := parseMatchQueries(r, models.Matchers{matchAll})
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	result, err := completeMatchingTags(ctx, h.store, queries, true, nil)
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeMetadataResponse(w, tagNames(result), result.Warnings, logger)
+}
+
+// tagNames returns the names of the completed tags, which are already sorted
+// and unique
+func tagNames(result storage.CompleteTagsResult) []string {
+	names := make([]string, 0, len(result.CompletedTags))
+	for _, tag := range result.CompletedTags {
+		names = append(names, string(tag.Name))
+	}
+
+	return names
+}
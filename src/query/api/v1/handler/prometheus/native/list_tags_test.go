@@ -0,0 +1,156 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tagsStorage emulates the index aggregation of the tags of a fixed set of
+// series, ignoring the query matchers
+type tagsStorage struct {
+	mock.Storage
+
+	series  []models.Tags
+	queries []*storage.CompleteTagsQuery
+}
+
+func newTagsStorage() *tagsStorage {
+	return &tagsStorage{
+		Storage: mock.NewMockStorage(),
+		series: []models.Tags{
+			{
+				{Name: models.MetricName, Value: "up"},
+				{Name: "instance", Value: "a"},
+				{Name: "job", Value: "api"},
+			},
+			{
+				{Name: models.MetricName, Value: "up"},
+				{Name: "dc", Value: "east"},
+				{Name: "instance", Value: "b"},
+				{Name: "job", Value: "api"},
+			},
+		},
+	}
+}
+
+func (s *tagsStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	_ *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	s.queries = append(s.queries, query)
+
+	filtered := func(name string) bool {
+		if len(query.FilterNameTags) == 0 {
+			return false
+		}
+		for _, filter := range query.FilterNameTags {
+			if string(filter) == name {
+				return false
+			}
+		}
+		return true
+	}
+
+	result := &storage.CompleteTagsResult{CompleteNameOnly: query.CompleteNameOnly}
+	for _, tags := range s.series {
+		for _, tag := range tags {
+			if filtered(tag.Name) {
+				continue
+			}
+
+			completed := storage.CompletedTag{Name: []byte(tag.Name)}
+			if !query.CompleteNameOnly {
+				completed.Values = [][]byte{[]byte(tag.Value)}
+			}
+			result.CompletedTags = append(result.CompletedTags, completed)
+		}
+	}
+
+	return result, nil
+}
+
+func TestListTags(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newTagsStorage()
+	req := httptest.NewRequest(ListTagsHTTPMethod, ListTagsURL+`?match[]=up&match[]={job="api"}`, nil)
+	res := httptest.NewRecorder()
+	NewListTagsHandler(store).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	expected := `{"status":"success","data":["__name__","dc","instance","job"]}`
+	assert.Equal(t, expected, res.Body.String())
+
+	require.Equal(t, 2, len(store.queries))
+	for _, query := range store.queries {
+		assert.True(t, query.CompleteNameOnly)
+		assert.Nil(t, query.FilterNameTags)
+	}
+}
+
+func TestListTagsEmpty(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetCompleteTagsResult(&storage.CompleteTagsResult{CompleteNameOnly: true}, nil)
+
+	req := httptest.NewRequest(ListTagsHTTPMethod, ListTagsURL, nil)
+	res := httptest.NewRecorder()
+	NewListTagsHandler(store).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `{"status":"success","data":[]}`, res.Body.String())
+}
+
+func TestListTagsInvalidSelector(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req := httptest.NewRequest(ListTagsHTTPMethod, ListTagsURL+"?match[]=sum(up)", nil)
+	res := httptest.NewRecorder()
+	NewListTagsHandler(newTagsStorage()).ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestListTagsFetchError(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetCompleteTagsResult(nil, errors.New("fetch error"))
+
+	req := httptest.NewRequest(ListTagsHTTPMethod, ListTagsURL, nil)
+	res := httptest.NewRecorder()
+	NewListTagsHandler(store).ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	pql "github.com/prometheus/prometheus/promql"
+	"go.uber.org/zap"
+)
+
+const (
+	// PromReadInstantURL is the url for native instantaneous prom read
+	// handler, this matches the default URL for the query endpoint
+	// found on a Prometheus server
+	PromReadInstantURL = handler.RoutePrefixV1 + "/query"
+)
+
+var (
+	// PromReadInstantHTTPMethods are the HTTP methods for this handler.
+	PromReadInstantHTTPMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+	}
+
+	errExplainRangeSelector = errors.New("range vector selectors are read without planning and can not be explained")
+)
+
+// PromReadInstantHandler represents a handler for prometheus instantaneous read endpoint.
+type PromReadInstantHandler struct {
+	engine *executor.Engine
+}
+
+// NewPromReadInstantHandler returns a new instance of handler.
+func NewPromReadInstantHandler(engine *executor.Engine) http.Handler {
+	return &PromReadInstantHandler{engine: engine}
+}
+
+func (h *PromReadInstantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	params, rErr := parseInstantaneousParams(r)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if params.Debug {
+		logger.Info("Request params", zap.Any("params", params))
+	}
+
+	// The result shape depends on the type the expression evaluates to
+	expr, err := pql.ParseExpr(params.Query)
+	if err != nil {
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	instant := params.End
+	resultType := expr.Type()
+	switch resultType {
+	case pql.ValueTypeMatrix:
+		selector, ok := expr.(*pql.MatrixSelector)
+		if !ok {
+			handler.Error(w, fmt.Errorf("unsupported range expression: %s", expr), http.StatusBadRequest)
+			return
+		}
+
+		if parseExplainFlag(r) {
+			handler.Error(w, errExplainRangeSelector, http.StatusBadRequest)
+			return
+		}
+
+		h.serveRange(ctx, w, selector, params)
+		return
+
+	case pql.ValueTypeVector, pql.ValueTypeScalar:
+
+	default:
+		handler.Error(w, fmt.Errorf("unsupported result type: %s", resultType), http.StatusBadRequest)
+		return
+	}
+
+	if parseExplainFlag(r) {
+		explain(ctx, h.engine, w, params)
+		return
+	}
+
+	result, err := read(ctx, h.engine, w, params)
+	if err != nil {
+		logger.Error("unable to fetch data", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	warnings := result.Warnings.Strings()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	handler.SetWarningsHeader(w, warnings)
+	switch resultType {
+	case pql.ValueTypeScalar:
+		renderScalarResultJSON(w, result.Series, instant, warnings)
+	default:
+		renderInstantVectorResultsJSON(w, result.Series, instant, warnings)
+	}
+}
+
+// serveRange responds with the raw datapoints of a range vector selector,
+// which are within its range before the instant shifted by its offset.
+func (h *PromReadInstantHandler) serveRange(
+	ctx context.Context,
+	w http.ResponseWriter,
+	selector *pql.MatrixSelector,
+	params models.RequestParams,
+) {
+	end := params.End.Add(-selector.Offset)
+	params.Start = end.Add(-selector.Range)
+	params.End = end
+
+	result, err := readRange(ctx, h.engine, w, selector, params)
+	if err != nil {
+		logging.WithContext(ctx).Error("unable to fetch data", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	warnings := result.Warnings.Strings()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	handler.SetWarningsHeader(w, warnings)
+	renderResultsJSON(w, result.Series, params, warnings)
+}
+
+func readRange(
+	reqCtx context.Context,
+	engine *executor.Engine,
+	w http.ResponseWriter,
+	selector *pql.MatrixSelector,
+	params models.RequestParams,
+) (executor.ReadResult, error) {
+	matchers, err := promql.MatrixSelectorMatchers(selector)
+	if err != nil {
+		return executor.ReadResult{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(reqCtx, params.Timeout)
+	defer cancel()
+
+	// Datapoints at the end of the range are included
+	query := &storage.FetchQuery{
+		Raw:         params.Query,
+		TagMatchers: matchers,
+		Start:       params.Start,
+		End:         params.End.Add(time.Nanosecond),
+	}
+
+	// Detect clients closing connections
+	abortCh, closingCh := handler.CloseWatcher(ctx, w)
+	opts := &executor.EngineOptions{
+		AbortCh:       abortCh,
+		StoragePolicy: params.StoragePolicy,
+	}
+
+	// Results is closed by execute
+	results := make(chan *storage.QueryResult)
+	go engine.Execute(ctx, query, opts, closingCh, results)
+
+	var rangeResult executor.ReadResult
+	for result := range results {
+		if result.Err != nil {
+			return executor.ReadResult{}, result.Err
+		}
+
+		for _, s := range result.FetchResult.SeriesList {
+			rangeResult.Series = append(rangeResult.Series, seriesInRange(s, params.Start, params.End))
+		}
+
+		rangeResult.Warnings = append(rangeResult.Warnings, result.FetchResult.Warnings...)
+	}
+
+	return rangeResult, nil
+}
+
+// seriesInRange returns the datapoints of a series within a range, inclusive
+func seriesInRange(s *ts.Series, start, end time.Time) *ts.Series {
+	var (
+		values     = s.Values()
+		datapoints = make(ts.Datapoints, 0, s.Len())
+	)
+	for i := 0; i < s.Len(); i++ {
+		dp := values.DatapointAt(i)
+		if dp.Timestamp.Before(start) || dp.Timestamp.After(end) {
+			continue
+		}
+
+		datapoints = append(datapoints, dp)
+	}
+
+	return ts.NewSeries(s.Name(), datapoints, s.Tags)
+}
@@ -0,0 +1,191 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	xtest "github.com/m3db/m3/src/dbnode/x/test"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPromReadInstantHandler() http.Handler {
+	start := time.Unix(1535948880, 0)
+	values, bounds := test.GenerateValuesAndBounds(nil, &block.Bounds{
+		Start:    start,
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	})
+	b := test.NewBlockFromValues(bounds, values)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b}}, nil)
+	return NewPromReadInstantHandler(executor.NewEngine(mockStorage))
+}
+
+func TestPromReadInstantVector(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, "dummy0")
+	vals.Add(timeParam, "1535949000")
+	req := httptest.NewRequest(http.MethodGet, PromReadInstantURL+"?"+vals.Encode(), nil)
+	res := httptest.NewRecorder()
+	newTestPromReadInstantHandler().ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+
+	expected := mustPrettyJSON(t, `
+	{
+		"status": "success",
+		"data": {
+			"resultType": "vector",
+			"result": [
+				{
+					"metric": {
+						"__name__": "dummy0",
+						"dummy0": "dummy0"
+					},
+					"value": [1535949000, "2"]
+				},
+				{
+					"metric": {
+						"__name__": "dummy1",
+						"dummy1": "dummy1"
+					},
+					"value": [1535949000, "7"]
+				}
+			]
+		}
+	}
+	`)
+	actual := mustPrettyJSON(t, res.Body.String())
+	assert.Equal(t, expected, actual, xtest.Diff(expected, actual))
+}
+
+func TestPromReadInstantFormPost(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, "dummy0")
+	vals.Add(timeParam, "1535949000")
+	req := httptest.NewRequest(http.MethodPost, PromReadInstantURL, strings.NewReader(vals.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := httptest.NewRecorder()
+	newTestPromReadInstantHandler().ServeHTTP(res, req)
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Contains(t, res.Body.String(), `"resultType":"vector"`)
+}
+
+func TestPromReadInstantUnsupportedExpression(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(queryParam, `"foo"`)
+	req := httptest.NewRequest(http.MethodGet, PromReadInstantURL+"?"+vals.Encode(), nil)
+	res := httptest.NewRecorder()
+	newTestPromReadInstantHandler().ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestPromReadInstantRangeSelector(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	instant := time.Unix(1535949000, 0)
+	datapoints := ts.Datapoints{}
+	for _, offset := range []time.Duration{-6 * time.Minute, -5 * time.Minute, -197 * time.Second, 0, time.Minute} {
+		datapoints = append(datapoints, ts.Datapoint{
+			Timestamp: instant.Add(offset),
+			Value:     float64(offset / time.Second),
+		})
+	}
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{ts.NewSeries("foo", datapoints, models.Tags{
+			{Name: models.MetricName, Value: "foo"},
+		})},
+	}, nil)
+	h := NewPromReadInstantHandler(executor.NewEngine(mockStorage))
+
+	for _, tt := range []struct {
+		query    string
+		expected string
+	}{
+		{
+			// The raw datapoints within the range are returned
+			query:    "foo[5m]",
+			expected: `[[1535948700, "-300"], [1535948803, "-197"], [1535949000, "0"]]`,
+		},
+		{
+			query:    "foo[2m] offset 3m",
+			expected: `[[1535948700, "-300"], [1535948803, "-197"]]`,
+		},
+	} {
+		vals := url.Values{}
+		vals.Add(queryParam, tt.query)
+		vals.Add(timeParam, "1535949000")
+		req := httptest.NewRequest(http.MethodGet, PromReadInstantURL+"?"+vals.Encode(), nil)
+		res := httptest.NewRecorder()
+		h.ServeHTTP(res, req)
+		require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+
+		expected := mustPrettyJSON(t, `
+		{
+			"status": "success",
+			"data": {
+				"resultType": "matrix",
+				"result": [
+					{
+						"metric": {"__name__": "foo"},
+						"values": `+tt.expected+`
+					}
+				]
+			}
+		}
+		`)
+		actual := mustPrettyJSON(t, res.Body.String())
+		assert.Equal(t, expected, actual, tt.query+"\n"+xtest.Diff(expected, actual))
+	}
+
+	// Range selectors are not planned so can not be explained
+	vals := url.Values{}
+	vals.Add(queryParam, "foo[5m]")
+	vals.Add(explainParam, "true")
+	req := httptest.NewRequest(http.MethodGet, PromReadInstantURL+"?"+vals.Encode(), nil)
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
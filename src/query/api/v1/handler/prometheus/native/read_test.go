@@ -22,10 +22,12 @@ package native
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/storage/mock"
@@ -51,13 +53,42 @@ func TestPromRead(t *testing.T) {
 
 	r, parseErr := parseParams(req)
 	require.Nil(t, parseErr)
-	seriesList, err := promRead.read(context.TODO(), httptest.NewRecorder(), r)
+	result, err := read(context.TODO(), promRead.engine, httptest.NewRecorder(), r)
 	require.NoError(t, err)
-	require.Len(t, seriesList, 2)
-	s := seriesList[0]
+	require.Len(t, result.Series, 2)
+	assert.Empty(t, result.Warnings)
+	s := result.Series[0]
 
 	assert.Equal(t, 5, s.Values().Len())
 	for i := 0; i < s.Values().Len(); i++ {
 		assert.Equal(t, float64(i), s.Values().ValueAt(i))
 	}
 }
+
+func TestPromReadWarnings(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{
+		Blocks:   []block.Block{b},
+		Warnings: block.Warnings{{Name: "remote_store", Message: "remote error"}},
+	}, nil)
+
+	promRead := NewPromReadHandler(executor.NewEngine(mockStorage))
+	req, _ := http.NewRequest("GET", PromReadURL, nil)
+	req.URL.RawQuery = defaultParams().Encode()
+
+	recorder := httptest.NewRecorder()
+	promRead.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, "remote_store: remote error", recorder.Header().Get(handler.WarningsHeader))
+
+	var response struct {
+		Warnings []string `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, []string{"remote_store: remote error"}, response.Warnings)
+}
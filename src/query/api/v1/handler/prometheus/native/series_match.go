@@ -0,0 +1,190 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// PromSeriesMatchURL is the url for listing the series matching
+	// selectors, this matches the default URL for the series endpoint found
+	// on a Prometheus server
+	PromSeriesMatchURL = handler.RoutePrefixV1 + "/series"
+
+	limitParam = "limit"
+
+	// defaultSeriesMatchLimit is the maximum number of series returned when
+	// no limit is provided
+	defaultSeriesMatchLimit = 10000
+)
+
+var (
+	// PromSeriesMatchHTTPMethods are the HTTP methods for this handler.
+	PromSeriesMatchHTTPMethods = []string{
+		http.MethodGet,
+		http.MethodPost,
+	}
+
+	errNoMatchers = errors.New("no match[] selectors provided")
+)
+
+// PromSeriesMatchHandler represents a handler for the prometheus series endpoint.
+type PromSeriesMatchHandler struct {
+	store storage.Storage
+}
+
+// NewPromSeriesMatchHandler returns a new instance of handler.
+func NewPromSeriesMatchHandler(storage storage.Storage) http.Handler {
+	return &PromSeriesMatchHandler{store: storage}
+}
+
+func (h *PromSeriesMatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	limit, rErr := parseSeriesMatchLimit(r)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	if len(r.Form[matchParam]) == 0 {
+		handler.Error(w, errNoMatchers, http.StatusBadRequest)
+		return
+	}
+
+	queries, rErr := parseMatchQueries(r, nil)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	series, truncated, warnings, err := h.match(ctx, queries, limit)
+	if err != nil {
+		logger.Error("unable to fetch series", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	data := make([]map[string]string, 0, len(series))
+	for _, tags := range series {
+		data = append(data, tags.StringMap())
+	}
+
+	response := metadataResponse{
+		Status:   "success",
+		Data:     data,
+		Warnings: warnings.Strings(),
+	}
+
+	if truncated {
+		warning := fmt.Sprintf("results truncated to limit of %d series", limit)
+		response.Warnings = append(response.Warnings, warning)
+	}
+
+	handler.SetWarningsHeader(w, response.Warnings)
+
+	handler.WriteJSONResponse(w, response, logger)
+}
+
+// match returns the unique series matching any of the queries, sorted by ID,
+// whether there were more than limit series and the warnings of any stores
+// left out of the results
+func (h *PromSeriesMatchHandler) match(
+	ctx context.Context,
+	queries []*storage.FetchQuery,
+	limit int,
+) ([]models.Tags, bool, storage.Warnings, error) {
+	var (
+		// Fetch one more than the limit so truncation can be detected
+		opts     = &storage.FetchOptions{Limit: limit + 1}
+		seen     = make(map[string]models.Tags)
+		warnings storage.Warnings
+	)
+	for _, query := range queries {
+		result, err := h.store.FetchTags(ctx, query, opts)
+		if err != nil {
+			return nil, false, nil, err
+		}
+
+		warnings = append(warnings, result.Warnings...)
+
+		// The same series may be returned by several namespaces, or match
+		// more than one of the selectors
+		for _, metric := range result.Metrics {
+			seen[metric.Tags.ID()] = metric.Tags
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+	truncated := len(ids) > limit
+	if truncated {
+		ids = ids[:limit]
+	}
+
+	series := make([]models.Tags, 0, len(ids))
+	for _, id := range ids {
+		series = append(series, seen[id])
+	}
+
+	return series, truncated, warnings, nil
+}
+
+func parseSeriesMatchLimit(r *http.Request) (int, *handler.ParseError) {
+	if err := r.ParseForm(); err != nil {
+		return 0, handler.NewParseError(err, http.StatusBadRequest)
+	}
+
+	limitVal := r.FormValue(limitParam)
+	if limitVal == "" {
+		return defaultSeriesMatchLimit, nil
+	}
+
+	limit, err := strconv.Atoi(limitVal)
+	if err != nil {
+		return 0, handler.NewParseError(fmt.Errorf(formatErrStr, limitParam, err), http.StatusBadRequest)
+	}
+
+	if limit <= 0 {
+		err := fmt.Errorf("limit must be positive, got: %d", limit)
+		return 0, handler.NewParseError(fmt.Errorf(formatErrStr, limitParam, err), http.StatusBadRequest)
+	}
+
+	return limit, nil
+}
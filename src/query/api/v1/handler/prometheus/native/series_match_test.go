@@ -0,0 +1,132 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSeriesMatchStorage() mock.Storage {
+	upA := models.Tags{{Name: models.MetricName, Value: "up"}, {Name: "instance", Value: "a"}}
+	upB := models.Tags{{Name: models.MetricName, Value: "up"}, {Name: "instance", Value: "b"}}
+
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			{ID: "b", Namespace: "unaggregated", Tags: upB},
+			{ID: "a", Namespace: "unaggregated", Tags: upA},
+			{ID: "a", Namespace: "aggregated", Tags: upA},
+		},
+	}, nil)
+
+	return store
+}
+
+func TestPromSeriesMatch(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(matchParam, "up")
+	vals.Add(matchParam, `{instance="a"}`)
+	req := httptest.NewRequest(http.MethodGet, PromSeriesMatchURL+"?"+vals.Encode(), nil)
+	res := httptest.NewRecorder()
+	NewPromSeriesMatchHandler(newSeriesMatchStorage()).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	expected := `{"status":"success","data":[` +
+		`{"__name__":"up","instance":"a"},` +
+		`{"__name__":"up","instance":"b"}]}`
+	assert.Equal(t, expected, res.Body.String())
+	assert.Empty(t, res.Header().Get(handler.WarningsHeader))
+}
+
+func TestPromSeriesMatchTruncated(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	vals := url.Values{}
+	vals.Add(matchParam, "up")
+	vals.Add(limitParam, "1")
+	req := httptest.NewRequest(http.MethodPost, PromSeriesMatchURL, strings.NewReader(vals.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res := httptest.NewRecorder()
+	NewPromSeriesMatchHandler(newSeriesMatchStorage()).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	expected := `{"status":"success","data":[{"__name__":"up","instance":"a"}],` +
+		`"warnings":["results truncated to limit of 1 series"]}`
+	assert.Equal(t, expected, res.Body.String())
+	assert.Equal(t, "results truncated to limit of 1 series", res.Header().Get(handler.WarningsHeader))
+}
+
+func TestPromSeriesMatchPartialResults(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{
+			{ID: "a", Tags: models.Tags{{Name: models.MetricName, Value: "up"}}},
+		},
+		Warnings: storage.Warnings{{Name: "remote_store", Message: "unavailable"}},
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, PromSeriesMatchURL+"?match[]=up", nil)
+	res := httptest.NewRecorder()
+	NewPromSeriesMatchHandler(store).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	expected := `{"status":"success","data":[{"__name__":"up"}],` +
+		`"warnings":["remote_store: unavailable"]}`
+	assert.Equal(t, expected, res.Body.String())
+	assert.Equal(t, "remote_store: unavailable", res.Header().Get(handler.WarningsHeader))
+}
+
+func TestPromSeriesMatchNoSelectors(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req := httptest.NewRequest(http.MethodGet, PromSeriesMatchURL, nil)
+	res := httptest.NewRecorder()
+	NewPromSeriesMatchHandler(newSeriesMatchStorage()).ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestPromSeriesMatchInvalidLimit(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	for _, limit := range []string{"foo", "0", "-1"} {
+		req := httptest.NewRequest(http.MethodGet, PromSeriesMatchURL+"?match[]=up&limit="+limit, nil)
+		res := httptest.NewRecorder()
+		NewPromSeriesMatchHandler(newSeriesMatchStorage()).ServeHTTP(res, req)
+		assert.Equal(t, http.StatusBadRequest, res.Code, limit)
+	}
+}
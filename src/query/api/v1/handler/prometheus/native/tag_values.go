@@ -0,0 +1,114 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/m3db/m3/src/query/api/v1/handler"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+)
+
+const (
+	tagNameVar = "name"
+)
+
+var (
+	// TagValuesURL is the url for listing the values of a tag, this matches
+	// the default URL for the label values endpoint found on a Prometheus server
+	TagValuesURL = fmt.Sprintf("%s/label/{%s}/values", handler.RoutePrefixV1, tagNameVar)
+)
+
+const (
+	// TagValuesHTTPMethod is the HTTP method used with this resource.
+	TagValuesHTTPMethod = http.MethodGet
+)
+
+// TagValuesHandler represents a handler for the tag values endpoint.
+type TagValuesHandler struct {
+	store storage.Storage
+}
+
+// NewTagValuesHandler returns a new instance of handler.
+func NewTagValuesHandler(storage storage.Storage) http.Handler {
+	return &TagValuesHandler{store: storage}
+}
+
+func (h *TagValuesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.WithContext(ctx)
+
+	name := mux.Vars(r)[tagNameVar]
+	if !model.LabelName(name).IsValid() {
+		handler.Error(w, fmt.Errorf("invalid tag name: %s", name), http.StatusBadRequest)
+		return
+	}
+
+	// Only series which have the tag can contribute values
+	hasTag, err := models.NewMatcher(models.MatchRegexp, name, ".+")
+	if err != nil {
+		handler.Error(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	queries, rErr := parseMatchQueries(r, nil)
+	if rErr != nil {
+		handler.Error(w, rErr.Inner(), rErr.Code())
+		return
+	}
+
+	for _, query := range queries {
+		query.TagMatchers = append(query.TagMatchers, hasTag)
+	}
+
+	result, err := completeMatchingTags(ctx, h.store, queries, false, [][]byte{[]byte(name)})
+	if err != nil {
+		logger.Error("unable to complete tags", zap.Error(err))
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	writeMetadataResponse(w, tagValues(name, result), result.Warnings, logger)
+}
+
+// tagValues returns the values completed for the named tag, which are
+// already sorted and unique
+func tagValues(name string, result storage.CompleteTagsResult) []string {
+	values := make([]string, 0)
+	for _, tag := range result.CompletedTags {
+		if string(tag.Name) != name {
+			continue
+		}
+
+		for _, value := range tag.Values {
+			values = append(values, string(value))
+		}
+	}
+
+	return values
+}
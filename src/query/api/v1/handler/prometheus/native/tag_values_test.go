@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTagValuesRouter(store storage.Storage) *mux.Router {
+	router := mux.NewRouter()
+	router.Handle(TagValuesURL, NewTagValuesHandler(store)).Methods(TagValuesHTTPMethod)
+	return router
+}
+
+func TestTagValues(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := newTagsStorage()
+	req := httptest.NewRequest(TagValuesHTTPMethod, "/api/v1/label/instance/values?match[]=up", nil)
+	res := httptest.NewRecorder()
+	newTagValuesRouter(store).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `{"status":"success","data":["a","b"]}`, res.Body.String())
+
+	require.Equal(t, 1, len(store.queries))
+	assert.False(t, store.queries[0].CompleteNameOnly)
+	assert.Equal(t, [][]byte{[]byte("instance")}, store.queries[0].FilterNameTags)
+}
+
+func TestTagValuesOnlyFromSeriesWithTag(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req := httptest.NewRequest(TagValuesHTTPMethod, "/api/v1/label/dc/values", nil)
+	res := httptest.NewRecorder()
+	newTagValuesRouter(newTagsStorage()).ServeHTTP(res, req)
+
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, `{"status":"success","data":["east"]}`, res.Body.String())
+}
+
+func TestTagValuesInvalidName(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req := httptest.NewRequest(TagValuesHTTPMethod, "/api/v1/label/not-valid/values", nil)
+	res := httptest.NewRecorder()
+	newTagValuesRouter(newTagsStorage()).ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
@@ -30,8 +30,10 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/snappy"
@@ -93,10 +95,23 @@ func (h *PromReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.read(ctx, w, req, timeout)
+	sp, err := prometheus.ParseStoragePolicy(r)
+	if err != nil {
+		h.promReadMetrics.fetchErrorsClient.Inc(1)
+		handler.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.read(ctx, w, req, timeout, sp)
 	if err != nil {
-		h.promReadMetrics.fetchErrorsServer.Inc(1)
 		logger.Error("unable to fetch data", zap.Any("error", err))
+		if limits.IsLimitExceeded(err) {
+			h.promReadMetrics.fetchErrorsClient.Inc(1)
+			handler.Error(w, err, http.StatusBadRequest)
+			return
+		}
+
+		h.promReadMetrics.fetchErrorsServer.Inc(1)
 		handler.Error(w, err, http.StatusInternalServerError)
 		return
 	}
@@ -141,7 +156,13 @@ func (h *PromReadHandler) parseRequest(r *http.Request) (*prompb.ReadRequest, *h
 	return &req, nil
 }
 
-func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r *prompb.ReadRequest, timeout time.Duration) ([]*prompb.QueryResult, error) {
+func (h *PromReadHandler) read(
+	reqCtx context.Context,
+	w http.ResponseWriter,
+	r *prompb.ReadRequest,
+	timeout time.Duration,
+	sp policy.StoragePolicy,
+) ([]*prompb.QueryResult, error) {
 	// TODO: Handle multi query use case
 	if len(r.Queries) != 1 {
 		return nil, fmt.Errorf("prometheus read endpoint currently only supports one query at a time")
@@ -158,22 +179,25 @@ func (h *PromReadHandler) read(reqCtx context.Context, w http.ResponseWriter, r
 	// Results is closed by execute
 	results := make(chan *storage.QueryResult)
 
-	opts := &executor.EngineOptions{}
+	opts := &executor.EngineOptions{StoragePolicy: sp}
 	// Detect clients closing connections
 	abortCh, closingCh := handler.CloseWatcher(ctx, w)
 	opts.AbortCh = abortCh
 
 	go h.engine.Execute(ctx, query, opts, closingCh, results)
 
+	var warnings storage.Warnings
 	promResults := make([]*prompb.QueryResult, 0, 1)
 	for result := range results {
 		if result.Err != nil {
 			return nil, result.Err
 		}
 
+		warnings = append(warnings, result.FetchResult.Warnings...)
 		promRes := storage.FetchResultToPromResult(result.FetchResult)
 		promResults = append(promResults, promRes)
 	}
 
+	handler.SetWarningsHeader(w, warnings.Strings())
 	return promResults, nil
 }
@@ -31,10 +31,13 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/x/metrics"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test"
 	"github.com/m3db/m3/src/query/test/local"
+	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
 	xclock "github.com/m3db/m3x/clock"
 
 	"github.com/golang/mock/gomock"
@@ -89,7 +92,7 @@ func TestPromReadStorageWithFetchError(t *testing.T) {
 	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, true, fmt.Errorf("unable to get data"))
 	promRead := &PromReadHandler{engine: executor.NewEngine(storage), promReadMetrics: promReadTestMetrics}
 	req := test.GeneratePromReadRequest()
-	_, err := promRead.read(context.TODO(), httptest.NewRecorder(), req, time.Hour)
+	_, err := promRead.read(context.TODO(), httptest.NewRecorder(), req, time.Hour, policy.EmptyStoragePolicy)
 	require.NotNil(t, err, "unable to read from storage")
 }
 
@@ -151,3 +154,21 @@ func TestReadErrorMetricsCount(t *testing.T) {
 	}, 5*time.Second)
 	require.True(t, foundMetric)
 }
+
+func TestPromReadLimitExceeded(t *testing.T) {
+	logging.InitWithCores(nil)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	storage, session := local.NewStorageAndSession(t, ctrl)
+	session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 1, 2), true, nil)
+
+	engine := executor.NewEngineWithLimits(storage, limits.Limits{MaxDatapoints: 1}, tally.NoopScope)
+	promRead := &PromReadHandler{engine: engine, promReadMetrics: promReadTestMetrics}
+	req, _ := http.NewRequest("POST", PromReadURL, test.GeneratePromReadBody(t))
+	recorder := httptest.NewRecorder()
+	promRead.ServeHTTP(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "query exceeded limit")
+}
@@ -71,6 +71,7 @@ func (h *SearchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	SetWarningsHeader(w, results.Warnings.Strings())
 	WriteJSONResponse(w, results, logger)
 }
 
@@ -33,9 +33,12 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler"
 	"github.com/m3db/m3/src/query/api/v1/handler/database"
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite"
+	"github.com/m3db/m3/src/query/api/v1/handler/influxdb"
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
+	"github.com/m3db/m3/src/query/api/v1/handler/opentsdb"
 	"github.com/m3db/m3/src/query/api/v1/handler/placement"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
@@ -43,6 +46,8 @@ import (
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
 	clusterclient "github.com/m3db/m3cluster/client"
+	"github.com/m3db/m3x/instrument"
+	xsync "github.com/m3db/m3x/sync"
 
 	"github.com/gorilla/mux"
 	"github.com/uber-go/tally"
@@ -53,10 +58,14 @@ const (
 	healthURL = "/health"
 	pprofURL  = "/debug/pprof/profile"
 	routesURL = "/routes"
+
+	defaultWriteWorkerPoolSize = 1024
 )
 
 var (
-	remoteSource = map[string]string{"source": "remote"}
+	remoteSource   = map[string]string{"source": "remote"}
+	influxdbSource = map[string]string{"source": "influxdb"}
+	opentsdbSource = map[string]string{"source": "opentsdb"}
 )
 
 // Handler represents an HTTP handler.
@@ -119,13 +128,43 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// The write endpoints share a pool of goroutines to write the series of
+	// each request concurrently
+	writeWorkerPool, err := newWriteWorkerPool(h.config.WriteWorkerPoolSize, h.scope)
+	if err != nil {
+		return err
+	}
+
 	h.Router.HandleFunc(remote.PromReadURL, logged(promRemoteReadHandler).ServeHTTP).Methods(remote.PromReadHTTPMethod)
 	h.Router.HandleFunc(remote.PromWriteURL, logged(promRemoteWriteHandler).ServeHTTP).Methods(remote.PromWriteHTTPMethod)
 	h.Router.HandleFunc(native.PromReadURL, logged(native.NewPromReadHandler(h.engine)).ServeHTTP).Methods(native.PromReadHTTPMethod)
+	h.Router.HandleFunc(native.PromReadInstantURL, logged(native.NewPromReadInstantHandler(h.engine)).ServeHTTP).Methods(native.PromReadInstantHTTPMethods...)
+
+	// Prometheus metadata endpoints
+	h.Router.HandleFunc(native.ListTagsURL, logged(native.NewListTagsHandler(h.storage)).ServeHTTP).Methods(native.ListTagsHTTPMethod)
+	h.Router.HandleFunc(native.TagValuesURL, logged(native.NewTagValuesHandler(h.storage)).ServeHTTP).Methods(native.TagValuesHTTPMethod)
+	h.Router.HandleFunc(native.PromSeriesMatchURL, logged(native.NewPromSeriesMatchHandler(h.storage)).ServeHTTP).Methods(native.PromSeriesMatchHTTPMethods...)
+
+	// Prometheus admin endpoints, only registered when explicitly enabled
+	// as they permanently remove data
+	if h.config.EnableAdminAPI {
+		h.Router.HandleFunc(native.PromDeleteSeriesURL, logged(native.NewPromDeleteSeriesHandler(h.storage)).ServeHTTP).Methods(native.PromDeleteSeriesHTTPMethods...)
+	}
+
+	// Graphite endpoints
+	h.Router.HandleFunc(graphite.RenderURL, logged(graphite.NewRenderHandler(h.engine)).ServeHTTP).Methods(graphite.RenderHTTPMethods...)
+	h.Router.HandleFunc(graphite.FindURL, logged(graphite.NewFindHandler(h.storage)).ServeHTTP).Methods(graphite.FindHTTPMethods...)
+
+	// InfluxDB endpoints
+	h.Router.HandleFunc(influxdb.WriteURL, logged(influxdb.NewWriteHandler(h.storage, writeWorkerPool, h.scope.Tagged(influxdbSource))).ServeHTTP).Methods(influxdb.WriteHTTPMethod)
+
+	// OpenTSDB endpoints
+	h.Router.HandleFunc(opentsdb.PutURL, logged(opentsdb.NewPutHandler(h.storage, writeWorkerPool, h.scope.Tagged(opentsdbSource))).ServeHTTP).Methods(opentsdb.PutHTTPMethod)
+	h.Router.HandleFunc(opentsdb.QueryURL, logged(opentsdb.NewQueryHandler(h.engine)).ServeHTTP).Methods(opentsdb.QueryHTTPMethods...)
 
 	// Native M3 search and write endpoints
 	h.Router.HandleFunc(handler.SearchURL, logged(handler.NewSearchHandler(h.storage)).ServeHTTP).Methods(handler.SearchHTTPMethod)
-	h.Router.HandleFunc(m3json.WriteJSONURL, logged(m3json.NewWriteJSONHandler(h.storage)).ServeHTTP).Methods(m3json.JSONWriteHTTPMethod)
+	h.Router.HandleFunc(m3json.WriteJSONURL, logged(m3json.NewWriteJSONHandler(h.storage, writeWorkerPool)).ServeHTTP).Methods(m3json.JSONWriteHTTPMethod)
 
 	if h.clusterClient != nil {
 		placement.RegisterRoutes(h.Router, h.clusterClient, h.config)
@@ -140,6 +179,23 @@ func (h *Handler) RegisterRoutes() error {
 	return nil
 }
 
+func newWriteWorkerPool(size int, scope tally.Scope) (xsync.PooledWorkerPool, error) {
+	if size == 0 {
+		size = defaultWriteWorkerPoolSize
+	}
+
+	opts := xsync.NewPooledWorkerPoolOptions().
+		SetInstrumentOptions(instrument.NewOptions().
+			SetMetricsScope(scope.SubScope("write-worker-pool")))
+	pool, err := xsync.NewPooledWorkerPool(size, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pool.Init()
+	return pool, nil
+}
+
 // Endpoints useful for profiling the service
 func (h *Handler) registerHealthEndpoints() {
 	h.Router.HandleFunc(healthURL, func(w http.ResponseWriter, r *http.Request) {
@@ -107,6 +107,61 @@ func TestPromNativeReadPost(t *testing.T) {
 	require.Equal(t, res.Code, http.StatusMethodNotAllowed, "POST method not defined")
 }
 
+func TestPromNativeReadInstantGet(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req, _ := http.NewRequest("GET", native.PromReadInstantURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := local.NewStorageAndSession(t, ctrl)
+
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+		config.Configuration{}, nil, tally.NewTestScope("", nil))
+	require.NoError(t, err, "unable to setup handler")
+	h.RegisterRoutes()
+	h.Router.ServeHTTP(res, req)
+	require.Equal(t, res.Code, http.StatusBadRequest, "Empty request")
+}
+
+func TestPromNativeReadInstantPost(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	req, _ := http.NewRequest("POST", native.PromReadInstantURL, nil)
+	res := httptest.NewRecorder()
+	ctrl := gomock.NewController(t)
+	storage, _ := local.NewStorageAndSession(t, ctrl)
+
+	h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+		config.Configuration{}, nil, tally.NewTestScope("", nil))
+	require.NoError(t, err, "unable to setup handler")
+	h.RegisterRoutes()
+	h.Router.ServeHTTP(res, req)
+	require.Equal(t, res.Code, http.StatusBadRequest, "Empty request")
+}
+
+func TestPromDeleteSeriesRequiresAdminAPI(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	for _, enabled := range []bool{false, true} {
+		req, _ := http.NewRequest("POST", native.PromDeleteSeriesURL, nil)
+		res := httptest.NewRecorder()
+		ctrl := gomock.NewController(t)
+		storage, _ := local.NewStorageAndSession(t, ctrl)
+
+		h, err := NewHandler(storage, nil, executor.NewEngine(storage), nil,
+			config.Configuration{EnableAdminAPI: enabled}, nil, tally.NewTestScope("", nil))
+		require.NoError(t, err, "unable to setup handler")
+		require.NoError(t, h.RegisterRoutes())
+		h.Router.ServeHTTP(res, req)
+		if enabled {
+			require.Equal(t, http.StatusBadRequest, res.Code, "no matchers")
+		} else {
+			require.Equal(t, http.StatusNotFound, res.Code, "admin API disabled")
+		}
+		ctrl.Finish()
+	}
+}
+
 func TestJSONWritePost(t *testing.T) {
 	logging.InitWithCores(nil)
 
@@ -213,5 +213,29 @@ type Builder interface {
 
 // Result is the result from a block query
 type Result struct {
-	Blocks []Block
+	Blocks   []Block
+	Warnings Warnings // Failures of the storages left out of a partial result
+}
+
+// Warning is a non-fatal error encountered while fulfilling a query, the
+// results returned alongside a warning may be incomplete.
+type Warning struct {
+	Name    string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s", w.Name, w.Message)
+}
+
+// Warnings is a list of warnings.
+type Warnings []Warning
+
+// Strings returns the warnings formatted as strings.
+func (w Warnings) Strings() []string {
+	strs := make([]string, 0, len(w))
+	for _, warning := range w {
+		strs = append(strs, warning.String())
+	}
+	return strs
 }
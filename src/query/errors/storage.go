@@ -28,6 +28,9 @@ var (
 	// ErrNilWriteQuery is returned when trying to write a nil query
 	ErrNilWriteQuery = errors.New("nil write query")
 
+	// ErrNilDeleteQuery is returned when trying to delete with a nil query
+	ErrNilDeleteQuery = errors.New("nil delete query")
+
 	// ErrNotImplemented is returned when the storage endpoint is not implemented
 	ErrNotImplemented = errors.New("not implemented")
 
@@ -23,12 +23,16 @@ package executor
 import (
 	"context"
 
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
 
+	"github.com/uber-go/tally"
 	"go.uber.org/zap"
 )
 
@@ -38,26 +42,54 @@ type Engine struct {
 	tracker *Tracker
 	Stats   *QueryStatistics
 	store   storage.Storage
+
+	limits       limits.Limits
+	limitMetrics *limits.Metrics
 }
 
 // EngineOptions can be used to pass custom flags to engine
 type EngineOptions struct {
 	// AbortCh is a channel that signals when results are no longer desired by the caller.
 	AbortCh <-chan bool
+	// StoragePolicy restricts the query to a single storage policy if set.
+	StoragePolicy policy.StoragePolicy
+	// Explain collects an explanation of how the query is planned and
+	// executed.
+	Explain bool
 }
 
 // Query is the result after execution
 type Query struct {
 	Err    error
 	Result Result
+	// Explanation is set if the query is explained
+	Explanation *Explanation
+}
+
+// Explanation explains how a query is planned and executed, the statistics
+// of its execution are complete once its results have been read.
+type Explanation struct {
+	Nodes        parser.Nodes
+	Edges        parser.Edges
+	LogicalPlan  plan.LogicalPlan
+	PhysicalPlan plan.PhysicalPlan
+	Stats        *transform.Stats
 }
 
 // NewEngine returns a new instance of QueryExecutor.
 func NewEngine(store storage.Storage) *Engine {
+	return NewEngineWithLimits(store, limits.Limits{}, tally.NoopScope)
+}
+
+// NewEngineWithLimits returns a new instance of QueryExecutor which limits
+// the resources used by each query.
+func NewEngineWithLimits(store storage.Storage, queryLimits limits.Limits, scope tally.Scope) *Engine {
 	return &Engine{
-		tracker: NewTracker(),
-		Stats:   &QueryStatistics{},
-		store:   store,
+		tracker:      NewTracker(),
+		Stats:        &QueryStatistics{},
+		store:        store,
+		limits:       queryLimits,
+		limitMetrics: limits.NewMetrics(scope),
 	}
 }
 
@@ -84,7 +116,9 @@ func (e *Engine) Execute(ctx context.Context, query *storage.FetchQuery, opts *E
 	defer e.tracker.DetachQuery(task.qid)
 
 	result, err := e.store.Fetch(ctx, query, &storage.FetchOptions{
-		KillChan: task.closing,
+		KillChan:      task.closing,
+		StoragePolicy: opts.StoragePolicy,
+		Enforcer:      limits.NewEnforcer(e.limits, e.limitMetrics),
 	})
 	if err != nil {
 		results <- &storage.QueryResult{Err: err}
@@ -125,7 +159,19 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 		logging.WithContext(ctx).Info("physical plan", zap.String("plan", pp.String()))
 	}
 
-	state, err := GenerateExecutionState(pp, e.store)
+	var explanation *Explanation
+	if opts.Explain {
+		explanation = &Explanation{
+			Nodes:        nodes,
+			Edges:        edges,
+			LogicalPlan:  lp,
+			PhysicalPlan: pp,
+			Stats:        transform.NewStats(),
+		}
+	}
+
+	enforcer := limits.NewEnforcer(e.limits, e.limitMetrics)
+	state, err := GenerateExecutionState(pp, e.store, enforcer, explanation.stats())
 	// free up resources
 	if err != nil {
 		results <- Query{Err: err}
@@ -137,7 +183,7 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 	}
 
 	result := state.resultNode
-	results <- Query{Result: result}
+	results <- Query{Result: result, Explanation: explanation}
 	if err := state.Execute(ctx); err != nil {
 		result.abort(err)
 	} else {
@@ -145,6 +191,14 @@ func (e *Engine) ExecuteExpr(ctx context.Context, parser parser.Parser, opts *En
 	}
 }
 
+func (e *Explanation) stats() *transform.Stats {
+	if e == nil {
+		return nil
+	}
+
+	return e.Stats
+}
+
 // Close kills all running queries and prevents new queries from being attached.
 func (e *Engine) Close() error {
 	return e.tracker.Close()
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage/mock"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadParsed(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{
+		Blocks:   []block.Block{b},
+		Warnings: block.Warnings{{Name: "remote_store", Message: "remote error"}},
+	}, nil)
+
+	query := `http_requests_total{job="prometheus"}`
+	parsed, err := promql.Parse(query)
+	require.NoError(t, err)
+
+	result, err := NewEngine(store).ReadParsed(context.TODO(), parsed, &EngineOptions{}, models.RequestParams{
+		Start: bounds.Start,
+		End:   bounds.End(),
+		Now:   time.Now(),
+		Step:  bounds.StepSize,
+		Query: query,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Series, 2)
+	assert.Nil(t, result.Explanation)
+	assert.Equal(t, block.Warnings{{Name: "remote_store", Message: "remote error"}}, result.Warnings)
+
+	s := result.Series[0]
+	require.Equal(t, 5, s.Values().Len())
+	for i := 0; i < s.Values().Len(); i++ {
+		assert.Equal(t, float64(i), s.Values().ValueAt(i))
+	}
+}
+
+func TestInstantValue(t *testing.T) {
+	start := time.Unix(1535948880, 0)
+	values := ts.NewFixedStepValues(time.Second, 4, 1, start)
+	values.SetValueAt(1, 2)
+	values.SetValueAt(2, math.NaN())
+	values.SetValueAt(3, 4)
+	s := ts.NewSeries("foo", values, models.EmptyTags())
+
+	dp, ok := InstantValue(s, start.Add(3*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, 4.0, dp.Value)
+
+	// NaNs are skipped
+	dp, ok = InstantValue(s, start.Add(2*time.Second))
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Second), dp.Timestamp)
+	assert.Equal(t, 2.0, dp.Value)
+
+	_, ok = InstantValue(s, start.Add(-time.Second))
+	assert.False(t, ok)
+}
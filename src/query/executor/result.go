@@ -24,6 +24,7 @@ import (
 	"sync"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/parser"
 
 	"github.com/pkg/errors"
@@ -43,6 +44,9 @@ type Result interface {
 	abort(err error)
 	done()
 	ResultChan() chan ResultChan
+	// Warnings returns the warnings of the query, which are complete once
+	// the result channel is closed
+	Warnings() block.Warnings
 }
 
 // ResultNode is used to provide the results to the caller from the query execution
@@ -50,6 +54,7 @@ type ResultNode struct {
 	mu         sync.Mutex
 	resultChan chan ResultChan
 	aborted    bool
+	warnings   *transform.Warnings
 }
 
 // ResultChan has the result from a block
@@ -58,9 +63,9 @@ type ResultChan struct {
 	Err   error
 }
 
-func newResultNode() *ResultNode {
+func newResultNode(warnings *transform.Warnings) *ResultNode {
 	blocks := make(chan ResultChan, channelSize)
-	return &ResultNode{resultChan: blocks}
+	return &ResultNode{resultChan: blocks, warnings: warnings}
 }
 
 // Process the block
@@ -81,6 +86,11 @@ func (r *ResultNode) ResultChan() chan ResultChan {
 	return r.resultChan
 }
 
+// Warnings returns the warnings of the query
+func (r *ResultNode) Warnings() block.Warnings {
+	return r.warnings.Warnings()
+}
+
 // TODO: Signal error downstream
 func (r *ResultNode) abort(err error) {
 	r.mu.Lock()
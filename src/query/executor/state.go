@@ -23,8 +23,10 @@ package executor
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/plan"
 	"github.com/m3db/m3/src/query/storage"
@@ -101,10 +103,14 @@ type ScalarParams interface {
 	) parser.Source
 }
 
-// GenerateExecutionState creates an execution state from the physical plan
+// GenerateExecutionState creates an execution state from the physical plan,
+// the enforcer limits the resources used by the query and the statistics of
+// each node are collected into stats, either may be nil
 func GenerateExecutionState(
 	pplan plan.PhysicalPlan,
 	storage storage.Storage,
+	enforcer *limits.Enforcer,
+	stats *transform.Stats,
 ) (*ExecutionState, error) {
 	result := pplan.ResultStep
 	state := &ExecutionState{
@@ -118,8 +124,12 @@ func GenerateExecutionState(
 	}
 
 	options := transform.Options{
-		TimeSpec: pplan.TimeSpec,
-		Debug:    pplan.Debug,
+		TimeSpec:      pplan.TimeSpec,
+		Debug:         pplan.Debug,
+		StoragePolicy: pplan.StoragePolicy,
+		Enforcer:      enforcer,
+		Stats:         stats,
+		Warnings:      transform.NewWarnings(),
 	}
 	controller, err := state.createNode(step, options)
 	if err != nil {
@@ -130,7 +140,7 @@ func GenerateExecutionState(
 		return nil, errors.New("empty sources for the execution state")
 	}
 
-	rNode := newResultNode()
+	rNode := newResultNode(options.Warnings)
 	state.resultNode = rNode
 	controller.AddTransform(rNode)
 
@@ -147,14 +157,14 @@ func (s *ExecutionState) createNode(
 	sourceParams, ok := step.Transform.Op.(SourceParams)
 	if ok {
 		source, controller := CreateSource(step.ID(), sourceParams, s.storage, options)
-		s.sources = append(s.sources, source)
+		s.addSource(source, controller, options)
 		return controller, nil
 	}
 
 	scalarParams, ok := step.Transform.Op.(ScalarParams)
 	if ok {
 		source, controller := CreateScalarSource(step.ID(), scalarParams, options)
-		s.sources = append(s.sources, source)
+		s.addSource(source, controller, options)
 		return controller, nil
 	}
 
@@ -164,6 +174,11 @@ func (s *ExecutionState) createNode(
 	}
 
 	transformNode, controller := CreateTransform(step.ID(), transformParams, options)
+	if options.Stats != nil {
+		controller.Stats = options.Stats.Node(step.ID())
+		transformNode = transform.NewStatsNode(transformNode, controller.Stats)
+	}
+
 	for _, parentID := range step.Parents {
 		parentStep, ok := s.plan.Step(parentID)
 		if !ok {
@@ -181,6 +196,19 @@ func (s *ExecutionState) createNode(
 	return controller, nil
 }
 
+func (s *ExecutionState) addSource(
+	source parser.Source,
+	controller *transform.Controller,
+	options transform.Options,
+) {
+	if options.Stats != nil {
+		controller.Stats = options.Stats.Node(controller.ID)
+		source = statsSource{source: source, stats: controller.Stats}
+	}
+
+	s.sources = append(s.sources, source)
+}
+
 // Execute the sources in parallel and return the first error
 func (s *ExecutionState) Execute(ctx context.Context) error {
 	requests := make([]execution.Request, len(s.sources))
@@ -203,3 +231,16 @@ type sourceRequest struct {
 func (s sourceRequest) Process(ctx context.Context) error {
 	return s.source.Execute(ctx)
 }
+
+// statsSource records the time spent executing a source
+type statsSource struct {
+	source parser.Source
+	stats  *transform.NodeStats
+}
+
+func (s statsSource) Execute(ctx context.Context) error {
+	start := time.Now()
+	err := s.source.Execute(ctx)
+	s.stats.RecordProcessing(time.Since(start))
+	return err
+}
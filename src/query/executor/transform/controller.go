@@ -21,6 +21,8 @@
 package transform
 
 import (
+	"time"
+
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/parser"
 )
@@ -29,6 +31,8 @@ import (
 type Controller struct {
 	ID         parser.NodeID
 	transforms []OpNode
+	// Stats are the statistics of the node, which are only collected if set
+	Stats *NodeStats
 }
 
 // AddTransform adds a dependent transformation to the controller
@@ -38,6 +42,15 @@ func (t *Controller) AddTransform(node OpNode) {
 
 // Process performs processing on the underlying transforms
 func (t *Controller) Process(block block.Block) error {
+	if t.Stats != nil {
+		start := time.Now()
+		defer func() {
+			t.Stats.recordDownstream(time.Since(start))
+		}()
+
+		block = newStatsBlock(block, t.Stats)
+	}
+
 	for _, ts := range t.transforms {
 		err := ts.Process(t.ID, block)
 		if err != nil {
@@ -0,0 +1,223 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// Stats collects the statistics of executing each node of a query, which
+// are collected when a query is explained. It is safe for concurrent use.
+type Stats struct {
+	sync.Mutex
+	nodes map[parser.NodeID]*NodeStats
+}
+
+// NewStats returns a new collector of node statistics.
+func NewStats() *Stats {
+	return &Stats{nodes: make(map[parser.NodeID]*NodeStats)}
+}
+
+// Node returns the statistics of a node.
+func (s *Stats) Node(ID parser.NodeID) *NodeStats {
+	s.Lock()
+	defer s.Unlock()
+
+	stats, ok := s.nodes[ID]
+	if !ok {
+		stats = &NodeStats{}
+		s.nodes[ID] = stats
+	}
+
+	return stats
+}
+
+// Snapshot returns a snapshot of the statistics of each node.
+func (s *Stats) Snapshot() map[parser.NodeID]NodeStatsSnapshot {
+	s.Lock()
+	defer s.Unlock()
+
+	snapshot := make(map[parser.NodeID]NodeStatsSnapshot, len(s.nodes))
+	for ID, stats := range s.nodes {
+		snapshot[ID] = stats.Snapshot()
+	}
+
+	return snapshot
+}
+
+// NodeStatsSnapshot is a snapshot of the statistics of a node.
+type NodeStatsSnapshot struct {
+	// Blocks is the number of blocks the node output.
+	Blocks int
+	// Series is the number of series of the blocks the node output.
+	Series int
+	// Datapoints is the number of datapoints read from the blocks the node
+	// output.
+	Datapoints int
+	// Duration is the time spent executing the node, excluding the time
+	// spent by the nodes it output blocks to. Lazy nodes are evaluated as
+	// their blocks are read, so that time is attributed to the node which
+	// reads them.
+	Duration time.Duration
+}
+
+// NodeStats are the statistics of executing a node.
+type NodeStats struct {
+	sync.Mutex
+	blocks     int
+	series     int
+	datapoints int
+	processing time.Duration
+	downstream time.Duration
+}
+
+// Snapshot returns a snapshot of the statistics.
+func (s *NodeStats) Snapshot() NodeStatsSnapshot {
+	s.Lock()
+	defer s.Unlock()
+
+	return NodeStatsSnapshot{
+		Blocks:     s.blocks,
+		Series:     s.series,
+		Datapoints: s.datapoints,
+		Duration:   s.processing - s.downstream,
+	}
+}
+
+// RecordProcessing records time spent executing the node, including any
+// time spent by the nodes it output blocks to.
+func (s *NodeStats) RecordProcessing(d time.Duration) {
+	s.Lock()
+	s.processing += d
+	s.Unlock()
+}
+
+func (s *NodeStats) recordDownstream(d time.Duration) {
+	s.Lock()
+	s.downstream += d
+	s.Unlock()
+}
+
+func (s *NodeStats) addBlock() {
+	s.Lock()
+	s.blocks++
+	s.Unlock()
+}
+
+func (s *NodeStats) addSeries(n int) {
+	s.Lock()
+	s.series += n
+	s.Unlock()
+}
+
+func (s *NodeStats) addDatapoints(n int) {
+	s.Lock()
+	s.datapoints += n
+	s.Unlock()
+}
+
+type statsNode struct {
+	node  OpNode
+	stats *NodeStats
+}
+
+// NewStatsNode wraps a node to record the time spent processing blocks.
+func NewStatsNode(node OpNode, stats *NodeStats) OpNode {
+	return &statsNode{node: node, stats: stats}
+}
+
+func (n *statsNode) Process(ID parser.NodeID, b block.Block) error {
+	start := time.Now()
+	err := n.node.Process(ID, b)
+	n.stats.RecordProcessing(time.Since(start))
+	return err
+}
+
+// statsBlock counts the series and datapoints read from a block, the
+// series are counted once however many times the block is read
+type statsBlock struct {
+	block.Block
+	stats       *NodeStats
+	countSeries sync.Once
+}
+
+func newStatsBlock(b block.Block, stats *NodeStats) block.Block {
+	stats.addBlock()
+	return &statsBlock{Block: b, stats: stats}
+}
+
+func (b *statsBlock) StepIter() (block.StepIter, error) {
+	iter, err := b.Block.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	b.countSeries.Do(func() {
+		b.stats.addSeries(len(iter.SeriesMeta()))
+	})
+
+	return &statsStepIter{StepIter: iter, stats: b.stats}, nil
+}
+
+func (b *statsBlock) SeriesIter() (block.SeriesIter, error) {
+	iter, err := b.Block.SeriesIter()
+	if err != nil {
+		return nil, err
+	}
+
+	b.countSeries.Do(func() {
+		b.stats.addSeries(iter.SeriesCount())
+	})
+
+	return &statsSeriesIter{SeriesIter: iter, stats: b.stats}, nil
+}
+
+type statsStepIter struct {
+	block.StepIter
+	stats *NodeStats
+}
+
+func (i *statsStepIter) Current() (block.Step, error) {
+	step, err := i.StepIter.Current()
+	if err == nil && step != nil {
+		i.stats.addDatapoints(len(step.Values()))
+	}
+
+	return step, err
+}
+
+type statsSeriesIter struct {
+	block.SeriesIter
+	stats *NodeStats
+}
+
+func (i *statsSeriesIter) Current() (block.Series, error) {
+	series, err := i.SeriesIter.Current()
+	if err == nil {
+		i.stats.addDatapoints(series.Len())
+	}
+
+	return series, err
+}
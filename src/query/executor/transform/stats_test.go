@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCountsBlockReads(t *testing.T) {
+	stats := NewStats()
+	sNode := &sinkNode{}
+	controller := &Controller{ID: parser.NodeID(1), Stats: stats.Node(parser.NodeID(1))}
+	controller.AddTransform(NewStatsNode(sNode, stats.Node(parser.NodeID(2))))
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+	start := time.Now()
+	require.NoError(t, controller.Process(b))
+	controller.Stats.RecordProcessing(time.Since(start))
+	require.NotNil(t, sNode.block)
+
+	// Series are only counted once however many times the block is read
+	for i := 0; i < 2; i++ {
+		iter, err := sNode.block.StepIter()
+		require.NoError(t, err)
+		for iter.Next() {
+			_, err := iter.Current()
+			require.NoError(t, err)
+		}
+	}
+
+	seriesIter, err := sNode.block.SeriesIter()
+	require.NoError(t, err)
+	for seriesIter.Next() {
+		_, err := seriesIter.Current()
+		require.NoError(t, err)
+	}
+
+	snapshot := stats.Snapshot()
+	require.Len(t, snapshot, 2)
+	source := snapshot[parser.NodeID(1)]
+	assert.Equal(t, 1, source.Blocks)
+	assert.Equal(t, 2, source.Series)
+	assert.Equal(t, 30, source.Datapoints)
+
+	// The sink does not output blocks, so only its processing time is recorded
+	sink := snapshot[parser.NodeID(2)]
+	assert.Equal(t, 0, sink.Blocks)
+	assert.True(t, sink.Duration >= 0)
+	assert.True(t, source.Duration >= 0)
+}
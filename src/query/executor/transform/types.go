@@ -24,13 +24,21 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3metrics/policy"
 )
 
 // Options to create transform nodes
 type Options struct {
-	TimeSpec TimeSpec
-	Debug    bool
+	TimeSpec      TimeSpec
+	Debug         bool
+	StoragePolicy policy.StoragePolicy
+	Enforcer      *limits.Enforcer
+	// Stats collects the statistics of each node if set
+	Stats *Stats
+	// Warnings collects the warnings of each node if set
+	Warnings *Warnings
 }
 
 // OpNode represents the execution node
@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package transform
+
+import (
+	"sync"
+
+	"github.com/m3db/m3/src/query/block"
+)
+
+// Warnings collects the warnings of the nodes of a query, such as the
+// failures of the storages left out of a partial result. It is safe for
+// concurrent use.
+type Warnings struct {
+	sync.Mutex
+	warnings block.Warnings
+}
+
+// NewWarnings returns a new collector of warnings.
+func NewWarnings() *Warnings {
+	return &Warnings{}
+}
+
+// Add adds warnings to the collector, it is a no-op if the collector is nil.
+func (w *Warnings) Add(warnings block.Warnings) {
+	if w == nil || len(warnings) == 0 {
+		return
+	}
+
+	w.Lock()
+	w.warnings = append(w.warnings, warnings...)
+	w.Unlock()
+}
+
+// Warnings returns the collected warnings.
+func (w *Warnings) Warnings() block.Warnings {
+	if w == nil {
+		return nil
+	}
+
+	w.Lock()
+	defer w.Unlock()
+	return append(block.Warnings(nil), w.warnings...)
+}
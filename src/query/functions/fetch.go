@@ -26,10 +26,12 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
 
 	"go.uber.org/zap"
 )
@@ -49,11 +51,14 @@ type FetchOp struct {
 // FetchNode is the execution node
 // TODO: Make FetchNode private
 type FetchNode struct {
-	op         FetchOp
-	controller *transform.Controller
-	storage    storage.Storage
-	timespec   transform.TimeSpec
-	debug      bool
+	op            FetchOp
+	controller    *transform.Controller
+	storage       storage.Storage
+	timespec      transform.TimeSpec
+	debug         bool
+	storagePolicy policy.StoragePolicy
+	enforcer      *limits.Enforcer
+	warnings      *transform.Warnings
 }
 
 // OpType for the operator
@@ -76,7 +81,16 @@ func (o FetchOp) String() string {
 
 // Node creates an execution node
 func (o FetchOp) Node(controller *transform.Controller, storage storage.Storage, options transform.Options) parser.Source {
-	return &FetchNode{op: o, controller: controller, storage: storage, timespec: options.TimeSpec, debug: options.Debug}
+	return &FetchNode{
+		op:            o,
+		controller:    controller,
+		storage:       storage,
+		timespec:      options.TimeSpec,
+		debug:         options.Debug,
+		storagePolicy: options.StoragePolicy,
+		enforcer:      options.Enforcer,
+		warnings:      options.Warnings,
+	}
 }
 
 // Execute runs the fetch node operation
@@ -90,11 +104,23 @@ func (n *FetchNode) Execute(ctx context.Context) error {
 		End:         endTime,
 		TagMatchers: n.op.Matchers,
 		Interval:    timeSpec.Step,
-	}, &storage.FetchOptions{})
+	}, &storage.FetchOptions{
+		StoragePolicy: n.storagePolicy,
+		Enforcer:      n.enforcer,
+	})
 	if err != nil {
 		return err
 	}
 
+	n.warnings.Add(blockResult.Warnings)
+	if err := n.enforcer.AddBlocks(len(blockResult.Blocks)); err != nil {
+		for _, block := range blockResult.Blocks {
+			block.Close()
+		}
+
+		return err
+	}
+
 	for _, block := range blockResult.Blocks {
 		if n.debug {
 			// Ignore any errors
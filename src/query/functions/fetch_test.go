@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test"
@@ -48,3 +49,18 @@ func TestFetch(t *testing.T) {
 	assert.Len(t, sink.Values, 2)
 	assert.Equal(t, expected, sink.Values)
 }
+
+func TestFetchBlocksLimit(t *testing.T) {
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	b := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	mockStorage := mock.NewMockStorage()
+	mockStorage.SetFetchBlocksResult(block.Result{Blocks: []block.Block{b, b}}, nil)
+	source := (&FetchOp{}).Node(c, mockStorage, transform.Options{
+		Enforcer: limits.NewEnforcer(limits.Limits{MaxBlocks: 1}, nil),
+	})
+	err := source.Execute(context.TODO())
+	require.Error(t, err)
+	assert.True(t, limits.IsLimitExceeded(err))
+	assert.Len(t, sink.Values, 0)
+}
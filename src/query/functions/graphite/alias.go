@@ -0,0 +1,130 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+)
+
+const (
+	// PathNameType names each series read from storage by its graphite path
+	PathNameType = "graphitePathName"
+
+	// AliasType renames each series
+	AliasType = "alias"
+
+	// AliasByNodeType renames each series to a subset of the nodes of its path
+	AliasByNodeType = "aliasByNode"
+)
+
+// NewPathNameOp creates a new op which names each series by the path held
+// in its graphite tags, series without a complete path keep their name
+func NewPathNameOp() transform.Params {
+	return seriesOp{
+		opType: PathNameType,
+		nameFn: func(meta block.SeriesMeta) string {
+			if path, ok := graphite.PathFromTags(meta.Tags); ok {
+				return path
+			}
+
+			return meta.Name
+		},
+	}
+}
+
+func newAliasOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", AliasType, len(args))
+	}
+
+	name, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast to string argument for %s: %v", AliasType, args[0])
+	}
+
+	return seriesOp{
+		opType: AliasType,
+		nameFn: func(block.SeriesMeta) string {
+			return name
+		},
+	}, nil
+}
+
+func newAliasByNodeOp(args []interface{}) (transform.Params, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", AliasByNodeType, len(args))
+	}
+
+	nodes := make([]int, 0, len(args))
+	for _, arg := range args {
+		node, ok := arg.(float64)
+		if !ok {
+			return nil, fmt.Errorf("unable to cast to node index for %s: %v", AliasByNodeType, arg)
+		}
+
+		nodes = append(nodes, int(node))
+	}
+
+	return seriesOp{
+		opType: AliasByNodeType,
+		nameFn: func(meta block.SeriesMeta) string {
+			return aliasByNode(meta.Name, nodes)
+		},
+	}, nil
+}
+
+// aliasByNode joins the given nodes of the path within a series name, negative
+// indices count back from the last node and out of range nodes are skipped
+func aliasByNode(name string, nodes []int) string {
+	pathNodes := strings.Split(pathFromName(name), ".")
+	aliased := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if node < 0 {
+			node += len(pathNodes)
+		}
+
+		if node < 0 || node >= len(pathNodes) {
+			continue
+		}
+
+		aliased = append(aliased, pathNodes[node])
+	}
+
+	return strings.Join(aliased, ".")
+}
+
+// pathFromName extracts the innermost path from a series name which may be
+// wrapped by functions, e.g. "foo.bar" from "scale(perSecond(foo.bar),2)"
+func pathFromName(name string) string {
+	if idx := strings.LastIndex(name, "("); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	if idx := strings.IndexAny(name, ",)"); idx >= 0 {
+		name = name[:idx]
+	}
+
+	return name
+}
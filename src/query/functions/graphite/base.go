@@ -0,0 +1,119 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package graphite implements graphite render functions on top of blocks,
+// the name of each series carries its graphite display name.
+package graphite
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// seriesNameFunc computes the display name of a series
+type seriesNameFunc func(meta block.SeriesMeta) string
+
+// seriesValuesFunc transforms the values of a single series, the values are
+// a copy and may be modified in place
+type seriesValuesFunc func(values []float64, bounds block.Bounds) []float64
+
+// seriesOp stores required properties for functions applied to each series
+// independently; a nil valuesFn passes values through untouched
+type seriesOp struct {
+	opType   string
+	nameFn   seriesNameFunc
+	valuesFn seriesValuesFunc
+}
+
+// OpType for the operator
+func (o seriesOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o seriesOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o seriesOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &seriesNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type seriesNode struct {
+	op         seriesOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *seriesNode) Process(ID parser.NodeID, b block.Block) error {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	meta := seriesIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, seriesIter.SeriesMeta())
+	for i, seriesMeta := range seriesMetas {
+		seriesMetas[i].Name = n.op.nameFn(seriesMeta)
+	}
+
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(meta.Bounds.Steps()); err != nil {
+		return err
+	}
+
+	for seriesIter.Next() {
+		series, err := seriesIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := make([]float64, series.Len())
+		copy(values, series.Values())
+		if n.op.valuesFn != nil {
+			values = n.op.valuesFn(values, meta.Bounds)
+		}
+
+		for i, value := range values {
+			if err := builder.AppendValue(i, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBounds = block.Bounds{
+	Start:    time.Unix(1500000000, 0),
+	Duration: 5 * time.Minute,
+	StepSize: time.Minute,
+}
+
+func pathMeta(name string, path ...string) block.SeriesMeta {
+	tags := make(models.Tags, 0, len(path)+1)
+	for i, node := range path {
+		tags = append(tags, models.Tag{Name: graphite.TagName(i), Value: node})
+	}
+
+	tags = append(tags, models.Tag{Name: "dc", Value: "east"})
+	return block.SeriesMeta{Name: name, Tags: tags}
+}
+
+func namedMetas(names ...string) []block.SeriesMeta {
+	metas := make([]block.SeriesMeta, len(names))
+	for i, name := range names {
+		metas[i] = block.SeriesMeta{Name: name, Tags: models.EmptyTags()}
+	}
+
+	return metas
+}
+
+// processOp runs the op over a block built from the given metas and values
+func processOp(
+	t *testing.T,
+	op transform.Params,
+	bounds block.Bounds,
+	metas []block.SeriesMeta,
+	vals [][]float64,
+) *executor.SinkNode {
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, vals)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.Node(c, transform.Options{})
+	err := node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+	return sink
+}
+
+func sinkNames(sink *executor.SinkNode) []string {
+	names := make([]string, len(sink.Metas))
+	for i, meta := range sink.Metas {
+		names[i] = meta.Name
+	}
+
+	return names
+}
+
+func TestPathName(t *testing.T) {
+	metas := []block.SeriesMeta{
+		pathMeta("id-a", "foo", "a", "bar"),
+		pathMeta("id-b", "foo", "b", "bar"),
+		{Name: "not-graphite", Tags: models.Tags{{Name: "dc", Value: "east"}}},
+	}
+
+	vals := [][]float64{{1, 2, 3, 4, 5}, {5, 4, 3, 2, 1}, {0, 0, 0, 0, 0}}
+	sink := processOp(t, NewPathNameOp(), testBounds, metas, vals)
+	assert.Equal(t, []string{"foo.a.bar", "foo.b.bar", "not-graphite"}, sinkNames(sink))
+	assert.Equal(t, vals, sink.Values)
+	assert.Equal(t, models.Tags{{Name: "dc", Value: "east"}}, sink.Meta.Tags)
+}
+
+func TestAlias(t *testing.T) {
+	op, err := NewFunctionOp(AliasType, "foo.*", []interface{}{"renamed"})
+	require.NoError(t, err)
+
+	sink := processOp(t, op, testBounds, namedMetas("foo.a", "foo.b"), [][]float64{{1, 2, 3, 4, 5}, {5, 4, 3, 2, 1}})
+	assert.Equal(t, []string{"renamed", "renamed"}, sinkNames(sink))
+
+	_, err = NewFunctionOp(AliasType, "foo.*", []interface{}{1.0})
+	assert.Error(t, err)
+}
+
+func TestAliasByNode(t *testing.T) {
+	op, err := NewFunctionOp(AliasByNodeType, "foo.*.bar", []interface{}{1.0, -1.0})
+	require.NoError(t, err)
+
+	metas := namedMetas("foo.a.bar", "perSecond(foo.b.bar)", "scale(movingAverage(foo.c.bar,5),2)")
+	vals := [][]float64{{1, 2, 3, 4, 5}, {5, 4, 3, 2, 1}, {0, 0, 0, 0, 0}}
+	sink := processOp(t, op, testBounds, metas, vals)
+	assert.Equal(t, []string{"a.bar", "b.bar", "c.bar"}, sinkNames(sink))
+
+	assert.Equal(t, "foo", aliasByNode("foo.a.bar", []int{0, 5}))
+
+	_, err = NewFunctionOp(AliasByNodeType, "foo.*.bar", nil)
+	assert.Error(t, err)
+
+	_, err = NewFunctionOp(AliasByNodeType, "foo.*.bar", []interface{}{"1"})
+	assert.Error(t, err)
+}
+
+func TestUnsupportedFunction(t *testing.T) {
+	_, err := NewFunctionOp("holtWintersForecast", "foo.*", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// SumSeriesType adds the series together at each step
+	SumSeriesType = "sumSeries"
+
+	// AverageSeriesType averages the series at each step
+	AverageSeriesType = "averageSeries"
+
+	// MinSeriesType takes the minimum of the series at each step
+	MinSeriesType = "minSeries"
+
+	// MaxSeriesType takes the maximum of the series at each step
+	MaxSeriesType = "maxSeries"
+)
+
+// combineFunc combines the values of all series at a step, NaNs are skipped
+// and a step without any values is NaN
+type combineFunc func(values []float64) float64
+
+var combineFuncs = map[string]combineFunc{
+	SumSeriesType:     sumValues,
+	AverageSeriesType: averageValues,
+	MinSeriesType:     minValues,
+	MaxSeriesType:     maxValues,
+}
+
+// combineOp stores required properties for functions combining all series
+// into one
+type combineOp struct {
+	opType    string
+	name      string
+	combineFn combineFunc
+}
+
+func newCombineOp(opType, seriesExpr string, args []interface{}) (transform.Params, error) {
+	if len(args) != 0 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", opType, len(args))
+	}
+
+	fn, ok := combineFuncs[opType]
+	if !ok {
+		return nil, fmt.Errorf("unknown combine function: %s", opType)
+	}
+
+	return combineOp{
+		opType:    opType,
+		name:      fmt.Sprintf("%s(%s)", opType, seriesExpr),
+		combineFn: fn,
+	}, nil
+}
+
+// OpType for the operator
+func (o combineOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o combineOp) String() string {
+	return fmt.Sprintf("type: %s, name: %s", o.OpType(), o.name)
+}
+
+// Node creates an execution node
+func (o combineOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &combineNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type combineNode struct {
+	op         combineOp
+	controller *transform.Controller
+}
+
+// Process the block; the output has a single series unless the block is
+// empty, in which case there is nothing to combine
+func (n *combineNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	var combinedMetas []block.SeriesMeta
+	if len(seriesMetas) > 0 {
+		// Only tags shared by all series are kept on the combined series
+		meta.Tags, _ = utils.DedupeMetadata(seriesMetas)
+		combinedMetas = []block.SeriesMeta{{Name: n.op.name, Tags: models.EmptyTags()}}
+	}
+
+	builder, err := n.controller.BlockBuilder(meta, combinedMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		if len(combinedMetas) == 0 {
+			continue
+		}
+
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		if err := builder.AppendValue(index, n.op.combineFn(step.Values())); err != nil {
+			return err
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+func sumValues(values []float64) float64 {
+	sum, count := 0.0, 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+	}
+
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return sum
+}
+
+func averageValues(values []float64) float64 {
+	sum, count := 0.0, 0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+	}
+
+	if count == 0 {
+		return math.NaN()
+	}
+
+	return sum / float64(count)
+}
+
+func minValues(values []float64) float64 {
+	min := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(min) || v < min) {
+			min = v
+		}
+	}
+
+	return min
+}
+
+func maxValues(values []float64) float64 {
+	max := math.NaN()
+	for _, v := range values {
+		if !math.IsNaN(v) && (math.IsNaN(max) || v > max) {
+			max = v
+		}
+	}
+
+	return max
+}
+
+func lastValue(values []float64) float64 {
+	for i := len(values) - 1; i >= 0; i-- {
+		if !math.IsNaN(values[i]) {
+			return values[i]
+		}
+	}
+
+	return math.NaN()
+}
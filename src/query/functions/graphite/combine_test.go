@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineSeries(t *testing.T) {
+	nan := math.NaN()
+	vals := [][]float64{
+		{1, nan, 3, nan, 5},
+		{2, 4, nan, nan, 1},
+	}
+
+	for _, test := range []struct {
+		name     string
+		expected []float64
+	}{
+		{name: SumSeriesType, expected: []float64{3, 4, 3, nan, 6}},
+		{name: AverageSeriesType, expected: []float64{1.5, 4, 3, nan, 3}},
+		{name: MinSeriesType, expected: []float64{1, 4, 3, nan, 1}},
+		{name: MaxSeriesType, expected: []float64{2, 4, 3, nan, 5}},
+	} {
+		op, err := NewFunctionOp(test.name, "foo.*", nil)
+		require.NoError(t, err)
+
+		metas := []block.SeriesMeta{pathMeta("foo.a", "foo", "a"), pathMeta("foo.b", "foo", "b")}
+		sink := processOp(t, op, testBounds, metas, vals)
+		require.Len(t, sink.Values, 1, test.name)
+		assert.Equal(t, []string{test.name + "(foo.*)"}, sinkNames(sink))
+		testEqualsWithNans(t, test.expected, sink.Values[0])
+
+		// Only the tags shared by all series are kept
+		assert.Equal(t, models.Tags{
+			{Name: "__g0__", Value: "foo"},
+			{Name: "dc", Value: "east"},
+		}, sink.Meta.Tags)
+	}
+}
+
+func TestCombineSeriesShorthand(t *testing.T) {
+	op, err := NewFunctionOp("sum", "foo.*", nil)
+	require.NoError(t, err)
+	assert.Equal(t, SumSeriesType, op.OpType())
+}
+
+func TestCombineSeriesEmpty(t *testing.T) {
+	op, err := NewFunctionOp(SumSeriesType, "foo.*", nil)
+	require.NoError(t, err)
+
+	builder := block.NewColumnBlockBuilder(block.Metadata{Bounds: testBounds}, nil)
+	require.NoError(t, builder.AddCols(testBounds.Steps()))
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	err = op.Node(c, transform.Options{}).Process(parser.NodeID(0), builder.Build())
+	require.NoError(t, err)
+	assert.Len(t, sink.Values, 0)
+}
+
+func TestCombineSeriesInvalidArgs(t *testing.T) {
+	_, err := NewFunctionOp(SumSeriesType, "foo.*", []interface{}{1.0})
+	assert.Error(t, err)
+}
+
+func testEqualsWithNans(t *testing.T, expected, actual []float64) {
+	test.EqualsWithNansWithDelta(t, expected, actual, 0.00001)
+}
+
+func TestGroupSeriesLists(t *testing.T) {
+	op := NewGroupOp([]parser.NodeID{"2", "0"})
+	c, sink := executor.NewControllerWithSink(parser.NodeID("3"))
+	node := op.Node(c, transform.Options{})
+
+	foo := test.NewBlockFromValuesWithSeriesMeta(testBounds,
+		[]block.SeriesMeta{pathMeta("foo.a", "foo", "a"), pathMeta("foo.b", "foo", "b")},
+		[][]float64{{1, 2, 3, 4, 5}, {6, 7, 8, 9, 10}})
+	bar := test.NewBlockFromValuesWithSeriesMeta(testBounds,
+		[]block.SeriesMeta{pathMeta("bar.a", "bar", "a")},
+		[][]float64{{5, 4, 3, 2, 1}})
+
+	// Nothing is output until a block is received from every parent
+	require.NoError(t, node.Process(parser.NodeID("0"), foo))
+	assert.Len(t, sink.Values, 0)
+	require.NoError(t, node.Process(parser.NodeID("2"), bar))
+
+	// The series are in the order of the parents
+	assert.Equal(t, []string{"bar.a", "foo.a", "foo.b"}, sinkNames(sink))
+	assert.Equal(t, [][]float64{{5, 4, 3, 2, 1}, {1, 2, 3, 4, 5}, {6, 7, 8, 9, 10}}, sink.Values)
+	assert.Equal(t, models.Tags{{Name: "dc", Value: "east"}}, sink.Meta.Tags)
+	assert.Equal(t, models.Tags{{Name: "__g0__", Value: "bar"}, {Name: "__g1__", Value: "a"}}, sink.Metas[0].Tags)
+}
+
+func TestGroupUnexpectedBlock(t *testing.T) {
+	op := NewGroupOp([]parser.NodeID{"0", "1"})
+	c, _ := executor.NewControllerWithSink(parser.NodeID("2"))
+	node := op.Node(c, transform.Options{})
+
+	values, bounds := test.GenerateValuesAndBounds(nil, nil)
+	require.NoError(t, node.Process(parser.NodeID("0"), test.NewBlockFromValues(bounds, values)))
+	assert.Error(t, node.Process(parser.NodeID("0"), test.NewBlockFromValues(bounds, values)))
+	assert.Error(t, node.Process(parser.NodeID("3"), test.NewBlockFromValues(bounds, values)))
+}
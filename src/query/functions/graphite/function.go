@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+// NewFunctionOp creates the op for a graphite function applied to a series
+// list, seriesExpr is the expression of the series list argument and args
+// holds the remaining arguments
+func NewFunctionOp(name, seriesExpr string, args []interface{}) (transform.Params, error) {
+	switch name {
+	case SumSeriesType, AverageSeriesType, MinSeriesType, MaxSeriesType:
+		return newCombineOp(name, seriesExpr, args)
+	case MovingAverageType:
+		return newMovingAverageOp(args)
+	case PerSecondType:
+		return newPerSecondOp(args)
+	case ScaleType:
+		return newScaleOp(args)
+	case SummarizeType:
+		return newSummarizeOp(args)
+	case AliasType:
+		return newAliasOp(args)
+	case AliasByNodeType:
+		return newAliasByNodeOp(args)
+	}
+
+	// Common shorthands supported by graphite
+	switch name {
+	case "sum":
+		return newCombineOp(SumSeriesType, seriesExpr, args)
+	case "avg":
+		return newCombineOp(AverageSeriesType, seriesExpr, args)
+	}
+
+	return nil, fmt.Errorf("unsupported graphite function: %s", name)
+}
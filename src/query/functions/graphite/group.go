@@ -0,0 +1,209 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// GroupType groups the series of each of its series list arguments into a
+// single series list
+const GroupType = "group"
+
+// AcceptsSeriesLists returns true if the function takes any number of series
+// lists as arguments rather than a single one
+func AcceptsSeriesLists(name string) bool {
+	switch name {
+	case GroupType, SumSeriesType, AverageSeriesType, MinSeriesType,
+		MaxSeriesType, "sum", "avg":
+		return true
+	}
+
+	return false
+}
+
+type groupOp struct {
+	parents []parser.NodeID
+}
+
+// NewGroupOp creates a new op which outputs the series of the blocks of each
+// parent as a single block, in the order of the parents
+func NewGroupOp(parents []parser.NodeID) transform.Params {
+	return groupOp{parents: parents}
+}
+
+// OpType for the operator
+func (o groupOp) OpType() string {
+	return GroupType
+}
+
+// String representation
+func (o groupOp) String() string {
+	return fmt.Sprintf("type: %s, parents: %v", o.OpType(), o.parents)
+}
+
+// Node creates an execution node
+func (o groupOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &groupNode{
+		op:         o,
+		controller: controller,
+		inputs:     make(map[parser.NodeID]groupInput, len(o.parents)),
+	}
+}
+
+// groupInput is the series read from the block of a parent, the block is
+// read as it arrives since the parents may close their blocks once processed
+type groupInput struct {
+	meta        block.Metadata
+	seriesMetas []block.SeriesMeta
+	steps       [][]float64
+}
+
+type groupNode struct {
+	op         groupOp
+	controller *transform.Controller
+	mu         sync.Mutex
+	inputs     map[parser.NodeID]groupInput
+}
+
+// Process the block, the grouped block is output once a block is received
+// from every parent
+func (n *groupNode) Process(ID parser.NodeID, b block.Block) error {
+	input, err := newGroupInput(b)
+	if err != nil {
+		return err
+	}
+
+	if !n.isParent(ID) {
+		return fmt.Errorf("unexpected block from %s for %s", ID, GroupType)
+	}
+
+	n.mu.Lock()
+	if _, ok := n.inputs[ID]; ok {
+		n.mu.Unlock()
+		return fmt.Errorf("duplicate block from %s for %s", ID, GroupType)
+	}
+
+	n.inputs[ID] = input
+	if len(n.inputs) < len(n.op.parents) {
+		n.mu.Unlock()
+		return nil
+	}
+
+	inputs := make([]groupInput, 0, len(n.op.parents))
+	for _, parent := range n.op.parents {
+		inputs = append(inputs, n.inputs[parent])
+	}
+
+	n.inputs = make(map[parser.NodeID]groupInput, len(n.op.parents))
+	n.mu.Unlock()
+
+	nextBlock, err := n.group(inputs)
+	if err != nil {
+		return err
+	}
+
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+func (n *groupNode) isParent(ID parser.NodeID) bool {
+	for _, parent := range n.op.parents {
+		if parent == ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newGroupInput(b block.Block) (groupInput, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return groupInput{}, err
+	}
+
+	meta := stepIter.Meta()
+	input := groupInput{
+		meta:        meta,
+		seriesMetas: utils.FlattenMetadata(meta, stepIter.SeriesMeta()),
+		steps:       make([][]float64, 0, stepIter.StepCount()),
+	}
+
+	for stepIter.Next() {
+		step, err := stepIter.Current()
+		if err != nil {
+			return groupInput{}, err
+		}
+
+		values := make([]float64, len(step.Values()))
+		copy(values, step.Values())
+		input.steps = append(input.steps, values)
+	}
+
+	return input, nil
+}
+
+func (n *groupNode) group(inputs []groupInput) (block.Block, error) {
+	var (
+		meta        = inputs[0].meta
+		numSteps    = len(inputs[0].steps)
+		seriesMetas []block.SeriesMeta
+	)
+	for _, input := range inputs {
+		if len(input.steps) != numSteps {
+			return nil, fmt.Errorf("unable to group blocks with %d and %d steps",
+				numSteps, len(input.steps))
+		}
+
+		seriesMetas = append(seriesMetas, input.seriesMetas...)
+	}
+
+	// Only tags shared by all series are kept on the block
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := builder.AddCols(numSteps); err != nil {
+		return nil, err
+	}
+
+	for _, input := range inputs {
+		for index, values := range input.steps {
+			if err := builder.AppendValues(index, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return builder.Build(), nil
+}
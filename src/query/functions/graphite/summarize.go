@@ -0,0 +1,214 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// SummarizeType aggregates each series into buckets of a fixed interval
+	SummarizeType = "summarize"
+
+	defaultSummarizeFunc = "sum"
+)
+
+var summarizeFuncs = map[string]combineFunc{
+	"sum":  sumValues,
+	"avg":  averageValues,
+	"min":  minValues,
+	"max":  maxValues,
+	"last": lastValue,
+}
+
+type summarizeOp struct {
+	interval    time.Duration
+	intervalStr string
+	fnName      string
+	fn          combineFunc
+	alignToFrom bool
+}
+
+func newSummarizeOp(args []interface{}) (transform.Params, error) {
+	if len(args) < 1 || len(args) > 3 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", SummarizeType, len(args))
+	}
+
+	intervalStr, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast to interval for %s: %v", SummarizeType, args[0])
+	}
+
+	interval, err := graphite.ParseInterval(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("invalid interval for %s: %s", SummarizeType, intervalStr)
+	}
+
+	op := summarizeOp{
+		interval:    interval,
+		intervalStr: intervalStr,
+		fnName:      defaultSummarizeFunc,
+	}
+
+	if len(args) > 1 {
+		if op.fnName, ok = args[1].(string); !ok {
+			return nil, fmt.Errorf("unable to cast to function name for %s: %v", SummarizeType, args[1])
+		}
+	}
+
+	if op.fn, ok = summarizeFuncs[op.fnName]; !ok {
+		return nil, fmt.Errorf("unknown function for %s: %s", SummarizeType, op.fnName)
+	}
+
+	if len(args) > 2 {
+		if op.alignToFrom, ok = args[2].(bool); !ok {
+			return nil, fmt.Errorf("unable to cast to alignToFrom for %s: %v", SummarizeType, args[2])
+		}
+	}
+
+	return op, nil
+}
+
+// OpType for the operator
+func (o summarizeOp) OpType() string {
+	return SummarizeType
+}
+
+// String representation
+func (o summarizeOp) String() string {
+	return fmt.Sprintf("type: %s, interval: %v, func: %s, alignToFrom: %v",
+		o.OpType(), o.interval, o.fnName, o.alignToFrom)
+}
+
+func (o summarizeOp) seriesName(name string) string {
+	if o.alignToFrom {
+		return fmt.Sprintf("%s(%s, %q, %q, true)", SummarizeType, name, o.intervalStr, o.fnName)
+	}
+
+	return fmt.Sprintf("%s(%s, %q, %q)", SummarizeType, name, o.intervalStr, o.fnName)
+}
+
+// bucketBounds returns the bounds of the buckets covering the input bounds,
+// buckets are aligned to the epoch unless aligned to the start of the input
+func (o summarizeOp) bucketBounds(bounds block.Bounds) block.Bounds {
+	start := bounds.Start
+	if !o.alignToFrom {
+		nanos := start.UnixNano()
+		start = time.Unix(0, nanos-nanos%int64(o.interval))
+	}
+
+	numBuckets := int((bounds.End().Sub(start) + o.interval - 1) / o.interval)
+	return block.Bounds{
+		Start:    start,
+		Duration: time.Duration(numBuckets) * o.interval,
+		StepSize: o.interval,
+	}
+}
+
+// Node creates an execution node
+func (o summarizeOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &summarizeNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type summarizeNode struct {
+	op         summarizeOp
+	controller *transform.Controller
+}
+
+// Process the block; the output block steps are the buckets
+func (n *summarizeNode) Process(ID parser.NodeID, b block.Block) error {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	meta := seriesIter.Meta()
+	bounds := meta.Bounds
+	seriesMetas := utils.FlattenMetadata(meta, seriesIter.SeriesMeta())
+	for i, seriesMeta := range seriesMetas {
+		seriesMetas[i].Name = n.op.seriesName(seriesMeta.Name)
+	}
+
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	meta.Bounds = n.op.bucketBounds(bounds)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	numBuckets := meta.Bounds.Steps()
+	if err := builder.AddCols(numBuckets); err != nil {
+		return err
+	}
+
+	bucket := make([]float64, 0, int(n.op.interval/bounds.StepSize)+1)
+	for seriesIter.Next() {
+		series, err := seriesIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := series.Values()
+		idx := 0
+		for bucketIdx := 0; bucketIdx < numBuckets; bucketIdx++ {
+			bucketEnd := meta.Bounds.Start.Add(time.Duration(bucketIdx+1) * n.op.interval)
+			bucket = bucket[:0]
+			for ; idx < len(values); idx++ {
+				t := bounds.Start.Add(time.Duration(idx) * bounds.StepSize)
+				if !t.Before(bucketEnd) {
+					break
+				}
+
+				bucket = append(bucket, values[idx])
+			}
+
+			value := math.NaN()
+			if len(bucket) > 0 {
+				value = n.op.fn(bucket)
+			}
+
+			if err := builder.AppendValue(bucketIdx, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	nan := math.NaN()
+	bounds := block.Bounds{
+		// One minute past an epoch aligned two minute bucket
+		Start:    time.Unix(1500000060, 0),
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	}
+
+	vals := [][]float64{{1, 2, 3, nan, 5}}
+	op, err := NewFunctionOp(SummarizeType, "foo.a", []interface{}{"2min"})
+	require.NoError(t, err)
+
+	sink := processOp(t, op, bounds, namedMetas("foo.a"), vals)
+	assert.Equal(t, []string{`summarize(foo.a, "2min", "sum")`}, sinkNames(sink))
+	assert.Equal(t, block.Bounds{
+		Start:    time.Unix(1500000000, 0),
+		Duration: 6 * time.Minute,
+		StepSize: 2 * time.Minute,
+	}, sink.Meta.Bounds)
+	testEqualsWithNans(t, []float64{1, 5, 5}, sink.Values[0])
+
+	op, err = NewFunctionOp(SummarizeType, "foo.a", []interface{}{"2min", "max", true})
+	require.NoError(t, err)
+
+	sink = processOp(t, op, bounds, namedMetas("foo.a"), vals)
+	assert.Equal(t, []string{`summarize(foo.a, "2min", "max", true)`}, sinkNames(sink))
+	assert.Equal(t, bounds.Start, sink.Meta.Bounds.Start)
+	testEqualsWithNans(t, []float64{2, 3, 5}, sink.Values[0])
+}
+
+func TestSummarizeInvalidArgs(t *testing.T) {
+	for _, args := range [][]interface{}{
+		nil,
+		{1.0},
+		{"1x"},
+		{"0min"},
+		{"1min", "median"},
+		{"1min", "sum", "true"},
+		{"1min", "sum", true, 1.0},
+	} {
+		_, err := NewFunctionOp(SummarizeType, "foo.a", args)
+		assert.Error(t, err, "%v", args)
+	}
+}
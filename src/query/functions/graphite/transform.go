@@ -0,0 +1,207 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+)
+
+const (
+	// MovingAverageType averages each series over a trailing window
+	MovingAverageType = "movingAverage"
+
+	// PerSecondType computes the per second rate of change of each series
+	PerSecondType = "perSecond"
+
+	// ScaleType multiplies each series by a factor
+	ScaleType = "scale"
+)
+
+func newMovingAverageOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", MovingAverageType, len(args))
+	}
+
+	var (
+		windowPoints   int
+		windowInterval time.Duration
+		windowStr      string
+	)
+
+	switch window := args[0].(type) {
+	case float64:
+		windowPoints = int(window)
+		if windowPoints <= 0 {
+			return nil, fmt.Errorf("invalid window size for %s: %v", MovingAverageType, window)
+		}
+
+		windowStr = fmt.Sprintf("%d", windowPoints)
+	case string:
+		interval, err := graphite.ParseInterval(window)
+		if err != nil {
+			return nil, err
+		}
+
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid window size for %s: %s", MovingAverageType, window)
+		}
+
+		windowInterval = interval
+		windowStr = fmt.Sprintf("%q", window)
+	default:
+		return nil, fmt.Errorf("unable to cast to window size for %s: %v", MovingAverageType, args[0])
+	}
+
+	return seriesOp{
+		opType: MovingAverageType,
+		nameFn: func(meta block.SeriesMeta) string {
+			return fmt.Sprintf("%s(%s,%s)", MovingAverageType, meta.Name, windowStr)
+		},
+		valuesFn: func(values []float64, bounds block.Bounds) []float64 {
+			points := windowPoints
+			if windowInterval > 0 {
+				points = int(windowInterval / bounds.StepSize)
+			}
+
+			return movingAverage(values, points)
+		},
+	}, nil
+}
+
+// movingAverage averages the non NaN values of a trailing window which ends
+// with the current step; windows at the start of the series are partial
+func movingAverage(values []float64, windowPoints int) []float64 {
+	if windowPoints < 1 {
+		windowPoints = 1
+	}
+
+	averages := make([]float64, len(values))
+	sum, count := 0.0, 0
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			sum += v
+			count++
+		}
+
+		if i >= windowPoints {
+			if expired := values[i-windowPoints]; !math.IsNaN(expired) {
+				sum -= expired
+				count--
+			}
+		}
+
+		if count == 0 {
+			averages[i] = math.NaN()
+			continue
+		}
+
+		averages[i] = sum / float64(count)
+	}
+
+	return averages
+}
+
+func newPerSecondOp(args []interface{}) (transform.Params, error) {
+	if len(args) > 1 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", PerSecondType, len(args))
+	}
+
+	maxValue := math.NaN()
+	if len(args) == 1 {
+		max, ok := args[0].(float64)
+		if !ok {
+			return nil, fmt.Errorf("unable to cast to max value for %s: %v", PerSecondType, args[0])
+		}
+
+		maxValue = max
+	}
+
+	return seriesOp{
+		opType: PerSecondType,
+		nameFn: func(meta block.SeriesMeta) string {
+			return fmt.Sprintf("%s(%s)", PerSecondType, meta.Name)
+		},
+		valuesFn: func(values []float64, bounds block.Bounds) []float64 {
+			return perSecond(values, bounds.StepSize, maxValue)
+		},
+	}, nil
+}
+
+// perSecond returns the rate of change between each value and the previous
+// non NaN value; a decrease is treated as a counter wrapping at maxValue if
+// set, otherwise as a reset with an unknown rate
+func perSecond(values []float64, stepSize time.Duration, maxValue float64) []float64 {
+	rates := make([]float64, len(values))
+	prevIdx := -1
+	for i, v := range values {
+		rates[i] = math.NaN()
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if prevIdx >= 0 {
+			prev := values[prevIdx]
+			delta := v - prev
+			if delta < 0 && !math.IsNaN(maxValue) && maxValue >= prev {
+				delta = maxValue - prev + v + 1
+			}
+
+			if delta >= 0 {
+				elapsed := time.Duration(i-prevIdx) * stepSize
+				rates[i] = delta / elapsed.Seconds()
+			}
+		}
+
+		prevIdx = i
+	}
+
+	return rates
+}
+
+func newScaleOp(args []interface{}) (transform.Params, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("invalid number of args for %s: %d", ScaleType, len(args))
+	}
+
+	factor, ok := args[0].(float64)
+	if !ok {
+		return nil, fmt.Errorf("unable to cast to factor for %s: %v", ScaleType, args[0])
+	}
+
+	return seriesOp{
+		opType: ScaleType,
+		nameFn: func(meta block.SeriesMeta) string {
+			return fmt.Sprintf("%s(%s,%g)", ScaleType, meta.Name, factor)
+		},
+		valuesFn: func(values []float64, _ block.Bounds) []float64 {
+			for i := range values {
+				values[i] *= factor
+			}
+
+			return values
+		},
+	}, nil
+}
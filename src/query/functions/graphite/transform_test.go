@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovingAverage(t *testing.T) {
+	nan := math.NaN()
+	vals := [][]float64{{1, 3, nan, 5, 7}}
+
+	op, err := NewFunctionOp(MovingAverageType, "foo.a", []interface{}{2.0})
+	require.NoError(t, err)
+	sink := processOp(t, op, testBounds, namedMetas("foo.a"), vals)
+	assert.Equal(t, []string{"movingAverage(foo.a,2)"}, sinkNames(sink))
+	testEqualsWithNans(t, []float64{1, 2, 3, 5, 6}, sink.Values[0])
+
+	// Interval windows are converted to a number of steps
+	op, err = NewFunctionOp(MovingAverageType, "foo.a", []interface{}{"3min"})
+	require.NoError(t, err)
+	sink = processOp(t, op, testBounds, namedMetas("foo.a"), vals)
+	assert.Equal(t, []string{`movingAverage(foo.a,"3min")`}, sinkNames(sink))
+	testEqualsWithNans(t, []float64{1, 2, 2, 4, 6}, sink.Values[0])
+
+	testEqualsWithNans(t, []float64{nan, nan, 1}, movingAverage([]float64{nan, nan, 1}, 2))
+
+	for _, args := range [][]interface{}{nil, {0.0}, {"-1min"}, {"foo"}, {true}} {
+		_, err = NewFunctionOp(MovingAverageType, "foo.a", args)
+		assert.Error(t, err)
+	}
+}
+
+func TestPerSecond(t *testing.T) {
+	nan := math.NaN()
+	op, err := NewFunctionOp(PerSecondType, "foo.a", nil)
+	require.NoError(t, err)
+
+	vals := [][]float64{{60, 120, nan, 240, 60}}
+	sink := processOp(t, op, testBounds, namedMetas("foo.a"), vals)
+	assert.Equal(t, []string{"perSecond(foo.a)"}, sinkNames(sink))
+	testEqualsWithNans(t, []float64{nan, 1, nan, 1, nan}, sink.Values[0])
+
+	// Decreases wrap around the max value
+	wrapped := perSecond([]float64{250, 5}, time.Second, 255)
+	testEqualsWithNans(t, []float64{nan, 11}, wrapped)
+
+	_, err = NewFunctionOp(PerSecondType, "foo.a", []interface{}{"max"})
+	assert.Error(t, err)
+}
+
+func TestScale(t *testing.T) {
+	op, err := NewFunctionOp(ScaleType, "foo.a", []interface{}{2.5})
+	require.NoError(t, err)
+
+	input := []float64{1, 2, 3, 4, 5}
+	sink := processOp(t, op, testBounds, namedMetas("foo.a"), [][]float64{input})
+	assert.Equal(t, []string{"scale(foo.a,2.5)"}, sinkNames(sink))
+	testEqualsWithNans(t, []float64{2.5, 5, 7.5, 10, 12.5}, sink.Values[0])
+
+	// The input block is left untouched
+	assert.Equal(t, []float64{1, 2, 3, 4, 5}, input)
+}
@@ -0,0 +1,321 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// HistogramQuantileType calculates the quantile for histogram buckets.
+	//
+	// NB: each sample must contain a tag with a bucket name (given by tag
+	// options) that denotes the upper bound of that bucket; series without this
+	// tag are ignored.
+	HistogramQuantileType = "histogram_quantile"
+
+	// bucketTag is the tag which denotes the upper bound of a bucket
+	bucketTag = "le"
+
+	// initIndexBucketLength is the initial length of the bucket slices
+	initIndexBucketLength = 10
+)
+
+// NewHistogramQuantileOp creates a new histogram quantile operation
+func NewHistogramQuantileOp(
+	args []interface{},
+	opType string,
+) (parser.Params, error) {
+	if len(args) != 1 {
+		return emptyOp, fmt.Errorf("invalid number of args for histogram_quantile: %d", len(args))
+	}
+
+	if opType != HistogramQuantileType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	q, ok := args[0].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v", args[0])
+	}
+
+	return newHistogramQuantileOp(q, opType), nil
+}
+
+// histogramQuantileOp stores required properties for histogram quantile ops
+type histogramQuantileOp struct {
+	q      float64
+	opType string
+}
+
+// OpType for the operator
+func (o histogramQuantileOp) OpType() string {
+	return o.opType
+}
+
+// String representation
+func (o histogramQuantileOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node
+func (o histogramQuantileOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &histogramQuantileNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+func newHistogramQuantileOp(q float64, opType string) histogramQuantileOp {
+	return histogramQuantileOp{
+		q:      q,
+		opType: opType,
+	}
+}
+
+type histogramQuantileNode struct {
+	op         histogramQuantileOp
+	controller *transform.Controller
+}
+
+// indexedBucket is a bucket of a histogram, along with the index of the
+// series which holds the bucket's values
+type indexedBucket struct {
+	upperBound float64
+	idx        int
+}
+
+type indexedBuckets []indexedBucket
+
+func (b indexedBuckets) Len() int           { return len(b) }
+func (b indexedBuckets) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b indexedBuckets) Less(i, j int) bool { return b[i].upperBound < b[j].upperBound }
+
+// bucketValue is the cumulative count of a bucket at a given step
+type bucketValue struct {
+	upperBound float64
+	value      float64
+}
+
+// sortedBuckets groups series into histograms by all tags except the bucket
+// tag, returning the buckets of each histogram sorted by their upper bound
+func sortedBuckets(
+	opType string,
+	seriesMetas []block.SeriesMeta,
+) ([]indexedBuckets, []block.SeriesMeta) {
+	// Series without a valid bucket tag are not part of any histogram
+	bucketedMetas := make([]block.SeriesMeta, 0, len(seriesMetas))
+	upperBounds := make([]float64, 0, len(seriesMetas))
+	seriesIndices := make([]int, 0, len(seriesMetas))
+	for i, meta := range seriesMetas {
+		le, ok := meta.Tags.Get(bucketTag)
+		if !ok {
+			continue
+		}
+
+		upperBound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			continue
+		}
+
+		bucketedMetas = append(bucketedMetas, meta)
+		upperBounds = append(upperBounds, upperBound)
+		seriesIndices = append(seriesIndices, i)
+	}
+
+	groups, metas := utils.GroupSeries(
+		[]string{bucketTag, models.MetricName},
+		true,
+		opType,
+		bucketedMetas,
+	)
+
+	histograms := make([]indexedBuckets, len(groups))
+	for i, group := range groups {
+		buckets := make(indexedBuckets, 0, len(group))
+		for _, idx := range group {
+			buckets = append(buckets, indexedBucket{
+				upperBound: upperBounds[idx],
+				idx:        seriesIndices[idx],
+			})
+		}
+
+		sort.Sort(buckets)
+		histograms[i] = buckets
+	}
+
+	return histograms, metas
+}
+
+// Process the block
+func (n *histogramQuantileNode) Process(ID parser.NodeID, b block.Block) error {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	meta := stepIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	histograms, metas := sortedBuckets(n.op.opType, seriesMetas)
+	meta.Tags, metas = utils.DedupeMetadata(metas)
+
+	builder, err := n.controller.BlockBuilder(meta, metas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(stepIter.StepCount()); err != nil {
+		return err
+	}
+
+	q := n.op.q
+	bucketValues := make([]bucketValue, 0, initIndexBucketLength)
+	quantileValues := make([]float64, len(histograms))
+	for index := 0; stepIter.Next(); index++ {
+		step, err := stepIter.Current()
+		if err != nil {
+			return err
+		}
+
+		values := step.Values()
+		for i, buckets := range histograms {
+			bucketValues = bucketValues[:0]
+			for _, bucket := range buckets {
+				val := values[bucket.idx]
+				if math.IsNaN(val) {
+					continue
+				}
+
+				bucketValues = append(bucketValues, bucketValue{
+					upperBound: bucket.upperBound,
+					value:      val,
+				})
+			}
+
+			quantileValues[i] = bucketQuantile(q, bucketValues)
+		}
+
+		builder.AppendValues(index, quantileValues)
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+// bucketQuantile calculates the quantile 'q' based on the given buckets, which
+// must be sorted by upper bound. The buckets will be modified in place.
+// The quantile value is interpolated assuming a linear distribution within a
+// bucket. If q < 0, -Inf is returned and if q > 1, +Inf is returned. NaN is
+// returned if there is no bucket with a +Inf upper bound, or if there are fewer
+// than two buckets. If the quantile falls in the highest bucket, the upper bound
+// of the second highest bucket is returned. If the quantile falls in the lowest
+// bucket and its upper bound is not positive, that upper bound is returned.
+func bucketQuantile(q float64, buckets []bucketValue) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+
+	if q > 1 {
+		return math.Inf(+1)
+	}
+
+	if len(buckets) == 0 || !math.IsInf(buckets[len(buckets)-1].upperBound, +1) {
+		return math.NaN()
+	}
+
+	buckets = coalesceBuckets(buckets)
+	ensureMonotonic(buckets)
+
+	if len(buckets) < 2 {
+		return math.NaN()
+	}
+
+	rank := q * buckets[len(buckets)-1].value
+	b := sort.Search(len(buckets)-1, func(i int) bool {
+		return buckets[i].value >= rank
+	})
+
+	if b == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	var (
+		bucketStart float64
+		bucketEnd   = buckets[b].upperBound
+		count       = buckets[b].value
+	)
+
+	if b > 0 {
+		bucketStart = buckets[b-1].upperBound
+		count -= buckets[b-1].value
+		rank -= buckets[b-1].value
+	}
+
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
+
+// coalesceBuckets merges buckets with the same upper bound, which can happen
+// when the bucket tags are formatted differently (e.g. "1" and "1.0")
+func coalesceBuckets(buckets []bucketValue) []bucketValue {
+	last := buckets[0]
+	coalesced := buckets[:0]
+	for _, b := range buckets[1:] {
+		if b.upperBound == last.upperBound {
+			last.value += b.value
+		} else {
+			coalesced = append(coalesced, last)
+			last = b
+		}
+	}
+
+	return append(coalesced, last)
+}
+
+// ensureMonotonic makes bucket values monotonically increasing. Due to
+// scrape timing and counter resets, this is not always the case and would
+// otherwise give nonsensical quantiles
+func ensureMonotonic(buckets []bucketValue) {
+	max := math.Inf(-1)
+	for i := range buckets {
+		if buckets[i].value > max {
+			max = buckets[i].value
+		} else if buckets[i].value < max {
+			buckets[i].value = max
+		}
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package linear
+
+import (
+	"math"
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bucketMeta(service, le string) block.SeriesMeta {
+	tags := map[string]string{
+		models.MetricName: "request_duration_bucket",
+		"service":         service,
+	}
+
+	if le != "" {
+		tags[bucketTag] = le
+	}
+
+	return block.SeriesMeta{
+		Name: "request_duration_bucket",
+		Tags: models.FromMap(tags),
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	seriesMetas := []block.SeriesMeta{
+		bucketMeta("a", "1"),
+		bucketMeta("b", "5"),
+		bucketMeta("a", "+Inf"),
+		bucketMeta("b", "+Inf"),
+		bucketMeta("a", "2"),
+		bucketMeta("b", ""),
+	}
+
+	v := [][]float64{
+		{1, 1, 0, nan, 2},
+		{2, 2, 2, 2, 2},
+		{4, 1, 0, nan, 8},
+		{4, 4, 4, 4, 4},
+		{3, 1, 0, nan, 2},
+		{100, 100, 100, 100, 100},
+	}
+
+	values, bounds := test.GenerateValuesAndBounds(v, nil)
+	b := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	op, err := NewHistogramQuantileOp([]interface{}{0.5}, HistogramQuantileType)
+	require.NoError(t, err)
+
+	node := op.(transform.Params).Node(c, transform.Options{})
+	err = node.Process(parser.NodeID(0), b)
+	require.NoError(t, err)
+	require.Len(t, sink.Values, 2)
+	require.Len(t, sink.Metas, 2)
+
+	expected := map[string][]float64{
+		"a": {1.5, 0.5, nan, nan, 2},
+		"b": {5, 5, 5, 5, 5},
+	}
+
+	for i, meta := range sink.Metas {
+		service, ok := meta.Tags.Get("service")
+		require.True(t, ok)
+		_, hasBucket := meta.Tags.Get(bucketTag)
+		assert.False(t, hasBucket)
+		_, hasName := meta.Tags.Get(models.MetricName)
+		assert.False(t, hasName)
+		test.EqualsWithNans(t, expected[service], sink.Values[i])
+	}
+}
+
+func TestHistogramQuantileInvalidArgs(t *testing.T) {
+	_, err := NewHistogramQuantileOp([]interface{}{}, HistogramQuantileType)
+	require.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{"0.5"}, HistogramQuantileType)
+	require.Error(t, err)
+
+	_, err = NewHistogramQuantileOp([]interface{}{0.5}, "unknown_quantile")
+	require.Error(t, err)
+}
+
+func TestBucketQuantile(t *testing.T) {
+	buckets := func() []bucketValue {
+		return []bucketValue{
+			{upperBound: 0.1, value: 10},
+			{upperBound: 0.5, value: 20},
+			{upperBound: 0.5, value: 10},
+			{upperBound: 1, value: 25},
+			{upperBound: math.Inf(1), value: 40},
+		}
+	}
+
+	assert.Equal(t, math.Inf(-1), bucketQuantile(-1, buckets()))
+	assert.Equal(t, math.Inf(1), bucketQuantile(2, buckets()))
+
+	// Buckets with the same upper bound are merged, and the non monotonic
+	// bucket at 1 is raised to the value of the previous bucket
+	assert.InDelta(t, 0.04, bucketQuantile(0.1, buckets()), 0.0001)
+	assert.InDelta(t, 0.3, bucketQuantile(0.5, buckets()), 0.0001)
+	assert.Equal(t, 1.0, bucketQuantile(0.9, buckets()))
+
+	noInf := []bucketValue{
+		{upperBound: 0.1, value: 10},
+		{upperBound: 0.5, value: 20},
+	}
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, noInf)))
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, nil)))
+
+	onlyInf := []bucketValue{{upperBound: math.Inf(1), value: 10}}
+	assert.True(t, math.IsNaN(bucketQuantile(0.5, onlyInf)))
+}
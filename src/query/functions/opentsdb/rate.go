@@ -0,0 +1,166 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package opentsdb implements the OpenTSDB query functions which have no
+// equivalent among the prometheus functions.
+package opentsdb
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// RateType computes the per second rate of change of each series
+	RateType = "opentsdb_rate"
+)
+
+// RateOptions are the options of a rate, matching the rateOptions of an
+// OpenTSDB query.
+type RateOptions struct {
+	// Counter treats a decrease in value as a counter wrapping or resetting.
+	Counter bool `json:"counter"`
+	// CounterMax is the value a counter wraps at, defaulting to the maximum
+	// int64 value.
+	CounterMax float64 `json:"counterMax"`
+	// ResetValue is the rate above which a counter is instead considered
+	// reset and the rate is zero, it is ignored if zero.
+	ResetValue float64 `json:"resetValue"`
+	// DropResets drops the rate of a counter which decreases rather than
+	// assuming it wrapped.
+	DropResets bool `json:"dropResets"`
+}
+
+// NewRateOp creates a new rate op
+func NewRateOp(opts RateOptions) parser.Params {
+	if opts.Counter && opts.CounterMax == 0 {
+		opts.CounterMax = math.MaxInt64
+	}
+
+	return rateOp{opts: opts}
+}
+
+type rateOp struct {
+	opts RateOptions
+}
+
+// OpType for the operator
+func (o rateOp) OpType() string {
+	return RateType
+}
+
+// String representation
+func (o rateOp) String() string {
+	return fmt.Sprintf("type: %s, counter: %v", o.OpType(), o.opts.Counter)
+}
+
+// Node creates an execution node
+func (o rateOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &rateNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+type rateNode struct {
+	op         rateOp
+	controller *transform.Controller
+}
+
+// Process the block
+func (n *rateNode) Process(ID parser.NodeID, b block.Block) error {
+	seriesIter, err := b.SeriesIter()
+	if err != nil {
+		return err
+	}
+
+	meta := seriesIter.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, seriesIter.SeriesMeta())
+	meta.Tags, seriesMetas = utils.DedupeMetadata(seriesMetas)
+	builder, err := n.controller.BlockBuilder(meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	if err := builder.AddCols(meta.Bounds.Steps()); err != nil {
+		return err
+	}
+
+	for seriesIter.Next() {
+		series, err := seriesIter.Current()
+		if err != nil {
+			return err
+		}
+
+		for i, value := range rate(series.Values(), meta.Bounds.StepSize, n.op.opts) {
+			if err := builder.AppendValue(i, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	nextBlock := builder.Build()
+	defer nextBlock.Close()
+	return n.controller.Process(nextBlock)
+}
+
+// rate returns the per second rate of change between each value and the
+// previous non NaN value, the first value has no rate
+func rate(values []float64, stepSize time.Duration, opts RateOptions) []float64 {
+	rates := make([]float64, len(values))
+	prevIdx := -1
+	for i, v := range values {
+		rates[i] = math.NaN()
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if prevIdx >= 0 {
+			var (
+				prev    = values[prevIdx]
+				delta   = v - prev
+				elapsed = (time.Duration(i-prevIdx) * stepSize).Seconds()
+			)
+
+			switch {
+			case !opts.Counter || delta >= 0:
+				rates[i] = delta / elapsed
+			case !opts.DropResets:
+				rates[i] = (opts.CounterMax - prev + v) / elapsed
+			}
+
+			if opts.Counter && opts.ResetValue > 0 && rates[i] > opts.ResetValue {
+				rates[i] = 0
+			}
+		}
+
+		prevIdx = i
+	}
+
+	return rates
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRate(t *testing.T) {
+	nan := math.NaN()
+	values := []float64{1, 3, nan, 7, 2, 4}
+
+	tests := []struct {
+		name     string
+		opts     RateOptions
+		expected []float64
+	}{
+		{
+			name:     "gauge",
+			expected: []float64{nan, 0.2, nan, 0.2, -0.5, 0.2},
+		},
+		{
+			name:     "counter wrapping",
+			opts:     RateOptions{Counter: true, CounterMax: 10},
+			expected: []float64{nan, 0.2, nan, 0.2, 0.5, 0.2},
+		},
+		{
+			name:     "counter dropping resets",
+			opts:     RateOptions{Counter: true, DropResets: true},
+			expected: []float64{nan, 0.2, nan, 0.2, nan, 0.2},
+		},
+		{
+			name:     "counter above reset value",
+			opts:     RateOptions{Counter: true, CounterMax: 10, ResetValue: 0.3},
+			expected: []float64{nan, 0.2, nan, 0.2, 0, 0.2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op, ok := NewRateOp(tt.opts).(transform.Params)
+			require.True(t, ok)
+
+			bounds := block.Bounds{
+				Start:    time.Unix(1500000000, 0),
+				Duration: time.Minute,
+				StepSize: 10 * time.Second,
+			}
+			b := test.NewBlockFromValues(bounds, [][]float64{values})
+			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+			node := op.Node(c, transform.Options{})
+			require.NoError(t, node.Process(parser.NodeID(0), b))
+
+			require.Len(t, sink.Values, 1)
+			test.EqualsWithNans(t, tt.expected, sink.Values[0])
+		})
+	}
+}
+
+func TestRateDefaultCounterMax(t *testing.T) {
+	rates := rate([]float64{10, 5}, time.Second, NewRateOp(RateOptions{Counter: true}).(rateOp).opts)
+	assert.Equal(t, float64(math.MaxInt64)-5, rates[1])
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/executor"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	instanceMetas = []block.SeriesMeta{
+		{
+			Name: "up",
+			Tags: models.FromMap(map[string]string{
+				models.MetricName: "up",
+				"instance":        "host-a:9090",
+				"job":             "api",
+			}),
+		},
+		{
+			Name: "up",
+			Tags: models.FromMap(map[string]string{
+				models.MetricName: "up",
+				"instance":        "host-b:9100",
+				"job":             "api",
+			}),
+		},
+	}
+
+	instanceVals = [][]float64{
+		{1, 1, 0, 1, 1},
+		{0, 1, 1, 1, 0},
+	}
+)
+
+// processTagOp runs the op over a block built from the given metas and
+// values, returning the sink along with the flattened output tags
+func processTagOp(
+	t *testing.T,
+	op parser.Params,
+	metas []block.SeriesMeta,
+	vals [][]float64,
+) (*executor.SinkNode, []models.Tags) {
+	_, bounds := test.GenerateValuesAndBounds(nil, nil)
+	inputMetas := make([]block.SeriesMeta, len(metas))
+	for i, meta := range metas {
+		inputMetas[i] = block.SeriesMeta{Name: meta.Name, Tags: meta.Tags.Clone()}
+	}
+
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, inputMetas, vals)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(1))
+	node := op.(baseOp).Node(c, transform.Options{})
+	err := node.Process(parser.NodeID(0), bl)
+	require.NoError(t, err)
+
+	require.Len(t, sink.Metas, len(metas))
+	outputMetas := utils.FlattenMetadata(sink.Meta, sink.Metas)
+	tags := make([]models.Tags, len(outputMetas))
+	for i, meta := range outputMetas {
+		tags[i] = meta.Tags
+	}
+
+	return sink, tags
+}
+
+func TestSetTag(t *testing.T) {
+	tags := models.Tags{{Name: "a", Value: "1"}, {Name: "c", Value: "3"}}
+
+	updated := setTag(tags, "b", "2")
+	assert.Equal(t, models.Tags{
+		{Name: "a", Value: "1"},
+		{Name: "b", Value: "2"},
+		{Name: "c", Value: "3"},
+	}, updated)
+
+	updated = setTag(tags, "a", "10")
+	assert.Equal(t, models.Tags{{Name: "a", Value: "10"}, {Name: "c", Value: "3"}}, updated)
+
+	updated = setTag(tags, "a", "")
+	assert.Equal(t, models.Tags{{Name: "c", Value: "3"}}, updated)
+
+	// Original tags are left untouched
+	assert.Equal(t, models.Tags{{Name: "a", Value: "1"}, {Name: "c", Value: "3"}}, tags)
+}
+
+func TestStringArgs(t *testing.T) {
+	strs, err := stringArgs(LabelJoinType, []interface{}{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, strs)
+
+	_, err = stringArgs(LabelJoinType, []interface{}{"a", 1.0})
+	assert.Error(t, err)
+}
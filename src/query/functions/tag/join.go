@@ -0,0 +1,80 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/prometheus/common/model"
+)
+
+// LabelJoinType joins the values of all source tags using a separator, and
+// sets the result as the value of the destination tag
+const LabelJoinType = "label_join"
+
+// NewLabelJoinOp creates a new label join operation, expecting the
+// destination tag, separator and any number of source tags as arguments
+func NewLabelJoinOp(args []interface{}, opType string) (parser.Params, error) {
+	if opType != LabelJoinType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	if len(args) < 2 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", opType, len(args))
+	}
+
+	strArgs, err := stringArgs(opType, args)
+	if err != nil {
+		return emptyOp, err
+	}
+
+	dst, separator, srcs := strArgs[0], strArgs[1], strArgs[2:]
+	for _, src := range srcs {
+		if !model.LabelName(src).IsValid() {
+			return emptyOp, fmt.Errorf("invalid source label name in %s: %s", opType, src)
+		}
+	}
+
+	if !model.LabelName(dst).IsValid() {
+		return emptyOp, fmt.Errorf("invalid destination label name in %s: %s", opType, dst)
+	}
+
+	return baseOp{
+		opType: opType,
+		tagFn:  makeJoinFn(dst, separator, srcs),
+	}, nil
+}
+
+func makeJoinFn(dst, separator string, srcs []string) tagTransformFunc {
+	return func(tags models.Tags) models.Tags {
+		values := make([]string, 0, len(srcs))
+		for _, src := range srcs {
+			val, _ := tags.Get(src)
+			values = append(values, val)
+		}
+
+		return setTag(tags, dst, strings.Join(values, separator))
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelJoin(t *testing.T) {
+	op, err := NewLabelJoinOp(
+		[]interface{}{"id", "/", "job", "instance", "missing"},
+		LabelJoinType,
+	)
+	require.NoError(t, err)
+
+	sink, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	test.EqualsWithNans(t, instanceVals, sink.Values)
+	assert.Equal(t, []models.Tags{
+		models.FromMap(map[string]string{
+			models.MetricName: "up",
+			"id":              "api/host-a:9090/",
+			"instance":        "host-a:9090",
+			"job":             "api",
+		}),
+		models.FromMap(map[string]string{
+			models.MetricName: "up",
+			"id":              "api/host-b:9100/",
+			"instance":        "host-b:9100",
+			"job":             "api",
+		}),
+	}, tags)
+}
+
+func TestLabelJoinNoSources(t *testing.T) {
+	// Joining no sources gives an empty value, which removes the tag
+	op, err := NewLabelJoinOp([]interface{}{"job", ","}, LabelJoinType)
+	require.NoError(t, err)
+
+	_, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	for i, tag := range tags {
+		assert.Equal(t, instanceMetas[i].Tags.TagsWithoutKeys([]string{"job"}), tag)
+	}
+}
+
+func TestLabelJoinInvalidArgs(t *testing.T) {
+	_, err := NewLabelJoinOp([]interface{}{"a"}, LabelJoinType)
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"a", ",", 1.0}, LabelJoinType)
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"a", ",", "invalid-name"}, LabelJoinType)
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"invalid-name", ",", "b"}, LabelJoinType)
+	assert.Error(t, err)
+
+	_, err = NewLabelJoinOp([]interface{}{"a", ",", "b"}, LabelReplaceType)
+	assert.Error(t, err)
+}
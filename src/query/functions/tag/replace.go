@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/prometheus/common/model"
+)
+
+// LabelReplaceType matches the tag value of a source tag against a regex, and
+// if it matches, sets the destination tag to the replacement, with capture
+// groups from the regex expanded
+const LabelReplaceType = "label_replace"
+
+// NewLabelReplaceOp creates a new label replace operation, expecting the
+// destination tag, replacement, source tag and regex as arguments
+func NewLabelReplaceOp(args []interface{}, opType string) (parser.Params, error) {
+	if opType != LabelReplaceType {
+		return emptyOp, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	if len(args) != 4 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", opType, len(args))
+	}
+
+	strArgs, err := stringArgs(opType, args)
+	if err != nil {
+		return emptyOp, err
+	}
+
+	dst, replacement, src, regexStr := strArgs[0], strArgs[1], strArgs[2], strArgs[3]
+	regex, err := regexp.Compile("^(?:" + regexStr + ")$")
+	if err != nil {
+		return emptyOp, fmt.Errorf("invalid regular expression in %s: %s", opType, regexStr)
+	}
+
+	if !model.LabelName(dst).IsValid() {
+		return emptyOp, fmt.Errorf("invalid destination label name in %s: %s", opType, dst)
+	}
+
+	return baseOp{
+		opType: opType,
+		tagFn:  makeReplaceFn(dst, replacement, src, regex),
+	}, nil
+}
+
+func makeReplaceFn(
+	dst, replacement, src string,
+	regex *regexp.Regexp,
+) tagTransformFunc {
+	return func(tags models.Tags) models.Tags {
+		// A missing source tag is treated as an empty value
+		srcVal, _ := tags.Get(src)
+		indices := regex.FindStringSubmatchIndex(srcVal)
+		if indices == nil {
+			return tags
+		}
+
+		expanded := regex.ExpandString(nil, replacement, srcVal, indices)
+		return setTag(tags, dst, string(expanded))
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tag
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelReplace(t *testing.T) {
+	op, err := NewLabelReplaceOp(
+		[]interface{}{"host", "$1", "instance", "(.*):.*"},
+		LabelReplaceType,
+	)
+	require.NoError(t, err)
+
+	sink, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	test.EqualsWithNans(t, instanceVals, sink.Values)
+	assert.Equal(t, []models.Tags{
+		models.FromMap(map[string]string{
+			models.MetricName: "up",
+			"host":            "host-a",
+			"instance":        "host-a:9090",
+			"job":             "api",
+		}),
+		models.FromMap(map[string]string{
+			models.MetricName: "up",
+			"host":            "host-b",
+			"instance":        "host-b:9100",
+			"job":             "api",
+		}),
+	}, tags)
+}
+
+func TestLabelReplaceNoMatch(t *testing.T) {
+	// The regex is anchored, so a partial match does not replace
+	op, err := NewLabelReplaceOp(
+		[]interface{}{"host", "$1", "instance", "host-(.)"},
+		LabelReplaceType,
+	)
+	require.NoError(t, err)
+
+	_, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	assert.Equal(t, []models.Tags{instanceMetas[0].Tags, instanceMetas[1].Tags}, tags)
+}
+
+func TestLabelReplaceEmptyRemovesTag(t *testing.T) {
+	op, err := NewLabelReplaceOp(
+		[]interface{}{"instance", "", "instance", ".*:9100"},
+		LabelReplaceType,
+	)
+	require.NoError(t, err)
+
+	_, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	assert.Equal(t, []models.Tags{
+		instanceMetas[0].Tags,
+		models.FromMap(map[string]string{
+			models.MetricName: "up",
+			"job":             "api",
+		}),
+	}, tags)
+}
+
+func TestLabelReplaceMissingSource(t *testing.T) {
+	// A missing source tag matches as an empty value
+	op, err := NewLabelReplaceOp(
+		[]interface{}{"dc", "unknown", "datacenter", ""},
+		LabelReplaceType,
+	)
+	require.NoError(t, err)
+
+	_, tags := processTagOp(t, op, instanceMetas, instanceVals)
+	for i, tag := range tags {
+		expected := instanceMetas[i].Tags.AddTag(models.Tag{Name: "dc", Value: "unknown"})
+		assert.Equal(t, expected, tag)
+	}
+}
+
+func TestLabelReplaceInvalidArgs(t *testing.T) {
+	_, err := NewLabelReplaceOp([]interface{}{"a", "b", "c"}, LabelReplaceType)
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"a", "b", "c", 1.0}, LabelReplaceType)
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"a", "b", "c", "(.*"}, LabelReplaceType)
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"invalid-name", "b", "c", ".*"}, LabelReplaceType)
+	assert.Error(t, err)
+
+	_, err = NewLabelReplaceOp([]interface{}{"a", "b", "c", ".*"}, LabelJoinType)
+	assert.Error(t, err)
+}
@@ -23,6 +23,7 @@ package temporal
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/m3db/m3/src/query/executor/transform"
 )
@@ -87,7 +88,7 @@ type aggNode struct {
 	aggFunc    func([]float64) float64
 }
 
-func (a *aggNode) Process(values []float64) float64 {
+func (a *aggNode) Process(values []float64, _ time.Duration) float64 {
 	return a.aggFunc(values)
 }
 
@@ -232,8 +232,14 @@ func TestAggregationAllNaNs(t *testing.T) {
 	testAggregation(t, testCasesNaNs, v)
 }
 
-// B1 has NaN in first series, first position
 func testAggregation(t *testing.T, testCases []testCase, vals [][]float64) {
+	testTemporalFunc(t, testCases, vals, NewAggOp)
+}
+
+type newOpFn func(args []interface{}, optype string) (transform.Params, error)
+
+// B1 has NaN in first series, first position
+func testTemporalFunc(t *testing.T, testCases []testCase, vals [][]float64, newOp newOpFn) {
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
 			values, bounds := test.GenerateValuesAndBounds(vals, nil)
@@ -241,7 +247,7 @@ func testAggregation(t *testing.T, testCases []testCase, vals [][]float64) {
 			block3 := test.NewBlockFromValues(bounds, values)
 			c, sink := executor.NewControllerWithSink(parser.NodeID(1))
 
-			baseOp, err := NewAggOp([]interface{}{5 * time.Minute}, tt.opType)
+			baseOp, err := newOp([]interface{}{5 * time.Minute}, tt.opType)
 			require.NoError(t, err)
 			node := baseOp.Node(c, transform.Options{
 				TimeSpec: transform.TimeSpec{
@@ -291,7 +291,7 @@ func (c *baseNode) processSingleRequest(request processRequest) error {
 			// TODO: Consider using a rotating slice since this is inefficient
 			if desiredLength <= len(values) {
 				values = values[len(values)-desiredLength:]
-				newVal = c.processor.Process(values)
+				newVal = c.processor.Process(values, bounds.StepSize)
 			}
 
 			builder.AppendValue(i, newVal)
@@ -329,9 +329,10 @@ func (c *baseNode) sweep(processedKeys []bool, maxBlocks int) {
 	}
 }
 
-// Processor is implemented by the underlying transforms
+// Processor is implemented by the underlying transforms. Values are step
+// aligned, with the last value being the one at the current step
 type Processor interface {
-	Process(values []float64) float64
+	Process(values []float64, stepSize time.Duration) float64
 }
 
 // MakeProcessor is a way to create a transform
@@ -37,7 +37,7 @@ import (
 type processor struct {
 }
 
-func (p *processor) Process(f []float64) float64 {
+func (p *processor) Process(f []float64, _ time.Duration) float64 {
 	sum := 0.0
 	for _, n := range f {
 		sum += n
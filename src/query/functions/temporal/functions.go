@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// ChangesType returns the number of times a value changes within the specified interval
+	ChangesType = "changes"
+
+	// ResetsType returns the number of counter resets within the specified interval
+	ResetsType = "resets"
+)
+
+var (
+	temporalFuncs = map[string]aggFunc{
+		ChangesType: changes,
+		ResetsType:  resets,
+	}
+)
+
+// NewFunctionOp creates a new base temporal transform for functions which
+// compare consecutive values in the interval
+func NewFunctionOp(args []interface{}, optype string) (transform.Params, error) {
+	if fn, ok := temporalFuncs[optype]; ok {
+		return newBaseOp(args, optype, newAggNode, fn)
+	}
+
+	return emptyOp, fmt.Errorf("unknown function type: %s", optype)
+}
+
+func changes(values []float64) float64 {
+	return countTransitions(values, func(prev, curr float64) bool {
+		return curr != prev
+	})
+}
+
+func resets(values []float64) float64 {
+	return countTransitions(values, func(prev, curr float64) bool {
+		return curr < prev
+	})
+}
+
+// countTransitions counts the number of consecutive pairs of values, ignoring
+// NaNs, for which the transition function holds
+func countTransitions(values []float64, transitionFn func(prev, curr float64) bool) float64 {
+	var (
+		count, prev float64
+		seen        bool
+	)
+
+	for _, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if seen && transitionFn(prev, v) {
+			count++
+		}
+
+		prev = v
+		seen = true
+	}
+
+	if !seen {
+		return math.NaN()
+	}
+
+	return count
+}
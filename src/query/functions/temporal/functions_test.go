@@ -0,0 +1,82 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var temporalFunctionTestCases = []testCase{
+	{
+		name:   "changes",
+		opType: ChangesType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 2},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 3},
+		},
+		afterAllBlocks: [][]float64{
+			{3, 4, 3, 3, 3},
+			{3, 3, 4, 3, 3},
+		},
+	},
+	{
+		name:   "resets",
+		opType: ResetsType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 2},
+		},
+		afterAllBlocks: [][]float64{
+			{2, 2, 1, 2, 1},
+			{2, 2, 3, 3, 2},
+		},
+	},
+}
+
+func TestTemporalFunctions(t *testing.T) {
+	v := [][]float64{
+		{0, 1, 1, 0, 2},
+		{5, 4, 3, 3, 9},
+	}
+	testTemporalFunc(t, temporalFunctionTestCases, v, NewFunctionOp)
+}
+
+func TestChanges(t *testing.T) {
+	assert.Equal(t, 2.0, changes([]float64{1, 1, 2, math.NaN(), 2, 3}))
+	assert.Equal(t, 0.0, changes([]float64{math.NaN(), 1}))
+	assert.True(t, math.IsNaN(changes([]float64{math.NaN(), math.NaN()})))
+}
+
+func TestResets(t *testing.T) {
+	assert.Equal(t, 2.0, resets([]float64{5, 3, math.NaN(), 4, 1, 1}))
+	assert.Equal(t, 0.0, resets([]float64{1, 2, 3}))
+	assert.True(t, math.IsNaN(resets([]float64{})))
+}
+
+func TestUnknownFunction(t *testing.T) {
+	_, err := NewFunctionOp([]interface{}{5 * time.Minute}, "unknown_func")
+	require.Error(t, err)
+}
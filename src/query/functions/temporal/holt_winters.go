@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+// HoltWintersType produces a smoothed value for time series based on the specified interval.
+// The algorithm used comes from https://en.wikipedia.org/wiki/Exponential_smoothing#Double_exponential_smoothing.
+// Holt-Winters should only be used with gauges
+const HoltWintersType = "holt_winters"
+
+type holtWintersOp struct {
+	sf float64
+	tf float64
+}
+
+// NewHoltWintersOp creates a new base temporal transform for the holt_winters function,
+// expecting the smoothing factor and the trend factor as arguments after the duration
+func NewHoltWintersOp(args []interface{}, optype string) (transform.Params, error) {
+	if optype != HoltWintersType {
+		return emptyOp, fmt.Errorf("unknown holt winters type: %s", optype)
+	}
+
+	if len(args) != 3 {
+		return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+	}
+
+	sf, ok := args[1].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], optype)
+	}
+
+	tf, ok := args[2].(float64)
+	if !ok {
+		return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[2], optype)
+	}
+
+	// Both factors must be between 0 and 1 exclusive
+	if sf <= 0 || sf >= 1 {
+		return emptyOp, fmt.Errorf("invalid smoothing factor. Expected: 0 < sf < 1, got: %f", sf)
+	}
+
+	if tf <= 0 || tf >= 1 {
+		return emptyOp, fmt.Errorf("invalid trend factor. Expected: 0 < tf < 1, got: %f", tf)
+	}
+
+	spec := holtWintersOp{
+		sf: sf,
+		tf: tf,
+	}
+
+	return newBaseOp(args[:1], optype, makeHoltWintersProcessor(spec), nil)
+}
+
+func makeHoltWintersProcessor(spec holtWintersOp) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller) Processor {
+		return &holtWintersNode{
+			op:         spec,
+			controller: controller,
+		}
+	}
+}
+
+type holtWintersNode struct {
+	op         holtWintersOp
+	controller *transform.Controller
+}
+
+func (h *holtWintersNode) Process(values []float64, _ time.Duration) float64 {
+	return holtWinters(values, h.op.sf, h.op.tf)
+}
+
+// holtWinters applies double exponential smoothing to the values, ignoring
+// NaNs, and returns the last smoothed value
+func holtWinters(values []float64, sf, tf float64) float64 {
+	points := make([]float64, 0, len(values))
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			points = append(points, v)
+		}
+	}
+
+	// Smoothing needs at least two points to establish an initial trend
+	if len(points) < 2 {
+		return math.NaN()
+	}
+
+	var (
+		s0 float64
+		s1 = points[0]
+		b  = points[1] - points[0]
+	)
+
+	for i := 1; i < len(points); i++ {
+		// Scale the raw value against the smoothing factor
+		x := sf * points[i]
+
+		// Scale the last smoothed value with the trend at this point
+		b = calcTrendValue(i-1, tf, s0, s1, b)
+		y := (1 - sf) * (s1 + b)
+
+		s0, s1 = s1, x+y
+	}
+
+	return s1
+}
+
+// calcTrendValue calculates the trend value at the given index; the initial
+// trend is used as is
+func calcTrendValue(i int, tf, s0, s1, b float64) float64 {
+	if i == 0 {
+		return b
+	}
+
+	x := tf * (s1 - s0)
+	y := (1 - tf) * b
+	return x + y
+}
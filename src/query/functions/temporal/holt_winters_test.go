@@ -0,0 +1,85 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var holtWintersTestCases = []testCase{
+	{
+		name:   "holt_winters",
+		opType: HoltWintersType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1.125},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5.375},
+		},
+		afterAllBlocks: [][]float64{
+			{0.6563, 0.4063, 1.75, -0.4688, 1.6563},
+			{5.7188, 5.5625, 6.4375, 0.9063, 5.375},
+		},
+	},
+}
+
+func TestHoltWinters(t *testing.T) {
+	v := [][]float64{
+		{0, 1, 1, 0, 2},
+		{5, 4, 3, 3, 9},
+	}
+	newOp := func(args []interface{}, optype string) (transform.Params, error) {
+		return NewHoltWintersOp(append(args, 0.5, 0.5), optype)
+	}
+	testTemporalFunc(t, holtWintersTestCases, v, newOp)
+}
+
+func TestHoltWintersLinear(t *testing.T) {
+	// Smoothing a perfectly linear series gives back the last value
+	assert.Equal(t, 3.0, holtWinters([]float64{1, 2, 3}, 0.5, 0.5))
+	assert.InDelta(t, 8.0, holtWinters([]float64{2, math.NaN(), 4, 6, 8}, 0.3, 0.7), 0.0001)
+}
+
+func TestHoltWintersNotEnoughValues(t *testing.T) {
+	assert.True(t, math.IsNaN(holtWinters([]float64{math.NaN(), 1}, 0.5, 0.5)))
+}
+
+func TestHoltWintersInvalidArgs(t *testing.T) {
+	_, err := NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5}, HoltWintersType)
+	require.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, "0.5", 0.5}, HoltWintersType)
+	require.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5, 1.0}, HoltWintersType)
+	require.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 0.0, 0.5}, HoltWintersType)
+	require.Error(t, err)
+
+	_, err = NewHoltWintersOp([]interface{}{5 * time.Minute, 0.5, 0.5}, "unknown_type")
+	require.Error(t, err)
+}
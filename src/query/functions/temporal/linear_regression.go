@@ -0,0 +1,128 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// DerivType calculates the per-second derivative of the time series in the specified
+	// interval, using a simple linear regression. This should only be used with gauges
+	DerivType = "deriv"
+
+	// PredictLinearType predicts the value of the time series the provided number of seconds
+	// from now, using a simple linear regression over the specified interval
+	PredictLinearType = "predict_linear"
+)
+
+type linearRegressionOp struct {
+	opType   string
+	duration float64
+}
+
+// NewLinearRegressionOp creates a new base temporal transform for the linear regression functions
+func NewLinearRegressionOp(args []interface{}, optype string) (transform.Params, error) {
+	switch optype {
+	case DerivType:
+		return newBaseOp(args, optype, makeLinearRegressionProcessor(linearRegressionOp{opType: optype}), nil)
+
+	case PredictLinearType:
+		if len(args) != 2 {
+			return emptyOp, fmt.Errorf("invalid number of args for %s: %d", optype, len(args))
+		}
+
+		duration, ok := args[1].(float64)
+		if !ok {
+			return emptyOp, fmt.Errorf("unable to cast to scalar argument: %v for %s", args[1], optype)
+		}
+
+		spec := linearRegressionOp{
+			opType:   optype,
+			duration: duration,
+		}
+
+		return newBaseOp(args[:1], optype, makeLinearRegressionProcessor(spec), nil)
+
+	default:
+		return emptyOp, fmt.Errorf("unknown linear regression type: %s", optype)
+	}
+}
+
+func makeLinearRegressionProcessor(spec linearRegressionOp) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller) Processor {
+		return &linearRegressionNode{
+			op:         spec,
+			controller: controller,
+		}
+	}
+}
+
+type linearRegressionNode struct {
+	op         linearRegressionOp
+	controller *transform.Controller
+}
+
+func (l *linearRegressionNode) Process(values []float64, stepSize time.Duration) float64 {
+	slope, intercept := linearRegression(values, stepSize)
+	if l.op.opType == DerivType {
+		return slope
+	}
+
+	return slope*l.op.duration + intercept
+}
+
+// linearRegression performs a least-squares linear regression of the values,
+// returning the slope per second and the intercept at the time of the last value
+func linearRegression(values []float64, stepSize time.Duration) (float64, float64) {
+	var (
+		n, sumX, sumY, sumXY, sumX2 float64
+		lastIdx                     = len(values) - 1
+		step                        = stepSize.Seconds()
+	)
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		x := -float64(lastIdx-i) * step
+		n++
+		sumY += v
+		sumX += x
+		sumXY += x * v
+		sumX2 += x * x
+	}
+
+	if n < 2 {
+		return math.NaN(), math.NaN()
+	}
+
+	covXY := sumXY - sumX*sumY/n
+	varX := sumX2 - sumX*sumX/n
+	slope := covXY / varX
+	intercept := sumY/n - slope*sumX/n
+	return slope, intercept
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var derivTestCases = []testCase{
+	{
+		name:   "deriv",
+		opType: DerivType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0167},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0167},
+		},
+		afterAllBlocks: [][]float64{
+			{0, -0.0083, -0.0083, 0, 0.0167},
+			{0, -0.0083, -0.0083, 0, 0.0167},
+		},
+	},
+}
+
+var predictLinearTestCases = []testCase{
+	{
+		name:   "predict_linear",
+		opType: PredictLinearType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5.6667},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 10.6667},
+		},
+		afterAllBlocks: [][]float64{
+			{2, 0.1667, 0.1667, 2, 5.6667},
+			{7, 5.1667, 5.1667, 7, 10.6667},
+		},
+	},
+}
+
+func TestDeriv(t *testing.T) {
+	v := [][]float64{
+		{0, 1, 2, 3, 4},
+		{5, 6, 7, 8, 9},
+	}
+	testTemporalFunc(t, derivTestCases, v, NewLinearRegressionOp)
+}
+
+func TestPredictLinear(t *testing.T) {
+	v := [][]float64{
+		{0, 1, 2, 3, 4},
+		{5, 6, 7, 8, 9},
+	}
+	newOp := func(args []interface{}, optype string) (transform.Params, error) {
+		return NewLinearRegressionOp(append(args, 100.0), optype)
+	}
+	testTemporalFunc(t, predictLinearTestCases, v, newOp)
+}
+
+func TestLinearRegressionNotEnoughValues(t *testing.T) {
+	slope, intercept := linearRegression([]float64{math.NaN(), 1, math.NaN()}, time.Minute)
+	assert.True(t, math.IsNaN(slope))
+	assert.True(t, math.IsNaN(intercept))
+}
+
+func TestPredictLinearInvalidArgs(t *testing.T) {
+	_, err := NewLinearRegressionOp([]interface{}{5 * time.Minute}, PredictLinearType)
+	require.Error(t, err)
+
+	_, err = NewLinearRegressionOp([]interface{}{5 * time.Minute, "100"}, PredictLinearType)
+	require.Error(t, err)
+}
+
+func TestUnknownLinearRegression(t *testing.T) {
+	_, err := NewLinearRegressionOp([]interface{}{5 * time.Minute}, "unknown_regression_func")
+	require.Error(t, err)
+}
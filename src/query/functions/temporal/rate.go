@@ -0,0 +1,210 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+)
+
+const (
+	// RateType calculates the per-second average rate of increase of the time series
+	// in the specified interval, accounting for counter resets
+	RateType = "rate"
+
+	// IRateType calculates the per-second instant rate of increase of the time series
+	// based on the last two values in the specified interval
+	IRateType = "irate"
+
+	// IncreaseType calculates the increase of the time series in the specified interval,
+	// accounting for counter resets
+	IncreaseType = "increase"
+
+	// DeltaType calculates the difference between the first and last value of the time series
+	// in the specified interval. This should only be used with gauges
+	DeltaType = "delta"
+
+	// IDeltaType calculates the difference between the last two values of the time series
+	// in the specified interval. This should only be used with gauges
+	IDeltaType = "idelta"
+)
+
+type rateFn func(values []float64, stepSize, duration time.Duration, isRate, isCounter bool) float64
+
+type rateSpec struct {
+	isRate    bool
+	isCounter bool
+	rateFn    rateFn
+}
+
+var (
+	rateSpecs = map[string]rateSpec{
+		RateType:     {isRate: true, isCounter: true, rateFn: extrapolatedRate},
+		IncreaseType: {isRate: false, isCounter: true, rateFn: extrapolatedRate},
+		DeltaType:    {isRate: false, isCounter: false, rateFn: extrapolatedRate},
+		IRateType:    {isRate: true, isCounter: true, rateFn: instantValue},
+		IDeltaType:   {isRate: false, isCounter: false, rateFn: instantValue},
+	}
+)
+
+// NewRateOp creates a new base temporal transform for the rate functions
+func NewRateOp(args []interface{}, optype string) (transform.Params, error) {
+	if spec, ok := rateSpecs[optype]; ok {
+		return newBaseOp(args, optype, makeRateProcessor(spec), nil)
+	}
+
+	return emptyOp, fmt.Errorf("unknown rate type: %s", optype)
+}
+
+func makeRateProcessor(spec rateSpec) MakeProcessor {
+	return func(op baseOp, controller *transform.Controller) Processor {
+		return &rateNode{
+			op:         op,
+			controller: controller,
+			spec:       spec,
+		}
+	}
+}
+
+type rateNode struct {
+	op         baseOp
+	controller *transform.Controller
+	spec       rateSpec
+}
+
+func (r *rateNode) Process(values []float64, stepSize time.Duration) float64 {
+	return r.spec.rateFn(values, stepSize, r.op.duration, r.spec.isRate, r.spec.isCounter)
+}
+
+// extrapolatedRate calculates the rate, increase or delta of the values, extrapolating
+// the result to the boundaries of the range in the same way Prometheus does.
+// The range is (t - duration, t] where t is the time of the last value
+func extrapolatedRate(values []float64, stepSize, duration time.Duration, isRate, isCounter bool) float64 {
+	var (
+		firstIdx, lastIdx = -1, -1
+		firstVal, lastVal float64
+		counterCorrection float64
+		numValues         int
+		endIdx            = len(values) - 1
+	)
+
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+
+		if firstIdx == -1 {
+			firstIdx = i
+			firstVal = v
+		} else if isCounter && v < lastVal {
+			counterCorrection += lastVal
+		}
+
+		lastIdx = i
+		lastVal = v
+		numValues++
+	}
+
+	if numValues < 2 {
+		return math.NaN()
+	}
+
+	step := stepSize.Seconds()
+	resultValue := lastVal - firstVal + counterCorrection
+	durationToStart := duration.Seconds() - float64(endIdx-firstIdx)*step
+	durationToEnd := float64(endIdx-lastIdx) * step
+	sampledInterval := float64(lastIdx-firstIdx) * step
+	averageDurationBetweenSamples := sampledInterval / float64(numValues-1)
+
+	if isCounter && resultValue > 0 && firstVal >= 0 {
+		// Counters cannot be negative, so do not extrapolate past the point
+		// where the counter would have been zero
+		durationToZero := sampledInterval * (firstVal / resultValue)
+		if durationToZero < durationToStart {
+			durationToStart = durationToZero
+		}
+	}
+
+	// If the first or last value is close enough to the boundary of the range,
+	// extrapolate all the way to the boundary, otherwise only extrapolate by
+	// half the average duration between samples
+	extrapolationThreshold := averageDurationBetweenSamples * 1.1
+	extrapolateToInterval := sampledInterval
+	if durationToStart < extrapolationThreshold {
+		extrapolateToInterval += durationToStart
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+
+	if durationToEnd < extrapolationThreshold {
+		extrapolateToInterval += durationToEnd
+	} else {
+		extrapolateToInterval += averageDurationBetweenSamples / 2
+	}
+
+	resultValue = resultValue * (extrapolateToInterval / sampledInterval)
+	if isRate {
+		resultValue = resultValue / duration.Seconds()
+	}
+
+	return resultValue
+}
+
+// instantValue calculates the irate or idelta based on the last two values
+func instantValue(values []float64, stepSize, _ time.Duration, isRate, _ bool) float64 {
+	lastIdx, previousIdx := -1, -1
+	for i := len(values) - 1; i >= 0; i-- {
+		if math.IsNaN(values[i]) {
+			continue
+		}
+
+		if lastIdx == -1 {
+			lastIdx = i
+			continue
+		}
+
+		previousIdx = i
+		break
+	}
+
+	if previousIdx == -1 {
+		return math.NaN()
+	}
+
+	lastVal, previousVal := values[lastIdx], values[previousIdx]
+	var resultValue float64
+	if isRate && lastVal < previousVal {
+		// Counter reset
+		resultValue = lastVal
+	} else {
+		resultValue = lastVal - previousVal
+	}
+
+	if isRate {
+		sampledInterval := float64(lastIdx-previousIdx) * stepSize.Seconds()
+		resultValue = resultValue / sampledInterval
+	}
+
+	return resultValue
+}
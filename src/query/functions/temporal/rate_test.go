@@ -0,0 +1,122 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package temporal
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var rateTestCases = []testCase{
+	{
+		name:   "rate",
+		opType: RateType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0133},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0167},
+		},
+		afterAllBlocks: [][]float64{
+			{0.0125, 0.0125, 0.0125, 0.0125, 0.0133},
+			{0.0333, 0.0333, 0.0333, 0.0333, 0.0167},
+		},
+	},
+	{
+		name:   "increase",
+		opType: IncreaseType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 4},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5},
+		},
+		afterAllBlocks: [][]float64{
+			{3.75, 3.75, 3.75, 3.75, 4},
+			{10, 10, 10, 10, 5},
+		},
+	},
+	{
+		name:   "delta",
+		opType: DeltaType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 3.5},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5},
+		},
+		afterAllBlocks: [][]float64{
+			{-1.25, -1.25, -1.25, -1.25, 5},
+			{-1.25, -1.25, -1.25, -1.25, 5},
+		},
+	},
+	{
+		name:   "irate",
+		opType: IRateType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0167},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 0.0167},
+		},
+		afterAllBlocks: [][]float64{
+			{0, 0.0167, 0.0167, 0.0167, 0.0167},
+			{0.0833, 0.0167, 0.0167, 0.0167, 0.0167},
+		},
+	},
+	{
+		name:   "idelta",
+		opType: IDeltaType,
+		afterBlockOne: [][]float64{
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1},
+			{math.NaN(), math.NaN(), math.NaN(), math.NaN(), 1},
+		},
+		afterAllBlocks: [][]float64{
+			{-4, 1, 1, 1, 1},
+			{-4, 1, 1, 1, 1},
+		},
+	},
+}
+
+func TestRate(t *testing.T) {
+	v := [][]float64{
+		{0, 1, 2, 3, 4},
+		{5, 6, 7, 8, 9},
+	}
+	testTemporalFunc(t, rateTestCases, v, NewRateOp)
+}
+
+func TestRateCounterReset(t *testing.T) {
+	// Reset between the third and fourth values
+	values := []float64{1, 2, 3, 1, 2}
+	increase := extrapolatedRate(values, time.Minute, 5*time.Minute, false, true)
+	assert.InDelta(t, 5.0, increase, 0.0001)
+
+	delta := extrapolatedRate(values, time.Minute, 5*time.Minute, false, false)
+	assert.InDelta(t, 1.25, delta, 0.0001)
+}
+
+func TestRateNotEnoughValues(t *testing.T) {
+	values := []float64{math.NaN(), math.NaN(), 3, math.NaN()}
+	assert.True(t, math.IsNaN(extrapolatedRate(values, time.Minute, 4*time.Minute, true, true)))
+	assert.True(t, math.IsNaN(instantValue(values, time.Minute, 4*time.Minute, true, true)))
+}
+
+func TestUnknownRate(t *testing.T) {
+	_, err := NewRateOp([]interface{}{5 * time.Minute}, "unknown_rate_func")
+	require.Error(t, err)
+}
@@ -45,6 +45,13 @@
 		CompressedDatapoints
 		Tag
 		Series
+		SearchResults
+		Metric
+		FetchBlocksResult
+		Block
+		BlockMetadata
+		SeriesMetadata
+		Step
 */
 package rpcpb
 
@@ -242,6 +249,7 @@ type FetchQuery struct {
 	Start       int64      `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
 	End         int64      `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
 	TagMatchers []*Matcher `protobuf:"bytes,3,rep,name=tagMatchers" json:"tagMatchers,omitempty"`
+	Interval    int64      `protobuf:"varint,4,opt,name=interval,proto3" json:"interval,omitempty"`
 }
 
 func (m *FetchQuery) Reset()                    { *m = FetchQuery{} }
@@ -270,6 +278,13 @@ func (m *FetchQuery) GetTagMatchers() []*Matcher {
 	return nil
 }
 
+func (m *FetchQuery) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
 type FetchOptions struct {
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
@@ -526,6 +541,182 @@ func (m *Series) GetCompressed() *CompressedDatapoints {
 	return nil
 }
 
+type SearchResults struct {
+	Metrics []*Metric `protobuf:"bytes,1,rep,name=metrics" json:"metrics,omitempty"`
+}
+
+func (m *SearchResults) Reset()                    { *m = SearchResults{} }
+func (m *SearchResults) String() string            { return proto.CompactTextString(m) }
+func (*SearchResults) ProtoMessage()               {}
+func (*SearchResults) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{17} }
+
+func (m *SearchResults) GetMetrics() []*Metric {
+	if m != nil {
+		return m.Metrics
+	}
+	return nil
+}
+
+type Metric struct {
+	Namespace []byte `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Id        []byte `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Tags      []*Tag `protobuf:"bytes,3,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *Metric) Reset()                    { *m = Metric{} }
+func (m *Metric) String() string            { return proto.CompactTextString(m) }
+func (*Metric) ProtoMessage()               {}
+func (*Metric) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{18} }
+
+func (m *Metric) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *Metric) GetId() []byte {
+	if m != nil {
+		return m.Id
+	}
+	return nil
+}
+
+func (m *Metric) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type FetchBlocksResult struct {
+	Blocks []*Block `protobuf:"bytes,1,rep,name=blocks" json:"blocks,omitempty"`
+}
+
+func (m *FetchBlocksResult) Reset()                    { *m = FetchBlocksResult{} }
+func (m *FetchBlocksResult) String() string            { return proto.CompactTextString(m) }
+func (*FetchBlocksResult) ProtoMessage()               {}
+func (*FetchBlocksResult) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{19} }
+
+func (m *FetchBlocksResult) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type Block struct {
+	Meta       *BlockMetadata    `protobuf:"bytes,1,opt,name=meta" json:"meta,omitempty"`
+	SeriesMeta []*SeriesMetadata `protobuf:"bytes,2,rep,name=seriesMeta" json:"seriesMeta,omitempty"`
+	Steps      []*Step           `protobuf:"bytes,3,rep,name=steps" json:"steps,omitempty"`
+}
+
+func (m *Block) Reset()                    { *m = Block{} }
+func (m *Block) String() string            { return proto.CompactTextString(m) }
+func (*Block) ProtoMessage()               {}
+func (*Block) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{20} }
+
+func (m *Block) GetMeta() *BlockMetadata {
+	if m != nil {
+		return m.Meta
+	}
+	return nil
+}
+
+func (m *Block) GetSeriesMeta() []*SeriesMetadata {
+	if m != nil {
+		return m.SeriesMeta
+	}
+	return nil
+}
+
+func (m *Block) GetSteps() []*Step {
+	if m != nil {
+		return m.Steps
+	}
+	return nil
+}
+
+type BlockMetadata struct {
+	Start    int64  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Duration int64  `protobuf:"varint,2,opt,name=duration,proto3" json:"duration,omitempty"`
+	StepSize int64  `protobuf:"varint,3,opt,name=stepSize,proto3" json:"stepSize,omitempty"`
+	Tags     []*Tag `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *BlockMetadata) Reset()                    { *m = BlockMetadata{} }
+func (m *BlockMetadata) String() string            { return proto.CompactTextString(m) }
+func (*BlockMetadata) ProtoMessage()               {}
+func (*BlockMetadata) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{21} }
+
+func (m *BlockMetadata) GetStart() int64 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *BlockMetadata) GetDuration() int64 {
+	if m != nil {
+		return m.Duration
+	}
+	return 0
+}
+
+func (m *BlockMetadata) GetStepSize() int64 {
+	if m != nil {
+		return m.StepSize
+	}
+	return 0
+}
+
+func (m *BlockMetadata) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type SeriesMetadata struct {
+	Name []byte `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags []*Tag `protobuf:"bytes,2,rep,name=tags" json:"tags,omitempty"`
+}
+
+func (m *SeriesMetadata) Reset()                    { *m = SeriesMetadata{} }
+func (m *SeriesMetadata) String() string            { return proto.CompactTextString(m) }
+func (*SeriesMetadata) ProtoMessage()               {}
+func (*SeriesMetadata) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{22} }
+
+func (m *SeriesMetadata) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+func (m *SeriesMetadata) GetTags() []*Tag {
+	if m != nil {
+		return m.Tags
+	}
+	return nil
+}
+
+type Step struct {
+	Values []float64 `protobuf:"fixed64,1,rep,packed,name=values" json:"values,omitempty"`
+}
+
+func (m *Step) Reset()                    { *m = Step{} }
+func (m *Step) String() string            { return proto.CompactTextString(m) }
+func (*Step) ProtoMessage()               {}
+func (*Step) Descriptor() ([]byte, []int) { return fileDescriptorQuery, []int{23} }
+
+func (m *Step) GetValues() []float64 {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WriteMessage)(nil), "rpcpb.WriteMessage")
 	proto.RegisterType((*WriteQuery)(nil), "rpcpb.WriteQuery")
@@ -544,6 +735,13 @@ func init() {
 	proto.RegisterType((*CompressedDatapoints)(nil), "rpcpb.CompressedDatapoints")
 	proto.RegisterType((*Tag)(nil), "rpcpb.Tag")
 	proto.RegisterType((*Series)(nil), "rpcpb.Series")
+	proto.RegisterType((*SearchResults)(nil), "rpcpb.SearchResults")
+	proto.RegisterType((*Metric)(nil), "rpcpb.Metric")
+	proto.RegisterType((*FetchBlocksResult)(nil), "rpcpb.FetchBlocksResult")
+	proto.RegisterType((*Block)(nil), "rpcpb.Block")
+	proto.RegisterType((*BlockMetadata)(nil), "rpcpb.BlockMetadata")
+	proto.RegisterType((*SeriesMetadata)(nil), "rpcpb.SeriesMetadata")
+	proto.RegisterType((*Step)(nil), "rpcpb.Step")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -559,6 +757,8 @@ const _ = grpc.SupportPackageIsVersion4
 type QueryClient interface {
 	Fetch(ctx context.Context, in *FetchMessage, opts ...grpc.CallOption) (Query_FetchClient, error)
 	Write(ctx context.Context, opts ...grpc.CallOption) (Query_WriteClient, error)
+	Search(ctx context.Context, in *FetchMessage, opts ...grpc.CallOption) (Query_SearchClient, error)
+	FetchBlocks(ctx context.Context, in *FetchMessage, opts ...grpc.CallOption) (Query_FetchBlocksClient, error)
 }
 
 type queryClient struct {
@@ -635,11 +835,77 @@ func (x *queryWriteClient) CloseAndRecv() (*Error, error) {
 	return m, nil
 }
 
+func (c *queryClient) Search(ctx context.Context, in *FetchMessage, opts ...grpc.CallOption) (Query_SearchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Query_serviceDesc.Streams[2], c.cc, "/rpcpb.Query/Search", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &querySearchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_SearchClient interface {
+	Recv() (*SearchResults, error)
+	grpc.ClientStream
+}
+
+type querySearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *querySearchClient) Recv() (*SearchResults, error) {
+	m := new(SearchResults)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *queryClient) FetchBlocks(ctx context.Context, in *FetchMessage, opts ...grpc.CallOption) (Query_FetchBlocksClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_Query_serviceDesc.Streams[3], c.cc, "/rpcpb.Query/FetchBlocks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &queryFetchBlocksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Query_FetchBlocksClient interface {
+	Recv() (*FetchBlocksResult, error)
+	grpc.ClientStream
+}
+
+type queryFetchBlocksClient struct {
+	grpc.ClientStream
+}
+
+func (x *queryFetchBlocksClient) Recv() (*FetchBlocksResult, error) {
+	m := new(FetchBlocksResult)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // Server API for Query service
 
 type QueryServer interface {
 	Fetch(*FetchMessage, Query_FetchServer) error
 	Write(Query_WriteServer) error
+	Search(*FetchMessage, Query_SearchServer) error
+	FetchBlocks(*FetchMessage, Query_FetchBlocksServer) error
 }
 
 func RegisterQueryServer(s *grpc.Server, srv QueryServer) {
@@ -693,6 +959,48 @@ func (x *queryWriteServer) Recv() (*WriteMessage, error) {
 	return m, nil
 }
 
+func _Query_Search_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).Search(m, &querySearchServer{stream})
+}
+
+type Query_SearchServer interface {
+	Send(*SearchResults) error
+	grpc.ServerStream
+}
+
+type querySearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *querySearchServer) Send(m *SearchResults) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Query_FetchBlocks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchMessage)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(QueryServer).FetchBlocks(m, &queryFetchBlocksServer{stream})
+}
+
+type Query_FetchBlocksServer interface {
+	Send(*FetchBlocksResult) error
+	grpc.ServerStream
+}
+
+type queryFetchBlocksServer struct {
+	grpc.ServerStream
+}
+
+func (x *queryFetchBlocksServer) Send(m *FetchBlocksResult) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "rpcpb.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -708,6 +1016,16 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Query_Write_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "Search",
+			Handler:       _Query_Search_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FetchBlocks",
+			Handler:       _Query_FetchBlocks_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "github.com/m3db/m3/src/query/generated/proto/rpcpb/query.proto",
 }
@@ -1000,6 +1318,11 @@ func (m *FetchQuery) MarshalTo(dAtA []byte) (int, error) {
 			i += n
 		}
 	}
+	if m.Interval != 0 {
+		dAtA[i] = 0x20
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Interval))
+	}
 	return i, nil
 }
 
@@ -1340,38 +1663,315 @@ func (m *Series) MarshalTo(dAtA []byte) (int, error) {
 	return i, nil
 }
 
-func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SearchResults) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return offset + 1
+	return dAtA[:n], nil
 }
-func (m *WriteMessage) Size() (n int) {
+
+func (m *SearchResults) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Query != nil {
-		l = m.Query.Size()
-		n += 1 + l + sovQuery(uint64(l))
+	if len(m.Metrics) > 0 {
+		for _, msg := range m.Metrics {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
-	if m.Options != nil {
-		l = m.Options.Size()
-		n += 1 + l + sovQuery(uint64(l))
+	return i, nil
+}
+
+func (m *Metric) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *WriteQuery) Size() (n int) {
+func (m *Metric) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Unit != 0 {
-		n += 1 + sovQuery(uint64(m.Unit))
+	if len(m.Namespace) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Namespace)))
+		i += copy(dAtA[i:], m.Namespace)
 	}
-	l = len(m.Annotation)
-	if l > 0 {
-		n += 1 + l + sovQuery(uint64(l))
+	if len(m.Id) > 0 {
+		dAtA[i] = 0x12
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Id)))
+		i += copy(dAtA[i:], m.Id)
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *FetchBlocksResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FetchBlocksResult) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, msg := range m.Blocks {
+			dAtA[i] = 0xa
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Block) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Block) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Meta != nil {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Meta.Size()))
+		n8, err := m.Meta.MarshalTo(dAtA[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n8
+	}
+	if len(m.SeriesMeta) > 0 {
+		for _, msg := range m.SeriesMeta {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if len(m.Steps) > 0 {
+		for _, msg := range m.Steps {
+			dAtA[i] = 0x1a
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *BlockMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockMetadata) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Start != 0 {
+		dAtA[i] = 0x8
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Start))
+	}
+	if m.Duration != 0 {
+		dAtA[i] = 0x10
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.Duration))
+	}
+	if m.StepSize != 0 {
+		dAtA[i] = 0x18
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(m.StepSize))
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x22
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *SeriesMetadata) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesMetadata) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Name) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Name)))
+		i += copy(dAtA[i:], m.Name)
+	}
+	if len(m.Tags) > 0 {
+		for _, msg := range m.Tags {
+			dAtA[i] = 0x12
+			i++
+			i = encodeVarintQuery(dAtA, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(dAtA[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	return i, nil
+}
+
+func (m *Step) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalTo(dAtA)
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Step) MarshalTo(dAtA []byte) (int, error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		dAtA[i] = 0xa
+		i++
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Values)*8))
+		for _, num := range m.Values {
+			f9 := math.Float64bits(float64(num))
+			dAtA[i] = uint8(f9)
+			i++
+			dAtA[i] = uint8(f9 >> 8)
+			i++
+			dAtA[i] = uint8(f9 >> 16)
+			i++
+			dAtA[i] = uint8(f9 >> 24)
+			i++
+			dAtA[i] = uint8(f9 >> 32)
+			i++
+			dAtA[i] = uint8(f9 >> 40)
+			i++
+			dAtA[i] = uint8(f9 >> 48)
+			i++
+			dAtA[i] = uint8(f9 >> 56)
+			i++
+		}
+	}
+	return i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return offset + 1
+}
+func (m *WriteMessage) Size() (n int) {
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Options != nil {
+		l = m.Options.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *WriteQuery) Size() (n int) {
+	var l int
+	_ = l
+	if m.Unit != 0 {
+		n += 1 + sovQuery(uint64(m.Unit))
+	}
+	l = len(m.Annotation)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
 	}
 	if len(m.Datapoints) > 0 {
 		for _, e := range m.Datapoints {
@@ -1466,6 +2066,9 @@ func (m *FetchQuery) Size() (n int) {
 			n += 1 + l + sovQuery(uint64(l))
 		}
 	}
+	if m.Interval != 0 {
+		n += 1 + sovQuery(uint64(m.Interval))
+	}
 	return n
 }
 
@@ -1620,27 +2223,139 @@ func (m *Series) Size() (n int) {
 	return n
 }
 
-func sovQuery(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
+func (m *SearchResults) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Metrics) > 0 {
+		for _, e := range m.Metrics {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
 		}
 	}
 	return n
 }
-func sozQuery(x uint64) (n int) {
-	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *Metric) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
 }
-func (m *WriteMessage) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
+
+func (m *FetchBlocksResult) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, e := range m.Blocks {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Block) Size() (n int) {
+	var l int
+	_ = l
+	if m.Meta != nil {
+		l = m.Meta.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.SeriesMeta) > 0 {
+		for _, e := range m.SeriesMeta {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	if len(m.Steps) > 0 {
+		for _, e := range m.Steps {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BlockMetadata) Size() (n int) {
+	var l int
+	_ = l
+	if m.Start != 0 {
+		n += 1 + sovQuery(uint64(m.Start))
+	}
+	if m.Duration != 0 {
+		n += 1 + sovQuery(uint64(m.Duration))
+	}
+	if m.StepSize != 0 {
+		n += 1 + sovQuery(uint64(m.StepSize))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SeriesMetadata) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if len(m.Tags) > 0 {
+		for _, e := range m.Tags {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Step) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Values) > 0 {
+		n += 1 + sovQuery(uint64(len(m.Values)*8)) + len(m.Values)*8
+	}
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *WriteMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
 				return ErrIntOverflowQuery
 			}
 			if iNdEx >= l {
@@ -2108,9 +2823,891 @@ func (m *Datapoint) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Value = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Datapoints) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Datapoints: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Datapoints: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datapoints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Datapoints = append(m.Datapoints, &Datapoint{})
+			if err := m.Datapoints[len(m.Datapoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FixedResolution", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.FixedResolution = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Error) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchMessage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchMessage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Query == nil {
+				m.Query = &FetchQuery{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Options == nil {
+				m.Options = &FetchOptions{}
+			}
+			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			m.Start = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Start |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			m.End = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.End |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TagMatchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TagMatchers = append(m.TagMatchers, &Matcher{})
+			if err := m.TagMatchers[len(m.TagMatchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Interval", wireType)
+			}
+			m.Interval = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Interval |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchOptions) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchOptions: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Matcher) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Matcher: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Matcher: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FetchResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FetchResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FetchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Series", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Series = append(m.Series, &Series{})
+			if err := m.Series[len(m.Series)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Segment) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Segment: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Segment: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
+			if m.Head == nil {
+				m.Head = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tail", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tail = append(m.Tail[:0], dAtA[iNdEx:postIndex]...)
+			if m.Tail == nil {
+				m.Tail = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			}
+			m.StartTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTime |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
 			}
-			m.Timestamp = 0
+			m.BlockSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2120,22 +3717,11 @@ func (m *Datapoint) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Timestamp |= (int64(b) & 0x7F) << shift
+				m.BlockSize |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Value = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -2157,7 +3743,7 @@ func (m *Datapoint) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Datapoints) Unmarshal(dAtA []byte) error {
+func (m *Segments) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2180,15 +3766,15 @@ func (m *Datapoints) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Datapoints: wiretype end group for non-group")
+			return fmt.Errorf("proto: Segments: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Datapoints: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Segments: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Datapoints", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Merged", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2212,16 +3798,18 @@ func (m *Datapoints) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Datapoints = append(m.Datapoints, &Datapoint{})
-			if err := m.Datapoints[len(m.Datapoints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Merged == nil {
+				m.Merged = &Segment{}
+			}
+			if err := m.Merged.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field FixedResolution", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unmerged", wireType)
 			}
-			var v int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2231,12 +3819,23 @@ func (m *Datapoints) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				v |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			m.FixedResolution = bool(v != 0)
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unmerged = append(m.Unmerged, &Segment{})
+			if err := m.Unmerged[len(m.Unmerged)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -2258,7 +3857,7 @@ func (m *Datapoints) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Error) Unmarshal(dAtA []byte) error {
+func (m *CompressedValuesReplica) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2281,17 +3880,17 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Error: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompressedValuesReplica: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Error: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompressedValuesReplica: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Segments", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2301,20 +3900,22 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Error = string(dAtA[iNdEx:postIndex])
+			m.Segments = append(m.Segments, &Segments{})
+			if err := m.Segments[len(m.Segments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2337,7 +3938,7 @@ func (m *Error) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchMessage) Unmarshal(dAtA []byte) error {
+func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2360,17 +3961,17 @@ func (m *FetchMessage) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchMessage: wiretype end group for non-group")
+			return fmt.Errorf("proto: CompressedDatapoints: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchMessage: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: CompressedDatapoints: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2380,30 +3981,28 @@ func (m *FetchMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Query == nil {
-				m.Query = &FetchQuery{}
-			}
-			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Options", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
 			}
-			var msglen int
+			m.StartTime = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2413,80 +4012,16 @@ func (m *FetchMessage) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.StartTime |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Options == nil {
-				m.Options = &FetchOptions{}
-			}
-			if err := m.Options.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipQuery(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if skippy < 0 {
-				return ErrInvalidLengthQuery
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *FetchQuery) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowQuery
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: FetchQuery: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchQuery: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
 			}
-			m.Start = 0
+			m.EndTime = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2496,16 +4031,16 @@ func (m *FetchQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Start |= (int64(b) & 0x7F) << shift
+				m.EndTime |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedTags", wireType)
 			}
-			m.End = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2515,14 +4050,26 @@ func (m *FetchQuery) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.End |= (int64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedTags = append(m.CompressedTags[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedTags == nil {
+				m.CompressedTags = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field TagMatchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2546,8 +4093,8 @@ func (m *FetchQuery) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.TagMatchers = append(m.TagMatchers, &Matcher{})
-			if err := m.TagMatchers[len(m.TagMatchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Replicas = append(m.Replicas, &CompressedValuesReplica{})
+			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2572,7 +4119,7 @@ func (m *FetchQuery) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchOptions) Unmarshal(dAtA []byte) error {
+func (m *Tag) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2595,17 +4142,17 @@ func (m *FetchOptions) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchOptions: wiretype end group for non-group")
+			return fmt.Errorf("proto: Tag: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchOptions: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Tag: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2615,20 +4162,53 @@ func (m *FetchOptions) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -2651,7 +4231,7 @@ func (m *FetchOptions) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Matcher) Unmarshal(dAtA []byte) error {
+func (m *Series) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2674,17 +4254,17 @@ func (m *Matcher) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Matcher: wiretype end group for non-group")
+			return fmt.Errorf("proto: Series: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Matcher: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Series: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2694,26 +4274,28 @@ func (m *Matcher) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
+			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2723,26 +4305,30 @@ func (m *Matcher) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = string(dAtA[iNdEx:postIndex])
+			if m.Values == nil {
+				m.Values = &Datapoints{}
+			}
+			if err := m.Values.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2752,11 +4338,56 @@ func (m *Matcher) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, &Tag{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compressed", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Compressed == nil {
+				m.Compressed = &CompressedDatapoints{}
+			}
+			if err := m.Compressed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -2778,7 +4409,7 @@ func (m *Matcher) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *FetchResult) Unmarshal(dAtA []byte) error {
+func (m *SearchResults) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2801,15 +4432,15 @@ func (m *FetchResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: FetchResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: SearchResults: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: FetchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SearchResults: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Series", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Metrics", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2833,8 +4464,8 @@ func (m *FetchResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Series = append(m.Series, &Series{})
-			if err := m.Series[len(m.Series)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Metrics = append(m.Metrics, &Metric{})
+			if err := m.Metrics[len(m.Metrics)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2859,7 +4490,7 @@ func (m *FetchResult) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Segment) Unmarshal(dAtA []byte) error {
+func (m *Metric) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2882,15 +4513,15 @@ func (m *Segment) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Segment: wiretype end group for non-group")
+			return fmt.Errorf("proto: Metric: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Segment: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Metric: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Head", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2914,14 +4545,14 @@ func (m *Segment) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Head = append(m.Head[:0], dAtA[iNdEx:postIndex]...)
-			if m.Head == nil {
-				m.Head = []byte{}
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tail", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2945,16 +4576,16 @@ func (m *Segment) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Tail = append(m.Tail[:0], dAtA[iNdEx:postIndex]...)
-			if m.Tail == nil {
-				m.Tail = []byte{}
+			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
+			if m.Id == nil {
+				m.Id = []byte{}
 			}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			m.StartTime = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -2964,30 +4595,23 @@ func (m *Segment) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTime |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field BlockSize", wireType)
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
 			}
-			m.BlockSize = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.BlockSize |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tags = append(m.Tags, &Tag{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -3009,7 +4633,7 @@ func (m *Segment) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Segments) Unmarshal(dAtA []byte) error {
+func (m *FetchBlocksResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3032,48 +4656,15 @@ func (m *Segments) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Segments: wiretype end group for non-group")
+			return fmt.Errorf("proto: FetchBlocksResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Segments: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: FetchBlocksResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Merged", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Merged == nil {
-				m.Merged = &Segment{}
-			}
-			if err := m.Merged.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unmerged", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3097,8 +4688,8 @@ func (m *Segments) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Unmerged = append(m.Unmerged, &Segment{})
-			if err := m.Unmerged[len(m.Unmerged)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Blocks = append(m.Blocks, &Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3123,7 +4714,7 @@ func (m *Segments) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompressedValuesReplica) Unmarshal(dAtA []byte) error {
+func (m *Block) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3133,28 +4724,92 @@ func (m *CompressedValuesReplica) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowQuery
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Meta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Meta == nil {
+				m.Meta = &BlockMetadata{}
+			}
+			if err := m.Meta.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesMeta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			m.SeriesMeta = append(m.SeriesMeta, &SeriesMetadata{})
+			if err := m.SeriesMeta[len(m.SeriesMeta)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CompressedValuesReplica: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompressedValuesReplica: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Segments", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Steps", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3178,8 +4833,8 @@ func (m *CompressedValuesReplica) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Segments = append(m.Segments, &Segments{})
-			if err := m.Segments[len(m.Segments)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Steps = append(m.Steps, &Step{})
+			if err := m.Steps[len(m.Steps)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3204,7 +4859,7 @@ func (m *CompressedValuesReplica) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
+func (m *BlockMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3227,17 +4882,17 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: CompressedDatapoints: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockMetadata: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CompressedDatapoints: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
 			}
-			var byteLen int
+			m.Start = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -3247,28 +4902,16 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				m.Start |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
-			if m.Namespace == nil {
-				m.Namespace = []byte{}
-			}
-			iNdEx = postIndex
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Duration", wireType)
 			}
-			m.StartTime = 0
+			m.Duration = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -3278,16 +4921,16 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.StartTime |= (int64(b) & 0x7F) << shift
+				m.Duration |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTime", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StepSize", wireType)
 			}
-			m.EndTime = 0
+			m.StepSize = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -3297,45 +4940,14 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.EndTime |= (int64(b) & 0x7F) << shift
+				m.StepSize |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CompressedTags", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.CompressedTags = append(m.CompressedTags[:0], dAtA[iNdEx:postIndex]...)
-			if m.CompressedTags == nil {
-				m.CompressedTags = []byte{}
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Replicas", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3359,8 +4971,8 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Replicas = append(m.Replicas, &CompressedValuesReplica{})
-			if err := m.Replicas[len(m.Replicas)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Tags = append(m.Tags, &Tag{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3385,7 +4997,7 @@ func (m *CompressedDatapoints) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Tag) Unmarshal(dAtA []byte) error {
+func (m *SeriesMetadata) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3408,10 +5020,10 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Tag: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeriesMetadata: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Tag: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeriesMetadata: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -3447,9 +5059,9 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowQuery
@@ -3459,21 +5071,21 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthQuery
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Tags = append(m.Tags, &Tag{})
+			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
 		default:
@@ -3497,7 +5109,7 @@ func (m *Tag) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Series) Unmarshal(dAtA []byte) error {
+func (m *Step) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3520,140 +5132,58 @@ func (m *Series) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Series: wiretype end group for non-group")
+			return fmt.Errorf("proto: Step: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Series: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Step: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
-			}
-			var byteLen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if byteLen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + byteLen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Id = append(m.Id[:0], dAtA[iNdEx:postIndex]...)
-			if m.Id == nil {
-				m.Id = []byte{}
-			}
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Values == nil {
-				m.Values = &Datapoints{}
-			}
-			if err := m.Values.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Tags", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
-				}
-				if iNdEx >= l {
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Values = append(m.Values, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowQuery
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= (int(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
 				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Tags = append(m.Tags, &Tag{})
-			if err := m.Tags[len(m.Tags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Compressed", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowQuery
+				if packedLen < 0 {
+					return ErrInvalidLengthQuery
 				}
-				if iNdEx >= l {
+				postIndex := iNdEx + packedLen
+				if postIndex > l {
 					return io.ErrUnexpectedEOF
 				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Values = append(m.Values, v2)
 				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthQuery
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Compressed == nil {
-				m.Compressed = &CompressedDatapoints{}
-			}
-			if err := m.Compressed.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipQuery(dAtA[iNdEx:])
@@ -3785,56 +5315,69 @@ func init() {
 }
 
 var fileDescriptorQuery = []byte{
-	// 801 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdb, 0x6e, 0x1c, 0x45,
-	0x10, 0x65, 0x76, 0x3d, 0xeb, 0xdd, 0xf2, 0xe2, 0x38, 0x4d, 0x24, 0x46, 0x21, 0x8c, 0xa2, 0x91,
-	0x08, 0xe6, 0xb6, 0x63, 0xad, 0x91, 0x40, 0x41, 0x02, 0x09, 0x92, 0xf0, 0x14, 0x21, 0xda, 0x2b,
-	0x78, 0x43, 0xea, 0x9d, 0x29, 0xcf, 0x8e, 0xb2, 0x73, 0xa1, 0xbb, 0x17, 0x61, 0xbe, 0x82, 0x4f,
-	0xe0, 0x73, 0x78, 0xcc, 0x07, 0xf0, 0x80, 0xcc, 0x8f, 0xa0, 0xae, 0xee, 0xb9, 0xad, 0x8d, 0xf0,
-	0x5b, 0x75, 0xd5, 0x99, 0xba, 0x9c, 0x3e, 0x5d, 0x03, 0x5f, 0x66, 0xb9, 0xde, 0xec, 0xd6, 0x8b,
-	0xa4, 0x2a, 0xe2, 0xe2, 0x3c, 0x5d, 0xc7, 0xc5, 0x79, 0xac, 0x64, 0x12, 0xff, 0xbc, 0x43, 0x79,
-	0x15, 0x67, 0x58, 0xa2, 0x14, 0x1a, 0xd3, 0xb8, 0x96, 0x95, 0xae, 0x62, 0x59, 0x27, 0xf5, 0xda,
-	0xc6, 0x16, 0xe4, 0x61, 0x3e, 0xb9, 0xa2, 0x4b, 0x98, 0xff, 0x28, 0x73, 0x8d, 0x2f, 0x51, 0x29,
-	0x91, 0x21, 0x7b, 0x1f, 0x7c, 0x42, 0x05, 0xde, 0x63, 0xef, 0xf4, 0x68, 0x79, 0x7f, 0x41, 0xb0,
-	0x05, 0x61, 0xbe, 0x37, 0x01, 0x6e, 0xe3, 0xec, 0x13, 0x38, 0xac, 0x6a, 0x9d, 0x57, 0xa5, 0x0a,
-	0x46, 0x04, 0x7d, 0xab, 0x0f, 0xfd, 0xce, 0x86, 0x78, 0x83, 0x89, 0xfe, 0xf2, 0x00, 0xba, 0x24,
-	0x8c, 0xc1, 0xc1, 0xae, 0xcc, 0x35, 0x55, 0xf1, 0x39, 0xd9, 0x2c, 0x04, 0x10, 0x65, 0x59, 0x69,
-	0x61, 0xbe, 0xa0, 0xa4, 0x73, 0xde, 0xf3, 0xb0, 0x33, 0x80, 0x54, 0x68, 0x51, 0x57, 0x79, 0xa9,
-	0x55, 0x30, 0x7e, 0x3c, 0x3e, 0x3d, 0x5a, 0x9e, 0xb8, 0xa2, 0xcf, 0x9a, 0x00, 0xef, 0x61, 0x58,
-	0x0c, 0x07, 0x5a, 0x64, 0x2a, 0x38, 0x20, 0xec, 0x3b, 0x37, 0x66, 0x59, 0xac, 0x44, 0xa6, 0x9e,
-	0x97, 0x5a, 0x5e, 0x71, 0x02, 0x3e, 0xfc, 0x0c, 0x66, 0xad, 0x8b, 0x9d, 0xc0, 0xf8, 0x15, 0x5a,
-	0x22, 0x66, 0xdc, 0x98, 0xec, 0x01, 0xf8, 0xbf, 0x88, 0xed, 0x0e, 0xa9, 0xb9, 0x19, 0xb7, 0x87,
-	0xa7, 0xa3, 0xcf, 0xbd, 0x28, 0x74, 0x34, 0xba, 0xb9, 0xd9, 0x31, 0x8c, 0xf2, 0xd4, 0x7d, 0x3a,
-	0xca, 0xd3, 0xe8, 0x2b, 0x98, 0xb5, 0x2d, 0xb2, 0x47, 0x30, 0xd3, 0x79, 0x81, 0x4a, 0x8b, 0xa2,
-	0x26, 0xcc, 0x98, 0x77, 0x8e, 0x61, 0x11, 0xcf, 0x15, 0x89, 0x36, 0x00, 0xcf, 0xba, 0xc1, 0x86,
-	0x54, 0x78, 0x77, 0xa0, 0xe2, 0x14, 0xee, 0x5d, 0xe6, 0xbf, 0x62, 0xca, 0x51, 0x55, 0xdb, 0x5d,
-	0xcb, 0xf0, 0x94, 0xef, 0xbb, 0xa3, 0x77, 0xc1, 0x7f, 0x2e, 0x65, 0x25, 0x4d, 0x23, 0x68, 0x0c,
-	0x37, 0x86, 0x3d, 0x18, 0xc1, 0xbc, 0x40, 0x9d, 0x6c, 0xfe, 0x47, 0x30, 0x84, 0xb9, 0x9b, 0x60,
-	0x08, 0x7a, 0x43, 0x30, 0x97, 0x00, 0x5d, 0x0e, 0xd3, 0x8b, 0xd2, 0x42, 0x6a, 0x47, 0x97, 0x3d,
-	0x98, 0x1b, 0xc2, 0x32, 0xa5, 0x74, 0x63, 0x6e, 0x4c, 0x76, 0x06, 0x47, 0x5a, 0x64, 0x2f, 0x85,
-	0x4e, 0x36, 0x28, 0x1b, 0x91, 0x1c, 0xbb, 0x42, 0xce, 0xcd, 0xfb, 0x10, 0x73, 0x73, 0xfd, 0x06,
-	0x6e, 0xdc, 0xdc, 0xb7, 0x70, 0xe8, 0xb0, 0x46, 0xb4, 0xa5, 0x28, 0xd0, 0x05, 0xc9, 0xbe, 0x5d,
-	0x12, 0x06, 0xa9, 0xaf, 0x6a, 0x0c, 0xc6, 0xd4, 0x19, 0xd9, 0xd1, 0xa7, 0x70, 0x44, 0x85, 0x38,
-	0xaa, 0xdd, 0x56, 0xb3, 0xf7, 0x60, 0xa2, 0x50, 0xe6, 0xd8, 0x5c, 0xdf, 0x9b, 0xae, 0xc9, 0x0b,
-	0x72, 0x72, 0x17, 0x8c, 0x0a, 0x38, 0xbc, 0xc0, 0xac, 0xc0, 0x52, 0x9b, 0xa4, 0x1b, 0x14, 0xb6,
-	0xb7, 0x39, 0x27, 0x9b, 0x0a, 0x89, 0x7c, 0xeb, 0x5e, 0x0b, 0xd9, 0x46, 0x5e, 0x44, 0xcf, 0x2a,
-	0x2f, 0x9a, 0x0e, 0x3a, 0x87, 0x89, 0xae, 0xb7, 0x55, 0xf2, 0xea, 0x22, 0xff, 0x0d, 0x83, 0x03,
-	0x1b, 0x6d, 0x1d, 0xd1, 0x4f, 0x30, 0x75, 0xe5, 0x14, 0x7b, 0x02, 0x93, 0x02, 0x65, 0x86, 0xa9,
-	0xbb, 0xda, 0xe3, 0xb6, 0x43, 0x02, 0x70, 0x17, 0x65, 0x1f, 0xc2, 0x74, 0x57, 0x3a, 0xe4, 0x68,
-	0x40, 0x78, 0x83, 0x6c, 0xe3, 0xd1, 0x0b, 0x78, 0xfb, 0x9b, 0xaa, 0xa8, 0x25, 0x2a, 0x85, 0xe9,
-	0x0f, 0x86, 0x2b, 0xc5, 0xb1, 0xde, 0xe6, 0x89, 0x60, 0x1f, 0xc1, 0x54, 0xb9, 0xd2, 0x8e, 0x92,
-	0x7b, 0xc3, 0x34, 0x8a, 0xb7, 0x80, 0xe8, 0xb5, 0x07, 0x0f, 0xba, 0x44, 0xbd, 0x97, 0xf1, 0x08,
-	0x66, 0xe6, 0x5e, 0x54, 0x2d, 0x12, 0x74, 0x4c, 0x75, 0x8e, 0x21, 0x35, 0xa3, 0x7d, 0x6a, 0x02,
-	0x38, 0xc4, 0x32, 0xed, 0xd1, 0xd6, 0x1c, 0xd9, 0x13, 0x38, 0x4e, 0xda, 0x6a, 0x2b, 0xbb, 0x52,
-	0x4c, 0xea, 0x3d, 0x2f, 0x7b, 0x0a, 0x53, 0x69, 0xc7, 0x51, 0x81, 0x4f, 0x33, 0x84, 0x6e, 0x86,
-	0xff, 0x98, 0x9a, 0xb7, 0xf8, 0x28, 0x86, 0xf1, 0x4a, 0x64, 0x03, 0x91, 0xcd, 0x6f, 0x13, 0xd9,
-	0xbc, 0x59, 0x09, 0x7f, 0x78, 0x30, 0xb1, 0x6a, 0xe9, 0x89, 0x76, 0x6e, 0x44, 0xcb, 0x3e, 0x80,
-	0x09, 0x61, 0x9a, 0xa7, 0x76, 0x7f, 0x7f, 0x37, 0x28, 0xee, 0x00, 0x2c, 0x74, 0x3b, 0xd2, 0x3e,
-	0x15, 0x70, 0xc0, 0x95, 0xc8, 0xec, 0x4a, 0x64, 0x5f, 0x00, 0x74, 0x43, 0xd2, 0xd8, 0xdd, 0x26,
-	0xbd, 0xed, 0x06, 0x78, 0x0f, 0xbe, 0xcc, 0xc1, 0xb7, 0xef, 0x77, 0x09, 0x3e, 0x89, 0x9f, 0x0d,
-	0x1e, 0xbd, 0xdb, 0x21, 0x0f, 0x59, 0xdf, 0x69, 0xdf, 0xc7, 0x99, 0xc7, 0x3e, 0x06, 0x9f, 0x76,
-	0x2a, 0x1b, 0xfc, 0x59, 0x9a, 0x6f, 0xe6, 0xce, 0x49, 0xbb, 0xea, 0xd4, 0xfb, 0xfa, 0xe4, 0xcf,
-	0xeb, 0xd0, 0x7b, 0x7d, 0x1d, 0x7a, 0x7f, 0x5f, 0x87, 0xde, 0xef, 0xff, 0x84, 0x6f, 0xac, 0x27,
-	0xf4, 0xa3, 0x3b, 0xff, 0x37, 0x00, 0x00, 0xff, 0xff, 0x25, 0xa1, 0x04, 0x1f, 0x2a, 0x07, 0x00,
-	0x00,
+	// 1023 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdd, 0x6e, 0x1b, 0x45,
+	0x14, 0x66, 0x6d, 0xaf, 0x63, 0x9f, 0xb8, 0x6e, 0x32, 0x04, 0xb0, 0x42, 0x59, 0x85, 0x15, 0xb4,
+	0xe6, 0xcf, 0xb6, 0x12, 0x2a, 0x4a, 0x91, 0x8a, 0x54, 0xd2, 0x72, 0x15, 0x21, 0x26, 0x56, 0xb9,
+	0x43, 0x1a, 0xef, 0x4e, 0xd7, 0xab, 0x7a, 0x7f, 0x98, 0x19, 0x57, 0x04, 0x89, 0x1b, 0x6e, 0xb9,
+	0xe1, 0x11, 0x78, 0x1c, 0x2e, 0xfb, 0x00, 0x5c, 0x54, 0xe1, 0x45, 0xd0, 0x9c, 0x99, 0xfd, 0x73,
+	0x1c, 0xa5, 0x77, 0xb3, 0xe7, 0x7c, 0x73, 0x7e, 0xbf, 0x73, 0x66, 0xe1, 0x51, 0x14, 0xab, 0xe5,
+	0x7a, 0x31, 0x09, 0xb2, 0x64, 0x9a, 0x9c, 0x84, 0x8b, 0x69, 0x72, 0x32, 0x95, 0x22, 0x98, 0xfe,
+	0xb2, 0xe6, 0xe2, 0x62, 0x1a, 0xf1, 0x94, 0x0b, 0xa6, 0x78, 0x38, 0xcd, 0x45, 0xa6, 0xb2, 0xa9,
+	0xc8, 0x83, 0x7c, 0x61, 0x74, 0x13, 0x94, 0x10, 0x17, 0x45, 0xfe, 0x73, 0x18, 0xfc, 0x24, 0x62,
+	0xc5, 0xcf, 0xb8, 0x94, 0x2c, 0xe2, 0xe4, 0x1e, 0xb8, 0x88, 0x1a, 0x39, 0x47, 0xce, 0x78, 0xf7,
+	0x78, 0x7f, 0x82, 0xb0, 0x09, 0x62, 0x7e, 0xd4, 0x0a, 0x6a, 0xf4, 0xe4, 0x0b, 0xd8, 0xc9, 0x72,
+	0x15, 0x67, 0xa9, 0x1c, 0xb5, 0x10, 0xfa, 0x76, 0x1d, 0xfa, 0x83, 0x51, 0xd1, 0x02, 0xe3, 0xff,
+	0xeb, 0x00, 0x54, 0x46, 0x08, 0x81, 0xce, 0x3a, 0x8d, 0x15, 0x7a, 0x71, 0x29, 0x9e, 0x89, 0x07,
+	0xc0, 0xd2, 0x34, 0x53, 0x4c, 0xdf, 0x40, 0xa3, 0x03, 0x5a, 0x93, 0x90, 0x19, 0x40, 0xc8, 0x14,
+	0xcb, 0xb3, 0x38, 0x55, 0x72, 0xd4, 0x3e, 0x6a, 0x8f, 0x77, 0x8f, 0xf7, 0xac, 0xd3, 0xd3, 0x42,
+	0x41, 0x6b, 0x18, 0x32, 0x85, 0x8e, 0x62, 0x91, 0x1c, 0x75, 0x10, 0xfb, 0xfe, 0x95, 0x5c, 0x26,
+	0x73, 0x16, 0xc9, 0x27, 0xa9, 0x12, 0x17, 0x14, 0x81, 0x87, 0x5f, 0x41, 0xbf, 0x14, 0x91, 0x3d,
+	0x68, 0xbf, 0xe0, 0xa6, 0x10, 0x7d, 0xaa, 0x8f, 0xe4, 0x00, 0xdc, 0x97, 0x6c, 0xb5, 0xe6, 0x18,
+	0x5c, 0x9f, 0x9a, 0x8f, 0x87, 0xad, 0x07, 0x8e, 0xef, 0xc1, 0xa0, 0x9e, 0x37, 0x19, 0x42, 0x2b,
+	0x0e, 0xed, 0xd5, 0x56, 0x1c, 0xfa, 0xdf, 0x42, 0xbf, 0x0c, 0x91, 0xdc, 0x81, 0xbe, 0x8a, 0x13,
+	0x2e, 0x15, 0x4b, 0x72, 0xc4, 0xb4, 0x69, 0x25, 0x68, 0x3a, 0x71, 0xac, 0x13, 0x7f, 0x09, 0x70,
+	0x5a, 0x25, 0xd6, 0x2c, 0x85, 0xf3, 0x06, 0xa5, 0x18, 0xc3, 0xed, 0xe7, 0xf1, 0xaf, 0x3c, 0xa4,
+	0x5c, 0x66, 0xab, 0x75, 0x59, 0xe1, 0x1e, 0xdd, 0x14, 0xfb, 0x1f, 0x80, 0xfb, 0x44, 0x88, 0x4c,
+	0xe8, 0x40, 0xb8, 0x3e, 0xd8, 0x34, 0xcc, 0x87, 0x26, 0xcc, 0x53, 0xae, 0x82, 0xe5, 0x0d, 0x84,
+	0x41, 0xcc, 0x9b, 0x11, 0x06, 0xa1, 0x57, 0x08, 0xf3, 0x87, 0x03, 0x50, 0x19, 0xd1, 0xc1, 0x48,
+	0xc5, 0x84, 0xb2, 0xf5, 0x32, 0x1f, 0xba, 0x45, 0x3c, 0x0d, 0xd1, 0x5e, 0x9b, 0xea, 0x23, 0x99,
+	0xc1, 0xae, 0x62, 0xd1, 0x19, 0x53, 0xc1, 0x92, 0x8b, 0x82, 0x25, 0x43, 0xeb, 0xc9, 0x8a, 0x69,
+	0x1d, 0x42, 0x0e, 0xa1, 0x17, 0xa7, 0x8a, 0x8b, 0x97, 0x6c, 0x35, 0xea, 0xa0, 0xa1, 0xf2, 0x5b,
+	0xb7, 0xb5, 0x1e, 0xdd, 0x95, 0xb6, 0x7e, 0x0f, 0x3b, 0xd6, 0x8e, 0x66, 0x74, 0xca, 0x12, 0x6e,
+	0x95, 0x78, 0xde, 0xce, 0x17, 0x8d, 0x54, 0x17, 0x39, 0x1f, 0xb5, 0xd1, 0x19, 0x9e, 0xfd, 0x2f,
+	0x61, 0x17, 0x1d, 0x51, 0x2e, 0xd7, 0x2b, 0x45, 0x3e, 0x86, 0xae, 0xe4, 0x22, 0xe6, 0x45, 0x6f,
+	0x6f, 0xd9, 0x04, 0xce, 0x51, 0x48, 0xad, 0xd2, 0x4f, 0x60, 0xe7, 0x9c, 0x47, 0x09, 0x4f, 0x95,
+	0x36, 0xba, 0xe4, 0xcc, 0xc4, 0x36, 0xa0, 0x78, 0x46, 0x47, 0x2c, 0x5e, 0xd9, 0x51, 0xc2, 0xb3,
+	0xe6, 0x1e, 0x96, 0x6e, 0x1e, 0x27, 0x45, 0x04, 0x95, 0x40, 0x6b, 0x17, 0xab, 0x2c, 0x78, 0x71,
+	0x1e, 0xff, 0xc6, 0x6d, 0x31, 0x2a, 0x81, 0xff, 0x33, 0xf4, 0xac, 0x3b, 0x49, 0xee, 0x42, 0x37,
+	0xe1, 0x22, 0xe2, 0xa1, 0xed, 0xfb, 0xb0, 0x8c, 0x10, 0x01, 0xd4, 0x6a, 0xc9, 0xa7, 0xd0, 0x5b,
+	0xa7, 0x16, 0xd9, 0x3a, 0x6a, 0x6f, 0x41, 0x96, 0x7a, 0xff, 0x29, 0xbc, 0xf7, 0x5d, 0x96, 0xe4,
+	0x82, 0x4b, 0xc9, 0xc3, 0x67, 0xba, 0x56, 0x92, 0xf2, 0x7c, 0x15, 0x07, 0x8c, 0x7c, 0x06, 0x3d,
+	0x69, 0x5d, 0xdb, 0x92, 0xdc, 0x6e, 0x9a, 0x91, 0xb4, 0x04, 0xf8, 0xaf, 0x1c, 0x38, 0xa8, 0x0c,
+	0xd5, 0xc6, 0xe6, 0x0e, 0xf4, 0x75, 0x5f, 0x64, 0xce, 0x02, 0x6e, 0x2b, 0x55, 0x09, 0x9a, 0xa5,
+	0x69, 0x6d, 0x96, 0x66, 0x04, 0x3b, 0x3c, 0x0d, 0x6b, 0x65, 0x2b, 0x3e, 0xc9, 0x5d, 0x18, 0x06,
+	0xa5, 0xb7, 0xb9, 0xd9, 0x37, 0xda, 0xf4, 0x86, 0x94, 0x3c, 0x84, 0x9e, 0x30, 0xe9, 0xc8, 0x91,
+	0x8b, 0x39, 0x78, 0x36, 0x87, 0x6b, 0xb2, 0xa6, 0x25, 0xde, 0x9f, 0x42, 0x7b, 0xce, 0xa2, 0x06,
+	0xc9, 0x06, 0xdb, 0x48, 0x36, 0x28, 0xf6, 0xc5, 0xdf, 0x0e, 0x74, 0x0d, 0x5b, 0x6a, 0xa4, 0x1d,
+	0x68, 0xd2, 0x92, 0x4f, 0xa0, 0x8b, 0x98, 0x62, 0x0e, 0xf7, 0x37, 0x17, 0x87, 0xa4, 0x16, 0x40,
+	0x3c, 0xbb, 0x40, 0xcd, 0x18, 0x81, 0x05, 0xce, 0x59, 0x64, 0xf6, 0x25, 0xf9, 0x06, 0xa0, 0x4a,
+	0x12, 0xd3, 0xae, 0xd6, 0xec, 0xb6, 0x0e, 0xd0, 0x1a, 0xdc, 0x7f, 0x00, 0xb7, 0xce, 0x39, 0x13,
+	0x05, 0xe9, 0x25, 0xb9, 0x07, 0x3b, 0x09, 0x57, 0x22, 0x0e, 0x36, 0x69, 0x7f, 0x86, 0x52, 0x5a,
+	0x68, 0xfd, 0x67, 0xd0, 0x35, 0xa2, 0x1b, 0x3a, 0x6a, 0x32, 0x6f, 0x95, 0x99, 0xdf, 0x90, 0x8e,
+	0xff, 0x35, 0xec, 0xe3, 0x14, 0x3e, 0xd6, 0x94, 0x97, 0x76, 0x16, 0x3f, 0x82, 0x2e, 0x8e, 0x40,
+	0x11, 0xd4, 0xc0, 0x5e, 0x43, 0x10, 0xb5, 0x3a, 0xff, 0x4f, 0x07, 0x5c, 0x94, 0x90, 0x31, 0x74,
+	0x12, 0xae, 0x98, 0x9d, 0x8b, 0x83, 0x3a, 0xfa, 0x8c, 0x2b, 0xa6, 0x77, 0x32, 0x45, 0x04, 0xb9,
+	0x0f, 0x60, 0x06, 0x59, 0xcb, 0xed, 0x74, 0xbc, 0xd3, 0x98, 0xf4, 0xf2, 0x42, 0x0d, 0x48, 0x3e,
+	0xd4, 0xab, 0x90, 0xe7, 0x45, 0x1a, 0xbb, 0xc5, 0x0d, 0xc5, 0x73, 0x6a, 0x34, 0xfe, 0xef, 0x70,
+	0xab, 0xe1, 0xf0, 0x9a, 0xf5, 0x79, 0x08, 0xbd, 0x70, 0x2d, 0xaa, 0xf7, 0xb6, 0x4d, 0xcb, 0x6f,
+	0xad, 0xd3, 0xb6, 0x70, 0x13, 0x18, 0xc2, 0x97, 0xdf, 0xc4, 0x6b, 0xbc, 0xab, 0x57, 0xeb, 0x78,
+	0x0a, 0xc3, 0x66, 0xfc, 0x5b, 0x89, 0x5b, 0x58, 0x69, 0x5d, 0x63, 0xc5, 0x83, 0x8e, 0xce, 0x89,
+	0xbc, 0x5b, 0xf2, 0x55, 0x37, 0xc0, 0x29, 0xc8, 0x79, 0xfc, 0xda, 0x01, 0xd7, 0x3c, 0x0e, 0xc7,
+	0xe0, 0x62, 0xdf, 0x48, 0xe3, 0x49, 0xb1, 0x2f, 0xd4, 0x21, 0xa9, 0x0b, 0x4d, 0x53, 0x67, 0x0e,
+	0xf9, 0x1c, 0x5c, 0x7c, 0xb1, 0x49, 0xe3, 0xbf, 0xa5, 0xb8, 0x53, 0x34, 0x19, 0x5f, 0xc2, 0xb1,
+	0x43, 0xee, 0x43, 0xd7, 0x70, 0x75, 0xbb, 0x8b, 0x83, 0xb2, 0x6b, 0x35, 0x3e, 0xcf, 0x1c, 0xf2,
+	0xc8, 0xae, 0x75, 0x43, 0xa8, 0xed, 0x77, 0x47, 0x75, 0x61, 0x9d, 0x79, 0x33, 0xe7, 0xf1, 0xde,
+	0x3f, 0x97, 0x9e, 0xf3, 0xea, 0xd2, 0x73, 0x5e, 0x5f, 0x7a, 0xce, 0x5f, 0xff, 0x79, 0x6f, 0x2d,
+	0xba, 0xf8, 0xf7, 0x76, 0xf2, 0xff, 0x00, 0xfb, 0x9a, 0x32, 0x6b, 0xff, 0x09, 0x00, 0x00,
 }
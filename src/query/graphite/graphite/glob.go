@@ -0,0 +1,187 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	globChars = "*?{["
+
+	// matchAll matches any node value, a graphite tag that does not match
+	// it is one which is absent from the series
+	matchAll = ".*"
+)
+
+var (
+	errEmptyPath = errors.New("empty graphite path")
+)
+
+// NodeMatchers returns a matcher for each node of a graphite path query,
+// these also match series with more nodes than the query
+func NodeMatchers(query string) (models.Matchers, error) {
+	if query == "" {
+		return nil, errEmptyPath
+	}
+
+	nodes := strings.Split(query, ".")
+	matchers := make(models.Matchers, 0, len(nodes)+1)
+	for i, node := range nodes {
+		if node == "" {
+			return nil, fmt.Errorf("empty node at index %d in query: %s", i, query)
+		}
+
+		matcher, err := nodeMatcher(TagName(i), node)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+// PathMatchers returns the matchers selecting the series whose path matches
+// a graphite path query, i.e. that have exactly as many nodes as the query
+func PathMatchers(query string) (models.Matchers, error) {
+	matchers, err := NodeMatchers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	terminator, err := AbsentMatcher(len(matchers))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(matchers, terminator), nil
+}
+
+// AbsentMatcher returns a matcher selecting series without a node at the
+// given path index
+func AbsentMatcher(idx int) (*models.Matcher, error) {
+	return models.NewMatcher(models.MatchNotRegexp, TagName(idx), matchAll)
+}
+
+// PresentMatcher returns a matcher selecting series with a node at the given
+// path index
+func PresentMatcher(idx int) (*models.Matcher, error) {
+	return models.NewMatcher(models.MatchRegexp, TagName(idx), matchAll)
+}
+
+func nodeMatcher(name, node string) (*models.Matcher, error) {
+	if !strings.ContainsAny(node, globChars) {
+		return models.NewMatcher(models.MatchEqual, name, node)
+	}
+
+	pattern, err := GlobToRegex(node)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewMatcher(models.MatchRegexp, name, pattern)
+}
+
+// GlobToRegex converts a graphite glob for a single path node into a regular
+// expression; the expression is not anchored since tag regexps are matched
+// against the whole value
+func GlobToRegex(glob string) (string, error) {
+	var (
+		buf        bytes.Buffer
+		inBraces   bool
+		inBrackets bool
+	)
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		if inBrackets {
+			switch {
+			case c == ']':
+				inBrackets = false
+				buf.WriteByte(c)
+			case c == '\\':
+				buf.WriteString(`\\`)
+			default:
+				buf.WriteByte(c)
+			}
+
+			continue
+		}
+
+		switch c {
+		case '*':
+			buf.WriteString(".*")
+		case '?':
+			buf.WriteByte('.')
+		case '[':
+			inBrackets = true
+			buf.WriteByte('[')
+			if i+1 < len(glob) && glob[i+1] == '!' {
+				buf.WriteByte('^')
+				i++
+			}
+		case '{':
+			if inBraces {
+				return "", fmt.Errorf("nested braces are not supported in glob: %s", glob)
+			}
+
+			inBraces = true
+			buf.WriteString("(")
+		case '}':
+			if !inBraces {
+				return "", fmt.Errorf("unbalanced braces in glob: %s", glob)
+			}
+
+			inBraces = false
+			buf.WriteString(")")
+		case ',':
+			if inBraces {
+				buf.WriteByte('|')
+			} else {
+				buf.WriteByte(c)
+			}
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if inBraces {
+		return "", fmt.Errorf("unbalanced braces in glob: %s", glob)
+	}
+
+	if inBrackets {
+		return "", fmt.Errorf("unbalanced brackets in glob: %s", glob)
+	}
+
+	pattern := buf.String()
+	if _, err := regexp.Compile(pattern); err != nil {
+		return "", fmt.Errorf("invalid glob %s: %v", glob, err)
+	}
+
+	return pattern, nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobToRegex(t *testing.T) {
+	for _, test := range []struct {
+		glob     string
+		expected string
+	}{
+		{glob: "foo", expected: "foo"},
+		{glob: "foo*", expected: "foo.*"},
+		{glob: "f?o", expected: "f.o"},
+		{glob: "{foo,bar}", expected: "(foo|bar)"},
+		{glob: "{foo,ba*}-baz", expected: "(foo|ba.*)-baz"},
+		{glob: "host[0-9]", expected: "host[0-9]"},
+		{glob: "host[!0-9]", expected: "host[^0-9]"},
+		{glob: "a+b(c)", expected: `a\+b\(c\)`},
+		{glob: "a,b", expected: "a,b"},
+	} {
+		pattern, err := GlobToRegex(test.glob)
+		require.NoError(t, err, test.glob)
+		assert.Equal(t, test.expected, pattern, test.glob)
+	}
+}
+
+func TestGlobToRegexErrors(t *testing.T) {
+	for _, glob := range []string{"{foo", "foo}", "{a,{b,c}}", "host[0-9"} {
+		_, err := GlobToRegex(glob)
+		assert.Error(t, err, glob)
+	}
+}
+
+func TestPathMatchers(t *testing.T) {
+	matchers, err := PathMatchers("foo.b*.{x,y}")
+	require.NoError(t, err)
+	require.Len(t, matchers, 4)
+
+	expected := []struct {
+		matchType models.MatchType
+		name      string
+		value     string
+	}{
+		{models.MatchEqual, "__g0__", "foo"},
+		{models.MatchRegexp, "__g1__", "b.*"},
+		{models.MatchRegexp, "__g2__", "(x|y)"},
+		{models.MatchNotRegexp, "__g3__", ".*"},
+	}
+
+	for i, e := range expected {
+		assert.Equal(t, e.matchType, matchers[i].Type)
+		assert.Equal(t, e.name, matchers[i].Name)
+		assert.Equal(t, e.value, matchers[i].Value)
+	}
+
+	assert.True(t, matchers[1].Matches("bar"))
+	assert.False(t, matchers[1].Matches("abar"))
+	assert.True(t, matchers[2].Matches("y"))
+	assert.False(t, matchers[2].Matches("xy"))
+}
+
+func TestNodeMatchersErrors(t *testing.T) {
+	_, err := NodeMatchers("")
+	assert.Error(t, err)
+
+	_, err = NodeMatchers("foo..bar")
+	assert.Error(t, err)
+
+	_, err = NodeMatchers("foo.{bar")
+	assert.Error(t, err)
+}
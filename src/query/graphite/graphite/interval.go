@@ -0,0 +1,88 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = 30 * day
+	year  = 365 * day
+)
+
+// intervalUnits are matched by prefix in order, so longer prefixes sharing
+// a first letter with another unit are listed first
+var intervalUnits = []struct {
+	prefix string
+	unit   time.Duration
+}{
+	{prefix: "mon", unit: month},
+	{prefix: "min", unit: time.Minute},
+	{prefix: "ms", unit: time.Millisecond},
+	{prefix: "m", unit: time.Minute},
+	{prefix: "s", unit: time.Second},
+	{prefix: "h", unit: time.Hour},
+	{prefix: "d", unit: day},
+	{prefix: "w", unit: week},
+	{prefix: "y", unit: year},
+}
+
+// ParseInterval parses a graphite interval such as "5min", "1h" or "-7d";
+// intervals are signed and months and years are 30 and 365 days long
+func ParseInterval(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(str, "-"):
+		sign = -1
+		str = str[1:]
+	case strings.HasPrefix(str, "+"):
+		str = str[1:]
+	}
+
+	end := 0
+	for end < len(str) && str[end] >= '0' && str[end] <= '9' {
+		end++
+	}
+
+	if end == 0 {
+		return 0, fmt.Errorf("invalid interval, missing count: %s", s)
+	}
+
+	count, err := strconv.Atoi(str[:end])
+	if err != nil {
+		return 0, fmt.Errorf("invalid interval %s: %v", s, err)
+	}
+
+	unitStr := strings.ToLower(str[end:])
+	for _, u := range intervalUnits {
+		if strings.HasPrefix(unitStr, u.prefix) {
+			return sign * time.Duration(count) * u.unit, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid interval, unknown unit: %s", s)
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	for _, test := range []struct {
+		interval string
+		expected time.Duration
+	}{
+		{interval: "30s", expected: 30 * time.Second},
+		{interval: "10seconds", expected: 10 * time.Second},
+		{interval: "5min", expected: 5 * time.Minute},
+		{interval: "5m", expected: 5 * time.Minute},
+		{interval: "2minutes", expected: 2 * time.Minute},
+		{interval: "-1h", expected: -time.Hour},
+		{interval: "+3hours", expected: 3 * time.Hour},
+		{interval: "7d", expected: 7 * 24 * time.Hour},
+		{interval: "2w", expected: 14 * 24 * time.Hour},
+		{interval: "1mon", expected: 30 * 24 * time.Hour},
+		{interval: "1y", expected: 365 * 24 * time.Hour},
+	} {
+		d, err := ParseInterval(test.interval)
+		require.NoError(t, err, test.interval)
+		assert.Equal(t, test.expected, d, test.interval)
+	}
+}
+
+func TestParseIntervalErrors(t *testing.T) {
+	for _, interval := range []string{"", "h", "-", "10", "10x"} {
+		_, err := ParseInterval(interval)
+		assert.Error(t, err, interval)
+	}
+}
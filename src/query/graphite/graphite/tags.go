@@ -0,0 +1,139 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package graphite contains the conventions used to store graphite metrics,
+// where each node of a dotted metric path is kept as a positional tag.
+package graphite
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/models"
+)
+
+const (
+	tagNamePrefix = "__g"
+	tagNameSuffix = "__"
+
+	// numPrecomputedTagNames is the number of tag names computed upfront,
+	// paths with more nodes than this format their tag names on demand
+	numPrecomputedTagNames = 64
+)
+
+var precomputedTagNames = func() []string {
+	names := make([]string, numPrecomputedTagNames)
+	for i := range names {
+		names[i] = formatTagName(i)
+	}
+
+	return names
+}()
+
+func formatTagName(idx int) string {
+	return fmt.Sprintf("%s%d%s", tagNamePrefix, idx, tagNameSuffix)
+}
+
+// TagName returns the name of the tag holding the node at the given index of
+// a graphite path, i.e. "__g0__" for the first node
+func TagName(idx int) string {
+	if idx < numPrecomputedTagNames {
+		return precomputedTagNames[idx]
+	}
+
+	return formatTagName(idx)
+}
+
+// TagIndex returns the path index for a graphite tag name and false if the
+// name is not a graphite tag
+func TagIndex(name string) (int, bool) {
+	if !strings.HasPrefix(name, tagNamePrefix) || !strings.HasSuffix(name, tagNameSuffix) {
+		return 0, false
+	}
+
+	digits := name[len(tagNamePrefix) : len(name)-len(tagNameSuffix)]
+	if len(digits) == 0 {
+		return 0, false
+	}
+
+	idx, err := strconv.Atoi(digits)
+	if err != nil || idx < 0 || digits != strconv.Itoa(idx) {
+		return 0, false
+	}
+
+	return idx, true
+}
+
+// TagsFromPath splits a dotted graphite path into its positional tags
+func TagsFromPath(path string) (models.Tags, error) {
+	if path == "" {
+		return nil, errEmptyPath
+	}
+
+	nodes := strings.Split(path, ".")
+	tags := make(models.Tags, 0, len(nodes))
+	for i, node := range nodes {
+		if node == "" {
+			return nil, fmt.Errorf("empty node at index %d in path: %s", i, path)
+		}
+
+		tags = append(tags, models.Tag{Name: TagName(i), Value: node})
+	}
+
+	return tags, nil
+}
+
+// PathFromTags joins the graphite tags of a series back into its dotted path,
+// returning false if the tags do not hold a complete path
+func PathFromTags(tags models.Tags) (string, bool) {
+	nodes := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		idx, ok := TagIndex(tag.Name)
+		if !ok {
+			continue
+		}
+
+		for len(nodes) <= idx {
+			nodes = append(nodes, "")
+		}
+
+		nodes[idx] = tag.Value
+	}
+
+	if len(nodes) == 0 {
+		return "", false
+	}
+
+	var buf bytes.Buffer
+	for i, node := range nodes {
+		if node == "" {
+			return "", false
+		}
+
+		if i > 0 {
+			buf.WriteByte('.')
+		}
+
+		buf.WriteString(node)
+	}
+
+	return buf.String(), true
+}
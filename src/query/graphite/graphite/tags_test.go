@@ -0,0 +1,92 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagName(t *testing.T) {
+	assert.Equal(t, "__g0__", TagName(0))
+	assert.Equal(t, "__g12__", TagName(12))
+	assert.Equal(t, "__g100__", TagName(100))
+}
+
+func TestTagIndex(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		idx   int
+		valid bool
+	}{
+		{name: "__g0__", idx: 0, valid: true},
+		{name: "__g21__", idx: 21, valid: true},
+		{name: "__g__"},
+		{name: "__g01__"},
+		{name: "__g-1__"},
+		{name: "__gx__"},
+		{name: "__name__"},
+		{name: "g1"},
+	} {
+		idx, ok := TagIndex(test.name)
+		assert.Equal(t, test.valid, ok, test.name)
+		assert.Equal(t, test.idx, idx, test.name)
+	}
+}
+
+func TestTagsFromPath(t *testing.T) {
+	tags, err := TagsFromPath("foo.bar.baz")
+	require.NoError(t, err)
+	assert.Equal(t, models.Tags{
+		{Name: "__g0__", Value: "foo"},
+		{Name: "__g1__", Value: "bar"},
+		{Name: "__g2__", Value: "baz"},
+	}, tags)
+
+	_, err = TagsFromPath("")
+	assert.Error(t, err)
+
+	_, err = TagsFromPath("foo..baz")
+	assert.Error(t, err)
+}
+
+func TestPathFromTags(t *testing.T) {
+	path, ok := PathFromTags(models.Tags{
+		{Name: "__g1__", Value: "bar"},
+		{Name: "dc", Value: "east"},
+		{Name: "__g0__", Value: "foo"},
+	})
+	require.True(t, ok)
+	assert.Equal(t, "foo.bar", path)
+
+	_, ok = PathFromTags(models.Tags{{Name: "dc", Value: "east"}})
+	assert.False(t, ok)
+
+	// A missing node leaves a gap in the path
+	_, ok = PathFromTags(models.Tags{
+		{Name: "__g0__", Value: "foo"},
+		{Name: "__g2__", Value: "baz"},
+	})
+	assert.False(t, ok)
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package limits enforces limits on the resources used by a single query.
+package limits
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	xerrors "github.com/m3db/m3x/errors"
+
+	"github.com/uber-go/tally"
+)
+
+const (
+	seriesLimit     = "series"
+	datapointsLimit = "datapoints"
+	blocksLimit     = "blocks"
+)
+
+// Limits are the maximum resources a single query may use, a limit of zero
+// is unlimited.
+type Limits struct {
+	// MaxSeries is the maximum number of series a query may fetch.
+	MaxSeries int
+	// MaxDatapoints is the maximum number of datapoints a query may decode.
+	MaxDatapoints int
+	// MaxBlocks is the maximum number of blocks a query may materialize.
+	MaxBlocks int
+}
+
+// LimitExceededError is returned when a query exceeds one of its limits.
+type LimitExceededError struct {
+	// Limit is the name of the limit exceeded.
+	Limit string
+	// Max is the value of the limit exceeded.
+	Max int
+}
+
+func (e LimitExceededError) Error() string {
+	return fmt.Sprintf("query exceeded limit: more than %d %s", e.Max, e.Limit)
+}
+
+// IsLimitExceeded returns whether an error, or any error of a multi error,
+// is the result of a query exceeding one of its limits.
+func IsLimitExceeded(err error) bool {
+	switch e := err.(type) {
+	case LimitExceededError:
+		return true
+	case xerrors.MultiError:
+		for _, err := range e.Errors() {
+			if IsLimitExceeded(err) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Metrics count the queries exceeding each limit.
+type Metrics struct {
+	seriesExceeded     tally.Counter
+	datapointsExceeded tally.Counter
+	blocksExceeded     tally.Counter
+}
+
+// NewMetrics returns new limit metrics.
+func NewMetrics(scope tally.Scope) *Metrics {
+	exceeded := func(limit string) tally.Counter {
+		return scope.Tagged(map[string]string{"limit": limit}).Counter("limit-exceeded")
+	}
+
+	return &Metrics{
+		seriesExceeded:     exceeded(seriesLimit),
+		datapointsExceeded: exceeded(datapointsLimit),
+		blocksExceeded:     exceeded(blocksLimit),
+	}
+}
+
+// Enforcer accounts for the resources used by a single query, returning an
+// error once the query exceeds one of its limits. It is safe for concurrent
+// use and a nil enforcer enforces no limits.
+type Enforcer struct {
+	limits     Limits
+	metrics    *Metrics
+	series     int64
+	datapoints int64
+	blocks     int64
+}
+
+// NewEnforcer returns a new enforcer for a single query.
+func NewEnforcer(limits Limits, metrics *Metrics) *Enforcer {
+	if metrics == nil {
+		metrics = NewMetrics(tally.NoopScope)
+	}
+
+	return &Enforcer{limits: limits, metrics: metrics}
+}
+
+// AddSeries accounts for series fetched by the query.
+func (e *Enforcer) AddSeries(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return add(&e.series, n, e.limits.MaxSeries, seriesLimit, e.metrics.seriesExceeded)
+}
+
+// AddDatapoints accounts for datapoints decoded by the query.
+func (e *Enforcer) AddDatapoints(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return add(&e.datapoints, n, e.limits.MaxDatapoints, datapointsLimit, e.metrics.datapointsExceeded)
+}
+
+// AddBlocks accounts for blocks materialized by the query.
+func (e *Enforcer) AddBlocks(n int) error {
+	if e == nil {
+		return nil
+	}
+
+	return add(&e.blocks, n, e.limits.MaxBlocks, blocksLimit, e.metrics.blocksExceeded)
+}
+
+// MaxSeries returns the series limit of the query, which is zero if there
+// is no limit.
+func (e *Enforcer) MaxSeries() int {
+	if e == nil {
+		return 0
+	}
+
+	return e.limits.MaxSeries
+}
+
+func add(total *int64, n, max int, limit string, exceeded tally.Counter) error {
+	if max <= 0 {
+		return nil
+	}
+
+	updated := atomic.AddInt64(total, int64(n))
+	if updated <= int64(max) {
+		return nil
+	}
+
+	// Only count the query the first time it exceeds the limit
+	if updated-int64(n) <= int64(max) {
+		exceeded.Inc(1)
+	}
+
+	return LimitExceededError{Limit: limit, Max: max}
+}
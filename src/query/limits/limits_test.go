@@ -0,0 +1,100 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package limits
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	xerrors "github.com/m3db/m3x/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+)
+
+func TestEnforcer(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	enforcer := NewEnforcer(Limits{MaxSeries: 10, MaxBlocks: 2}, NewMetrics(scope))
+
+	require.NoError(t, enforcer.AddSeries(6))
+	require.NoError(t, enforcer.AddSeries(4))
+
+	err := enforcer.AddSeries(1)
+	require.Error(t, err)
+	assert.True(t, IsLimitExceeded(err))
+	assert.Equal(t, "query exceeded limit: more than 10 series", err.Error())
+
+	// The query is only counted once for each limit it exceeds
+	require.Error(t, enforcer.AddSeries(1))
+
+	// Datapoints are not limited
+	require.NoError(t, enforcer.AddDatapoints(1000000))
+
+	require.NoError(t, enforcer.AddBlocks(2))
+	assert.Error(t, enforcer.AddBlocks(1))
+
+	counters := scope.Snapshot().Counters()
+	assert.Equal(t, int64(1), counters["limit-exceeded+limit=series"].Value())
+	assert.Equal(t, int64(1), counters["limit-exceeded+limit=blocks"].Value())
+	assert.Equal(t, int64(0), counters["limit-exceeded+limit=datapoints"].Value())
+}
+
+func TestEnforcerConcurrent(t *testing.T) {
+	enforcer := NewEnforcer(Limits{MaxDatapoints: 100}, nil)
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		exceeded int
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := enforcer.AddDatapoints(10); err != nil {
+				lock.Lock()
+				exceeded++
+				lock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 10, exceeded)
+}
+
+func TestNilEnforcer(t *testing.T) {
+	var enforcer *Enforcer
+	assert.NoError(t, enforcer.AddSeries(1))
+	assert.NoError(t, enforcer.AddDatapoints(1))
+	assert.NoError(t, enforcer.AddBlocks(1))
+	assert.Equal(t, 0, enforcer.MaxSeries())
+}
+
+func TestIsLimitExceeded(t *testing.T) {
+	err := LimitExceededError{Limit: seriesLimit, Max: 1}
+	assert.True(t, IsLimitExceeded(err))
+	assert.True(t, IsLimitExceeded(xerrors.NewMultiError().Add(errors.New("a")).Add(err)))
+	assert.False(t, IsLimitExceeded(errors.New("a")))
+	assert.False(t, IsLimitExceeded(nil))
+}
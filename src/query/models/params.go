@@ -22,6 +22,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/m3db/m3metrics/policy"
 )
 
 // RequestParams represents the params from the request
@@ -35,6 +37,8 @@ type RequestParams struct {
 	Query      string
 	Debug      bool
 	IncludeEnd bool
+	// StoragePolicy restricts the query to a single storage policy if set
+	StoragePolicy policy.StoragePolicy
 }
 
 // ExclusiveEnd returns the end exclusive
@@ -0,0 +1,119 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenWord
+	tokenString
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	typ tokenType
+	val string
+	pos int
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of expression"
+	}
+
+	return fmt.Sprintf("%q at position %d", t.val, t.pos)
+}
+
+// lex splits a graphite target into tokens; words are function names,
+// paths, numbers and booleans, which may contain commas inside globs
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for pos := 0; pos < len(input); {
+		c := input[pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			pos++
+		case c == '(':
+			tokens = append(tokens, token{typ: tokenLeftParen, val: "(", pos: pos})
+			pos++
+		case c == ')':
+			tokens = append(tokens, token{typ: tokenRightParen, val: ")", pos: pos})
+			pos++
+		case c == ',':
+			tokens = append(tokens, token{typ: tokenComma, val: ",", pos: pos})
+			pos++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[pos+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated string at position %d", pos)
+			}
+
+			tokens = append(tokens, token{typ: tokenString, val: input[pos+1 : pos+1+end], pos: pos})
+			pos += end + 2
+		default:
+			end, err := lexWord(input, pos)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{typ: tokenWord, val: input[pos:end], pos: pos})
+			pos = end
+		}
+	}
+
+	return append(tokens, token{typ: tokenEOF, pos: len(input)}), nil
+}
+
+// lexWord returns the end of the word starting at pos; commas and parens
+// within braces or brackets are part of the word
+func lexWord(input string, pos int) (int, error) {
+	var braces, brackets int
+	start := pos
+	for ; pos < len(input); pos++ {
+		switch input[pos] {
+		case '{':
+			braces++
+		case '}':
+			braces--
+		case '[':
+			brackets++
+		case ']':
+			brackets--
+		case '(', ')', ',', ' ', '\t', '\n', '\r', '"', '\'':
+			if braces == 0 && brackets == 0 {
+				return pos, nil
+			}
+		}
+	}
+
+	if braces != 0 || brackets != 0 {
+		return 0, fmt.Errorf("unbalanced glob at position %d", start)
+	}
+
+	return pos, nil
+}
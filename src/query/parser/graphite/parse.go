@@ -0,0 +1,321 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/m3db/m3/src/query/functions"
+	gfunctions "github.com/m3db/m3/src/query/functions/graphite"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+type graphiteParser struct {
+	expr expr
+}
+
+// Parse takes a graphite target and parses it into a DAG
+func Parse(q string) (parser.Parser, error) {
+	tokens, err := lex(q)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &exprParser{tokens: tokens}
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.next(); next.typ != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s", next)
+	}
+
+	if !isSeriesList(e) {
+		return nil, fmt.Errorf("target is not a series list: %s", q)
+	}
+
+	return &graphiteParser{expr: e}, nil
+}
+
+func (p *graphiteParser) DAG() (parser.Nodes, parser.Edges, error) {
+	state := &parseState{}
+	err := state.walk(p.expr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return state.transforms, state.edges, nil
+}
+
+func (p *graphiteParser) String() string {
+	return p.expr.String()
+}
+
+// expr is a node of a parsed graphite target
+type expr interface {
+	String() string
+}
+
+type pathExpr struct {
+	path string
+}
+
+func (e pathExpr) String() string {
+	return e.path
+}
+
+type callExpr struct {
+	name string
+	args []expr
+}
+
+func (e callExpr) String() string {
+	args := make([]string, 0, len(e.args))
+	for _, arg := range e.args {
+		args = append(args, arg.String())
+	}
+
+	return fmt.Sprintf("%s(%s)", e.name, strings.Join(args, ","))
+}
+
+type numberExpr float64
+
+func (e numberExpr) String() string {
+	return strconv.FormatFloat(float64(e), 'g', -1, 64)
+}
+
+type stringExpr string
+
+func (e stringExpr) String() string {
+	return strconv.Quote(string(e))
+}
+
+type boolExpr bool
+
+func (e boolExpr) String() string {
+	return strconv.FormatBool(bool(e))
+}
+
+func isSeriesList(e expr) bool {
+	switch e.(type) {
+	case pathExpr, callExpr:
+		return true
+	}
+
+	return false
+}
+
+type exprParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *exprParser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *exprParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *exprParser) parseExpr() (expr, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenString:
+		return stringExpr(t.val), nil
+	case tokenWord:
+		if p.peek().typ == tokenLeftParen {
+			p.next()
+			return p.parseCall(t)
+		}
+
+		return parseWord(t.val), nil
+	}
+
+	return nil, fmt.Errorf("unexpected %s", t)
+}
+
+func (p *exprParser) parseCall(name token) (expr, error) {
+	call := callExpr{name: name.val}
+	if p.peek().typ == tokenRightParen {
+		p.next()
+		return call, nil
+	}
+
+	for {
+		arg, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+
+		call.args = append(call.args, arg)
+		switch t := p.next(); t.typ {
+		case tokenComma:
+			continue
+		case tokenRightParen:
+			return call, nil
+		default:
+			return nil, fmt.Errorf("unexpected %s in call to %s", t, name.val)
+		}
+	}
+}
+
+func parseWord(word string) expr {
+	switch strings.ToLower(word) {
+	case "true":
+		return boolExpr(true)
+	case "false":
+		return boolExpr(false)
+	}
+
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return numberExpr(n)
+	}
+
+	return pathExpr{path: word}
+}
+
+type parseState struct {
+	edges      parser.Edges
+	transforms parser.Nodes
+}
+
+func (p *parseState) lastTransformID() parser.NodeID {
+	if len(p.transforms) == 0 {
+		return parser.NodeID(-1)
+	}
+
+	return p.transforms[len(p.transforms)-1].ID
+}
+
+func (p *parseState) transformLen() int {
+	return len(p.transforms)
+}
+
+func (p *parseState) addTransform(op parser.Params, parentID parser.NodeID) {
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: parentID,
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+}
+
+func (p *parseState) addTransformWithParents(op parser.Params, parentIDs []parser.NodeID) {
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	for _, parentID := range parentIDs {
+		p.edges = append(p.edges, parser.Edge{
+			ParentID: parentID,
+			ChildID:  opTransform.ID,
+		})
+	}
+	p.transforms = append(p.transforms, opTransform)
+}
+
+func (p *parseState) walk(e expr) error {
+	switch n := e.(type) {
+	case pathExpr:
+		matchers, err := graphite.PathMatchers(n.path)
+		if err != nil {
+			return err
+		}
+
+		fetch := functions.FetchOp{Name: n.path, Matchers: matchers}
+		p.transforms = append(p.transforms, parser.NewTransformFromOperation(fetch, p.transformLen()))
+		// Series are named by their graphite path rather than their ID
+		p.addTransform(gfunctions.NewPathNameOp(), p.lastTransformID())
+		return nil
+
+	case callExpr:
+		var (
+			seriesLists []expr
+			argValues   = make([]interface{}, 0, len(n.args))
+		)
+
+		for i, arg := range n.args {
+			switch a := arg.(type) {
+			case numberExpr:
+				argValues = append(argValues, float64(a))
+			case stringExpr:
+				argValues = append(argValues, string(a))
+			case boolExpr:
+				argValues = append(argValues, bool(a))
+			default:
+				if i != len(seriesLists) {
+					return fmt.Errorf("series list arguments of %s must come before any other arguments", n.name)
+				}
+
+				seriesLists = append(seriesLists, arg)
+			}
+		}
+
+		if len(seriesLists) == 0 {
+			return fmt.Errorf("missing series list argument for %s", n.name)
+		}
+
+		if n.name == gfunctions.GroupType && len(argValues) != 0 {
+			return fmt.Errorf("invalid number of args for %s: %d", n.name, len(argValues))
+		}
+
+		if len(seriesLists) > 1 && !gfunctions.AcceptsSeriesLists(n.name) {
+			return fmt.Errorf("only the first argument of %s may be a series list", n.name)
+		}
+
+		seriesExprs := make([]string, 0, len(seriesLists))
+		parents := make([]parser.NodeID, 0, len(seriesLists))
+		for _, seriesList := range seriesLists {
+			if err := p.walk(seriesList); err != nil {
+				return err
+			}
+
+			seriesExprs = append(seriesExprs, seriesList.String())
+			parents = append(parents, p.lastTransformID())
+		}
+
+		// The series of several series lists are grouped into a single list
+		// for the function to be applied to
+		if len(parents) > 1 || n.name == gfunctions.GroupType {
+			p.addTransformWithParents(gfunctions.NewGroupOp(parents), parents)
+		}
+
+		if n.name == gfunctions.GroupType {
+			return nil
+		}
+
+		op, err := gfunctions.NewFunctionOp(n.name, strings.Join(seriesExprs, ","), argValues)
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op, p.lastTransformID())
+		return nil
+	}
+
+	return fmt.Errorf("graphite.Walk: unhandled expression type %T, %v", e, e)
+}
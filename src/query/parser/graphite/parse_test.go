@@ -0,0 +1,176 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package graphite
+
+import (
+	"testing"
+
+	"github.com/m3db/m3/src/query/functions"
+	gfunctions "github.com/m3db/m3/src/query/functions/graphite"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAGWithPath(t *testing.T) {
+	p, err := Parse("foo.{a,b}.bar*")
+	require.NoError(t, err)
+	assert.Equal(t, "foo.{a,b}.bar*", p.String())
+
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 2)
+
+	fetch, ok := transforms[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, "foo.{a,b}.bar*", fetch.Name)
+	require.Len(t, fetch.Matchers, 4)
+	assert.Equal(t, models.MatchEqual, fetch.Matchers[0].Type)
+	assert.Equal(t, "(a|b)", fetch.Matchers[1].Value)
+	assert.Equal(t, "bar.*", fetch.Matchers[2].Value)
+	assert.Equal(t, models.MatchNotRegexp, fetch.Matchers[3].Type)
+
+	assert.Equal(t, gfunctions.PathNameType, transforms[1].Op.OpType())
+	assert.Equal(t, parser.Edges{{ParentID: "0", ChildID: "1"}}, edges)
+}
+
+func TestDAGWithNestedFunctions(t *testing.T) {
+	q := `aliasByNode(movingAverage(perSecond(foo.*.requests), '5min'), 1)`
+	p, err := Parse(q)
+	require.NoError(t, err)
+	assert.Equal(t, `aliasByNode(movingAverage(perSecond(foo.*.requests),"5min"),1)`, p.String())
+
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+
+	types := make([]string, 0, len(transforms))
+	for _, transform := range transforms {
+		types = append(types, transform.Op.OpType())
+	}
+
+	assert.Equal(t, []string{
+		functions.FetchType,
+		gfunctions.PathNameType,
+		gfunctions.PerSecondType,
+		gfunctions.MovingAverageType,
+		gfunctions.AliasByNodeType,
+	}, types)
+
+	require.Len(t, edges, 4)
+	for i, edge := range edges {
+		assert.Equal(t, transforms[i].ID, edge.ParentID)
+		assert.Equal(t, transforms[i+1].ID, edge.ChildID)
+	}
+}
+
+func TestDAGWithSeriesLists(t *testing.T) {
+	p, err := Parse("scale(sumSeries(foo.*, bar.*), 2)")
+	require.NoError(t, err)
+
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+
+	types := make([]string, 0, len(transforms))
+	for _, transform := range transforms {
+		types = append(types, transform.Op.OpType())
+	}
+
+	assert.Equal(t, []string{
+		functions.FetchType,
+		gfunctions.PathNameType,
+		functions.FetchType,
+		gfunctions.PathNameType,
+		gfunctions.GroupType,
+		gfunctions.SumSeriesType,
+		gfunctions.ScaleType,
+	}, types)
+
+	assert.Equal(t, parser.Edges{
+		{ParentID: "0", ChildID: "1"},
+		{ParentID: "2", ChildID: "3"},
+		{ParentID: "1", ChildID: "4"},
+		{ParentID: "3", ChildID: "4"},
+		{ParentID: "4", ChildID: "5"},
+		{ParentID: "5", ChildID: "6"},
+	}, edges)
+	assert.Equal(t, "type: sumSeries, name: sumSeries(foo.*,bar.*)", transforms[5].Op.String())
+
+	// A single series list can be grouped
+	p, err = Parse("group(foo.*)")
+	require.NoError(t, err)
+	transforms, _, err = p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, gfunctions.GroupType, transforms[2].Op.OpType())
+}
+
+func TestParseArgumentTypes(t *testing.T) {
+	p, err := Parse(`summarize(sumSeries(foo.*), "1h", "max", true)`)
+	require.NoError(t, err)
+
+	gp := p.(*graphiteParser)
+	call, ok := gp.expr.(callExpr)
+	require.True(t, ok)
+	require.Len(t, call.args, 4)
+	assert.Equal(t, callExpr{name: "sumSeries", args: []expr{pathExpr{path: "foo.*"}}}, call.args[0])
+	assert.Equal(t, stringExpr("1h"), call.args[1])
+	assert.Equal(t, stringExpr("max"), call.args[2])
+	assert.Equal(t, boolExpr(true), call.args[3])
+
+	p, err = Parse("scale(foo.bar, -1.5)")
+	require.NoError(t, err)
+	assert.Equal(t, numberExpr(-1.5), p.(*graphiteParser).expr.(callExpr).args[1])
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, q := range []string{
+		"",
+		"1",
+		`"foo"`,
+		"sumSeries(foo.*",
+		"sumSeries(foo.*))",
+		"sumSeries(foo.* bar)",
+		"foo.{a,b",
+		`alias(foo, "bar)`,
+	} {
+		_, err := Parse(q)
+		assert.Error(t, err, q)
+	}
+}
+
+func TestDAGErrors(t *testing.T) {
+	for _, q := range []string{
+		"scale(foo.*, bar.*, 2)",
+		"scale(2, foo.*)",
+		"group(foo.*, 2)",
+		"sumSeries()",
+		"noSuchFunction(foo.*)",
+		"movingAverage(foo.*, true)",
+		"foo..bar",
+	} {
+		p, err := Parse(q)
+		require.NoError(t, err, q)
+
+		_, _, err = p.DAG()
+		assert.Error(t, err, q)
+	}
+}
@@ -0,0 +1,521 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package opentsdb parses OpenTSDB metric queries into a DAG of a fetch and
+// the transforms equivalent to the downsampling, rate and aggregation of the
+// query.
+package opentsdb
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/opentsdb"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// NoneAggregator returns each series without aggregating them
+	NoneAggregator = "none"
+
+	// Filter types
+	literalOrFilter     = "literal_or"
+	iLiteralOrFilter    = "iliteral_or"
+	notLiteralOrFilter  = "not_literal_or"
+	notILiteralOrFilter = "not_iliteral_or"
+	wildcardFilter      = "wildcard"
+	iWildcardFilter     = "iwildcard"
+	regexpFilter        = "regexp"
+)
+
+var (
+	errMissingMetric     = errors.New("missing metric")
+	errMissingAggregator = errors.New("missing aggregator")
+
+	// aggregators maps the OpenTSDB aggregators to the aggregation functions,
+	// interpolation is not supported so the zimsum, mimmin and mimmax
+	// aggregators are the same as their interpolating counterparts
+	aggregators = map[string]string{
+		"sum":    aggregation.SumType,
+		"zimsum": aggregation.SumType,
+		"min":    aggregation.MinType,
+		"mimmin": aggregation.MinType,
+		"max":    aggregation.MaxType,
+		"mimmax": aggregation.MaxType,
+		"avg":    aggregation.AverageType,
+		"dev":    aggregation.StandardDeviationType,
+		"count":  aggregation.CountType,
+	}
+
+	// downsamplers maps the OpenTSDB downsampling functions to the temporal
+	// aggregation functions
+	downsamplers = map[string]string{
+		"sum":    temporal.SumTemporalType,
+		"zimsum": temporal.SumTemporalType,
+		"min":    temporal.MinTemporalType,
+		"mimmin": temporal.MinTemporalType,
+		"max":    temporal.MaxTemporalType,
+		"mimmax": temporal.MaxTemporalType,
+		"avg":    temporal.AvgTemporalType,
+		"dev":    temporal.StdDevTemporalType,
+		"count":  temporal.CountTemporalType,
+	}
+
+	// intervalUnits are the units of OpenTSDB intervals, months and years
+	// are a fixed number of days
+	intervalUnits = map[string]time.Duration{
+		"ms": time.Millisecond,
+		"s":  time.Second,
+		"m":  time.Minute,
+		"h":  time.Hour,
+		"d":  24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"n":  30 * 24 * time.Hour,
+		"y":  365 * 24 * time.Hour,
+	}
+
+	intervalRegex = regexp.MustCompile(`^(\d+)(ms|s|m|h|d|w|n|y)$`)
+)
+
+// Query is a metric query, matching a sub query of an OpenTSDB query request.
+type Query struct {
+	Aggregator  string               `json:"aggregator"`
+	Metric      string               `json:"metric"`
+	Downsample  string               `json:"downsample,omitempty"`
+	Rate        bool                 `json:"rate,omitempty"`
+	RateOptions opentsdb.RateOptions `json:"rateOptions"`
+	Tags        map[string]string    `json:"tags,omitempty"`
+	Filters     []Filter             `json:"filters,omitempty"`
+}
+
+// Filter is a filter on the values of a tag.
+type Filter struct {
+	Type    string `json:"type"`
+	TagK    string `json:"tagk"`
+	Filter  string `json:"filter"`
+	GroupBy bool   `json:"groupBy"`
+}
+
+// Downsample is a parsed downsampling specification such as "1m-avg".
+type Downsample struct {
+	// Interval is the width of each downsampled bucket.
+	Interval time.Duration
+	// Function is the temporal aggregation applied to each bucket.
+	Function string
+}
+
+// ParseInterval parses an OpenTSDB interval such as "1m" or "2h".
+func ParseInterval(str string) (time.Duration, error) {
+	match := intervalRegex.FindStringSubmatch(str)
+	if match == nil {
+		return 0, fmt.Errorf("invalid interval: %s", str)
+	}
+
+	n, err := strconv.Atoi(match[1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid interval: %s", str)
+	}
+
+	return time.Duration(n) * intervalUnits[match[2]], nil
+}
+
+// ParseDownsample parses a downsampling specification of the form
+// "<interval>-<function>[-<fill policy>]", missing buckets are always empty
+// so only the none, nan and null fill policies are supported.
+func ParseDownsample(str string) (Downsample, error) {
+	parts := strings.Split(str, "-")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Downsample{}, fmt.Errorf("invalid downsample: %s", str)
+	}
+
+	interval, err := ParseInterval(parts[0])
+	if err != nil {
+		return Downsample{}, err
+	}
+
+	fn, ok := downsamplers[parts[1]]
+	if !ok {
+		return Downsample{}, fmt.Errorf("unsupported downsample function: %s", parts[1])
+	}
+
+	if len(parts) == 3 {
+		switch parts[2] {
+		case "none", "nan", "null":
+		default:
+			return Downsample{}, fmt.Errorf("unsupported fill policy: %s", parts[2])
+		}
+	}
+
+	return Downsample{Interval: interval, Function: fn}, nil
+}
+
+// ParseMetricQuery parses a metric query in the form of the m parameter of
+// an OpenTSDB query request:
+// "<aggregator>:[<downsample>:][rate[{counter[,max[,reset]]}]:]<metric>[{<group by filters>}][{<filters>}]"
+func ParseMetricQuery(str string) (Query, error) {
+	parts := splitOutsideBraces(str, ':')
+	if len(parts) < 2 {
+		return Query{}, fmt.Errorf("invalid metric query: %s", str)
+	}
+
+	q := Query{Aggregator: parts[0]}
+	for _, part := range parts[1 : len(parts)-1] {
+		switch {
+		case strings.HasPrefix(part, "rate"):
+			opts, err := parseRateOptions(strings.TrimPrefix(part, "rate"))
+			if err != nil {
+				return Query{}, err
+			}
+
+			q.Rate = true
+			q.RateOptions = opts
+		case q.Downsample == "":
+			q.Downsample = part
+		default:
+			return Query{}, fmt.Errorf("invalid metric query part: %s", part)
+		}
+	}
+
+	metric := parts[len(parts)-1]
+	braces := ""
+	if idx := strings.Index(metric, "{"); idx >= 0 {
+		metric, braces = metric[:idx], metric[idx:]
+	}
+
+	q.Metric = metric
+	for groupBy := true; braces != ""; groupBy = false {
+		end := strings.Index(braces, "}")
+		if !strings.HasPrefix(braces, "{") || end < 0 {
+			return Query{}, fmt.Errorf("invalid filters: %s", braces)
+		}
+
+		filters, err := parseFilters(braces[1:end], groupBy)
+		if err != nil {
+			return Query{}, err
+		}
+
+		q.Filters = append(q.Filters, filters...)
+		braces = braces[end+1:]
+	}
+
+	return q, nil
+}
+
+// splitOutsideBraces splits a string on each separator which is not within
+// braces or parentheses
+func splitOutsideBraces(str string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '{', '(':
+			depth++
+		case '}', ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, str[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, str[start:])
+}
+
+// parseRateOptions parses rate options of the form "{counter[,max[,reset]]}"
+func parseRateOptions(str string) (opentsdb.RateOptions, error) {
+	var opts opentsdb.RateOptions
+	if str == "" {
+		return opts, nil
+	}
+
+	if !strings.HasPrefix(str, "{") || !strings.HasSuffix(str, "}") {
+		return opts, fmt.Errorf("invalid rate options: %s", str)
+	}
+
+	parts := strings.Split(str[1:len(str)-1], ",")
+	if parts[0] != "counter" {
+		return opts, fmt.Errorf("invalid rate options: %s", str)
+	}
+
+	opts.Counter = true
+	for i, dst := range []*float64{&opts.CounterMax, &opts.ResetValue} {
+		if len(parts) <= i+1 || parts[i+1] == "" {
+			continue
+		}
+
+		v, err := strconv.ParseFloat(parts[i+1], 64)
+		if err != nil {
+			return opts, fmt.Errorf("invalid rate options: %s", str)
+		}
+
+		*dst = v
+	}
+
+	return opts, nil
+}
+
+// parseFilters parses filters of the form "tag=value,tag=type(value)", an
+// untyped value is a wildcard if it contains "*" and a literal_or otherwise.
+func parseFilters(str string, groupBy bool) ([]Filter, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	var filters []Filter
+	for _, part := range splitOutsideBraces(str, ',') {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid filter: %s", part)
+		}
+
+		filter := Filter{TagK: kv[0], Filter: kv[1], GroupBy: groupBy}
+		if open := strings.Index(kv[1], "("); open > 0 && strings.HasSuffix(kv[1], ")") {
+			filter.Type = kv[1][:open]
+			filter.Filter = kv[1][open+1 : len(kv[1])-1]
+		} else if strings.Contains(kv[1], "*") {
+			filter.Type = wildcardFilter
+		} else {
+			filter.Type = literalOrFilter
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// filters returns the filters of a query including its tags, which are
+// grouped by filters in the same form as the filters of a metric query
+func (q Query) filters() ([]Filter, error) {
+	names := make([]string, 0, len(q.Tags))
+	for name := range q.Tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	filters := make([]Filter, 0, len(q.Tags)+len(q.Filters))
+	for _, name := range names {
+		parsed, err := parseFilters(name+"="+q.Tags[name], true)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, parsed...)
+	}
+
+	return append(filters, q.Filters...), nil
+}
+
+// GroupByTags returns the tags the series of a query are grouped by.
+func (q Query) GroupByTags() []string {
+	filters, _ := q.filters()
+	var tags []string
+	for _, filter := range filters {
+		if filter.GroupBy {
+			tags = append(tags, filter.TagK)
+		}
+	}
+
+	return tags
+}
+
+// matcher returns the matcher equivalent to a filter
+func (f Filter) matcher() (*models.Matcher, error) {
+	switch f.Type {
+	case literalOrFilter, notLiteralOrFilter, iLiteralOrFilter, notILiteralOrFilter:
+		values := strings.Split(f.Filter, "|")
+		for i, value := range values {
+			values[i] = regexp.QuoteMeta(value)
+		}
+
+		var (
+			caseInsensitive = f.Type == iLiteralOrFilter || f.Type == notILiteralOrFilter
+			negated         = f.Type == notLiteralOrFilter || f.Type == notILiteralOrFilter
+		)
+		if len(values) == 1 && !caseInsensitive {
+			if negated {
+				return models.NewMatcher(models.MatchNotEqual, f.TagK, f.Filter)
+			}
+
+			return models.NewMatcher(models.MatchEqual, f.TagK, f.Filter)
+		}
+
+		pattern := "(" + strings.Join(values, "|") + ")"
+		if caseInsensitive {
+			pattern = "(?i)" + pattern
+		}
+
+		if negated {
+			return models.NewMatcher(models.MatchNotRegexp, f.TagK, pattern)
+		}
+
+		return models.NewMatcher(models.MatchRegexp, f.TagK, pattern)
+
+	case wildcardFilter, iWildcardFilter:
+		parts := strings.Split(f.Filter, "*")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+
+		pattern := strings.Join(parts, ".*")
+		if f.Type == iWildcardFilter {
+			pattern = "(?i)" + pattern
+		}
+
+		return models.NewMatcher(models.MatchRegexp, f.TagK, pattern)
+
+	case regexpFilter:
+		return models.NewMatcher(models.MatchRegexp, f.TagK, f.Filter)
+	}
+
+	return nil, fmt.Errorf("unsupported filter type: %s", f.Type)
+}
+
+type opentsdbParser struct {
+	query Query
+	nodes parser.Nodes
+	edges parser.Edges
+}
+
+// Parse takes an OpenTSDB metric query and parses it into a DAG
+func Parse(q Query) (parser.Parser, error) {
+	if q.Metric == "" {
+		return nil, errMissingMetric
+	}
+
+	if q.Aggregator == "" {
+		return nil, errMissingAggregator
+	}
+
+	p := &opentsdbParser{query: q}
+	if err := p.build(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *opentsdbParser) build() error {
+	q := p.query
+	filters, err := q.filters()
+	if err != nil {
+		return err
+	}
+
+	nameMatcher, err := models.NewMatcher(models.MatchEqual, models.MetricName, q.Metric)
+	if err != nil {
+		return err
+	}
+
+	matchers := models.Matchers{nameMatcher}
+	for _, filter := range filters {
+		matcher, err := filter.matcher()
+		if err != nil {
+			return err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	p.addTransform(functions.FetchOp{Name: q.Metric, Matchers: matchers})
+
+	// The step of the query is the downsample interval, so each step is
+	// the aggregate of the interval ending at it
+	if q.Downsample != "" {
+		downsample, err := ParseDownsample(q.Downsample)
+		if err != nil {
+			return err
+		}
+
+		op, err := temporal.NewAggOp([]interface{}{downsample.Interval}, downsample.Function)
+		if err != nil {
+			return err
+		}
+
+		p.addTransform(op)
+	}
+
+	if q.Rate {
+		p.addTransform(opentsdb.NewRateOp(q.RateOptions))
+	}
+
+	if q.Aggregator == NoneAggregator {
+		return nil
+	}
+
+	aggregator, ok := aggregators[q.Aggregator]
+	if !ok {
+		return fmt.Errorf("unsupported aggregator: %s", q.Aggregator)
+	}
+
+	op, err := aggregation.NewAggregationOp(aggregator, aggregation.NodeParams{
+		MatchingTags: q.GroupByTags(),
+	})
+	if err != nil {
+		return err
+	}
+
+	p.addTransform(op)
+	return nil
+}
+
+func (p *opentsdbParser) addTransform(op parser.Params) {
+	transform := parser.NewTransformFromOperation(op, len(p.nodes))
+	if len(p.nodes) > 0 {
+		p.edges = append(p.edges, parser.Edge{
+			ParentID: p.nodes[len(p.nodes)-1].ID,
+			ChildID:  transform.ID,
+		})
+	}
+
+	p.nodes = append(p.nodes, transform)
+}
+
+func (p *opentsdbParser) DAG() (parser.Nodes, parser.Edges, error) {
+	return p.nodes, p.edges, nil
+}
+
+func (p *opentsdbParser) String() string {
+	var buf strings.Builder
+	buf.WriteString(p.query.Aggregator)
+	if p.query.Downsample != "" {
+		buf.WriteString(":" + p.query.Downsample)
+	}
+
+	if p.query.Rate {
+		buf.WriteString(":rate")
+	}
+
+	buf.WriteString(":" + p.query.Metric)
+	return buf.String()
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package opentsdb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
+	"github.com/m3db/m3/src/query/functions/opentsdb"
+	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/parser"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	for str, expected := range map[string]time.Duration{
+		"500ms": 500 * time.Millisecond,
+		"30s":   30 * time.Second,
+		"1m":    time.Minute,
+		"2h":    2 * time.Hour,
+		"1d":    24 * time.Hour,
+		"1w":    7 * 24 * time.Hour,
+	} {
+		interval, err := ParseInterval(str)
+		require.NoError(t, err, str)
+		assert.Equal(t, expected, interval, str)
+	}
+
+	for _, str := range []string{"", "m", "0m", "1x", "-1m", "1.5h"} {
+		_, err := ParseInterval(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestParseDownsample(t *testing.T) {
+	downsample, err := ParseDownsample("1m-avg")
+	require.NoError(t, err)
+	assert.Equal(t, Downsample{Interval: time.Minute, Function: temporal.AvgTemporalType}, downsample)
+
+	downsample, err = ParseDownsample("1h-zimsum-nan")
+	require.NoError(t, err)
+	assert.Equal(t, Downsample{Interval: time.Hour, Function: temporal.SumTemporalType}, downsample)
+
+	for _, str := range []string{"1m", "1m-p99", "0all-sum", "1m-sum-zero", "1m-sum-nan-x"} {
+		_, err := ParseDownsample(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestParseMetricQuery(t *testing.T) {
+	q, err := ParseMetricQuery("sum:1m-avg:rate{counter,100,10}:sys.cpu{host=*,dc=a|b}{env=regexp(prod.*)}")
+	require.NoError(t, err)
+	assert.Equal(t, Query{
+		Aggregator:  "sum",
+		Metric:      "sys.cpu",
+		Downsample:  "1m-avg",
+		Rate:        true,
+		RateOptions: opentsdb.RateOptions{Counter: true, CounterMax: 100, ResetValue: 10},
+		Filters: []Filter{
+			{Type: wildcardFilter, TagK: "host", Filter: "*", GroupBy: true},
+			{Type: literalOrFilter, TagK: "dc", Filter: "a|b", GroupBy: true},
+			{Type: regexpFilter, TagK: "env", Filter: "prod.*"},
+		},
+	}, q)
+	assert.Equal(t, []string{"host", "dc"}, q.GroupByTags())
+
+	q, err = ParseMetricQuery("avg:rate:sys.mem")
+	require.NoError(t, err)
+	assert.Equal(t, Query{Aggregator: "avg", Metric: "sys.mem", Rate: true}, q)
+
+	for _, str := range []string{
+		"sys.cpu",
+		"sum:1m-avg:5m-avg:sys.cpu",
+		"sum:rate{gauge}:sys.cpu",
+		"sum:sys.cpu{host}",
+		"sum:sys.cpu{host=a",
+	} {
+		_, err := ParseMetricQuery(str)
+		assert.Error(t, err, str)
+	}
+}
+
+func TestFilterMatchers(t *testing.T) {
+	tests := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Filter{Type: literalOrFilter, TagK: "host", Filter: "a"}, `host="a"`},
+		{Filter{Type: literalOrFilter, TagK: "host", Filter: "a.b|c"}, `host=~"(a\\.b|c)"`},
+		{Filter{Type: notLiteralOrFilter, TagK: "host", Filter: "a"}, `host!="a"`},
+		{Filter{Type: iLiteralOrFilter, TagK: "host", Filter: "a"}, `host=~"(?i)(a)"`},
+		{Filter{Type: notILiteralOrFilter, TagK: "host", Filter: "a|b"}, `host!~"(?i)(a|b)"`},
+		{Filter{Type: wildcardFilter, TagK: "host", Filter: "web*.local"}, `host=~"web.*\\.local"`},
+		{Filter{Type: iWildcardFilter, TagK: "host", Filter: "*"}, `host=~"(?i).*"`},
+		{Filter{Type: regexpFilter, TagK: "host", Filter: "web[0-9]+"}, `host=~"web[0-9]+"`},
+	}
+
+	for _, tt := range tests {
+		matcher, err := tt.filter.matcher()
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, matcher.String())
+	}
+
+	_, err := Filter{Type: "unknown", TagK: "host", Filter: "a"}.matcher()
+	assert.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	p, err := Parse(Query{
+		Aggregator: "sum",
+		Metric:     "sys.cpu",
+		Downsample: "1m-max",
+		Rate:       true,
+		Tags:       map[string]string{"host": "*"},
+		Filters:    []Filter{{Type: literalOrFilter, TagK: "dc", Filter: "east"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sum:1m-max:rate:sys.cpu", p.String())
+
+	nodes, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, nodes, 4)
+
+	fetch, ok := nodes[0].Op.(functions.FetchOp)
+	require.True(t, ok)
+	matchers := make([]string, 0, len(fetch.Matchers))
+	for _, matcher := range fetch.Matchers {
+		matchers = append(matchers, matcher.String())
+	}
+	assert.Equal(t, []string{`__name__="sys.cpu"`, `host=~".*"`, `dc="east"`}, matchers)
+	assert.Equal(t, temporal.MaxTemporalType, nodes[1].Op.OpType())
+	assert.Equal(t, opentsdb.RateType, nodes[2].Op.OpType())
+	assert.Equal(t, aggregation.SumType, nodes[3].Op.OpType())
+
+	assert.Equal(t, parser.Edges{
+		{ParentID: nodes[0].ID, ChildID: nodes[1].ID},
+		{ParentID: nodes[1].ID, ChildID: nodes[2].ID},
+		{ParentID: nodes[2].ID, ChildID: nodes[3].ID},
+	}, edges)
+
+	// The none aggregator returns each series as is
+	p, err = Parse(Query{Aggregator: NoneAggregator, Metric: "sys.cpu"})
+	require.NoError(t, err)
+	nodes, _, err = p.DAG()
+	require.NoError(t, err)
+	require.Len(t, nodes, 1)
+
+	for _, q := range []Query{
+		{Metric: "sys.cpu"},
+		{Aggregator: "sum"},
+		{Aggregator: "p99", Metric: "sys.cpu"},
+		{Aggregator: "sum", Metric: "sys.cpu", Downsample: "1m"},
+		{Aggregator: "sum", Metric: "sys.cpu", Filters: []Filter{{Type: "unknown", TagK: "a", Filter: "b"}}},
+	} {
+		_, err := Parse(q)
+		assert.Error(t, err)
+	}
+}
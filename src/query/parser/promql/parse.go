@@ -22,8 +22,10 @@ package promql
 
 import (
 	"fmt"
+	"math"
 
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
 	pql "github.com/prometheus/prometheus/promql"
@@ -45,6 +47,21 @@ func Parse(q string) (parser.Parser, error) {
 	}, nil
 }
 
+// ParseMatchers parses a series selector into the tag matchers it contains
+func ParseMatchers(selector string) (models.Matchers, error) {
+	labelMatchers, err := pql.ParseMetricSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return labelMatchersToModelMatcher(labelMatchers)
+}
+
+// MatrixSelectorMatchers returns the tag matchers of a range vector selector
+func MatrixSelectorMatchers(selector *pql.MatrixSelector) (models.Matchers, error) {
+	return labelMatchersToModelMatcher(selector.LabelMatchers)
+}
+
 func (p *promParser) DAG() (parser.Nodes, parser.Edges, error) {
 	state := &parseState{}
 	err := state.walk(p.expr)
@@ -128,8 +145,16 @@ func (p *parseState) walk(node pql.Node) error {
 			case *pql.NumberLiteral:
 				argValues = append(argValues, e.Val)
 				continue
+			case *pql.StringLiteral:
+				argValues = append(argValues, e.Val)
+				continue
 			case *pql.MatrixSelector:
 				argValues = append(argValues, e.Range)
+			case *pql.BinaryExpr, *pql.ParenExpr:
+				if val, ok := resolveScalarArgument(e); ok {
+					argValues = append(argValues, val)
+					continue
+				}
 			}
 
 			err := p.walk(expr)
@@ -198,3 +223,43 @@ func (p *parseState) walk(node pql.Node) error {
 	// TODO: This should go away once all cases have been implemented
 	return errors.ErrNotImplemented
 }
+
+// resolveScalarArgument evaluates function arguments which are built only from
+// number literals, such as the 4*3600 in predict_linear(up[6h], 4*3600)
+func resolveScalarArgument(expr pql.Expr) (float64, bool) {
+	switch e := expr.(type) {
+	case *pql.NumberLiteral:
+		return e.Val, true
+
+	case *pql.ParenExpr:
+		return resolveScalarArgument(e.Expr)
+
+	case *pql.BinaryExpr:
+		lhs, ok := resolveScalarArgument(e.LHS)
+		if !ok {
+			return 0, false
+		}
+
+		rhs, ok := resolveScalarArgument(e.RHS)
+		if !ok {
+			return 0, false
+		}
+
+		switch e.Op {
+		case pql.ItemType(itemADD):
+			return lhs + rhs, true
+		case pql.ItemType(itemSUB):
+			return lhs - rhs, true
+		case pql.ItemType(itemMUL):
+			return lhs * rhs, true
+		case pql.ItemType(itemDIV):
+			return lhs / rhs, true
+		case pql.ItemType(itemPOW):
+			return math.Pow(lhs, rhs), true
+		case pql.ItemType(itemMOD):
+			return math.Mod(lhs, rhs), true
+		}
+	}
+
+	return 0, false
+}
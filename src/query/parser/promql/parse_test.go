@@ -27,9 +27,12 @@ import (
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 
+	pql "github.com/prometheus/prometheus/promql"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -129,6 +132,23 @@ var linearParseTests = []struct {
 	{"year(up)", linear.YearType},
 }
 
+func TestHistogramQuantileParses(t *testing.T) {
+	q := "histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))"
+	p, err := Parse(q)
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+	assert.Equal(t, transforms[1].Op.OpType(), temporal.RateType)
+	assert.Equal(t, transforms[2].Op.OpType(), aggregation.SumType)
+	assert.Equal(t, transforms[3].Op.OpType(), linear.HistogramQuantileType)
+	assert.Equal(t, transforms[3].ID, parser.NodeID("3"))
+	require.Len(t, edges, 3)
+	assert.Equal(t, edges[2].ParentID, parser.NodeID("2"))
+	assert.Equal(t, edges[2].ChildID, parser.NodeID("3"))
+}
+
 func TestLinearParses(t *testing.T) {
 	for _, tt := range linearParseTests {
 		t.Run(tt.q, func(t *testing.T) {
@@ -247,6 +267,21 @@ var temporalParseTests = []struct {
 	{"sum_over_time(up[5m])", temporal.SumTemporalType},
 	{"stddev_over_time(up[5m])", temporal.StdDevTemporalType},
 	{"stdvar_over_time(up[5m])", temporal.StdVarTemporalType},
+
+	{"rate(up[5m])", temporal.RateType},
+	{"irate(up[5m])", temporal.IRateType},
+	{"increase(up[5m])", temporal.IncreaseType},
+	{"delta(up[5m])", temporal.DeltaType},
+	{"idelta(up[5m])", temporal.IDeltaType},
+
+	{"deriv(up[5m])", temporal.DerivType},
+	{"predict_linear(up[5m], 100)", temporal.PredictLinearType},
+	{"predict_linear(up[6h], 4*3600)", temporal.PredictLinearType},
+	{"predict_linear(up[6h], (2+2)*3600)", temporal.PredictLinearType},
+
+	{"holt_winters(up[5m], 0.2, 0.5)", temporal.HoltWintersType},
+	{"changes(up[5m])", temporal.ChangesType},
+	{"resets(up[5m])", temporal.ResetsType},
 }
 
 func TestTemporalParses(t *testing.T) {
@@ -274,3 +309,76 @@ func TestFailedTemporalParse(t *testing.T) {
 	_, err := Parse(q)
 	require.Error(t, err)
 }
+
+var tagParseTests = []struct {
+	q            string
+	expectedType string
+}{
+	{`label_replace(up, "host", "$1", "instance", "(.*):.*")`, tag.LabelReplaceType},
+	{`label_join(up, "id", ",", "job", "instance")`, tag.LabelJoinType},
+	{`label_join(up, "id", ",")`, tag.LabelJoinType},
+}
+
+func TestTagParses(t *testing.T) {
+	for _, tt := range tagParseTests {
+		t.Run(tt.q, func(t *testing.T) {
+			q := tt.q
+			p, err := Parse(q)
+			require.NoError(t, err)
+			transforms, edges, err := p.DAG()
+			require.NoError(t, err)
+			assert.Len(t, transforms, 2)
+			assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+			assert.Equal(t, transforms[0].ID, parser.NodeID("0"))
+			assert.Equal(t, transforms[1].Op.OpType(), tt.expectedType)
+			assert.Equal(t, transforms[1].ID, parser.NodeID("1"))
+			assert.Len(t, edges, 1)
+			assert.Equal(t, edges[0].ParentID, parser.NodeID("0"))
+			assert.Equal(t, edges[0].ChildID, parser.NodeID("1"))
+		})
+	}
+}
+
+func TestFailedTagParse(t *testing.T) {
+	q := `label_replace(up, "invalid-name", "$1", "instance", "(.*)")`
+	p, err := Parse(q)
+	require.NoError(t, err)
+	_, _, err = p.DAG()
+	require.Error(t, err)
+}
+
+func TestResolveScalarArgument(t *testing.T) {
+	p, err := Parse("predict_linear(up[6h], (2^2)*3600 - 10/5)")
+	require.NoError(t, err)
+	call, ok := p.(*promParser).expr.(*pql.Call)
+	require.True(t, ok)
+	val, ok := resolveScalarArgument(call.Args[1])
+	require.True(t, ok)
+	assert.Equal(t, 14398.0, val)
+
+	_, ok = resolveScalarArgument(call.Args[0])
+	assert.False(t, ok)
+}
+
+func TestParseMatchers(t *testing.T) {
+	matchers, err := ParseMatchers(`up{job="api",instance=~"host-.*"}`)
+	require.NoError(t, err)
+	require.Len(t, matchers, 3)
+
+	expected := []struct {
+		name, value string
+		matchType   models.MatchType
+	}{
+		{"job", "api", models.MatchEqual},
+		{"instance", "host-.*", models.MatchRegexp},
+		{models.MetricName, "up", models.MatchEqual},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.name, matchers[i].Name)
+		assert.Equal(t, e.value, matchers[i].Value)
+		assert.Equal(t, e.matchType, matchers[i].Type)
+	}
+
+	_, err = ParseMatchers("sum(up)")
+	require.Error(t, err)
+}
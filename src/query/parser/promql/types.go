@@ -28,6 +28,7 @@ import (
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/linear"
+	"github.com/m3db/m3/src/query/functions/tag"
 	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
@@ -167,11 +168,33 @@ func NewFunctionExpr(name string, argValues []interface{}) (parser.Params, error
 		linear.MinuteType, linear.MonthType, linear.YearType:
 		return linear.NewDateOp(name)
 
+	case linear.HistogramQuantileType:
+		return linear.NewHistogramQuantileOp(argValues, name)
+
 	case temporal.AvgTemporalType, temporal.CountTemporalType, temporal.MinTemporalType,
 		temporal.MaxTemporalType, temporal.SumTemporalType, temporal.StdDevTemporalType,
 		temporal.StdVarTemporalType:
 		return temporal.NewAggOp(argValues, name)
 
+	case temporal.RateType, temporal.IRateType, temporal.IncreaseType, temporal.DeltaType,
+		temporal.IDeltaType:
+		return temporal.NewRateOp(argValues, name)
+
+	case temporal.DerivType, temporal.PredictLinearType:
+		return temporal.NewLinearRegressionOp(argValues, name)
+
+	case temporal.HoltWintersType:
+		return temporal.NewHoltWintersOp(argValues, name)
+
+	case temporal.ChangesType, temporal.ResetsType:
+		return temporal.NewFunctionOp(argValues, name)
+
+	case tag.LabelReplaceType:
+		return tag.NewLabelReplaceOp(argValues, name)
+
+	case tag.LabelJoinType:
+		return tag.NewLabelJoinOp(argValues, name)
+
 	default:
 		// TODO: handle other types
 		return nil, fmt.Errorf("function not supported: %s", name)
@@ -28,15 +28,17 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3metrics/policy"
 )
 
 // PhysicalPlan represents the physical plan
 type PhysicalPlan struct {
-	steps      map[parser.NodeID]LogicalStep
-	pipeline   []parser.NodeID // Ordered list of steps to be performed
-	ResultStep ResultOp
-	TimeSpec   transform.TimeSpec
-	Debug      bool
+	steps         map[parser.NodeID]LogicalStep
+	pipeline      []parser.NodeID // Ordered list of steps to be performed
+	ResultStep    ResultOp
+	TimeSpec      transform.TimeSpec
+	Debug         bool
+	StoragePolicy policy.StoragePolicy
 }
 
 // ResultOp is resonsible for delivering results to the clients
@@ -59,7 +61,8 @@ func NewPhysicalPlan(lp LogicalPlan, storage storage.Storage, params models.Requ
 			Now:   params.Now,
 			Step:  params.Step,
 		},
-		Debug: params.Debug,
+		Debug:         params.Debug,
+		StoragePolicy: params.StoragePolicy,
 	}
 
 	pl, err := p.createResultNode()
@@ -136,6 +139,11 @@ func (p PhysicalPlan) Step(ID parser.NodeID) (LogicalStep, bool) {
 	return step, ok
 }
 
+// Pipeline returns the IDs of the steps of the plan in order
+func (p PhysicalPlan) Pipeline() []parser.NodeID {
+	return p.pipeline
+}
+
 // String representation of the physical plan
 func (p PhysicalPlan) String() string {
 	return fmt.Sprintf("StepCount: %s, Pipeline: %s, Result: %s, TimeSpec: %v", p.steps, p.pipeline, p.ResultStep, p.TimeSpec)
@@ -0,0 +1,143 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resolver
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tsdb"
+	"github.com/m3db/m3metrics/policy"
+	"github.com/m3db/m3x/clock"
+	xtime "github.com/m3db/m3x/time"
+)
+
+type resolutionResolver struct {
+	policies policy.StoragePolicies
+	nowFn    clock.NowFn
+}
+
+// NewResolutionResolver creates a resolver that resolves to the finest
+// resolution storage policy retaining the whole query range. If no single
+// storage policy retains the whole range the range is split at retention
+// boundaries, with each part resolved to the finest resolution storage
+// policy retaining it.
+func NewResolutionResolver(
+	policies policy.StoragePolicies,
+	nowFn clock.NowFn,
+) PolicyResolver {
+	sorted := policies.Clone()
+	sort.Sort(policy.ByResolutionAscRetentionDesc(sorted))
+	return &resolutionResolver{policies: sorted, nowFn: nowFn}
+}
+
+func (r *resolutionResolver) Resolve(
+	// Context needed here to satisfy PolicyResolver interface
+	ctx context.Context, // nolint: unparam
+	tagMatchers models.Matchers,
+	startTime, endTime time.Time,
+) ([]tsdb.FetchRequest, error) {
+	// The ranges only depend on the retention of the storage policies, so
+	// a single request covers all series matching the tag matchers
+	return []tsdb.FetchRequest{{
+		Ranges: r.resolve(startTime, endTime),
+	}}, nil
+}
+
+func (r *resolutionResolver) resolve(start, end time.Time) tsdb.FetchRanges {
+	if len(r.policies) == 0 {
+		return nil
+	}
+
+	now := r.nowFn()
+	retentionStart := func(p policy.StoragePolicy) time.Time {
+		return now.Add(-p.Retention().Duration())
+	}
+
+	// Policies are sorted finest resolution first
+	for _, p := range r.policies {
+		if !retentionStart(p).After(start) {
+			return tsdb.NewSingleRangeRequest("", start, end, p).Ranges
+		}
+	}
+
+	// Stitch together the finest policy retaining each part of the range,
+	// walking back from the end of the range. As fewer policies retain the
+	// older parts each range is served by a coarser resolution than the last.
+	var (
+		ranges   tsdb.FetchRanges
+		rangeEnd = end
+	)
+	for rangeEnd.After(start) {
+		p, ok := r.finestRetaining(rangeEnd, retentionStart)
+		if !ok {
+			break
+		}
+
+		rangeStart := retentionStart(p)
+		if rangeStart.Before(start) {
+			rangeStart = start
+		}
+
+		ranges = append(tsdb.FetchRanges{{
+			Range:         xtime.Range{Start: rangeStart, End: rangeEnd},
+			StoragePolicy: p,
+		}}, ranges...)
+		rangeEnd = rangeStart
+	}
+
+	if len(ranges) == 0 {
+		// None of the range is retained, resolve to the policy with the
+		// longest retention which may still hold some data
+		return tsdb.NewSingleRangeRequest("", start, end, r.longestRetention()).Ranges
+	}
+
+	// The ranges must cover the whole range even if the start is no longer
+	// retained by any policy
+	ranges[0].Start = start
+	return ranges
+}
+
+func (r *resolutionResolver) finestRetaining(
+	t time.Time,
+	retentionStart func(p policy.StoragePolicy) time.Time,
+) (policy.StoragePolicy, bool) {
+	for _, p := range r.policies {
+		if retentionStart(p).Before(t) {
+			return p, true
+		}
+	}
+
+	return policy.EmptyStoragePolicy, false
+}
+
+func (r *resolutionResolver) longestRetention() policy.StoragePolicy {
+	longest := r.policies[0]
+	for _, p := range r.policies[1:] {
+		if p.Retention().Duration() > longest.Retention().Duration() {
+			longest = p
+		}
+	}
+
+	return longest
+}
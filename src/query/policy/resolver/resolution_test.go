@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resolver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/tsdb"
+	"github.com/m3db/m3metrics/policy"
+	xtime "github.com/m3db/m3x/time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNow = time.Now().Truncate(time.Hour)
+
+	unaggregated2d  = policy.NewStoragePolicy(0, xtime.Second, 48*time.Hour)
+	aggregated1m40d = policy.NewStoragePolicy(time.Minute, xtime.Second, 40*24*time.Hour)
+	aggregated10m1y = policy.NewStoragePolicy(10*time.Minute, xtime.Second, 365*24*time.Hour)
+	aggregated1h1y  = policy.NewStoragePolicy(time.Hour, xtime.Second, 365*24*time.Hour)
+)
+
+func newTestResolutionResolver() PolicyResolver {
+	policies := policy.StoragePolicies{
+		aggregated1h1y, aggregated10m1y, unaggregated2d, aggregated1m40d,
+	}
+	return NewResolutionResolver(policies, func() time.Time {
+		return testNow
+	})
+}
+
+func resolveRanges(t *testing.T, start, end time.Time) tsdb.FetchRanges {
+	matchers := models.Matchers{{Type: models.MatchRegexp, Name: "foo", Value: "b.*"}}
+	requests, err := newTestResolutionResolver().Resolve(context.TODO(), matchers, start, end)
+	require.NoError(t, err)
+	require.Len(t, requests, 1)
+	return requests[0].Ranges
+}
+
+func TestResolutionResolverFinestCoveringPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    time.Duration
+		expected policy.StoragePolicy
+	}{
+		{"unaggregated", time.Hour, unaggregated2d},
+		{"unaggregated retention boundary", 48 * time.Hour, unaggregated2d},
+		{"aggregated", 7 * 24 * time.Hour, aggregated1m40d},
+		{"coarse aggregated", 90 * 24 * time.Hour, aggregated10m1y},
+	}
+
+	for _, test := range tests {
+		start, end := testNow.Add(-test.start), testNow
+		ranges := resolveRanges(t, start, end)
+		expected := tsdb.NewSingleRangeRequest("", start, end, test.expected).Ranges
+		assert.Equal(t, expected, ranges, test.name)
+	}
+}
+
+func TestResolutionResolverStitchesRetentionBoundaries(t *testing.T) {
+	var (
+		day   = 24 * time.Hour
+		start = testNow.Add(-2 * 365 * day)
+		end   = testNow.Add(-time.Hour)
+	)
+	ranges := resolveRanges(t, start, end)
+
+	expected := tsdb.FetchRanges{
+		{
+			Range:         xtime.Range{Start: start, End: testNow.Add(-40 * day)},
+			StoragePolicy: aggregated10m1y,
+		},
+		{
+			Range:         xtime.Range{Start: testNow.Add(-40 * day), End: testNow.Add(-2 * day)},
+			StoragePolicy: aggregated1m40d,
+		},
+		{
+			Range:         xtime.Range{Start: testNow.Add(-2 * day), End: end},
+			StoragePolicy: unaggregated2d,
+		},
+	}
+	assert.Equal(t, expected, ranges)
+}
+
+func TestResolutionResolverRangeNotRetained(t *testing.T) {
+	start := testNow.Add(-3 * 365 * 24 * time.Hour)
+	end := testNow.Add(-2 * 365 * 24 * time.Hour)
+	ranges := resolveRanges(t, start, end)
+
+	// Longest retention with the finest resolution
+	expected := tsdb.NewSingleRangeRequest("", start, end, aggregated10m1y).Ranges
+	assert.Equal(t, expected, ranges)
+}
@@ -24,6 +24,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -31,6 +32,8 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/carbon"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/rules"
 	dbconfig "github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/client"
@@ -48,6 +51,7 @@ import (
 	"github.com/m3db/m3/src/query/util/logging"
 	clusterclient "github.com/m3db/m3cluster/client"
 	etcdclient "github.com/m3db/m3cluster/client/etcd"
+	"github.com/m3db/m3metrics/policy"
 	"github.com/m3db/m3x/clock"
 	xconfig "github.com/m3db/m3x/config"
 	"github.com/m3db/m3x/ident"
@@ -55,6 +59,7 @@ import (
 	"github.com/m3db/m3x/pool"
 	xsync "github.com/m3db/m3x/sync"
 
+	"github.com/uber-go/tally"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
@@ -234,7 +239,8 @@ func Run(runOpts RunOptions) {
 			fanoutStorage, instrumentOptions)
 	}
 
-	engine := executor.NewEngine(fanoutStorage)
+	engine := executor.NewEngineWithLimits(fanoutStorage, cfg.Limits.Limits(),
+		scope.SubScope("query-limits"))
 
 	handler, err := httpd.NewHandler(fanoutStorage, downsampler, engine,
 		clusterClient, cfg, runOpts.DBConfig, scope)
@@ -243,6 +249,18 @@ func Run(runOpts RunOptions) {
 	}
 	handler.RegisterRoutes()
 
+	if cfg.Carbon != nil {
+		closeCarbon := startCarbonIngester(logger, cfg.Carbon, fanoutStorage,
+			downsampler, scope.SubScope("carbon"))
+		defer closeCarbon()
+	}
+
+	if cfg.Rules != nil {
+		closeRules := startRuleManager(logger, cfg.Rules, engine, fanoutStorage,
+			scope.SubScope("rules"))
+		defer closeRules()
+	}
+
 	listenAddress, err := cfg.ListenAddress.Resolve()
 	if err != nil {
 		logger.Fatal("unable to get listen address", zap.Error(err))
@@ -330,6 +348,7 @@ func newStorages(
 	localStorage := local.NewStorage(clusters, workerPool)
 	stores := []storage.Storage{localStorage}
 	remoteEnabled := false
+	partialResults := false
 	if cfg.RPC != nil && cfg.RPC.Enabled {
 		logger.Info("rpc enabled")
 		server := startGrpcServer(logger, localStorage, cfg.RPC)
@@ -345,6 +364,7 @@ func newStorages(
 
 			stores = append(stores, remote.NewStorage(client))
 			remoteEnabled = true
+			partialResults = cfg.RPC.PartialResultsEnabled
 		}
 	}
 
@@ -353,7 +373,7 @@ func newStorages(
 		readFilter = filter.AllowAll
 	}
 
-	fanoutStorage := fanout.NewStorage(stores, readFilter, filter.LocalOnly)
+	fanoutStorage := fanout.NewStorage(stores, readFilter, filter.LocalOnly, partialResults)
 	return fanoutStorage, cleanup
 }
 
@@ -371,3 +391,122 @@ func startGrpcServer(logger *zap.Logger, storage storage.Storage, cfg *config.RP
 	<-waitForStart
 	return server
 }
+
+func startCarbonIngester(
+	logger *zap.Logger,
+	cfg *config.CarbonConfiguration,
+	storage storage.Storage,
+	downsampler downsample.Downsampler,
+	scope tally.Scope,
+) func() {
+	opts := carbon.IngesterOptions{
+		Appender:    storage,
+		Downsampler: downsampler,
+		Scope:       scope,
+		Logger:      logger,
+	}
+
+	for _, rewriteCfg := range cfg.Rewrites {
+		rewrite, err := carbon.NewRewrite(rewriteCfg.Pattern, rewriteCfg.Replacement)
+		if err != nil {
+			logger.Fatal("invalid carbon rewrite", zap.String("pattern", rewriteCfg.Pattern), zap.Error(err))
+		}
+		opts.Rewrites = append(opts.Rewrites, rewrite)
+	}
+
+	for _, ruleCfg := range cfg.Rules {
+		rule, err := carbon.NewRule(ruleCfg.Pattern, ruleCfg.Policies)
+		if err != nil {
+			logger.Fatal("invalid carbon rule", zap.String("pattern", ruleCfg.Pattern), zap.Error(err))
+		}
+		opts.Rules = append(opts.Rules, rule)
+	}
+
+	ingester, err := carbon.NewIngester(opts)
+	if err != nil {
+		logger.Fatal("unable to create carbon ingester", zap.Error(err))
+	}
+
+	listener, err := net.Listen("tcp", cfg.ListenAddress)
+	if err != nil {
+		logger.Fatal("unable to listen for carbon", zap.String("address", cfg.ListenAddress), zap.Error(err))
+	}
+
+	logger.Info("starting carbon ingester", zap.String("address", cfg.ListenAddress))
+	go ingester.Serve(listener)
+
+	var packetConn net.PacketConn
+	if cfg.UDPListenAddress != "" {
+		packetConn, err = net.ListenPacket("udp", cfg.UDPListenAddress)
+		if err != nil {
+			logger.Fatal("unable to listen for carbon over udp",
+				zap.String("address", cfg.UDPListenAddress), zap.Error(err))
+		}
+
+		logger.Info("starting carbon udp ingester", zap.String("address", cfg.UDPListenAddress))
+		go ingester.ServePacket(packetConn)
+	}
+
+	return func() {
+		listener.Close()
+		if packetConn != nil {
+			packetConn.Close()
+		}
+	}
+}
+
+func startRuleManager(
+	logger *zap.Logger,
+	cfg *config.RulesConfiguration,
+	engine *executor.Engine,
+	storage storage.Storage,
+	scope tally.Scope,
+) func() {
+	groups, err := rules.LoadFiles(cfg.RuleFiles)
+	if err != nil {
+		logger.Fatal("unable to load rule files", zap.Error(err))
+	}
+
+	attributes := storageAttributes(cfg.StoragePolicy)
+	opts := rules.ManagerOptions{
+		Engine:          engine,
+		Appender:        storage,
+		Attributes:      attributes,
+		Groups:          groups,
+		DefaultInterval: cfg.EvaluationInterval,
+		QueryTimeout:    cfg.QueryTimeout,
+		Scope:           scope,
+		Logger:          logger,
+	}
+
+	if am := cfg.Alertmanager; am != nil {
+		logger.Info("sending alerts to alertmanager", zap.String("url", am.URL))
+		opts.Notifier = rules.NewWebhookNotifier(am.URL)
+		opts.NotificationTimeout = am.Timeout
+		opts.ResendDelay = am.ResendDelay
+	}
+
+	manager, err := rules.NewManager(opts)
+	if err != nil {
+		logger.Fatal("unable to create rule manager", zap.Error(err))
+	}
+
+	logger.Info("starting rule manager", zap.Int("ruleGroups", len(groups)),
+		zap.Stringer("metricsType", attributes.MetricsType))
+	manager.Start()
+	return manager.Close
+}
+
+// storageAttributes returns the attributes of the namespace matching a
+// storage policy, which is the unaggregated namespace if it is not set
+func storageAttributes(sp *policy.StoragePolicy) storage.Attributes {
+	if sp == nil {
+		return storage.Attributes{MetricsType: storage.UnaggregatedMetricsType}
+	}
+
+	return storage.Attributes{
+		MetricsType: storage.AggregatedMetricsType,
+		Resolution:  sp.Resolution().Window,
+		Retention:   sp.Retention().Duration(),
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+)
+
+var (
+	errMismatchedCompleteTagsType = errors.New(
+		"cannot combine complete tags results of different types")
+)
+
+// CompleteTagsResultBuilder combines complete tags results, deduplicating
+// the tag names and values across results
+type CompleteTagsResultBuilder struct {
+	nameOnly bool
+	tags     map[string]map[string]struct{}
+	warnings Warnings
+}
+
+// NewCompleteTagsResultBuilder creates a new complete tags result builder
+func NewCompleteTagsResultBuilder(nameOnly bool) *CompleteTagsResultBuilder {
+	return &CompleteTagsResultBuilder{
+		nameOnly: nameOnly,
+		tags:     make(map[string]map[string]struct{}),
+	}
+}
+
+// Add adds a complete tags result to the builder
+func (b *CompleteTagsResultBuilder) Add(result *CompleteTagsResult) error {
+	if result.CompleteNameOnly != b.nameOnly {
+		return errMismatchedCompleteTagsType
+	}
+
+	b.warnings = append(b.warnings, result.Warnings...)
+
+	for _, tag := range result.CompletedTags {
+		values, ok := b.tags[string(tag.Name)]
+		if !ok {
+			values = make(map[string]struct{}, len(tag.Values))
+			b.tags[string(tag.Name)] = values
+		}
+
+		if b.nameOnly {
+			continue
+		}
+
+		for _, value := range tag.Values {
+			values[string(value)] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+// AddWarnings adds warnings to the combined result
+func (b *CompleteTagsResultBuilder) AddWarnings(warnings ...Warning) {
+	b.warnings = append(b.warnings, warnings...)
+}
+
+// Build returns the combined result with tags sorted by name and values
+// sorted within each tag
+func (b *CompleteTagsResultBuilder) Build() CompleteTagsResult {
+	completed := make([]CompletedTag, 0, len(b.tags))
+	for name, values := range b.tags {
+		tag := CompletedTag{Name: []byte(name)}
+		if !b.nameOnly {
+			tag.Values = make([][]byte, 0, len(values))
+			for value := range values {
+				tag.Values = append(tag.Values, []byte(value))
+			}
+
+			sort.Slice(tag.Values, func(i, j int) bool {
+				return bytes.Compare(tag.Values[i], tag.Values[j]) < 0
+			})
+		}
+
+		completed = append(completed, tag)
+	}
+
+	sort.Slice(completed, func(i, j int) bool {
+		return bytes.Compare(completed[i].Name, completed[j].Name) < 0
+	})
+
+	return CompleteTagsResult{
+		CompleteNameOnly: b.nameOnly,
+		CompletedTags:    completed,
+		Warnings:         b.warnings,
+	}
+}
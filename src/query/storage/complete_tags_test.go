@@ -0,0 +1,84 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func strsToBytes(strs []string) [][]byte {
+	b := make([][]byte, 0, len(strs))
+	for _, s := range strs {
+		b = append(b, []byte(s))
+	}
+	return b
+}
+
+func TestCompleteTagsResultBuilderMergesValues(t *testing.T) {
+	b := NewCompleteTagsResultBuilder(false)
+	require.NoError(t, b.Add(&CompleteTagsResult{
+		CompletedTags: []CompletedTag{
+			{Name: []byte("foo"), Values: strsToBytes([]string{"b", "a"})},
+			{Name: []byte("bar"), Values: strsToBytes([]string{"c"})},
+		},
+	}))
+	require.NoError(t, b.Add(&CompleteTagsResult{
+		CompletedTags: []CompletedTag{
+			{Name: []byte("foo"), Values: strsToBytes([]string{"a", "d"})},
+		},
+	}))
+
+	expected := CompleteTagsResult{
+		CompletedTags: []CompletedTag{
+			{Name: []byte("bar"), Values: strsToBytes([]string{"c"})},
+			{Name: []byte("foo"), Values: strsToBytes([]string{"a", "b", "d"})},
+		},
+	}
+	assert.Equal(t, expected, b.Build())
+}
+
+func TestCompleteTagsResultBuilderNameOnly(t *testing.T) {
+	b := NewCompleteTagsResultBuilder(true)
+	require.NoError(t, b.Add(&CompleteTagsResult{
+		CompleteNameOnly: true,
+		CompletedTags:    []CompletedTag{{Name: []byte("foo")}, {Name: []byte("bar")}},
+	}))
+	require.NoError(t, b.Add(&CompleteTagsResult{
+		CompleteNameOnly: true,
+		CompletedTags:    []CompletedTag{{Name: []byte("foo")}, {Name: []byte("baz")}},
+	}))
+
+	expected := CompleteTagsResult{
+		CompleteNameOnly: true,
+		CompletedTags: []CompletedTag{
+			{Name: []byte("bar")}, {Name: []byte("baz")}, {Name: []byte("foo")},
+		},
+	}
+	assert.Equal(t, expected, b.Build())
+}
+
+func TestCompleteTagsResultBuilderMismatchedType(t *testing.T) {
+	b := NewCompleteTagsResultBuilder(true)
+	assert.Error(t, b.Add(&CompleteTagsResult{CompleteNameOnly: false}))
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3x/ident"
@@ -185,11 +186,16 @@ func SeriesToPromSamples(series *ts.Series) []*prompb.Sample {
 const (
 	// TODO(arnikola) get from config
 	initRawFetchAllocSize = 32
+
+	// datapointsLimitBatchSize is the number of datapoints decoded between
+	// checks of the datapoints limit of a query
+	datapointsLimitBatchSize = 1024
 )
 
 func iteratorToTsSeries(
 	iter encoding.SeriesIterator,
 	namespace ident.ID,
+	enforcer *limits.Enforcer,
 ) (*ts.Series, error) {
 	if namespace == nil {
 		namespace = iter.Namespace()
@@ -199,10 +205,26 @@ func iteratorToTsSeries(
 		return nil, err
 	}
 
-	datapoints := make(ts.Datapoints, 0, initRawFetchAllocSize)
+	var (
+		datapoints = make(ts.Datapoints, 0, initRawFetchAllocSize)
+		uncounted  int
+	)
 	for iter.Next() {
 		dp, _, _ := iter.Current()
 		datapoints = append(datapoints, ts.Datapoint{Timestamp: dp.Timestamp, Value: dp.Value})
+
+		// Count the datapoints as they are decoded so a query exceeding its
+		// limit stops decoding rather than holding every series in memory
+		if uncounted++; uncounted == datapointsLimitBatchSize {
+			if err := enforcer.AddDatapoints(uncounted); err != nil {
+				return nil, err
+			}
+			uncounted = 0
+		}
+	}
+
+	if err := enforcer.AddDatapoints(uncounted); err != nil {
+		return nil, err
 	}
 
 	return ts.NewSeries(metric.ID, datapoints, metric.Tags), nil
@@ -213,10 +235,11 @@ func decompressSequentially(
 	iterLength int,
 	iters []encoding.SeriesIterator,
 	namespace ident.ID,
+	enforcer *limits.Enforcer,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, iterLength)
 	for i, iter := range iters {
-		series, err := iteratorToTsSeries(iter, namespace)
+		series, err := iteratorToTsSeries(iter, namespace, enforcer)
 		if err != nil {
 			return nil, err
 		}
@@ -233,6 +256,7 @@ func decompressConcurrently(
 	iters []encoding.SeriesIterator,
 	namespace ident.ID,
 	pool xsync.WorkerPool,
+	enforcer *limits.Enforcer,
 ) (*FetchResult, error) {
 	seriesList := make([]*ts.Series, iterLength)
 	var wg sync.WaitGroup
@@ -258,7 +282,7 @@ func decompressConcurrently(
 				return
 			}
 
-			series, err := iteratorToTsSeries(iter, namespace)
+			series, err := iteratorToTsSeries(iter, namespace, enforcer)
 			if err != nil {
 				// Return the first error that is encountered.
 				select {
@@ -282,11 +306,13 @@ func decompressConcurrently(
 	}, nil
 }
 
-// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result
+// SeriesIteratorsToFetchResult converts SeriesIterators into a fetch result,
+// the datapoints decoded are counted against the limit of the enforcer
 func SeriesIteratorsToFetchResult(
 	seriesIterators encoding.SeriesIterators,
 	namespace ident.ID,
 	workerPools pool.ObjectPool,
+	enforcer *limits.Enforcer,
 ) (*FetchResult, error) {
 	defer seriesIterators.Close()
 
@@ -294,14 +320,14 @@ func SeriesIteratorsToFetchResult(
 	iterLength := seriesIterators.Len()
 
 	if workerPools == nil {
-		return decompressSequentially(iterLength, iters, namespace)
+		return decompressSequentially(iterLength, iters, namespace, enforcer)
 	}
 
 	pool, ok := workerPools.Get().(xsync.WorkerPool)
 	if !ok {
-		return decompressSequentially(iterLength, iters, namespace)
+		return decompressSequentially(iterLength, iters, namespace, enforcer)
 	}
 	defer workerPools.Put(pool)
 
-	return decompressConcurrently(iterLength, iters, namespace, pool)
+	return decompressConcurrently(iterLength, iters, namespace, pool, enforcer)
 }
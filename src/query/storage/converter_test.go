@@ -28,12 +28,15 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
 	xsync "github.com/m3db/m3x/sync"
+	xtime "github.com/m3db/m3x/time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +47,7 @@ func verifyExpandSeries(t *testing.T, ctrl *gomock.Controller, num int, pools po
 	testTags := seriesiter.GenerateTag()
 	iters := seriesiter.NewMockSeriesIters(ctrl, testTags, num, 2)
 
-	results, err := SeriesIteratorsToFetchResult(iters, ident.StringID("strID"), pools)
+	results, err := SeriesIteratorsToFetchResult(iters, ident.StringID("strID"), pools, nil)
 	assert.NoError(t, err)
 
 	require.NotNil(t, results)
@@ -135,11 +138,36 @@ func TestFailingExpandSeriesValidPools(t *testing.T) {
 	mockIters.EXPECT().Len().Return(len(iters)).Times(1)
 	mockIters.EXPECT().Close().Times(1)
 
-	result, err := SeriesIteratorsToFetchResult(mockIters, ident.StringID("strID"), objectPool)
+	result, err := SeriesIteratorsToFetchResult(mockIters, ident.StringID("strID"), objectPool, nil)
 	require.Nil(t, result)
 	require.EqualError(t, err, "error")
 }
 
+func TestExpandSeriesDatapointsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// The series never ends, so only stops decoding at the limit
+	tags := seriesiter.GenerateSingleSampleTagIterator(ctrl, seriesiter.GenerateTag())
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	iter.EXPECT().ID().Return(ident.StringID("foo"))
+	iter.EXPECT().Tags().Return(tags)
+	iter.EXPECT().Next().Return(true).AnyTimes()
+	iter.EXPECT().Current().Return(m3ts.Datapoint{Timestamp: time.Now(), Value: 1}, xtime.Second, nil).AnyTimes()
+
+	mockIters := encoding.NewMockSeriesIterators(ctrl)
+	mockIters.EXPECT().Iters().Return([]encoding.SeriesIterator{iter})
+	mockIters.EXPECT().Len().Return(1)
+	mockIters.EXPECT().Close().Do(func() {
+		tags.Close()
+	})
+
+	enforcer := limits.NewEnforcer(limits.Limits{MaxDatapoints: 10}, nil)
+	result, err := SeriesIteratorsToFetchResult(mockIters, ident.StringID("strID"), nil, enforcer)
+	require.Nil(t, result)
+	assert.True(t, limits.IsLimitExceeded(err))
+}
+
 func TestPromReadQueryToM3(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -22,6 +22,7 @@ package fanout
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
@@ -39,18 +40,31 @@ type fanoutStorage struct {
 	stores      []storage.Storage
 	fetchFilter filter.Storage
 	writeFilter filter.Storage
+	// partialResults allows fetches to succeed with warnings when non-local
+	// stores fail, local failures always fail the fetch
+	partialResults bool
 }
 
 // NewStorage creates a new fanout Storage instance.
-func NewStorage(stores []storage.Storage, fetchFilter filter.Storage, writeFilter filter.Storage) storage.Storage {
-	return &fanoutStorage{stores: stores, fetchFilter: fetchFilter, writeFilter: writeFilter}
+func NewStorage(
+	stores []storage.Storage,
+	fetchFilter filter.Storage,
+	writeFilter filter.Storage,
+	partialResults bool,
+) storage.Storage {
+	return &fanoutStorage{
+		stores:         stores,
+		fetchFilter:    fetchFilter,
+		writeFilter:    writeFilter,
+		partialResults: partialResults,
+	}
 }
 
 func (s *fanoutStorage) Fetch(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.FetchResult, error) {
 	stores := filterStores(s.stores, s.fetchFilter, query)
 	requests := make([]execution.Request, len(stores))
 	for idx, store := range stores {
-		requests[idx] = newFetchRequest(store, query, options)
+		requests[idx] = newFetchRequest(store, query, options, s.allowFailure(store))
 	}
 
 	err := execution.ExecuteParallel(ctx, requests)
@@ -70,6 +84,11 @@ func handleFetchResponses(requests []execution.Request) (*storage.FetchResult, e
 			return nil, errors.ErrFetchRequestType
 		}
 
+		if fetchreq.err != nil {
+			result.Warnings = append(result.Warnings, newStoreWarning(fetchreq.store, fetchreq.err))
+			continue
+		}
+
 		if fetchreq.result == nil {
 			return nil, errors.ErrInvalidFetchResult
 		}
@@ -79,28 +98,78 @@ func handleFetchResponses(requests []execution.Request) (*storage.FetchResult, e
 		}
 
 		result.SeriesList = append(result.SeriesList, fetchreq.result.SeriesList...)
+		result.Warnings = append(result.Warnings, fetchreq.result.Warnings...)
 	}
 
 	return result, nil
 }
 
 func (s *fanoutStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
-	var metrics models.Metrics
+	var (
+		metrics  models.Metrics
+		warnings storage.Warnings
+	)
 
 	stores := filterStores(s.stores, s.fetchFilter, query)
 	for _, store := range stores {
 		results, err := store.FetchTags(ctx, query, options)
 		if err != nil {
-			return nil, err
+			if !s.allowFailure(store) {
+				return nil, err
+			}
+			warnings = append(warnings, newStoreWarning(store, err))
+			continue
 		}
 		metrics = append(metrics, results.Metrics...)
+		warnings = append(warnings, results.Warnings...)
 	}
 
-	result := &storage.SearchResults{Metrics: metrics}
+	result := &storage.SearchResults{Metrics: metrics, Warnings: warnings}
 
 	return result, nil
 }
 
+func (s *fanoutStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	// NB: the fetch filter operates on fetch queries, so filter the stores
+	// using a fetch query with the same matchers and time range
+	fetchQuery := &storage.FetchQuery{
+		TagMatchers: query.TagMatchers,
+		Start:       query.Start,
+		End:         query.End,
+	}
+
+	builder := storage.NewCompleteTagsResultBuilder(query.CompleteNameOnly)
+	stores := filterStores(s.stores, s.fetchFilter, fetchQuery)
+	for _, store := range stores {
+		result, err := store.CompleteTags(ctx, query, options)
+		if err == errors.ErrNotImplemented && store.Type() != storage.TypeLocalDC {
+			// NB: remote stores cannot complete tags, so the tags are
+			// completed by the stores which can
+			continue
+		}
+
+		if err != nil {
+			if !s.allowFailure(store) {
+				return nil, err
+			}
+
+			builder.AddWarnings(newStoreWarning(store, err))
+			continue
+		}
+
+		if err := builder.Add(result); err != nil {
+			return nil, err
+		}
+	}
+
+	result := builder.Build()
+	return &result, nil
+}
+
 func (s *fanoutStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
 	stores := filterStores(s.stores, s.writeFilter, query)
 	requests := make([]execution.Request, len(stores))
@@ -111,6 +180,16 @@ func (s *fanoutStorage) Write(ctx context.Context, query *storage.WriteQuery) er
 	return execution.ExecuteParallel(ctx, requests)
 }
 
+func (s *fanoutStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	stores := filterStores(s.stores, s.writeFilter, query)
+	requests := make([]execution.Request, len(stores))
+	for idx, store := range stores {
+		requests[idx] = newDeleteRequest(store, query)
+	}
+
+	return execution.ExecuteParallel(ctx, requests)
+}
+
 func (s *fanoutStorage) Type() storage.Type {
 	return storage.TypeMultiDC
 }
@@ -122,10 +201,20 @@ func (s *fanoutStorage) FetchBlocks(
 	for _, store := range stores {
 		result, err := store.FetchBlocks(ctx, query, options)
 		if err != nil {
-			return block.Result{}, err
+			if !s.allowFailure(store) {
+				for _, b := range blockResult.Blocks {
+					b.Close()
+				}
+
+				return block.Result{}, err
+			}
+
+			blockResult.Warnings = append(blockResult.Warnings, newStoreWarning(store, err))
+			continue
 		}
 
 		blockResult.Blocks = append(blockResult.Blocks, result.Blocks...)
+		blockResult.Warnings = append(blockResult.Warnings, result.Warnings...)
 	}
 
 	return blockResult, nil
@@ -144,6 +233,19 @@ func (s *fanoutStorage) Close() error {
 	return lastErr
 }
 
+// allowFailure returns whether a fetch can return the results of the other
+// stores if the store fails.
+func (s *fanoutStorage) allowFailure(store storage.Storage) bool {
+	return s.partialResults && store.Type() != storage.TypeLocalDC
+}
+
+func newStoreWarning(store storage.Storage, err error) storage.Warning {
+	return storage.Warning{
+		Name:    fmt.Sprintf("%s_store", store.Type().String()),
+		Message: err.Error(),
+	}
+}
+
 func filterStores(stores []storage.Storage, filterPolicy filter.Storage, query storage.Query) []storage.Storage {
 	filtered := make([]storage.Storage, 0)
 	for _, s := range stores {
@@ -155,23 +257,36 @@ func filterStores(stores []storage.Storage, filterPolicy filter.Storage, query s
 }
 
 type fetchRequest struct {
-	store   storage.Storage
-	query   *storage.FetchQuery
-	options *storage.FetchOptions
-	result  *storage.FetchResult
+	store        storage.Storage
+	query        *storage.FetchQuery
+	options      *storage.FetchOptions
+	allowFailure bool
+	result       *storage.FetchResult
+	err          error
 }
 
-func newFetchRequest(store storage.Storage, query *storage.FetchQuery, options *storage.FetchOptions) execution.Request {
+func newFetchRequest(
+	store storage.Storage,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+	allowFailure bool,
+) execution.Request {
 	return &fetchRequest{
-		store:   store,
-		query:   query,
-		options: options,
+		store:        store,
+		query:        query,
+		options:      options,
+		allowFailure: allowFailure,
 	}
 }
 
 func (f *fetchRequest) Process(ctx context.Context) error {
 	result, err := f.store.Fetch(ctx, f.query, f.options)
 	if err != nil {
+		if f.allowFailure {
+			// Recorded as a warning rather than failing the other requests
+			f.err = err
+			return nil
+		}
 		return err
 	}
 
@@ -194,3 +309,19 @@ func newWriteRequest(store storage.Storage, query *storage.WriteQuery) execution
 func (f *writeRequest) Process(ctx context.Context) error {
 	return f.store.Write(ctx, f.query)
 }
+
+type deleteRequest struct {
+	store storage.Storage
+	query *storage.DeleteQuery
+}
+
+func newDeleteRequest(store storage.Storage, query *storage.DeleteQuery) execution.Request {
+	return &deleteRequest{
+		store: store,
+		query: query,
+	}
+}
+
+func (f *deleteRequest) Process(ctx context.Context) error {
+	return f.store.Delete(ctx, f.query)
+}
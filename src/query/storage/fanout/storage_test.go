@@ -27,9 +27,12 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/policy/filter"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/test/local"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
@@ -80,11 +83,13 @@ func setupFanoutRead(t *testing.T, output bool, response ...*fetchResponse) stor
 	session2.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).Return(response[len(response)-1].result, true, response[len(response)-1].err)
 	session1.EXPECT().FetchTaggedIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
 	session2.EXPECT().FetchTaggedIDs(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
+	session1.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
+	session2.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, false, errors.ErrNotImplemented)
 	stores := []storage.Storage{
 		store1, store2,
 	}
 
-	store := NewStorage(stores, filterFunc(output), filterFunc(output))
+	store := NewStorage(stores, filterFunc(output), filterFunc(output), false)
 	return store
 }
 
@@ -98,7 +103,21 @@ func setupFanoutWrite(t *testing.T, output bool, errs ...error) storage.Storage
 	stores := []storage.Storage{
 		store1, store2,
 	}
-	store := NewStorage(stores, filterFunc(output), filterFunc(output))
+	store := NewStorage(stores, filterFunc(output), filterFunc(output), false)
+	return store
+}
+
+func setupFanoutDelete(t *testing.T, output bool, errs ...error) storage.Storage {
+	setup()
+	ctrl := gomock.NewController(t)
+	store1, session1 := local.NewStorageAndSession(t, ctrl)
+	store2, session2 := local.NewStorageAndSession(t, ctrl)
+	session1.EXPECT().DeleteTagged(gomock.Any(), gomock.Any()).Return(int64(1), errs[0])
+	session2.EXPECT().DeleteTagged(gomock.Any(), gomock.Any()).Return(int64(1), errs[len(errs)-1])
+	stores := []storage.Storage{
+		store1, store2,
+	}
+	store := NewStorage(stores, filterFunc(output), filterFunc(output), false)
 	return store
 }
 
@@ -141,6 +160,152 @@ func TestFanoutSearchError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func setupFanoutPartial(localErr, remoteErr error) storage.Storage {
+	local, remote := mock.NewMockStorage(), mock.NewMockStorage()
+	local.SetTypeResult(storage.TypeLocalDC)
+	remote.SetTypeResult(storage.TypeRemoteDC)
+	local.SetFetchResult(&storage.FetchResult{
+		SeriesList: ts.SeriesList{ts.NewSeries("local", ts.NewFixedStepValues(time.Second, 1, 1, time.Now()), nil)},
+		LocalOnly:  true,
+	}, localErr)
+	remote.SetFetchResult(&storage.FetchResult{}, remoteErr)
+	local.SetFetchTagsResult(&storage.SearchResults{
+		Metrics: models.Metrics{{ID: "local"}},
+	}, localErr)
+	remote.SetFetchTagsResult(&storage.SearchResults{}, remoteErr)
+	local.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{block.NewScalar(1, block.Bounds{})},
+	}, localErr)
+	remote.SetFetchBlocksResult(block.Result{}, remoteErr)
+	local.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{{Name: []byte("local")}},
+	}, localErr)
+	remote.SetCompleteTagsResult(&storage.CompleteTagsResult{}, remoteErr)
+
+	stores := []storage.Storage{local, remote}
+	return NewStorage(stores, filterFunc(true), filterFunc(true), true)
+}
+
+func TestFanoutReadPartialResults(t *testing.T) {
+	store := setupFanoutPartial(nil, fmt.Errorf("remote error"))
+	res, err := store.Fetch(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.SeriesList, 1)
+	assert.Equal(t, "local", res.SeriesList[0].Name())
+	assert.True(t, res.LocalOnly)
+	assert.Equal(t, storage.Warnings{{Name: "remote_store", Message: "remote error"}}, res.Warnings)
+}
+
+func TestFanoutReadPartialResultsLocalError(t *testing.T) {
+	store := setupFanoutPartial(fmt.Errorf("local error"), nil)
+	_, err := store.Fetch(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutSearchPartialResults(t *testing.T) {
+	store := setupFanoutPartial(nil, fmt.Errorf("remote error"))
+	res, err := store.FetchTags(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.Metrics, 1)
+	assert.Equal(t, "local", res.Metrics[0].ID)
+	assert.Equal(t, storage.Warnings{{Name: "remote_store", Message: "remote error"}}, res.Warnings)
+}
+
+func TestFanoutSearchPartialResultsLocalError(t *testing.T) {
+	store := setupFanoutPartial(fmt.Errorf("local error"), nil)
+	_, err := store.FetchTags(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutFetchBlocksPartialResults(t *testing.T) {
+	store := setupFanoutPartial(nil, fmt.Errorf("remote error"))
+	res, err := store.FetchBlocks(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	assert.Len(t, res.Blocks, 1)
+	assert.Equal(t, block.Warnings{{Name: "remote_store", Message: "remote error"}}, res.Warnings)
+}
+
+func TestFanoutFetchBlocksPartialResultsLocalError(t *testing.T) {
+	store := setupFanoutPartial(fmt.Errorf("local error"), nil)
+	_, err := store.FetchBlocks(context.TODO(), &storage.FetchQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutCompleteTagsEmpty(t *testing.T) {
+	store := setupFanoutRead(t, false)
+	res, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{}, nil)
+	assert.NoError(t, err, "No error")
+	require.NotNil(t, res, "Non empty result")
+	assert.Len(t, res.CompletedTags, 0, "No tags")
+}
+
+func TestFanoutCompleteTagsError(t *testing.T) {
+	store := setupFanoutRead(t, true)
+	_, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{
+		End: time.Now(),
+	}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutCompleteTagsMergesStores(t *testing.T) {
+	store1, store2 := mock.NewMockStorage(), mock.NewMockStorage()
+	store1.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("foo"), Values: [][]byte{[]byte("b"), []byte("a")}},
+		},
+	}, nil)
+	store2.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("foo"), Values: [][]byte{[]byte("c"), []byte("a")}},
+			{Name: []byte("bar"), Values: [][]byte{[]byte("d")}},
+		},
+	}, nil)
+
+	store := NewStorage([]storage.Storage{store1, store2}, filterFunc(true), filterFunc(true), false)
+	res, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+
+	expected := &storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{
+			{Name: []byte("bar"), Values: [][]byte{[]byte("d")}},
+			{Name: []byte("foo"), Values: [][]byte{[]byte("a"), []byte("b"), []byte("c")}},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestFanoutCompleteTagsPartialResults(t *testing.T) {
+	store := setupFanoutPartial(nil, fmt.Errorf("remote error"))
+	res, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.CompletedTags, 1)
+	assert.Equal(t, "local", string(res.CompletedTags[0].Name))
+	assert.Equal(t, storage.Warnings{{Name: "remote_store", Message: "remote error"}}, res.Warnings)
+}
+
+func TestFanoutCompleteTagsPartialResultsLocalError(t *testing.T) {
+	store := setupFanoutPartial(fmt.Errorf("local error"), nil)
+	_, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestFanoutCompleteTagsSkipsRemoteNotImplemented(t *testing.T) {
+	local, remote := mock.NewMockStorage(), mock.NewMockStorage()
+	local.SetTypeResult(storage.TypeLocalDC)
+	remote.SetTypeResult(storage.TypeRemoteDC)
+	local.SetCompleteTagsResult(&storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{{Name: []byte("local")}},
+	}, nil)
+	remote.SetCompleteTagsResult(nil, errors.ErrNotImplemented)
+
+	store := NewStorage([]storage.Storage{local, remote}, filterFunc(true), filterFunc(true), false)
+	res, err := store.CompleteTags(context.TODO(), &storage.CompleteTagsQuery{}, &storage.FetchOptions{})
+	require.NoError(t, err)
+	require.Len(t, res.CompletedTags, 1)
+	assert.Equal(t, "local", string(res.CompletedTags[0].Name))
+	assert.Empty(t, res.Warnings)
+}
+
 func TestFanoutWriteEmpty(t *testing.T) {
 	store := setupFanoutWrite(t, false, fmt.Errorf("write error"))
 	err := store.Write(context.TODO(), nil)
@@ -166,3 +331,25 @@ func TestFanoutWriteSuccess(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestFanoutDeleteEmpty(t *testing.T) {
+	store := setupFanoutDelete(t, false, fmt.Errorf("delete error"))
+	err := store.Delete(context.TODO(), nil)
+	assert.NoError(t, err)
+}
+
+func TestFanoutDeleteError(t *testing.T) {
+	store := setupFanoutDelete(t, true, nil, fmt.Errorf("delete error"))
+	err := store.Delete(context.TODO(), &storage.DeleteQuery{
+		TagMatchers: models.Matchers{{Type: models.MatchEqual, Name: "foo", Value: "bar"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestFanoutDeleteSuccess(t *testing.T) {
+	store := setupFanoutDelete(t, true, nil)
+	err := store.Delete(context.TODO(), &storage.DeleteQuery{
+		TagMatchers: models.Matchers{{Type: models.MatchEqual, Name: "foo", Value: "bar"}},
+	})
+	assert.NoError(t, err)
+}
@@ -77,8 +77,15 @@ func TagsToIdentTagIterator(tags models.Tags) ident.TagIterator {
 
 // FetchOptionsToM3Options converts a set of coordinator options to M3 options
 func FetchOptionsToM3Options(fetchOptions *FetchOptions, fetchQuery *FetchQuery) index.QueryOptions {
+	limit := fetchOptions.Limit
+	if maxSeries := fetchOptions.Enforcer.MaxSeries(); limit == 0 && maxSeries > 0 {
+		// Fetch one more series than the limit so a query exceeding the
+		// limit is detected without fetching every series it matches
+		limit = maxSeries + 1
+	}
+
 	return index.QueryOptions{
-		Limit:          fetchOptions.Limit,
+		Limit:          limit,
 		StartInclusive: fetchQuery.Start,
 		EndExclusive:   fetchQuery.End,
 	}
@@ -100,6 +107,50 @@ func FetchQueryToM3Query(fetchQuery *FetchQuery) (index.Query, error) {
 	return index.Query{Query: q}, nil
 }
 
+// CompleteTagsQueryToM3Query converts an m3coordinator complete tags query to
+// an M3 aggregate query and its options
+func CompleteTagsQueryToM3Query(
+	query *CompleteTagsQuery,
+	fetchOptions *FetchOptions,
+) (index.Query, index.AggregateQueryOptions, error) {
+	m3query, err := FetchQueryToM3Query(&FetchQuery{TagMatchers: query.TagMatchers})
+	if err != nil {
+		return index.Query{}, index.AggregateQueryOptions{}, err
+	}
+
+	aggType := index.AggregateTagNamesAndValues
+	if query.CompleteNameOnly {
+		aggType = index.AggregateTagNames
+	}
+
+	return m3query, index.AggregateQueryOptions{
+		QueryOptions: index.QueryOptions{
+			Limit:          fetchOptions.Limit,
+			StartInclusive: query.Start,
+			EndExclusive:   query.End,
+		},
+		TagNameFilter: query.FilterNameTags,
+		Type:          aggType,
+	}, nil
+}
+
+// FromM3AggregateResults converts M3 aggregate results to a complete tags result
+func FromM3AggregateResults(results index.AggregateResults) *CompleteTagsResult {
+	tags := results.Tags()
+	completed := make([]CompletedTag, 0, len(tags))
+	for _, tag := range tags {
+		completed = append(completed, CompletedTag{
+			Name:   tag.Name,
+			Values: tag.Values,
+		})
+	}
+
+	return &CompleteTagsResult{
+		CompleteNameOnly: results.Type() == index.AggregateTagNames,
+		CompletedTags:    completed,
+	}
+}
+
 func matcherToQuery(matcher *models.Matcher) (idx.Query, error) {
 	negate := false
 	switch matcher.Type {
@@ -26,8 +26,10 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3metrics/policy"
 	xtime "github.com/m3db/m3x/time"
 )
 
@@ -43,10 +45,24 @@ const (
 	TypeMultiDC
 )
 
+func (t Type) String() string {
+	switch t {
+	case TypeLocalDC:
+		return "local"
+	case TypeRemoteDC:
+		return "remote"
+	case TypeMultiDC:
+		return "multi"
+	default:
+		return "unknown"
+	}
+}
+
 // Storage provides an interface for reading and writing to the tsdb
 type Storage interface {
 	Querier
 	Appender
+	Deleter
 	// Type identifies the type of the underlying storage
 	Type() Type
 	// Close is used to close the underlying storage and free up resources
@@ -60,8 +76,9 @@ type Query interface {
 	query()
 }
 
-func (q *FetchQuery) query() {}
-func (q *WriteQuery) query() {}
+func (q *FetchQuery) query()  {}
+func (q *WriteQuery) query()  {}
+func (q *DeleteQuery) query() {}
 
 // FetchQuery represents the input query which is fetched from M3DB
 type FetchQuery struct {
@@ -80,6 +97,11 @@ func (q *FetchQuery) String() string {
 type FetchOptions struct {
 	Limit    int
 	KillChan chan struct{}
+	// StoragePolicy restricts the fetch to the storage policy if set
+	StoragePolicy policy.StoragePolicy
+	// Enforcer enforces the resource limits of the query the fetch is part
+	// of, the fetch is not limited if it is not set
+	Enforcer *limits.Enforcer
 }
 
 // Querier handles queries against a storage.
@@ -91,6 +113,33 @@ type Querier interface {
 		ctx context.Context, query *FetchQuery, options *FetchOptions) (*SearchResults, error)
 	FetchBlocks(
 		ctx context.Context, query *FetchQuery, options *FetchOptions) (block.Result, error)
+	// CompleteTags returns the unique tag names, and optionally tag values, of
+	// the series matching a query
+	CompleteTags(
+		ctx context.Context, query *CompleteTagsQuery, options *FetchOptions) (*CompleteTagsResult, error)
+}
+
+// CompleteTagsQuery represents a query that returns the unique tag names, and
+// optionally tag values, of the series matching the tag matchers
+type CompleteTagsQuery struct {
+	CompleteNameOnly bool
+	FilterNameTags   [][]byte
+	TagMatchers      models.Matchers
+	Start            time.Time
+	End              time.Time
+}
+
+// CompletedTag is a unique tag name along with its unique values
+type CompletedTag struct {
+	Name   []byte
+	Values [][]byte
+}
+
+// CompleteTagsResult is the result from a complete tags query
+type CompleteTagsResult struct {
+	CompleteNameOnly bool
+	CompletedTags    []CompletedTag
+	Warnings         Warnings
 }
 
 // WriteQuery represents the input timeseries that is written to M3DB
@@ -113,9 +162,26 @@ type Appender interface {
 	Write(ctx context.Context, query *WriteQuery) error
 }
 
+// Deleter removes series from storage
+type Deleter interface {
+	// Delete removes all series matching the tag matchers of a query
+	Delete(ctx context.Context, query *DeleteQuery) error
+}
+
+// DeleteQuery represents a query that deletes the series matching the tag matchers
+type DeleteQuery struct {
+	Raw         string
+	TagMatchers models.Matchers
+}
+
+func (q *DeleteQuery) String() string {
+	return q.Raw
+}
+
 // SearchResults is the result from a search
 type SearchResults struct {
-	Metrics models.Metrics
+	Metrics  models.Metrics
+	Warnings Warnings `json:",omitempty"`
 }
 
 // FetchResult provides a fetch result and meta information
@@ -123,8 +189,16 @@ type FetchResult struct {
 	SeriesList ts.SeriesList // The aggregated list of results across all underlying storage calls
 	LocalOnly  bool
 	HasNext    bool
+	Warnings   Warnings // Failures of the storages left out of a partial result
 }
 
+// Warning is a non-fatal error encountered while fulfilling a query, the
+// results returned alongside a warning may be incomplete.
+type Warning = block.Warning
+
+// Warnings is a list of warnings.
+type Warnings = block.Warnings
+
 // QueryResult is the result from a query
 type QueryResult struct {
 	FetchResult *FetchResult
@@ -0,0 +1,106 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+)
+
+// namespaceSeries is a copy of a series fetched from a namespace.
+type namespaceSeries struct {
+	series *ts.Series
+	attrs  storage.Attributes
+}
+
+// timeSpan is the span of time between the first and last datapoint of a
+// series, inclusive.
+type timeSpan struct {
+	start time.Time
+	end   time.Time
+}
+
+func (s timeSpan) contains(t time.Time) bool {
+	return !t.Before(s.start) && !t.After(s.end)
+}
+
+// mergeSeries merges the copies of a series fetched from different
+// namespaces into a single series. Where the copies overlap only the
+// datapoints of the finest resolution copy are kept, coarser copies only
+// contribute the datapoints outside of the spans of the finer copies.
+func mergeSeries(copies []namespaceSeries) (*ts.Series, error) {
+	if len(copies) == 1 {
+		return copies[0].series, nil
+	}
+
+	// The unaggregated namespace has no resolution so sorts first
+	sort.SliceStable(copies, func(i, j int) bool {
+		return copies[i].attrs.Resolution < copies[j].attrs.Resolution
+	})
+
+	var (
+		first  = copies[0].series
+		merged ts.Datapoints
+		spans  = make([]timeSpan, 0, len(copies))
+	)
+	for _, c := range copies {
+		datapoints, ok := c.series.Values().(ts.Datapoints)
+		if !ok {
+			return nil, fmt.Errorf("unable to merge series %s: unexpected values type %T",
+				c.series.Name(), c.series.Values())
+		}
+
+		if len(datapoints) == 0 {
+			continue
+		}
+
+		for _, dp := range datapoints {
+			if !spansContain(spans, dp.Timestamp) {
+				merged = append(merged, dp)
+			}
+		}
+
+		spans = append(spans, timeSpan{
+			start: datapoints[0].Timestamp,
+			end:   datapoints[len(datapoints)-1].Timestamp,
+		})
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+
+	return ts.NewSeries(first.Name(), merged, first.Tags), nil
+}
+
+func spansContain(spans []timeSpan, t time.Time) bool {
+	for _, span := range spans {
+		if span.contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,258 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package local
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3x/ident"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMergeNamespaces struct {
+	unaggregated ClusterNamespace
+	aggregated1m ClusterNamespace
+	aggregated1h ClusterNamespace
+}
+
+func newTestMergeNamespaces(t *testing.T, ctrl *gomock.Controller) testMergeNamespaces {
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   2 * 24 * time.Hour,
+	}, AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_aggregated_1m"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   30 * 24 * time.Hour,
+		Resolution:  time.Minute,
+	}, AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_aggregated_1h"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   365 * 24 * time.Hour,
+		Resolution:  time.Hour,
+	})
+	require.NoError(t, err)
+
+	var namespaces testMergeNamespaces
+	for _, namespace := range clusters.ClusterNamespaces() {
+		switch namespace.Attributes().Resolution {
+		case 0:
+			namespaces.unaggregated = namespace
+		case time.Minute:
+			namespaces.aggregated1m = namespace
+		case time.Hour:
+			namespaces.aggregated1h = namespace
+		}
+	}
+
+	require.NotNil(t, namespaces.unaggregated)
+	require.NotNil(t, namespaces.aggregated1m)
+	require.NotNil(t, namespaces.aggregated1h)
+	return namespaces
+}
+
+var testMergeStart = time.Now().Truncate(time.Hour)
+
+func newTestMergeSeries(
+	name string,
+	namespace ClusterNamespace,
+	values map[time.Duration]float64,
+) namespaceSeries {
+	datapoints := make(ts.Datapoints, 0, len(values))
+	for offset, v := range values {
+		datapoints = append(datapoints, ts.Datapoint{
+			Timestamp: testMergeStart.Add(offset),
+			Value:     v,
+		})
+	}
+
+	// Datapoints are returned in order by storage
+	sort.Slice(datapoints, func(i, j int) bool {
+		return datapoints[i].Timestamp.Before(datapoints[j].Timestamp)
+	})
+	tags := models.Tags{{Name: "name", Value: name}}
+	return namespaceSeries{
+		series: ts.NewSeries(name, datapoints, tags),
+		attrs:  namespace.Attributes(),
+	}
+}
+
+func assertMergedValues(
+	t *testing.T,
+	expected map[time.Duration]float64,
+	series *ts.Series,
+) {
+	datapoints, ok := series.Values().(ts.Datapoints)
+	require.True(t, ok)
+	require.Equal(t, len(expected), len(datapoints))
+
+	for i, dp := range datapoints {
+		if i > 0 {
+			assert.True(t, dp.Timestamp.After(datapoints[i-1].Timestamp))
+		}
+
+		offset := dp.Timestamp.Sub(testMergeStart)
+		value, ok := expected[offset]
+		require.True(t, ok, "unexpected datapoint at %v", offset)
+		assert.Equal(t, value, dp.Value, "datapoint at %v", offset)
+	}
+}
+
+func TestMergeSeriesPrefersFinestResolution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	namespaces := newTestMergeNamespaces(t, ctrl)
+
+	// The aggregated copy is fetched first but the unaggregated datapoints
+	// win where the copies overlap
+	merged, err := mergeSeries([]namespaceSeries{
+		newTestMergeSeries("foo", namespaces.aggregated1m, map[time.Duration]float64{
+			time.Minute:     10,
+			2 * time.Minute: 20,
+			3 * time.Minute: 30,
+		}),
+		newTestMergeSeries("foo", namespaces.unaggregated, map[time.Duration]float64{
+			time.Minute:                    1,
+			time.Minute + 30*time.Second:   1.5,
+			2 * time.Minute:                2,
+			2*time.Minute + 30*time.Second: 2.5,
+		}),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "foo", merged.Name())
+	assert.Equal(t, models.Tags{{Name: "name", Value: "foo"}}, merged.Tags)
+	assertMergedValues(t, map[time.Duration]float64{
+		time.Minute:                    1,
+		time.Minute + 30*time.Second:   1.5,
+		2 * time.Minute:                2,
+		2*time.Minute + 30*time.Second: 2.5,
+		// Falls back to the aggregated datapoints after the unaggregated
+		3 * time.Minute: 30,
+	}, merged)
+}
+
+func TestMergeSeriesFallsBackAcrossResolutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	namespaces := newTestMergeNamespaces(t, ctrl)
+
+	merged, err := mergeSeries([]namespaceSeries{
+		newTestMergeSeries("foo", namespaces.aggregated1h, map[time.Duration]float64{
+			0:             100,
+			time.Hour:     200,
+			2 * time.Hour: 300,
+			3 * time.Hour: 400,
+		}),
+		newTestMergeSeries("foo", namespaces.unaggregated, map[time.Duration]float64{
+			3*time.Hour - 30*time.Second: 1,
+			3 * time.Hour:                2,
+		}),
+		newTestMergeSeries("foo", namespaces.aggregated1m, map[time.Duration]float64{
+			time.Hour + 59*time.Minute: 10,
+			2 * time.Hour:              20,
+			3 * time.Hour:              30,
+		}),
+	})
+	require.NoError(t, err)
+
+	assertMergedValues(t, map[time.Duration]float64{
+		0:                            100,
+		time.Hour:                    200,
+		time.Hour + 59*time.Minute:   10,
+		2 * time.Hour:                20,
+		3*time.Hour - 30*time.Second: 1,
+		3 * time.Hour:                2,
+	}, merged)
+}
+
+func TestMergeSeriesIgnoresEmptyCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	namespaces := newTestMergeNamespaces(t, ctrl)
+
+	merged, err := mergeSeries([]namespaceSeries{
+		newTestMergeSeries("foo", namespaces.unaggregated, nil),
+		newTestMergeSeries("foo", namespaces.aggregated1m, map[time.Duration]float64{
+			time.Minute: 10,
+		}),
+	})
+	require.NoError(t, err)
+
+	assertMergedValues(t, map[time.Duration]float64{
+		time.Minute: 10,
+	}, merged)
+}
+
+func TestMultiFetchResultDedupesSeriesAcrossNamespaces(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	namespaces := newTestMergeNamespaces(t, ctrl)
+
+	newResult := func(series ...namespaceSeries) *storage.FetchResult {
+		result := &storage.FetchResult{LocalOnly: true}
+		for _, s := range series {
+			result.SeriesList = append(result.SeriesList, s.series)
+		}
+		return result
+	}
+
+	result := newMultiFetchResult(2)
+	result.add(1, namespaces.unaggregated.Attributes(), newResult(
+		newTestMergeSeries("foo", namespaces.unaggregated, map[time.Duration]float64{
+			time.Minute: 1,
+		}),
+	), nil)
+	result.add(0, namespaces.aggregated1m.Attributes(), newResult(
+		newTestMergeSeries("foo", namespaces.aggregated1m, map[time.Duration]float64{
+			0:           10,
+			time.Minute: 20,
+		}),
+		newTestMergeSeries("bar", namespaces.aggregated1m, map[time.Duration]float64{
+			0: 30,
+		}),
+	), nil)
+
+	final, err := result.finalResult()
+	require.NoError(t, err)
+	assert.True(t, final.LocalOnly)
+	require.Len(t, final.SeriesList, 2)
+
+	assert.Equal(t, "foo", final.SeriesList[0].Name())
+	assertMergedValues(t, map[time.Duration]float64{
+		0:           10,
+		time.Minute: 1,
+	}, final.SeriesList[0])
+
+	assert.Equal(t, "bar", final.SeriesList[1].Name())
+	assertMergedValues(t, map[time.Duration]float64{
+		0: 30,
+	}, final.SeriesList[1])
+}
@@ -30,9 +30,14 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/policy/resolver"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/tsdb"
 	"github.com/m3db/m3/src/query/util/execution"
+	"github.com/m3db/m3metrics/policy"
 	xerrors "github.com/m3db/m3x/errors"
 	"github.com/m3db/m3x/ident"
 	"github.com/m3db/m3x/pool"
@@ -46,11 +51,39 @@ var (
 type localStorage struct {
 	clusters   Clusters
 	workerPool pool.ObjectPool
+	resolver   resolver.PolicyResolver
+	namespaces map[RetentionResolution]ClusterNamespace
 }
 
 // NewStorage creates a new local Storage instance.
 func NewStorage(clusters Clusters, workerPool pool.ObjectPool) storage.Storage {
-	return &localStorage{clusters: clusters, workerPool: workerPool}
+	var (
+		clusterNamespaces = clusters.ClusterNamespaces()
+		policies          = make(policy.StoragePolicies, 0, len(clusterNamespaces))
+		namespaces        = make(map[RetentionResolution]ClusterNamespace, len(clusterNamespaces))
+	)
+	for _, namespace := range clusterNamespaces {
+		attrs := namespace.Attributes()
+		// The unaggregated namespace has no resolution so it is always
+		// preferred by the resolver where it retains the range
+		p := policy.NewStoragePolicy(attrs.Resolution, xtime.Second, attrs.Retention)
+		policies = append(policies, p)
+		namespaces[retentionResolution(p)] = namespace
+	}
+
+	return &localStorage{
+		clusters:   clusters,
+		workerPool: workerPool,
+		resolver:   resolver.NewResolutionResolver(policies, time.Now),
+		namespaces: namespaces,
+	}
+}
+
+func retentionResolution(p policy.StoragePolicy) RetentionResolution {
+	return RetentionResolution{
+		Retention:  p.Retention().Duration(),
+		Resolution: p.Resolution().Window,
+	}
 }
 
 func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.FetchResult, error) {
@@ -68,25 +101,39 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 		return nil, err
 	}
 
-	// NB(r): Since we don't use a single index we fan out to each
-	// cluster that can completely fulfill this range and then prefer the
-	// highest resolution (most fine grained) results.
-	// This needs to be optimized, however this is a start.
+	// NB: Take the current time before resolving so the ranges are not
+	// clipped by the time that passes while resolving.
+	now := time.Now()
+	ranges, err := s.resolveRanges(ctx, query, options)
+	if err != nil {
+		return nil, err
+	}
+
 	var (
-		opts       = storage.FetchOptionsToM3Options(options, query)
-		namespaces = s.clusters.ClusterNamespaces()
-		now        = time.Now()
-		fetches    = 0
-		result     multiFetchResult
-		wg         sync.WaitGroup
+		opts    = storage.FetchOptionsToM3Options(options, query)
+		fetches = 0
+		result  = newMultiFetchResult(len(ranges))
+		wg      sync.WaitGroup
 	)
-	for _, namespace := range namespaces {
-		namespace := namespace // Capture var
+	for idx, fetchRange := range ranges {
+		idx := idx // Capture var
+
+		namespace, ok := s.namespaces[retentionResolution(fetchRange.StoragePolicy)]
+		if !ok {
+			return nil, fmt.Errorf("no configured cluster namespace for storage policy: %s",
+				fetchRange.StoragePolicy.String())
+		}
 
+		// The oldest range may extend past the retention of the namespace
+		rangeOpts := opts
+		rangeOpts.StartInclusive = fetchRange.Start
+		rangeOpts.EndExclusive = fetchRange.End
 		clusterStart := now.Add(-1 * namespace.Attributes().Retention)
+		if clusterStart.After(rangeOpts.StartInclusive) {
+			rangeOpts.StartInclusive = clusterStart
+		}
 
-		// Only include if cluster can completely fulfill the range
-		if clusterStart.After(query.Start) {
+		if !rangeOpts.StartInclusive.Before(rangeOpts.EndExclusive) {
 			continue
 		}
 
@@ -94,8 +141,8 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 
 		wg.Add(1)
 		go func() {
-			r, err := s.fetch(namespace, m3query, opts)
-			result.add(namespace.Attributes(), r, err)
+			r, err := s.fetch(namespace, m3query, rangeOpts, options.Enforcer)
+			result.add(idx, namespace.Attributes(), r, err)
 			wg.Done()
 		}()
 	}
@@ -105,16 +152,48 @@ func (s *localStorage) Fetch(ctx context.Context, query *storage.FetchQuery, opt
 	}
 
 	wg.Wait()
-	if err := result.err.FinalError(); err != nil {
+	fetchResult, err := result.finalResult()
+	if err != nil {
 		return nil, err
 	}
-	return result.result, nil
+
+	// Series fetched from more than one namespace are only counted once
+	if err := options.Enforcer.AddSeries(len(fetchResult.SeriesList)); err != nil {
+		return nil, err
+	}
+
+	return fetchResult, nil
+}
+
+// resolveRanges resolves the ranges of the query to the storage policies of
+// the namespaces to fetch them from.
+func (s *localStorage) resolveRanges(
+	ctx context.Context,
+	query *storage.FetchQuery,
+	options *storage.FetchOptions,
+) (tsdb.FetchRanges, error) {
+	if options.StoragePolicy != policy.EmptyStoragePolicy {
+		return tsdb.NewSingleRangeRequest("", query.Start, query.End, options.StoragePolicy).Ranges, nil
+	}
+
+	requests, err := s.resolver.Resolve(ctx, query.TagMatchers, query.Start, query.End)
+	if err != nil {
+		return nil, err
+	}
+
+	var ranges tsdb.FetchRanges
+	for _, request := range requests {
+		ranges = append(ranges, request.Ranges...)
+	}
+
+	return ranges, nil
 }
 
 func (s *localStorage) fetch(
 	namespace ClusterNamespace,
 	query index.Query,
 	opts index.QueryOptions,
+	enforcer *limits.Enforcer,
 ) (*storage.FetchResult, error) {
 	namespaceID := namespace.NamespaceID()
 	session := namespace.Session()
@@ -125,7 +204,7 @@ func (s *localStorage) fetch(
 		return nil, err
 	}
 
-	return storage.SeriesIteratorsToFetchResult(iters, namespaceID, s.workerPool)
+	return storage.SeriesIteratorsToFetchResult(iters, namespaceID, s.workerPool, enforcer)
 }
 
 func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
@@ -156,16 +235,23 @@ func (s *localStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 
 		clusterStart := now.Add(-1 * namespace.Attributes().Retention)
 
-		// Only include if cluster can completely fulfill the range
-		if clusterStart.After(query.Start) {
+		// Unlike datapoints, series metadata can be served partially; bound
+		// the query by the cluster retention and skip only if none of the
+		// range is retained
+		if !clusterStart.Before(query.End) {
 			continue
 		}
 
+		namespaceOpts := opts
+		if clusterStart.After(namespaceOpts.StartInclusive) {
+			namespaceOpts.StartInclusive = clusterStart
+		}
+
 		fetches++
 
 		wg.Add(1)
 		go func() {
-			result.add(s.fetchTags(namespace, m3query, opts))
+			result.add(s.fetchTags(namespace, m3query, namespaceOpts))
 			wg.Done()
 		}()
 	}
@@ -214,6 +300,134 @@ func (s *localStorage) fetchTags(
 	}, nil
 }
 
+func (s *localStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	// Check if the query was interrupted.
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-options.KillChan:
+		return nil, errors.ErrQueryInterrupted
+	default:
+	}
+
+	m3query, aggOpts, err := storage.CompleteTagsQueryToM3Query(query, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		namespaces = s.clusters.ClusterNamespaces()
+		now        = time.Now()
+		fetches    = 0
+		result     = multiCompleteTagsResult{
+			builder: storage.NewCompleteTagsResultBuilder(query.CompleteNameOnly),
+		}
+		wg sync.WaitGroup
+	)
+	for _, namespace := range namespaces {
+		namespace := namespace // Capture var
+
+		clusterStart := now.Add(-1 * namespace.Attributes().Retention)
+
+		// Bound the query by the cluster retention in the same way as FetchTags
+		if !clusterStart.Before(query.End) {
+			continue
+		}
+
+		namespaceOpts := aggOpts
+		if clusterStart.After(namespaceOpts.StartInclusive) {
+			namespaceOpts.StartInclusive = clusterStart
+		}
+
+		fetches++
+
+		wg.Add(1)
+		go func() {
+			result.add(s.completeTags(namespace, m3query, namespaceOpts))
+			wg.Done()
+		}()
+	}
+
+	if fetches == 0 {
+		return nil, errNoLocalClustersFulfillsQuery
+	}
+
+	wg.Wait()
+	if err := result.err.FinalError(); err != nil {
+		return nil, err
+	}
+
+	built := result.builder.Build()
+	return &built, nil
+}
+
+func (s *localStorage) completeTags(
+	namespace ClusterNamespace,
+	query index.Query,
+	opts index.AggregateQueryOptions,
+) (*storage.CompleteTagsResult, error) {
+	namespaceID := namespace.NamespaceID()
+	session := namespace.Session()
+
+	// TODO: Handle second return param
+	results, _, err := session.Aggregate(namespaceID, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return storage.FromM3AggregateResults(results), nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	// Check if the query was interrupted.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if query == nil {
+		return errors.ErrNilDeleteQuery
+	}
+
+	m3query, err := storage.FetchQueryToM3Query(&storage.FetchQuery{
+		TagMatchers: query.TagMatchers,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Series are written to the unaggregated namespace and then rolled up
+	// into the aggregated namespaces, so delete from every namespace.
+	var (
+		namespaces = s.clusters.ClusterNamespaces()
+		errLock    sync.Mutex
+		multiErr   xerrors.MultiError
+		wg         sync.WaitGroup
+	)
+	for _, namespace := range namespaces {
+		namespace := namespace // Capture var
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := namespace.Session().DeleteTagged(namespace.NamespaceID(), m3query)
+			if err != nil {
+				errLock.Lock()
+				multiErr = multiErr.Add(err)
+				errLock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	return multiErr.FinalError()
+}
+
 func (s *localStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
 	// Check if the query was interrupted.
 	select {
@@ -329,20 +543,24 @@ func newWriteRequest(writeRequestCommon *writeRequestCommon, timestamp time.Time
 	}
 }
 
+// multiFetchResult collects the results of fetching each range of a query
+// from the namespace it resolved to.
 type multiFetchResult struct {
 	sync.Mutex
-	result           *storage.FetchResult
-	err              xerrors.MultiError
-	dedupeFirstAttrs storage.Attributes
-	dedupeMap        map[string]multiFetchResultSeries
+	results []*storage.FetchResult
+	attrs   []storage.Attributes
+	err     xerrors.MultiError
 }
 
-type multiFetchResultSeries struct {
-	idx   int
-	attrs storage.Attributes
+func newMultiFetchResult(numRanges int) *multiFetchResult {
+	return &multiFetchResult{
+		results: make([]*storage.FetchResult, numRanges),
+		attrs:   make([]storage.Attributes, numRanges),
+	}
 }
 
 func (r *multiFetchResult) add(
+	idx int,
 	attrs storage.Attributes,
 	result *storage.FetchResult,
 	err error,
@@ -355,49 +573,66 @@ func (r *multiFetchResult) add(
 		return
 	}
 
-	if r.result == nil {
-		r.result = result
-		r.dedupeFirstAttrs = attrs
-		return
-	}
-
-	r.result.HasNext = r.result.HasNext && result.HasNext
-	r.result.LocalOnly = r.result.LocalOnly && result.LocalOnly
+	r.results[idx] = result
+	r.attrs[idx] = attrs
+}
 
-	// Need to dedupe
-	if r.dedupeMap == nil {
-		r.dedupeMap = make(map[string]multiFetchResultSeries, len(r.result.SeriesList))
-		for idx, s := range r.result.SeriesList {
-			r.dedupeMap[s.Name()] = multiFetchResultSeries{
-				idx:   idx,
-				attrs: r.dedupeFirstAttrs,
-			}
-		}
+// finalResult merges the results of each range, series fetched from more
+// than one namespace are merged into a single series.
+func (r *multiFetchResult) finalResult() (*storage.FetchResult, error) {
+	if err := r.err.FinalError(); err != nil {
+		return nil, err
 	}
 
-	for _, s := range result.SeriesList {
-		id := s.Name()
-		existing, exists := r.dedupeMap[id]
-		if exists && existing.attrs.Resolution <= attrs.Resolution {
-			// Already exists and resolution of result we are adding is not as precise
+	var (
+		result *storage.FetchResult
+		ids    []string
+		copies = make(map[string][]namespaceSeries)
+	)
+	for idx, rangeResult := range r.results {
+		if rangeResult == nil {
+			// Range was not fetched
 			continue
 		}
 
-		// Does not exist already or more precise, add result
-		var idx int
-		if !exists {
-			idx = len(r.result.SeriesList)
-			r.result.SeriesList = append(r.result.SeriesList, s)
+		if result == nil {
+			result = &storage.FetchResult{
+				HasNext:   rangeResult.HasNext,
+				LocalOnly: rangeResult.LocalOnly,
+			}
 		} else {
-			idx = existing.idx
-			r.result.SeriesList[idx] = s
+			result.HasNext = result.HasNext && rangeResult.HasNext
+			result.LocalOnly = result.LocalOnly && rangeResult.LocalOnly
 		}
 
-		r.dedupeMap[id] = multiFetchResultSeries{
-			idx:   idx,
-			attrs: attrs,
+		for _, s := range rangeResult.SeriesList {
+			id := s.Name()
+			if _, exists := copies[id]; !exists {
+				ids = append(ids, id)
+			}
+
+			copies[id] = append(copies[id], namespaceSeries{
+				series: s,
+				attrs:  r.attrs[idx],
+			})
+		}
+	}
+
+	if result == nil {
+		return nil, nil
+	}
+
+	result.SeriesList = make(ts.SeriesList, 0, len(ids))
+	for _, id := range ids {
+		merged, err := mergeSeries(copies[id])
+		if err != nil {
+			return nil, err
 		}
+
+		result.SeriesList = append(result.SeriesList, merged)
 	}
+
+	return result, nil
 }
 
 type multiFetchTagsResult struct {
@@ -445,3 +680,26 @@ func (r *multiFetchTagsResult) add(
 		r.dedupeMap[id] = struct{}{}
 	}
 }
+
+type multiCompleteTagsResult struct {
+	sync.Mutex
+	builder *storage.CompleteTagsResultBuilder
+	err     xerrors.MultiError
+}
+
+func (r *multiCompleteTagsResult) add(
+	result *storage.CompleteTagsResult,
+	err error,
+) {
+	r.Lock()
+	defer r.Unlock()
+
+	if err != nil {
+		r.err = r.err.Add(err)
+		return
+	}
+
+	if err := r.builder.Add(result); err != nil {
+		r.err = r.err.Add(err)
+	}
+}
@@ -29,11 +29,17 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	m3ts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test/seriesiter"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3metrics/policy"
 	"github.com/m3db/m3x/ident"
 	xtime "github.com/m3db/m3x/time"
 
@@ -207,11 +213,15 @@ func TestLocalRead(t *testing.T) {
 	defer ctrl.Finish()
 	store, sessions := setup(t, ctrl)
 	testTags := seriesiter.GenerateTag()
-	sessions.forEach(func(session *client.MockSession) {
-		session.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
-			Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
-	})
 	searchReq := newFetchReq()
+	// Both namespaces retain the range so only the finer resolution
+	// unaggregated namespace is read from
+	sessions.unaggregated1MonthRetention.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+			assert.Equal(t, searchReq.Start, opts.StartInclusive)
+			assert.Equal(t, searchReq.End, opts.EndExclusive)
+		}).
+		Return(seriesiter.NewMockSeriesIters(ctrl, testTags, 1, 2), true, nil)
 	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
 	assert.NoError(t, err)
 	tags := make(map[string]string, 1)
@@ -223,18 +233,203 @@ func TestLocalRead(t *testing.T) {
 	assert.Equal(t, models.FromMap(tags), results.SeriesList[0].Tags)
 }
 
+func TestLocalReadBoundsStartByRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	searchReq := newFetchReq()
+	searchReq.Start = time.Now().Add(-2 * testRetention)
+	sessions.unaggregated1MonthRetention.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+			assert.True(t, opts.StartInclusive.After(searchReq.Start))
+			assert.Equal(t, searchReq.End, opts.EndExclusive)
+		}).
+		Return(seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 1, 2), true, nil)
+
+	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, results.SeriesList, 1)
+}
+
 func TestLocalReadNoClustersForTimeRangeError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	store, _ := setup(t, ctrl)
 	searchReq := newFetchReq()
-	searchReq.Start = time.Now().Add(-2 * testRetention)
-	searchReq.End = time.Now()
+	searchReq.Start = time.Now().Add(-3 * testRetention)
+	searchReq.End = time.Now().Add(-2 * testRetention)
 	_, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
 	require.Error(t, err)
 	assert.Equal(t, errNoLocalClustersFulfillsQuery, err)
 }
 
+func TestLocalReadStoragePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.aggregated1MonthRetention1MinuteResolution.EXPECT().
+		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 1, 2), true, nil)
+
+	results, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{
+		Limit:         100,
+		StoragePolicy: policy.NewStoragePolicy(time.Minute, xtime.Second, testRetention),
+	})
+	require.NoError(t, err)
+	assert.Len(t, results.SeriesList, 1)
+}
+
+func TestLocalReadStoragePolicyNotConfiguredError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+	_, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{
+		Limit:         100,
+		StoragePolicy: policy.NewStoragePolicy(time.Hour, xtime.Second, testRetention),
+	})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "no configured cluster namespace"),
+		fmt.Sprintf("unexpected error string: %v", err.Error()))
+}
+
+func TestLocalReadStitchesRetentionRanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	logging.InitWithCores(nil)
+
+	var (
+		unaggregated1DayRetention                  = client.NewMockSession(ctrl)
+		aggregated1MonthRetention1MinuteResolution = client.NewMockSession(ctrl)
+		day                                        = 24 * time.Hour
+	)
+	clusters, err := NewClusters(UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_unaggregated"),
+		Session:     unaggregated1DayRetention,
+		Retention:   day,
+	}, AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_aggregated"),
+		Session:     aggregated1MonthRetention1MinuteResolution,
+		Retention:   testRetention,
+		Resolution:  time.Minute,
+	})
+	require.NoError(t, err)
+	store := NewStorage(clusters, nil)
+
+	// Neither namespace retains the whole range
+	searchReq := newFetchReq()
+	searchReq.Start = time.Now().Add(-2 * testRetention)
+
+	var (
+		now    = time.Now().Truncate(time.Minute)
+		fine   = []m3ts.Datapoint{{Timestamp: now, Value: 10}, {Timestamp: now.Add(10 * time.Second), Value: 11}}
+		coarse = []m3ts.Datapoint{
+			{Timestamp: now.Add(-3 * time.Minute), Value: 1},
+			{Timestamp: now.Add(-2 * time.Minute), Value: 2},
+			{Timestamp: now.Add(-time.Minute), Value: 3},
+			{Timestamp: now, Value: 4},
+		}
+	)
+
+	var unaggregatedOpts, aggregatedOpts index.QueryOptions
+	unaggregated1DayRetention.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+			unaggregatedOpts = opts
+		}).
+		Return(newMockSeriesIters(ctrl, fine), true, nil)
+	aggregated1MonthRetention1MinuteResolution.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+			aggregatedOpts = opts
+		}).
+		Return(newMockSeriesIters(ctrl, coarse), true, nil)
+
+	results, err := store.Fetch(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	// The copies of the series read from each namespace are merged, the
+	// coarse datapoint overlapping the fine copy is dropped
+	require.Len(t, results.SeriesList, 1)
+	merged := results.SeriesList[0]
+	require.Equal(t, 5, merged.Len())
+	for i, expected := range []float64{1, 2, 3, 10, 11} {
+		assert.Equal(t, expected, merged.Values().ValueAt(i))
+	}
+
+	// The most recent day is read from the unaggregated namespace and the
+	// rest of the retained range from the aggregated namespace
+	assert.Equal(t, searchReq.End, unaggregatedOpts.EndExclusive)
+	assert.WithinDuration(t, searchReq.End.Add(-day), unaggregatedOpts.StartInclusive, time.Minute)
+	assert.Equal(t, unaggregatedOpts.StartInclusive, aggregatedOpts.EndExclusive)
+	assert.WithinDuration(t, searchReq.End.Add(-testRetention), aggregatedOpts.StartInclusive, time.Minute)
+}
+
+// newMockSeriesIters returns a single mock series iterator with the given
+// datapoints.
+func newMockSeriesIters(ctrl *gomock.Controller, datapoints []m3ts.Datapoint) encoding.SeriesIterators {
+	tags := seriesiter.GenerateSingleSampleTagIterator(ctrl, seriesiter.GenerateTag())
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	for _, dp := range datapoints {
+		iter.EXPECT().Next().Return(true)
+		iter.EXPECT().Current().Return(dp, xtime.Second, nil)
+	}
+	iter.EXPECT().Next().Return(false)
+	iter.EXPECT().ID().Return(ident.StringID("foo"))
+	iter.EXPECT().Tags().Return(tags)
+	iter.EXPECT().Close().Do(func() {
+		tags.Close()
+	})
+
+	iters := encoding.NewMockSeriesIterators(ctrl)
+	iters.EXPECT().Iters().Return([]encoding.SeriesIterator{iter})
+	iters.EXPECT().Len().Return(1)
+	iters.EXPECT().Close().Do(func() {
+		iter.Close()
+	})
+
+	return iters
+}
+
+func TestLocalReadSeriesLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.unaggregated1MonthRetention.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+			// One more series than the limit is fetched to detect exceeding it
+			assert.Equal(t, 2, opts.Limit)
+		}).
+		Return(seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 1, 2), true, nil)
+
+	// The series of every fetch of a query count towards its limit
+	enforcer := limits.NewEnforcer(limits.Limits{MaxSeries: 1}, nil)
+	require.NoError(t, enforcer.AddSeries(1))
+
+	_, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{Enforcer: enforcer})
+	require.Error(t, err)
+	assert.True(t, limits.IsLimitExceeded(err))
+}
+
+func TestLocalReadDatapointsLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+	sessions.unaggregated1MonthRetention.EXPECT().FetchTagged(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(seriesiter.NewMockSeriesIters(ctrl, seriesiter.GenerateTag(), 1, 3), true, nil)
+
+	_, err := store.Fetch(context.TODO(), newFetchReq(), &storage.FetchOptions{
+		Enforcer: limits.NewEnforcer(limits.Limits{MaxDatapoints: 2}, nil),
+	})
+	require.Error(t, err)
+	assert.True(t, limits.IsLimitExceeded(err))
+}
+
+func TestMultiFetchResultError(t *testing.T) {
+	result := newMultiFetchResult(2)
+	result.add(0, storage.Attributes{}, &storage.FetchResult{}, nil)
+	result.add(1, storage.Attributes{}, nil, fmt.Errorf("an error"))
+
+	_, err := result.finalResult()
+	assert.Error(t, err)
+}
+
 func TestLocalSearchError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -333,3 +528,158 @@ func TestLocalSearchSuccess(t *testing.T) {
 		}}, actual.Tags)
 	}
 }
+
+func TestLocalSearchBoundsStartByRetention(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	searchReq := newFetchReq()
+	searchReq.Start = time.Now().Add(-2 * testRetention)
+	sessions.forEach(func(session *client.MockSession) {
+		iter := client.NewMockTaggedIDsIterator(ctrl)
+		gomock.InOrder(
+			iter.EXPECT().Next().Return(false),
+			iter.EXPECT().Err().Return(nil),
+			iter.EXPECT().Finalize(),
+		)
+
+		session.EXPECT().FetchTaggedIDs(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ ident.ID, _ index.Query, opts index.QueryOptions) {
+				assert.True(t, opts.StartInclusive.After(searchReq.Start))
+				assert.Equal(t, searchReq.End, opts.EndExclusive)
+			}).
+			Return(iter, true, nil)
+	})
+
+	result, err := store.FetchTags(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+	assert.Len(t, result.Metrics, 0)
+}
+
+func TestLocalSearchNoClustersForTimeRangeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+	searchReq := newFetchReq()
+	searchReq.Start = time.Now().Add(-3 * testRetention)
+	searchReq.End = time.Now().Add(-2 * testRetention)
+	_, err := store.FetchTags(context.TODO(), searchReq, &storage.FetchOptions{Limit: 100})
+	require.Error(t, err)
+	assert.Equal(t, errNoLocalClustersFulfillsQuery, err)
+}
+
+func newCompleteTagsReq() *storage.CompleteTagsQuery {
+	fetchReq := newFetchReq()
+	return &storage.CompleteTagsQuery{
+		FilterNameTags: [][]byte{[]byte("qux")},
+		TagMatchers:    fetchReq.TagMatchers,
+		Start:          fetchReq.Start,
+		End:            fetchReq.End,
+	}
+}
+
+func TestLocalCompleteTagsSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	values := map[*client.MockSession][]string{
+		sessions.unaggregated1MonthRetention:                {"qaz", "qar"},
+		sessions.aggregated1MonthRetention1MinuteResolution: {"qaz", "qel"},
+	}
+
+	req := newCompleteTagsReq()
+	sessions.forEach(func(session *client.MockSession) {
+		results := index.NewAggregateResults(ident.StringID("ns"), index.AggregateTagNamesAndValues)
+		for _, v := range values[session] {
+			results.Add([]byte("qux"), []byte(v))
+		}
+
+		session.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).
+			Do(func(_ ident.ID, _ index.Query, opts index.AggregateQueryOptions) {
+				assert.Equal(t, index.AggregateTagNamesAndValues, opts.Type)
+				assert.Equal(t, req.FilterNameTags, opts.TagNameFilter)
+				assert.Equal(t, 100, opts.Limit)
+			}).
+			Return(results, true, nil)
+	})
+
+	result, err := store.CompleteTags(context.TODO(), req, &storage.FetchOptions{Limit: 100})
+	require.NoError(t, err)
+
+	expected := &storage.CompleteTagsResult{
+		CompletedTags: []storage.CompletedTag{{
+			Name:   []byte("qux"),
+			Values: [][]byte{[]byte("qar"), []byte("qaz"), []byte("qel")},
+		}},
+	}
+	assert.Equal(t, expected, result)
+}
+
+func TestLocalCompleteTagsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().Aggregate(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, false, fmt.Errorf("an error"))
+	})
+
+	_, err := store.CompleteTags(context.TODO(), newCompleteTagsReq(), &storage.FetchOptions{})
+	assert.Error(t, err)
+}
+
+func TestLocalCompleteTagsNoClustersForTimeRangeError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+	req := newCompleteTagsReq()
+	req.Start = time.Now().Add(-3 * testRetention)
+	req.End = time.Now().Add(-2 * testRetention)
+	_, err := store.CompleteTags(context.TODO(), req, &storage.FetchOptions{})
+	require.Error(t, err)
+	assert.Equal(t, errNoLocalClustersFulfillsQuery, err)
+}
+
+func newDeleteQuery() *storage.DeleteQuery {
+	return &storage.DeleteQuery{TagMatchers: newFetchReq().TagMatchers}
+}
+
+func TestLocalDeleteSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	expected, err := storage.FetchQueryToM3Query(newFetchReq())
+	require.NoError(t, err)
+
+	sessions.forEach(func(session *client.MockSession) {
+		session.EXPECT().DeleteTagged(gomock.Any(), index.NewQueryMatcher(expected)).
+			Return(int64(1), nil)
+	})
+
+	require.NoError(t, store.Delete(context.TODO(), newDeleteQuery()))
+}
+
+func TestLocalDeleteError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, sessions := setup(t, ctrl)
+
+	sessions.unaggregated1MonthRetention.EXPECT().
+		DeleteTagged(gomock.Any(), gomock.Any()).Return(int64(1), nil)
+	sessions.aggregated1MonthRetention1MinuteResolution.EXPECT().
+		DeleteTagged(gomock.Any(), gomock.Any()).Return(int64(0), fmt.Errorf("an error"))
+
+	assert.Error(t, store.Delete(context.TODO(), newDeleteQuery()))
+}
+
+func TestLocalDeleteNilQuery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store, _ := setup(t, ctrl)
+
+	assert.Equal(t, errors.ErrNilDeleteQuery, store.Delete(context.TODO(), nil))
+}
@@ -43,7 +43,15 @@ func (s *remoteStorage) Fetch(ctx context.Context, query *storage.FetchQuery, op
 }
 
 func (s *remoteStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
-	// todo (braskin): implement remote FetchTags
+	return s.client.FetchTags(ctx, query, options)
+}
+
+func (s *remoteStorage) CompleteTags(
+	ctx context.Context,
+	query *storage.CompleteTagsQuery,
+	options *storage.FetchOptions,
+) (*storage.CompleteTagsResult, error) {
+	// todo: implement remote CompleteTags
 	return nil, errors.ErrNotImplemented
 }
 
@@ -51,6 +59,11 @@ func (s *remoteStorage) Write(ctx context.Context, query *storage.WriteQuery) er
 	return s.client.Write(ctx, query)
 }
 
+func (s *remoteStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	// todo: implement remote Delete
+	return errors.ErrNotImplemented
+}
+
 func (s *remoteStorage) Type() storage.Type {
 	return storage.TypeRemoteDC
 }
@@ -61,5 +74,5 @@ func (s *remoteStorage) Close() error {
 
 func (s *remoteStorage) FetchBlocks(
 	ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (block.Result, error) {
-	return block.Result{}, errors.ErrNotImplemented
+	return s.client.FetchBlocks(ctx, query, options)
 }
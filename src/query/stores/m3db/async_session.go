@@ -158,6 +158,41 @@ func (s *AsyncSession) FetchTaggedIDs(namespace ident.ID, q index.Query, opts in
 	return s.session.FetchTaggedIDs(namespace, q, opts)
 }
 
+// Aggregate resolves the provided query to the unique tag names, and optionally
+// tag values, of the matching series.
+func (s *AsyncSession) Aggregate(namespace ident.ID, q index.Query, opts index.AggregateQueryOptions) (index.AggregateResults, bool, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return nil, false, s.err
+	}
+
+	return s.session.Aggregate(namespace, q, opts)
+}
+
+// Delete removes the series with the given IDs from all replicas.
+func (s *AsyncSession) Delete(namespace ident.ID, ids ident.Iterator) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.Delete(namespace, ids)
+}
+
+// DeleteTagged resolves the provided query to known IDs and removes those
+// series from all replicas.
+func (s *AsyncSession) DeleteTagged(namespace ident.ID, q index.Query) (int64, error) {
+	s.RLock()
+	defer s.RUnlock()
+	if s.err != nil {
+		return 0, s.err
+	}
+
+	return s.session.DeleteTagged(namespace, q)
+}
+
 // ShardID returns the given shard for an ID for callers
 // to easily discern what shard is failing when operations
 // for given IDs begin failing
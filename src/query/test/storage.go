@@ -49,11 +49,21 @@ func (s *slowStorage) FetchTags(ctx context.Context, query *storage.FetchQuery,
 	return s.storage.FetchTags(ctx, query, options)
 }
 
+func (s *slowStorage) CompleteTags(ctx context.Context, query *storage.CompleteTagsQuery, options *storage.FetchOptions) (*storage.CompleteTagsResult, error) {
+	time.Sleep(s.delay)
+	return s.storage.CompleteTags(ctx, query, options)
+}
+
 func (s *slowStorage) Write(ctx context.Context, query *storage.WriteQuery) error {
 	time.Sleep(s.delay)
 	return s.storage.Write(ctx, query)
 }
 
+func (s *slowStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	time.Sleep(s.delay)
+	return s.storage.Delete(ctx, query)
+}
+
 func (s *slowStorage) Type() storage.Type {
 	return storage.TypeMultiDC
 }
@@ -26,6 +26,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3x/ident"
 )
 
@@ -55,17 +56,29 @@ func (b blockReplicas) Less(i, j int) bool {
 }
 
 // ConvertM3DBSeriesIterators converts m3db SeriesIterators to SeriesBlocks
-// which are used to construct Blocks for query processing.
-func ConvertM3DBSeriesIterators(iterators encoding.SeriesIterators, iterAlloc encoding.ReaderIteratorAllocate) ([]SeriesBlocks, error) {
+// which are used to construct Blocks for query processing, the series and
+// blocks are accounted for by the enforcer of the query.
+func ConvertM3DBSeriesIterators(
+	iterators encoding.SeriesIterators,
+	iterAlloc encoding.ReaderIteratorAllocate,
+	enforcer *limits.Enforcer,
+) ([]SeriesBlocks, error) {
 	defer iterators.Close()
-	multiSeriesBlocks := make([]SeriesBlocks, iterators.Len())
+	if err := enforcer.AddSeries(iterators.Len()); err != nil {
+		return nil, err
+	}
 
+	multiSeriesBlocks := make([]SeriesBlocks, iterators.Len())
 	for i, seriesIterator := range iterators.Iters() {
 		blockReplicas, err := blockReplicasFromSeriesIterator(seriesIterator, iterAlloc)
 		if err != nil {
 			return nil, err
 		}
 
+		if err := enforcer.AddBlocks(len(blockReplicas)); err != nil {
+			return nil, err
+		}
+
 		series, err := seriesBlocksFromBlockReplicas(blockReplicas, seriesIterator)
 		if err != nil {
 			return nil, err
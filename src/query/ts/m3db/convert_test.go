@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/encoding"
 	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/query/limits"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/test"
@@ -56,7 +57,7 @@ func TestConversion(t *testing.T) {
 	require.NoError(t, err)
 	iterators := encoding.NewSeriesIterators([]encoding.SeriesIterator{iter}, nil)
 
-	blocks, err := ConvertM3DBSeriesIterators(iterators, testIterAlloc)
+	blocks, err := ConvertM3DBSeriesIterators(iterators, testIterAlloc, nil)
 	require.NoError(t, err)
 
 	for _, block := range blocks {
@@ -90,6 +91,18 @@ func TestConversion(t *testing.T) {
 	}
 }
 
+func TestConversionBlocksLimit(t *testing.T) {
+	iter, err := test.BuildTestSeriesIterator()
+	require.NoError(t, err)
+	iterators := encoding.NewSeriesIterators([]encoding.SeriesIterator{iter}, nil)
+
+	// The series has two blocks
+	enforcer := limits.NewEnforcer(limits.Limits{MaxSeries: 1, MaxBlocks: 1}, nil)
+	_, err = ConvertM3DBSeriesIterators(iterators, testIterAlloc, enforcer)
+	require.Error(t, err)
+	assert.True(t, limits.IsLimitExceeded(err))
+}
+
 func checkTags(t *testing.T, tags ident.TagIterator) {
 	convertedTags, err := storage.FromIdentTagIteratorToTags(tags)
 	require.NoError(t, err)
@@ -85,7 +85,7 @@ func (s *localStorage) fetchBlocks(
 		return emptySeriesMap, err
 	}
 
-	seriesBlockList, err := m3block.ConvertM3DBSeriesIterators(seriesIters, iterAlloc)
+	seriesBlockList, err := m3block.ConvertM3DBSeriesIterators(seriesIters, iterAlloc, options.Enforcer)
 	if err != nil {
 		return emptySeriesMap, err
 	}
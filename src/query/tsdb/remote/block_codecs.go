@@ -0,0 +1,147 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	rpc "github.com/m3db/m3/src/query/generated/proto/rpcpb"
+	xtime "github.com/m3db/m3x/time"
+)
+
+// EncodeBlock encodes a block to an rpc block, the values of the block are
+// encoded step by step in the order of the block series metadata.
+func EncodeBlock(b block.Block) (*rpc.Block, error) {
+	iter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	defer iter.Close()
+	var (
+		meta       = iter.Meta()
+		seriesMeta = iter.SeriesMeta()
+		steps      = make([]*rpc.Step, 0, iter.StepCount())
+	)
+	// NB: blocks without any series have no values to step through
+	if len(seriesMeta) == 0 {
+		for i := 0; i < iter.StepCount(); i++ {
+			steps = append(steps, &rpc.Step{})
+		}
+	} else {
+		for iter.Next() {
+			step, err := iter.Current()
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, &rpc.Step{Values: step.Values()})
+		}
+	}
+
+	return &rpc.Block{
+		Meta: &rpc.BlockMetadata{
+			Start:    xtime.ToNanoseconds(meta.Bounds.Start),
+			Duration: int64(meta.Bounds.Duration),
+			StepSize: int64(meta.Bounds.StepSize),
+			Tags:     encodeTags(meta.Tags),
+		},
+		SeriesMeta: encodeSeriesMeta(seriesMeta),
+		Steps:      steps,
+	}, nil
+}
+
+func encodeSeriesMeta(seriesMeta []block.SeriesMeta) []*rpc.SeriesMetadata {
+	encoded := make([]*rpc.SeriesMetadata, len(seriesMeta))
+	for i, meta := range seriesMeta {
+		encoded[i] = &rpc.SeriesMetadata{
+			Name: []byte(meta.Name),
+			Tags: encodeTags(meta.Tags),
+		}
+	}
+	return encoded
+}
+
+// EncodeFetchBlocksResult encodes a block result to an rpc result
+func EncodeFetchBlocksResult(result block.Result) (*rpc.FetchBlocksResult, error) {
+	blocks := make([]*rpc.Block, len(result.Blocks))
+	for i, b := range result.Blocks {
+		encoded, err := EncodeBlock(b)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = encoded
+	}
+	return &rpc.FetchBlocksResult{Blocks: blocks}, nil
+}
+
+// DecodeBlock decodes an rpc block to a column block
+func DecodeBlock(b *rpc.Block) (block.Block, error) {
+	rpcMeta := b.GetMeta()
+	meta := block.Metadata{
+		Bounds: block.Bounds{
+			Start:    xtime.FromNanoseconds(rpcMeta.GetStart()),
+			Duration: time.Duration(rpcMeta.GetDuration()),
+			StepSize: time.Duration(rpcMeta.GetStepSize()),
+		},
+		Tags: decodeTags(rpcMeta.GetTags()),
+	}
+
+	builder := block.NewColumnBlockBuilder(meta, decodeSeriesMeta(b.GetSeriesMeta()))
+	steps := b.GetSteps()
+	if err := builder.AddCols(len(steps)); err != nil {
+		return nil, err
+	}
+	for i, step := range steps {
+		if err := builder.AppendValues(i, step.GetValues()); err != nil {
+			return nil, err
+		}
+	}
+	return builder.Build(), nil
+}
+
+func decodeSeriesMeta(seriesMeta []*rpc.SeriesMetadata) []block.SeriesMeta {
+	decoded := make([]block.SeriesMeta, len(seriesMeta))
+	for i, meta := range seriesMeta {
+		decoded[i] = block.SeriesMeta{
+			Name: string(meta.GetName()),
+			Tags: decodeTags(meta.GetTags()),
+		}
+	}
+	return decoded
+}
+
+// DecodeFetchBlocksResult decodes an rpc result to a block result
+func DecodeFetchBlocksResult(result *rpc.FetchBlocksResult) (block.Result, error) {
+	blocks := make([]block.Block, len(result.GetBlocks()))
+	for i, b := range result.GetBlocks() {
+		decoded, err := DecodeBlock(b)
+		if err != nil {
+			for _, decoded := range blocks[:i] {
+				decoded.Close()
+			}
+
+			return block.Result{}, err
+		}
+		blocks[i] = decoded
+	}
+	return block.Result{Blocks: blocks}, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2018 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestBlock() block.Block {
+	meta := block.Metadata{
+		Bounds: block.Bounds{
+			Start:    time.Unix(1535000000, 123),
+			Duration: 4 * time.Minute,
+			StepSize: time.Minute,
+		},
+		Tags: models.Tags{{Name: "common", Value: "tag"}},
+	}
+	return test.NewBlockFromValuesWithMetaAndSeriesMeta(meta,
+		test.NewSeriesMeta("foo", 2), [][]float64{
+			{1, 2, math.NaN(), 4},
+			{5, 6, 7, 8},
+		})
+}
+
+func assertBlocksEqual(t *testing.T, expected, actual block.Block) {
+	expectedIter, err := expected.StepIter()
+	require.NoError(t, err)
+	actualIter, err := actual.StepIter()
+	require.NoError(t, err)
+
+	assert.True(t, expectedIter.Meta().Bounds.Equals(actualIter.Meta().Bounds))
+	assert.Equal(t, expectedIter.Meta().Tags, actualIter.Meta().Tags)
+	assert.Equal(t, expectedIter.SeriesMeta(), actualIter.SeriesMeta())
+	require.Equal(t, expectedIter.StepCount(), actualIter.StepCount())
+	for expectedIter.Next() {
+		require.True(t, actualIter.Next())
+		expectedStep, err := expectedIter.Current()
+		require.NoError(t, err)
+		actualStep, err := actualIter.Current()
+		require.NoError(t, err)
+		assert.True(t, expectedStep.Time().Equal(actualStep.Time()))
+		require.Equal(t, len(expectedStep.Values()), len(actualStep.Values()))
+		for i, v := range expectedStep.Values() {
+			if math.IsNaN(v) {
+				assert.True(t, math.IsNaN(actualStep.Values()[i]))
+				continue
+			}
+			assert.Equal(t, v, actualStep.Values()[i])
+		}
+	}
+	assert.False(t, actualIter.Next())
+}
+
+func TestEncodeDecodeBlock(t *testing.T) {
+	b := createTestBlock()
+	encoded, err := EncodeBlock(b)
+	require.NoError(t, err)
+	require.Len(t, encoded.GetSteps(), 4)
+	require.Len(t, encoded.GetSeriesMeta(), 2)
+
+	decoded, err := DecodeBlock(encoded)
+	require.NoError(t, err)
+	assertBlocksEqual(t, b, decoded)
+}
+
+func TestEncodeDecodeFetchBlocksResult(t *testing.T) {
+	result := block.Result{Blocks: []block.Block{createTestBlock(), createTestBlock()}}
+	encoded, err := EncodeFetchBlocksResult(result)
+	require.NoError(t, err)
+	require.Len(t, encoded.GetBlocks(), 2)
+
+	// Round trip through the wire format
+	bytes, err := encoded.Marshal()
+	require.NoError(t, err)
+	encoded.Reset()
+	require.NoError(t, encoded.Unmarshal(bytes))
+
+	decoded, err := DecodeFetchBlocksResult(encoded)
+	require.NoError(t, err)
+	require.Len(t, decoded.Blocks, 2)
+	for i, b := range decoded.Blocks {
+		assertBlocksEqual(t, result.Blocks[i], b)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/errors"
 	rpc "github.com/m3db/m3/src/query/generated/proto/rpcpb"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -105,8 +106,39 @@ func (c *grpcClient) Fetch(ctx context.Context, query *storage.FetchQuery, optio
 	return &storage.FetchResult{LocalOnly: false, SeriesList: tsSeries}, nil
 }
 
+// FetchTags reads the series matching a query from remote client storage
 func (c *grpcClient) FetchTags(ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (*storage.SearchResults, error) {
-	return nil, nil
+	id := logging.ReadContextID(ctx)
+	searchClient, err := c.client.Search(ctx, EncodeFetchMessage(query, id))
+	if err != nil {
+		return nil, err
+	}
+
+	defer searchClient.CloseSend()
+
+	metrics := make(models.Metrics, 0)
+	for {
+		select {
+		// If query is killed during gRPC streaming, close the channel
+		case <-options.KillChan:
+			return nil, errors.ErrQueryInterrupted
+		default:
+		}
+		result, err := searchClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, DecodeSearchResults(result).Metrics...)
+	}
+
+	return &storage.SearchResults{Metrics: metrics}, nil
+}
+
+func (c *grpcClient) CompleteTags(ctx context.Context, query *storage.CompleteTagsQuery, options *storage.FetchOptions) (*storage.CompleteTagsResult, error) {
+	return nil, errors.ErrNotImplemented
 }
 
 // Write writes to remote client storage
@@ -133,9 +165,50 @@ func (c *grpcClient) Write(ctx context.Context, query *storage.WriteQuery) error
 	return err
 }
 
+// FetchBlocks reads blocks from remote client storage
 func (c *grpcClient) FetchBlocks(
 	ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (block.Result, error) {
-	return block.Result{}, errors.ErrNotImplemented
+	id := logging.ReadContextID(ctx)
+	fetchClient, err := c.client.FetchBlocks(ctx, EncodeFetchMessage(query, id))
+	if err != nil {
+		return block.Result{}, err
+	}
+
+	defer fetchClient.CloseSend()
+
+	blocks := make([]block.Block, 0)
+	// The blocks received before an error are closed as they are not returned
+	closeBlocks := func() {
+		for _, b := range blocks {
+			b.Close()
+		}
+	}
+
+	for {
+		select {
+		// If query is killed during gRPC streaming, close the channel
+		case <-options.KillChan:
+			closeBlocks()
+			return block.Result{}, errors.ErrQueryInterrupted
+		default:
+		}
+		result, err := fetchClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			closeBlocks()
+			return block.Result{}, err
+		}
+		decoded, err := DecodeFetchBlocksResult(result)
+		if err != nil {
+			closeBlocks()
+			return block.Result{}, err
+		}
+		blocks = append(blocks, decoded.Blocks...)
+	}
+
+	return block.Result{Blocks: blocks}, nil
 }
 
 // Close closes the underlying connection
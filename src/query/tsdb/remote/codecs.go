@@ -152,6 +152,32 @@ func decodeRawTs(r *rpc.Series) ts.Datapoints {
 	return datapoints
 }
 
+// EncodeSearchResults encodes search results to rpc results
+func EncodeSearchResults(results *storage.SearchResults) *rpc.SearchResults {
+	metrics := make([]*rpc.Metric, len(results.Metrics))
+	for i, metric := range results.Metrics {
+		metrics[i] = &rpc.Metric{
+			Namespace: []byte(metric.Namespace),
+			Id:        []byte(metric.ID),
+			Tags:      encodeTags(metric.Tags),
+		}
+	}
+	return &rpc.SearchResults{Metrics: metrics}
+}
+
+// DecodeSearchResults decodes search results from a GRPC-compatible type.
+func DecodeSearchResults(results *rpc.SearchResults) *storage.SearchResults {
+	metrics := make(models.Metrics, len(results.GetMetrics()))
+	for i, metric := range results.GetMetrics() {
+		metrics[i] = &models.Metric{
+			Namespace: string(metric.GetNamespace()),
+			ID:        string(metric.GetId()),
+			Tags:      decodeTags(metric.GetTags()),
+		}
+	}
+	return &storage.SearchResults{Metrics: metrics}
+}
+
 // EncodeFetchMessage encodes fetch query and fetch options into rpc WriteMessage
 func EncodeFetchMessage(query *storage.FetchQuery, queryID string) *rpc.FetchMessage {
 	return &rpc.FetchMessage{
@@ -166,6 +192,7 @@ func encodeFetchQuery(query *storage.FetchQuery) *rpc.FetchQuery {
 		Start:       fromTime(query.Start),
 		End:         fromTime(query.End),
 		TagMatchers: encodeTagMatchers(query.TagMatchers),
+		Interval:    int64(query.Interval),
 	}
 }
 
@@ -207,6 +234,7 @@ func decodeFetchQuery(query *rpc.FetchQuery) (*storage.FetchQuery, error) {
 		TagMatchers: tags,
 		Start:       toTime(query.Start),
 		End:         toTime(query.End),
+		Interval:    time.Duration(query.Interval),
 	}, nil
 }
 
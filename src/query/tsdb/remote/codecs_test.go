@@ -149,6 +149,7 @@ func TestDecodeFetchResult(t *testing.T) {
 func readQueriesAreEqual(t *testing.T, this, other *storage.FetchQuery) {
 	assert.True(t, this.Start.Equal(other.Start))
 	assert.True(t, this.End.Equal(other.End))
+	assert.Equal(t, this.Interval, other.Interval)
 	assert.Equal(t, len(this.TagMatchers), len(other.TagMatchers))
 	assert.Equal(t, 2, len(other.TagMatchers))
 	for i, matcher := range this.TagMatchers {
@@ -170,6 +171,7 @@ func createStorageFetchQuery(t *testing.T) (*storage.FetchQuery, time.Time, time
 		TagMatchers: matchers,
 		Start:       start,
 		End:         end,
+		Interval:    10 * time.Second,
 	}, start, end
 }
 
@@ -180,6 +182,7 @@ func TestEncodeFetchMessage(t *testing.T) {
 	require.NotNil(t, grpcQ)
 	assert.Equal(t, fromTime(start), grpcQ.GetQuery().GetStart())
 	assert.Equal(t, fromTime(end), grpcQ.GetQuery().GetEnd())
+	assert.Equal(t, int64(10*time.Second), grpcQ.GetQuery().GetInterval())
 	mRPC := grpcQ.GetQuery().GetTagMatchers()
 	assert.Equal(t, 2, len(mRPC))
 	assert.Equal(t, string(name0), mRPC[0].GetName())
@@ -204,6 +207,26 @@ func TestEncodeDecodeFetchQuery(t *testing.T) {
 	assert.Equal(t, gq, gqr)
 }
 
+func createStorageSearchResults() *storage.SearchResults {
+	return &storage.SearchResults{
+		Metrics: models.Metrics{
+			{Namespace: "ns", ID: "foo", Tags: models.Tags{{Name: "a", Value: "b"}}},
+			{Namespace: "ns", ID: "bar", Tags: models.Tags{{Name: "c", Value: "d"}}},
+		},
+	}
+}
+
+func TestEncodeDecodeSearchResults(t *testing.T) {
+	results := createStorageSearchResults()
+	encoded := EncodeSearchResults(results)
+	require.Len(t, encoded.GetMetrics(), 2)
+	assert.Equal(t, []byte("foo"), encoded.GetMetrics()[0].GetId())
+	assert.Equal(t, []byte("ns"), encoded.GetMetrics()[0].GetNamespace())
+
+	decoded := DecodeSearchResults(encoded)
+	assert.Equal(t, results, decoded)
+}
+
 func createStorageWriteQuery(t *testing.T) (*storage.WriteQuery, ts.Datapoints) {
 	t0, t1 := parseTimes(t)
 	points := []ts.Datapoint{
@@ -24,6 +24,7 @@ import (
 	"io"
 	"net"
 
+	"github.com/m3db/m3/src/query/block"
 	rpc "github.com/m3db/m3/src/query/generated/proto/rpcpb"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/util/logging"
@@ -32,6 +33,12 @@ import (
 	"google.golang.org/grpc"
 )
 
+const (
+	// searchResultsBatchSize is the maximum number of metrics sent in a single
+	// search results message
+	searchResultsBatchSize = 1024
+)
+
 type grpcServer struct {
 	storage storage.Storage
 }
@@ -74,7 +81,7 @@ func (s *grpcServer) Fetch(message *rpc.FetchMessage, stream rpc.Query_FetchServ
 
 	// Iterate while there are more results
 	for {
-		result, err := s.storage.Fetch(ctx, storeQuery, nil)
+		result, err := s.storage.Fetch(ctx, storeQuery, &storage.FetchOptions{})
 
 		if err != nil {
 			logger.Error("unable to fetch local query", zap.Any("error", err))
@@ -93,6 +100,78 @@ func (s *grpcServer) Fetch(message *rpc.FetchMessage, stream rpc.Query_FetchServ
 	return nil
 }
 
+// Search reads the series matching a query from local storage
+func (s *grpcServer) Search(message *rpc.FetchMessage, stream rpc.Query_SearchServer) error {
+	storeQuery, id, err := DecodeFetchMessage(message)
+	ctx := logging.NewContextWithID(stream.Context(), id)
+	logger := logging.WithContext(ctx)
+
+	if err != nil {
+		logger.Error("unable to decode search query", zap.Any("error", err))
+		return err
+	}
+
+	result, err := s.storage.FetchTags(ctx, storeQuery, &storage.FetchOptions{})
+	if err != nil {
+		logger.Error("unable to search local query", zap.Any("error", err))
+		return err
+	}
+
+	// Send the metrics in batches to bound the size of each message
+	for start := 0; start < len(result.Metrics); start += searchResultsBatchSize {
+		end := start + searchResultsBatchSize
+		if end > len(result.Metrics) {
+			end = len(result.Metrics)
+		}
+
+		batch := &storage.SearchResults{Metrics: result.Metrics[start:end]}
+		if err := stream.Send(EncodeSearchResults(batch)); err != nil {
+			logger.Error("unable to send search result", zap.Any("error", err))
+			return err
+		}
+	}
+	return nil
+}
+
+// FetchBlocks reads blocks from local storage
+func (s *grpcServer) FetchBlocks(message *rpc.FetchMessage, stream rpc.Query_FetchBlocksServer) error {
+	storeQuery, id, err := DecodeFetchMessage(message)
+	ctx := logging.NewContextWithID(stream.Context(), id)
+	logger := logging.WithContext(ctx)
+
+	if err != nil {
+		logger.Error("unable to decode fetch blocks query", zap.Any("error", err))
+		return err
+	}
+
+	result, err := s.storage.FetchBlocks(ctx, storeQuery, &storage.FetchOptions{})
+	if err != nil {
+		logger.Error("unable to fetch local blocks", zap.Any("error", err))
+		return err
+	}
+
+	defer func() {
+		for _, b := range result.Blocks {
+			b.Close()
+		}
+	}()
+
+	// Send each block in its own message to bound the size of each message
+	for _, b := range result.Blocks {
+		encoded, err := EncodeFetchBlocksResult(block.Result{Blocks: []block.Block{b}})
+		if err != nil {
+			logger.Error("unable to encode fetch blocks result", zap.Any("error", err))
+			return err
+		}
+
+		if err := stream.Send(encoded); err != nil {
+			logger.Error("unable to send fetch blocks result", zap.Any("error", err))
+			return err
+		}
+	}
+	return nil
+}
+
 // Write writes to local storage
 func (s *grpcServer) Write(stream rpc.Query_WriteServer) error {
 	for {
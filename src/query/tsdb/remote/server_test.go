@@ -30,8 +30,10 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	m3err "github.com/m3db/m3/src/query/errors"
+	rpc "github.com/m3db/m3/src/query/generated/proto/rpcpb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/mock"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 
@@ -104,6 +106,11 @@ func (s *mockStorage) Fetch(ctx context.Context, query *storage.FetchQuery, _ *s
 }
 
 func (s *mockStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, _ *storage.FetchOptions) (*storage.SearchResults, error) {
+	readQueriesAreEqual(s.t, s.read, query)
+	return createStorageSearchResults(), nil
+}
+
+func (s *mockStorage) CompleteTags(ctx context.Context, query *storage.CompleteTagsQuery, _ *storage.FetchOptions) (*storage.CompleteTagsResult, error) {
 	return nil, nil
 }
 
@@ -112,13 +119,18 @@ func (s *mockStorage) Write(ctx context.Context, query *storage.WriteQuery) erro
 	return nil
 }
 
+func (s *mockStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	return nil
+}
+
 func (s *mockStorage) Type() storage.Type {
 	return storage.Type(0)
 }
 
 func (s *mockStorage) FetchBlocks(
 	ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (block.Result, error) {
-	return block.Result{}, fmt.Errorf("not implemented")
+	readQueriesAreEqual(s.t, s.read, query)
+	return block.Result{Blocks: []block.Block{createTestBlock()}}, nil
 }
 
 func (s *mockStorage) Close() error {
@@ -172,6 +184,19 @@ func checkFetch(ctx context.Context, t *testing.T, client Client, read *storage.
 	checkRemoteFetch(t, fetch)
 }
 
+func checkFetchTags(ctx context.Context, t *testing.T, client Client, read *storage.FetchQuery, readOpts *storage.FetchOptions) {
+	result, err := client.FetchTags(ctx, read, readOpts)
+	require.NoError(t, err)
+	assert.Equal(t, createStorageSearchResults(), result)
+}
+
+func checkFetchBlocks(ctx context.Context, t *testing.T, client Client, read *storage.FetchQuery, readOpts *storage.FetchOptions) {
+	result, err := client.FetchBlocks(ctx, read, readOpts)
+	require.NoError(t, err)
+	require.Len(t, result.Blocks, 1)
+	assertBlocksEqual(t, createTestBlock(), result.Blocks[0])
+}
+
 func checkWrite(ctx context.Context, t *testing.T, client Client, write *storage.WriteQuery) {
 	err := client.Write(ctx, write)
 	require.Nil(t, err)
@@ -183,6 +208,18 @@ func checkErrorFetch(ctx context.Context, t *testing.T, client Client, read *sto
 	assert.Equal(t, errRead.Error(), grpc.ErrorDesc(err))
 }
 
+func checkErrorFetchTags(ctx context.Context, t *testing.T, client Client, read *storage.FetchQuery, readOpts *storage.FetchOptions) {
+	result, err := client.FetchTags(ctx, read, readOpts)
+	assert.Nil(t, result)
+	assert.Equal(t, errRead.Error(), grpc.ErrorDesc(err))
+}
+
+func checkErrorFetchBlocks(ctx context.Context, t *testing.T, client Client, read *storage.FetchQuery, readOpts *storage.FetchOptions) {
+	result, err := client.FetchBlocks(ctx, read, readOpts)
+	assert.Empty(t, result.Blocks)
+	assert.Equal(t, errRead.Error(), grpc.ErrorDesc(err))
+}
+
 func checkErrorWrite(ctx context.Context, t *testing.T, client Client, write *storage.WriteQuery) {
 	err := client.Write(ctx, write)
 	assert.Equal(t, errWrite.Error(), grpc.ErrorDesc(err))
@@ -206,6 +243,8 @@ func TestRpc(t *testing.T) {
 
 	checkWrite(ctx, t, client, write)
 	checkFetch(ctx, t, client, read, readOpts)
+	checkFetchTags(ctx, t, client, read, readOpts)
+	checkFetchBlocks(ctx, t, client, read, readOpts)
 }
 
 func TestRpcMultipleRead(t *testing.T) {
@@ -307,6 +346,11 @@ func (s *errStorage) Fetch(ctx context.Context, query *storage.FetchQuery, _ *st
 }
 
 func (s *errStorage) FetchTags(ctx context.Context, query *storage.FetchQuery, _ *storage.FetchOptions) (*storage.SearchResults, error) {
+	readQueriesAreEqual(s.t, s.read, query)
+	return nil, errRead
+}
+
+func (s *errStorage) CompleteTags(ctx context.Context, query *storage.CompleteTagsQuery, _ *storage.FetchOptions) (*storage.CompleteTagsResult, error) {
 	return nil, m3err.ErrNotImplemented
 }
 
@@ -315,9 +359,14 @@ func (s *errStorage) Write(ctx context.Context, query *storage.WriteQuery) error
 	return errWrite
 }
 
+func (s *errStorage) Delete(ctx context.Context, query *storage.DeleteQuery) error {
+	return m3err.ErrNotImplemented
+}
+
 func (s *errStorage) FetchBlocks(
 	ctx context.Context, query *storage.FetchQuery, options *storage.FetchOptions) (block.Result, error) {
-	return block.Result{}, fmt.Errorf("not implemented")
+	readQueriesAreEqual(s.t, s.read, query)
+	return block.Result{}, errRead
 }
 
 func (s *errStorage) Type() storage.Type {
@@ -353,6 +402,8 @@ func TestErrRpc(t *testing.T) {
 
 	checkErrorWrite(ctx, t, client, write)
 	checkErrorFetch(ctx, t, client, read, readOpts)
+	checkErrorFetchTags(ctx, t, client, read, readOpts)
+	checkErrorFetchBlocks(ctx, t, client, read, readOpts)
 }
 
 func TestRoundRobinClientRpc(t *testing.T) {
@@ -419,3 +470,73 @@ func TestRoundRobinClientRpc(t *testing.T) {
 	assert.True(t, hitHost, "round robin did not write to host")
 	assert.True(t, hitErrHost, "round robin did not write to error host")
 }
+
+type searchServerStream struct {
+	grpc.ServerStream
+	sent []*rpc.SearchResults
+}
+
+func (s *searchServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *searchServerStream) Send(result *rpc.SearchResults) error {
+	s.sent = append(s.sent, result)
+	return nil
+}
+
+func TestSearchSendsMetricsInBatches(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	metrics := make(models.Metrics, searchResultsBatchSize+1)
+	for i := range metrics {
+		metrics[i] = &models.Metric{ID: fmt.Sprintf("foo%d", i)}
+	}
+
+	store := mock.NewMockStorage()
+	store.SetFetchTagsResult(&storage.SearchResults{Metrics: metrics}, nil)
+
+	read, _, _ := createStorageFetchQuery(t)
+	stream := &searchServerStream{}
+	require.NoError(t, newServer(store).Search(EncodeFetchMessage(read, "id"), stream))
+
+	require.Len(t, stream.sent, 2)
+	assert.Len(t, stream.sent[0].GetMetrics(), searchResultsBatchSize)
+	assert.Len(t, stream.sent[1].GetMetrics(), 1)
+	assert.Equal(t, []byte(fmt.Sprintf("foo%d", searchResultsBatchSize)), stream.sent[1].GetMetrics()[0].GetId())
+}
+
+type fetchBlocksServerStream struct {
+	grpc.ServerStream
+	sent []*rpc.FetchBlocksResult
+}
+
+func (s *fetchBlocksServerStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fetchBlocksServerStream) Send(result *rpc.FetchBlocksResult) error {
+	s.sent = append(s.sent, result)
+	return nil
+}
+
+func TestFetchBlocksSendsEachBlock(t *testing.T) {
+	logging.InitWithCores(nil)
+
+	store := mock.NewMockStorage()
+	store.SetFetchBlocksResult(block.Result{
+		Blocks: []block.Block{createTestBlock(), createTestBlock()},
+	}, nil)
+
+	read, _, _ := createStorageFetchQuery(t)
+	stream := &fetchBlocksServerStream{}
+	require.NoError(t, newServer(store).FetchBlocks(EncodeFetchMessage(read, "id"), stream))
+
+	require.Len(t, stream.sent, 2)
+	for _, sent := range stream.sent {
+		decoded, err := DecodeFetchBlocksResult(sent)
+		require.NoError(t, err)
+		require.Len(t, decoded.Blocks, 1)
+		assertBlocksEqual(t, createTestBlock(), decoded.Blocks[0])
+	}
+}